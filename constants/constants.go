@@ -16,18 +16,66 @@ const (
 	RETRY_ANALYZER_JOB_URL  = SPECIFIC_JOB_URL + "/analyzer/%s/retry"
 	KILL_CONNECTOR_JOB_URL  = SPECIFIC_JOB_URL + "/connector/%s/kill"
 	RETRY_CONNECTOR_JOB_URL = SPECIFIC_JOB_URL + "/connector/%s/retry"
+	AGGREGATE_JOB_URL       = BASE_JOB_URL + "/aggregate/%s"
+	RECENT_SCANS_JOB_URL    = BASE_JOB_URL + "/recent_scans"
+)
+
+// These represent comment endpoints URL
+const (
+	BASE_COMMENT_URL     = "/api/comments"
+	SPECIFIC_COMMENT_URL = BASE_COMMENT_URL + "/%d"
 )
 
 // These represent analyzer endpoints URL
 const (
 	ANALYZER_CONFIG_URL      = "/api/get_analyzer_configs"
-	ANALYZER_HEALTHCHECK_URL = "/api/analyzer/%s/healthcheck"
+	BASE_ANALYZER_URL        = "/api/analyzer"
+	SPECIFIC_ANALYZER_URL    = BASE_ANALYZER_URL + "/%s"
+	ANALYZER_HEALTHCHECK_URL = SPECIFIC_ANALYZER_URL + "/health_check"
 )
 
 // These represent connector endpoints URL
 const (
 	CONNECTOR_CONFIG_URL      = "/api/get_connector_configs"
-	CONNECTOR_HEALTHCHECK_URL = "/api/connector/%s/healthcheck"
+	BASE_CONNECTOR_URL        = "/api/connector"
+	SPECIFIC_CONNECTOR_URL    = BASE_CONNECTOR_URL + "/%s"
+	CONNECTOR_HEALTHCHECK_URL = SPECIFIC_CONNECTOR_URL + "/health_check"
+)
+
+// These represent visualizer endpoints URL
+const (
+	BASE_VISUALIZER_URL     = "/api/visualizer"
+	SPECIFIC_VISUALIZER_URL = BASE_VISUALIZER_URL + "/%s"
+)
+
+// These represent pivot endpoints URL
+const (
+	BASE_PIVOT_URL     = "/api/pivot"
+	SPECIFIC_PIVOT_URL = BASE_PIVOT_URL + "/%s"
+)
+
+// These represent plugin config endpoints URL
+const (
+	BASE_PLUGIN_CONFIG_URL      = "/api/plugin-config"
+	SPECIFIC_PLUGIN_CONFIG_URL  = BASE_PLUGIN_CONFIG_URL + "/%d"
+	PLUGIN_CONFIG_OF_PLUGIN_URL = "/api/%s/%s/plugin_config"
+	PLUGIN_ORGANIZATION_URL     = "/api/%s/%s/organization"
+)
+
+// These represent ingestor endpoints URL
+const (
+	BASE_INGESTOR_URL        = "/api/ingestor"
+	SPECIFIC_INGESTOR_URL    = BASE_INGESTOR_URL + "/%s"
+	INGESTOR_HEALTHCHECK_URL = SPECIFIC_INGESTOR_URL + "/health_check"
+)
+
+// These represent investigation endpoints URL
+const (
+	BASE_INVESTIGATION_URL       = "/api/investigation"
+	SPECIFIC_INVESTIGATION_URL   = BASE_INVESTIGATION_URL + "/%d"
+	ADD_JOB_INVESTIGATION_URL    = SPECIFIC_INVESTIGATION_URL + "/add_job"
+	REMOVE_JOB_INVESTIGATION_URL = SPECIFIC_INVESTIGATION_URL + "/remove_job"
+	TREE_INVESTIGATION_URL       = SPECIFIC_INVESTIGATION_URL + "/tree"
 )
 
 // These represent analyze endpoints URL
@@ -36,6 +84,15 @@ const (
 	ANALYZE_MULTIPLE_OBSERVABLES_URL = "/api/analyze_multiple_observables"
 	ANALYZE_FILE_URL                 = "/api/analyze_file"
 	ANALYZE_MULTIPLE_FILES_URL       = "/api/analyze_multiple_files"
+	ASK_ANALYSIS_AVAILABILITY_URL    = "/api/ask_analysis_availability"
+)
+
+// These represent playbook endpoints URL
+const (
+	BASE_PLAYBOOK_URL                         = "/api/playbook"
+	SPECIFIC_PLAYBOOK_URL                     = BASE_PLAYBOOK_URL + "/%s"
+	PLAYBOOK_ANALYZE_MULTIPLE_OBSERVABLES_URL = BASE_PLAYBOOK_URL + "/analyze_multiple_observables"
+	PLAYBOOK_ANALYZE_MULTIPLE_FILES_URL       = BASE_PLAYBOOK_URL + "/analyze_multiple_files"
 )
 
 // These represent me endpoints URL
@@ -46,4 +103,32 @@ const (
 	ORGANIZATION_URL                    = BASE_ME_URL + "/organization"
 	INVITE_TO_ORGANIZATION_URL          = ORGANIZATION_URL + "/invite"
 	REMOVE_MEMBER_FROM_ORGANIZATION_URL = ORGANIZATION_URL + "/remove_member"
+	LEAVE_ORGANIZATION_URL              = ORGANIZATION_URL + "/leave"
+	INVITATIONS_URL                     = BASE_ME_URL + "/invitations"
+	ACCEPT_INVITATION_URL               = INVITATIONS_URL + "/%d/accept"
+	DECLINE_INVITATION_URL              = INVITATIONS_URL + "/%d/decline"
+	API_TOKEN_URL                       = "/api/auth/apiaccess"
+	LOGIN_URL                           = "/api/auth/login"
+	LOGOUT_URL                          = "/api/auth/logout"
+	REFRESH_TOKEN_URL                   = "/api/auth/refresh"
+)
+
+// These represent stats endpoints URL
+const (
+	BASE_STATS_URL            = "/api/stats"
+	SUBMISSIONS_STATS_URL     = BASE_STATS_URL + "/submissions"
+	TOP_ANALYZERS_STATS_URL   = BASE_STATS_URL + "/top_analyzers"
+	USERS_STATS_URL           = BASE_STATS_URL + "/users"
+	PLUGIN_FAILURES_STATS_URL = BASE_STATS_URL + "/plugin_failures"
+)
+
+// These represent notification endpoints URL
+const (
+	BASE_NOTIFICATION_URL      = "/api/notification"
+	MARK_READ_NOTIFICATION_URL = BASE_NOTIFICATION_URL + "/%d/mark-as-read"
+)
+
+// These represent websocket endpoints URL
+const (
+	JOB_WEBSOCKET_URL = "/ws/jobs/%d"
 )
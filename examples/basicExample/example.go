@@ -36,7 +36,7 @@ func main() {
 
 	basicAnalysisParams := gothreatmatrix.BasicAnalysisParams{
 		User:                 1,
-		Tlp:                  gothreatmatrix.WHITE,
+		Tlp:                  gothreatmatrix.TLPWhite,
 		RuntimeConfiguration: map[string]interface{}{},
 		AnalyzersRequested:   []string{},
 		ConnectorsRequested:  []string{},
@@ -0,0 +1,12 @@
+package export
+
+import "github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+
+// DefangJob returns a copy of the job whose observable is defanged through gothreatmatrix.Defang, to be exported
+// in reports read by people such as emails or tickets. MISP events and STIX bundles are meant for machines and
+// need the real observable, so they should be made from the job itself.
+func DefangJob(job *gothreatmatrix.Job) *gothreatmatrix.Job {
+	defanged := *job
+	defanged.ObservableName = gothreatmatrix.Defang(job.ObservableName)
+	return &defanged
+}
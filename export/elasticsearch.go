@@ -0,0 +1,339 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// DefaultElasticsearchBatchSize is how many documents an ElasticsearchExporter sends per bulk request by default.
+const DefaultElasticsearchBatchSize = 500
+
+// ErrBulkFailed is returned when Elasticsearch rejected some of the documents of a bulk request.
+var ErrBulkFailed = errors.New("export: elasticsearch bulk request failed")
+
+// ecsHashFields maps the length of a hex encoded hash to its ECS hash field.
+var ecsHashFields = map[int]string{
+	32: "md5",
+	40: "sha1",
+	64: "sha256",
+}
+
+// ECSDocument is an Elastic Common Schema document, ready to be marshalled into JSON and indexed.
+// Nested fields are nested maps, such as document["event"].(map[string]interface{})["kind"].
+type ECSDocument map[string]interface{}
+
+// ToECSDocument converts a job into an ECS enrichment event whose threat.indicator is the analyzed observable or file.
+// The job's verdict, status and plugins are kept under the threatmatrix field, its tags and TLP as tags and marking.
+func ToECSDocument(job *gothreatmatrix.Job) ECSDocument {
+	created, modified := jobTimes(job)
+	verdict := jobVerdict(job)
+	event := map[string]interface{}{
+		"kind":     "enrichment",
+		"category": []string{"threat"},
+		"type":     []string{"indicator"},
+		"module":   "threatmatrix",
+		"dataset":  "threatmatrix.job",
+		"id":       fmt.Sprint(job.ID),
+		"created":  created.UTC(),
+		"severity": verdictRanks[verdict],
+	}
+	if job.Status.IsTerminal() {
+		event["end"] = modified.UTC()
+		event["duration"] = modified.Sub(created).Nanoseconds()
+		event["outcome"] = "success"
+		if job.Status == gothreatmatrix.StatusFailed || job.Status == gothreatmatrix.StatusKilled {
+			event["outcome"] = "failure"
+		}
+	}
+	indicator := ecsIndicator(job)
+	if job.Tlp != "" {
+		indicator["marking"] = map[string]interface{}{"tlp": string(job.Tlp)}
+	}
+	tags := []string{}
+	for _, tag := range job.Tags {
+		tags = append(tags, tag.Label)
+	}
+	document := ECSDocument{
+		"@timestamp": modified.UTC(),
+		"event":      event,
+		"threat":     map[string]interface{}{"indicator": indicator},
+		"tags":       tags,
+		"threatmatrix": map[string]interface{}{
+			"job": map[string]interface{}{
+				"id":         job.ID,
+				"status":     string(job.Status),
+				"analyzers":  job.AnalyzersToExecute,
+				"connectors": job.ConnectorsToExecute,
+			},
+			"verdict": verdict,
+		},
+	}
+	if job.User.Username != "" {
+		document["user"] = map[string]interface{}{"name": job.User.Username}
+	}
+	if len(job.Errors) > 0 {
+		document["error"] = map[string]interface{}{"message": strings.Join(job.Errors, "\n")}
+	}
+	return document
+}
+
+// ReportToECSDocument converts the report of an analyzer, connector or pivot of the job into an ECS event.
+// The raw report is kept as is under threatmatrix.report.data, map it as flattened to avoid a mapping explosion.
+func ReportToECSDocument(job *gothreatmatrix.Job, report *gothreatmatrix.Report) ECSDocument {
+	event := map[string]interface{}{
+		"kind":     "enrichment",
+		"category": []string{"threat"},
+		"type":     []string{"info"},
+		"module":   "threatmatrix",
+		"dataset":  "threatmatrix.report",
+		"action":   report.Name,
+		"outcome":  "unknown",
+		"start":    report.StartTime.UTC(),
+		"end":      report.EndTime.UTC(),
+		"duration": report.EndTime.Sub(report.StartTime.Time).Nanoseconds(),
+	}
+	if report.Succeeded() {
+		event["outcome"] = "success"
+	} else if report.Failed() || strings.EqualFold(report.Status, gothreatmatrix.ReportStatusKilled) {
+		event["outcome"] = "failure"
+	}
+	document := ECSDocument{
+		"@timestamp": report.EndTime.UTC(),
+		"event":      event,
+		"threat":     map[string]interface{}{"indicator": ecsIndicator(job)},
+		"threatmatrix": map[string]interface{}{
+			"job": map[string]interface{}{"id": job.ID},
+			"report": map[string]interface{}{
+				"name":    report.Name,
+				"type":    report.Type,
+				"status":  report.Status,
+				"verdict": reportVerdict(report),
+				"data":    report.Report,
+			},
+		},
+	}
+	if len(report.Errors) > 0 {
+		document["error"] = map[string]interface{}{"message": strings.Join(report.Errors, "\n")}
+	}
+	return document
+}
+
+// ecsIndicator returns the threat.indicator fields of the observable or file the job analyzed.
+func ecsIndicator(job *gothreatmatrix.Job) map[string]interface{} {
+	indicator := map[string]interface{}{"provider": "ThreatMatrix"}
+	if job.IsSample || job.ObservableName == "" {
+		file := map[string]interface{}{}
+		if job.Md5 != "" {
+			file["hash"] = map[string]interface{}{"md5": job.Md5}
+		}
+		if job.FileName != "" {
+			file["name"] = job.FileName
+		}
+		if job.FileMimetype != "" {
+			file["mime_type"] = job.FileMimetype
+		}
+		indicator["type"] = "file"
+		indicator["file"] = file
+		return indicator
+	}
+
+	value := job.ObservableName
+	classification := job.ObservableClassification
+	if classification == "" {
+		classification = gothreatmatrix.Classify(value)
+	}
+	switch classification {
+	case gothreatmatrix.ClassificationIP:
+		indicator["type"] = "ipv4-addr"
+		if address, err := netip.ParseAddr(value); err == nil && address.Is6() && !address.Is4In6() {
+			indicator["type"] = "ipv6-addr"
+		}
+		indicator["ip"] = value
+	case gothreatmatrix.ClassificationDomain:
+		indicator["type"] = "domain-name"
+		indicator["url"] = map[string]interface{}{"domain": value}
+	case gothreatmatrix.ClassificationURL:
+		indicator["type"] = "url"
+		indicator["url"] = map[string]interface{}{"full": value}
+	case gothreatmatrix.ClassificationHash:
+		hashField, ok := ecsHashFields[len(value)]
+		if !ok {
+			hashField = "md5"
+		}
+		indicator["type"] = "file"
+		indicator["file"] = map[string]interface{}{"hash": map[string]interface{}{hashField: value}}
+	default:
+		if strings.Contains(value, "@") {
+			indicator["type"] = "email-addr"
+			indicator["email"] = map[string]interface{}{"address": value}
+		} else {
+			indicator["type"] = "unknown"
+			indicator["description"] = value
+		}
+	}
+	return indicator
+}
+
+// ElasticsearchOpType is the bulk action an ElasticsearchExporter writes the documents with.
+type ElasticsearchOpType string
+
+// Values of the ElasticsearchOpType enum.
+const (
+	// ElasticsearchOpIndex creates the documents or replaces them, it's the only one updating a job exported again.
+	ElasticsearchOpIndex ElasticsearchOpType = "index"
+	// ElasticsearchOpCreate only creates the documents, as data streams require.
+	ElasticsearchOpCreate ElasticsearchOpType = "create"
+)
+
+// ElasticsearchExporter indexes jobs, and optionally their reports, as ECS documents through the Elasticsearch bulk API.
+// Documents get identifiers derived from the jobs, so exporting a job again updates its documents instead of duplicating them.
+//
+// Data streams, such as the logs-*-* ones of the built-in index template, only accept ElasticsearchOpCreate: with it a
+// job exported again keeps its first documents, Elasticsearch answering 409 for them which isn't a failure.
+//
+//	exporter := &export.ElasticsearchExporter{URL: "https://elastic.local:9200", Index: "logs-threatmatrix-default", OpType: export.ElasticsearchOpCreate, APIKey: apiKey}
+//	err := exporter.Export(ctx, jobs...)
+type ElasticsearchExporter struct {
+	// URL is the URL of the Elasticsearch cluster.
+	URL string
+	// Index is the index, alias or data stream the documents are written to.
+	Index string
+	// OpType is the bulk action of the documents, ElasticsearchOpIndex when it is empty. Data streams need ElasticsearchOpCreate.
+	OpType ElasticsearchOpType
+	// APIKey is the base64 encoded API key, Username and Password are used for basic authentication instead when it is empty.
+	APIKey   string
+	Username string
+	Password string
+	// IncludeReports also indexes a document per analyzer and connector report.
+	IncludeReports bool
+	// BatchSize is how many documents are sent per bulk request, 0 being DefaultElasticsearchBatchSize.
+	BatchSize int
+	// HTTPClient sends the bulk requests, http.DefaultClient being used when it is nil.
+	HTTPClient *http.Client
+}
+
+// elasticsearchBulkItem is a document of a bulk request along with its identifier.
+type elasticsearchBulkItem struct {
+	id       string
+	document ECSDocument
+}
+
+// elasticsearchBulkResponse represents the answer of the bulk API.
+type elasticsearchBulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		ID     string          `json:"_id"`
+		Status int             `json:"status"`
+		Error  json.RawMessage `json:"error"`
+	} `json:"items"`
+}
+
+// Export indexes the jobs in batches of BatchSize documents, stopping at the first batch that fails.
+// ErrBulkFailed is returned when Elasticsearch rejected some documents of a batch.
+func (exporter *ElasticsearchExporter) Export(ctx context.Context, jobs ...*gothreatmatrix.Job) error {
+	items := []elasticsearchBulkItem{}
+	for _, job := range jobs {
+		items = append(items, elasticsearchBulkItem{id: fmt.Sprintf("threatmatrix-job-%d", job.ID), document: ToECSDocument(job)})
+		if !exporter.IncludeReports {
+			continue
+		}
+		for pluginIndex, reports := range [][]gothreatmatrix.Report{job.AnalyzerReports, job.ConnectorReports} {
+			pluginType := [...]string{"analyzer", "connector"}[pluginIndex]
+			for index := range reports {
+				report := &reports[index]
+				items = append(items, elasticsearchBulkItem{
+					id:       fmt.Sprintf("threatmatrix-job-%d-%s-%s", job.ID, pluginType, report.Name),
+					document: ReportToECSDocument(job, report),
+				})
+			}
+		}
+	}
+	batchSize := exporter.BatchSize
+	if batchSize < 1 {
+		batchSize = DefaultElasticsearchBatchSize
+	}
+	for start := 0; start < len(items); start += batchSize {
+		end := start + batchSize
+		if end > len(items) {
+			end = len(items)
+		}
+		if err := exporter.bulk(ctx, items[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bulk sends a single bulk request indexing items.
+func (exporter *ElasticsearchExporter) bulk(ctx context.Context, items []elasticsearchBulkItem) error {
+	opType := exporter.OpType
+	if opType == "" {
+		opType = ElasticsearchOpIndex
+	}
+	body := &bytes.Buffer{}
+	encoder := json.NewEncoder(body)
+	for _, item := range items {
+		action := map[string]interface{}{string(opType): map[string]interface{}{"_index": exporter.Index, "_id": item.id}}
+		if err := encoder.Encode(action); err != nil {
+			return err
+		}
+		if err := encoder.Encode(item.document); err != nil {
+			return err
+		}
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(exporter.URL, "/")+"/_bulk", body)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/x-ndjson")
+	if exporter.APIKey != "" {
+		request.Header.Set("Authorization", "ApiKey "+exporter.APIKey)
+	} else if exporter.Username != "" {
+		request.SetBasicAuth(exporter.Username, exporter.Password)
+	}
+	httpClient := exporter.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: status %d: %s", ErrBulkFailed, response.StatusCode, responseBody)
+	}
+	bulkResponse := elasticsearchBulkResponse{}
+	if err := json.Unmarshal(responseBody, &bulkResponse); err != nil {
+		return err
+	}
+	if !bulkResponse.Errors {
+		return nil
+	}
+	failures := []string{}
+	for _, item := range bulkResponse.Items {
+		for _, result := range item {
+			// a document created by an earlier export already holds the job
+			if result.Status >= http.StatusMultipleChoices && !(opType == ElasticsearchOpCreate && result.Status == http.StatusConflict) {
+				failures = append(failures, fmt.Sprintf("%s (%d): %s", result.ID, result.Status, result.Error))
+			}
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %d documents rejected: %s", ErrBulkFailed, len(failures), strings.Join(failures, "; "))
+}
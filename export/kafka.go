@@ -0,0 +1,203 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// ErrNoProducer is returned when a KafkaSink publishes without a producer.
+var ErrNoProducer = errors.New("export: kafka sink has no producer")
+
+// KafkaMessage is a record a KafkaSink hands to its producer.
+type KafkaMessage struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// KafkaProducer writes messages to Kafka. The module doesn't depend on a Kafka client, so wrap the one you use,
+// such as the Writer of segmentio/kafka-go or the SyncProducer of IBM/sarama.
+type KafkaProducer interface {
+	Produce(ctx context.Context, messages ...KafkaMessage) error
+}
+
+// KafkaProducerFunc is a function satisfying KafkaProducer.
+type KafkaProducerFunc func(ctx context.Context, messages ...KafkaMessage) error
+
+// Produce calls the function.
+func (producerFunc KafkaProducerFunc) Produce(ctx context.Context, messages ...KafkaMessage) error {
+	return producerFunc(ctx, messages...)
+}
+
+// JobCompletionEvent is the event published when a job is done being processed.
+type JobCompletionEvent struct {
+	JobID                    int                       `json:"job_id"`
+	Status                   string                    `json:"status"`
+	Verdict                  string                    `json:"verdict"`
+	ObservableName           string                    `json:"observable_name"`
+	ObservableClassification string                    `json:"observable_classification"`
+	FileName                 string                    `json:"file_name"`
+	Md5                      string                    `json:"md5"`
+	Tlp                      string                    `json:"tlp"`
+	Tags                     []string                  `json:"tags"`
+	Analyzers                []string                  `json:"analyzers"`
+	FinishedAnalysisTime     *gothreatmatrix.Timestamp `json:"finished_analysis_time"`
+}
+
+// ToJobCompletionEvent returns the completion event of the job.
+func ToJobCompletionEvent(job *gothreatmatrix.Job) *JobCompletionEvent {
+	event := &JobCompletionEvent{
+		JobID:                    job.ID,
+		Status:                   string(job.Status),
+		Verdict:                  jobVerdict(job),
+		ObservableName:           job.ObservableName,
+		ObservableClassification: job.ObservableClassification,
+		FileName:                 job.FileName,
+		Md5:                      job.Md5,
+		Tlp:                      job.Tlp.String(),
+		Tags:                     []string{},
+		Analyzers:                job.AnalyzersToExecute,
+		FinishedAnalysisTime:     job.FinishedAnalysisTime,
+	}
+	if event.Analyzers == nil {
+		event.Analyzers = []string{}
+	}
+	for _, tag := range job.Tags {
+		event.Tags = append(event.Tags, tag.Label)
+	}
+	return event
+}
+
+// KafkaSerializer encodes the completion event of a job into the value of a Kafka message.
+type KafkaSerializer interface {
+	// ContentType is sent as the content-type header of the messages.
+	ContentType() string
+	Serialize(event *JobCompletionEvent) ([]byte, error)
+}
+
+// JSONSerializer encodes the completion events as plain JSON objects.
+type JSONSerializer struct{}
+
+// ContentType returns application/json.
+func (JSONSerializer) ContentType() string {
+	return "application/json"
+}
+
+// Serialize marshals the event into JSON.
+func (JSONSerializer) Serialize(event *JobCompletionEvent) ([]byte, error) {
+	return json.Marshal(event)
+}
+
+// SchemaJSONSerializer encodes the completion events as the schema and payload envelopes of the Kafka Connect JsonConverter,
+// so the consumers get a typed record without needing a schema registry.
+type SchemaJSONSerializer struct{}
+
+// jobCompletionSchema is the Kafka Connect schema of a JobCompletionEvent.
+var jobCompletionSchema = map[string]interface{}{
+	"type":     "struct",
+	"name":     "threatmatrix.JobCompletionEvent",
+	"version":  1,
+	"optional": false,
+	"fields": []map[string]interface{}{
+		{"field": "job_id", "type": "int64", "optional": false},
+		{"field": "status", "type": "string", "optional": false},
+		{"field": "verdict", "type": "string", "optional": false},
+		{"field": "observable_name", "type": "string", "optional": true},
+		{"field": "observable_classification", "type": "string", "optional": true},
+		{"field": "file_name", "type": "string", "optional": true},
+		{"field": "md5", "type": "string", "optional": true},
+		{"field": "tlp", "type": "string", "optional": false},
+		{"field": "tags", "type": "array", "items": map[string]interface{}{"type": "string"}, "optional": false},
+		{"field": "analyzers", "type": "array", "items": map[string]interface{}{"type": "string"}, "optional": false},
+		{"field": "finished_analysis_time", "type": "int64", "name": "org.apache.kafka.connect.data.Timestamp", "version": 1, "optional": true},
+	},
+}
+
+// ContentType returns the content type of the Kafka Connect JSON envelopes.
+func (SchemaJSONSerializer) ContentType() string {
+	return "application/vnd.kafka.connect.json"
+}
+
+// Serialize wraps the event in a Kafka Connect envelope, the finished analysis time being in milliseconds since the epoch.
+func (SchemaJSONSerializer) Serialize(event *JobCompletionEvent) ([]byte, error) {
+	payload := struct {
+		*JobCompletionEvent
+		FinishedAnalysisTime *int64 `json:"finished_analysis_time"`
+	}{JobCompletionEvent: event}
+	if event.FinishedAnalysisTime != nil {
+		milliseconds := event.FinishedAnalysisTime.UnixMilli()
+		payload.FinishedAnalysisTime = &milliseconds
+	}
+	return json.Marshal(map[string]interface{}{
+		"schema":  jobCompletionSchema,
+		"payload": payload,
+	})
+}
+
+// KafkaSink publishes the completion events of jobs to a Kafka topic, keyed by job ID so the events of a job stay ordered.
+// Publish has the signature of a webhook.HandlerFunc, so jobs can be published as their callbacks arrive.
+//
+//	sink := &export.KafkaSink{Producer: producer, Topic: "threatmatrix.jobs"}
+//	receiver.Handle(sink.Publish)
+type KafkaSink struct {
+	// Producer writes the messages.
+	Producer KafkaProducer
+	// Topic is the topic the events are published to.
+	Topic string
+	// Serializer encodes the events, JSONSerializer being used when it is nil.
+	Serializer KafkaSerializer
+	// MaxTLP is the most restrictive TLP published, the jobs with a more restrictive, empty or unknown TLP being skipped.
+	// Every job is published when empty.
+	MaxTLP gothreatmatrix.TLP
+}
+
+// Publish publishes the completion event of the job, skipping the jobs that aren't done being processed or whose TLP is too restrictive.
+func (sink *KafkaSink) Publish(ctx context.Context, job *gothreatmatrix.Job) error {
+	return sink.PublishBatch(ctx, job)
+}
+
+// PublishBatch publishes the completion events of the jobs with a single call to the producer.
+func (sink *KafkaSink) PublishBatch(ctx context.Context, jobs ...*gothreatmatrix.Job) error {
+	if sink.Producer == nil {
+		return ErrNoProducer
+	}
+	serializer := sink.Serializer
+	if serializer == nil {
+		serializer = JSONSerializer{}
+	}
+	messages := []KafkaMessage{}
+	for _, job := range jobs {
+		if !job.Status.IsTerminal() || !tlpAllowed(job.Tlp, sink.MaxTLP) {
+			continue
+		}
+		value, err := serializer.Serialize(ToJobCompletionEvent(job))
+		if err != nil {
+			return err
+		}
+		messages = append(messages, KafkaMessage{
+			Topic:   sink.Topic,
+			Key:     []byte(strconv.Itoa(job.ID)),
+			Value:   value,
+			Headers: map[string]string{"content-type": serializer.ContentType()},
+		})
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return sink.Producer.Produce(ctx, messages...)
+}
+
+// tlpAllowed checks if the TLP isn't more restrictive than maxTLP, every TLP being allowed when maxTLP is empty.
+// An empty or unknown TLP is never allowed against a maxTLP, as how restrictive it is can't be told.
+func tlpAllowed(tlp gothreatmatrix.TLP, maxTLP gothreatmatrix.TLP) bool {
+	if maxTLP == "" {
+		return true
+	}
+	rank, known := gothreatmatrix.TLPVALUES[string(tlp)]
+	return known && rank <= gothreatmatrix.TLPVALUES[maxTLP.String()]
+}
@@ -0,0 +1,162 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// mispNamespace is the namespace of the identifiers of the MISP events and attributes created from jobs.
+var mispNamespace = [16]byte{0x2d, 0x84, 0xc1, 0x6a, 0x0f, 0x3b, 0x4e, 0x52, 0x8a, 0x17, 0x65, 0xe9, 0x41, 0xb0, 0x7c, 0x3d}
+
+// mispThreatLevels maps the verdicts to the MISP threat levels: 1 is high, 2 medium, 3 low and 4 undefined.
+var mispThreatLevels = map[string]string{
+	verdictMalicious:  "1",
+	verdictSuspicious: "2",
+	verdictBenign:     "3",
+	verdictUnknown:    "4",
+}
+
+// mispHashTypes maps the length of a hex encoded hash to its MISP attribute type.
+var mispHashTypes = map[int]string{
+	32: "md5",
+	40: "sha1",
+	64: "sha256",
+}
+
+// These are the MISP analysis levels.
+const (
+	mispAnalysisOngoing   = "1"
+	mispAnalysisCompleted = "2"
+)
+
+// mispDistributionOrganization only shares the event with your organization, the other instances deciding for themselves once it's pushed.
+const mispDistributionOrganization = "0"
+
+// MISPEvent represents a MISP event as the MISP REST API expects it when adding events.
+type MISPEvent struct {
+	Event MISPEventDetails `json:"Event"`
+}
+
+// MISPEventDetails represents the content of a MISP event.
+type MISPEventDetails struct {
+	UUID          string          `json:"uuid"`
+	Info          string          `json:"info"`
+	Date          string          `json:"date"`
+	ThreatLevelID string          `json:"threat_level_id"`
+	Analysis      string          `json:"analysis"`
+	Distribution  string          `json:"distribution"`
+	Attribute     []MISPAttribute `json:"Attribute"`
+	Tag           []MISPTag       `json:"Tag"`
+}
+
+// MISPAttribute represents an attribute of a MISP event.
+type MISPAttribute struct {
+	UUID     string `json:"uuid"`
+	Type     string `json:"type"`
+	Category string `json:"category"`
+	Value    string `json:"value"`
+	ToIDS    bool   `json:"to_ids"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// MISPTag represents a tag of a MISP event.
+type MISPTag struct {
+	Name string `json:"name"`
+}
+
+// ToMISPEvent converts a job into a MISP event holding the analyzed observable or the hash and name of the analyzed file,
+// and a text attribute with the verdict of each analyzer whose report has a registered type.
+// The analyzed observable or file is flagged for detection (to_ids) when the job is suspicious or malicious,
+// the job's tags and TLP become the event's tags.
+// It returns ErrNotExportable when the job has neither an observable nor a file hash.
+func ToMISPEvent(job *gothreatmatrix.Job) (*MISPEvent, error) {
+	verdict := jobVerdict(job)
+	toIds := verdict == verdictMalicious || verdict == verdictSuspicious
+	jobName := strconv.Itoa(job.ID)
+
+	attributes := []MISPAttribute{}
+	addAttribute := func(attributeType string, category string, value string, toIds bool, comment string) {
+		attributes = append(attributes, MISPAttribute{
+			UUID:     uuidV5(mispNamespace, []byte(jobName+"/"+attributeType+"/"+value)),
+			Type:     attributeType,
+			Category: category,
+			Value:    value,
+			ToIDS:    toIds,
+			Comment:  comment,
+		})
+	}
+	if job.IsSample || job.ObservableName == "" {
+		if job.Md5 == "" {
+			return nil, fmt.Errorf("%w: job %d has no observable or file hash", ErrNotExportable, job.ID)
+		}
+		addAttribute("md5", "Payload delivery", job.Md5, toIds, "")
+		if job.FileName != "" {
+			addAttribute("filename", "Payload delivery", job.FileName, false, "")
+		}
+		if job.FileMimetype != "" {
+			addAttribute("mime-type", "Payload delivery", job.FileMimetype, false, "")
+		}
+	} else {
+		attributeType, category := mispObservableType(job)
+		addAttribute(attributeType, category, job.ObservableName, toIds, "")
+	}
+	for index := range job.AnalyzerReports {
+		report := &job.AnalyzerReports[index]
+		if reported := reportVerdict(report); reported != verdictUnknown {
+			addAttribute("text", "External analysis", report.Name+": "+reported, false, "ThreatMatrix analyzer verdict")
+		}
+	}
+
+	tags := []MISPTag{}
+	if job.Tlp != "" {
+		tags = append(tags, MISPTag{Name: "tlp:" + strings.ToLower(string(job.Tlp))})
+	}
+	for _, tag := range job.Tags {
+		tags = append(tags, MISPTag{Name: tag.Label})
+	}
+
+	analysis := mispAnalysisOngoing
+	if job.Status.IsTerminal() {
+		analysis = mispAnalysisCompleted
+	}
+	created, _ := jobTimes(job)
+	return &MISPEvent{
+		Event: MISPEventDetails{
+			UUID:          uuidV5(mispNamespace, []byte(jobName)),
+			Info:          fmt.Sprintf("ThreatMatrix job %d: %s", job.ID, jobSubject(job)),
+			Date:          created.UTC().Format("2006-01-02"),
+			ThreatLevelID: mispThreatLevels[verdict],
+			Analysis:      analysis,
+			Distribution:  mispDistributionOrganization,
+			Attribute:     attributes,
+			Tag:           tags,
+		},
+	}, nil
+}
+
+// mispObservableType returns the MISP attribute type and category of the job's observable.
+func mispObservableType(job *gothreatmatrix.Job) (string, string) {
+	classification := job.ObservableClassification
+	if classification == "" {
+		classification = gothreatmatrix.Classify(job.ObservableName)
+	}
+	switch classification {
+	case gothreatmatrix.ClassificationIP:
+		return "ip-dst", "Network activity"
+	case gothreatmatrix.ClassificationDomain:
+		return "domain", "Network activity"
+	case gothreatmatrix.ClassificationURL:
+		return "url", "Network activity"
+	case gothreatmatrix.ClassificationHash:
+		if hashType, ok := mispHashTypes[len(job.ObservableName)]; ok {
+			return hashType, "Payload delivery"
+		}
+	}
+	if strings.Contains(job.ObservableName, "@") {
+		return "email-src", "Payload delivery"
+	}
+	return "text", "Other"
+}
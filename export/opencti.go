@@ -0,0 +1,173 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// ErrOpenCTIRequest is returned when OpenCTI answers a request with an error.
+var ErrOpenCTIRequest = errors.New("export: opencti request failed")
+
+// openCTIScores maps the verdicts to the OpenCTI scores, from 0 to 100.
+var openCTIScores = map[string]int{
+	verdictUnknown:    30,
+	verdictBenign:     10,
+	verdictSuspicious: 60,
+	verdictMalicious:  90,
+}
+
+// openCTIObservableTypes maps the STIX cyber observable types to the OpenCTI observable entity types.
+var openCTIObservableTypes = map[string]string{
+	"ipv4-addr":   "IPv4-Addr",
+	"ipv6-addr":   "IPv6-Addr",
+	"domain-name": "Domain-Name",
+	"url":         "Url",
+	"file":        "StixFile",
+	"email-addr":  "Email-Addr",
+}
+
+// openCTIIndicatorAdd is the GraphQL mutation creating, or updating, an indicator.
+const openCTIIndicatorAdd = `mutation IndicatorAdd($input: IndicatorAddInput!) {
+  indicatorAdd(input: $input) {
+    id
+    standard_id
+  }
+}`
+
+// OpenCTIIndicator represents an indicator as the indicatorAdd mutation of OpenCTI expects it.
+type OpenCTIIndicator struct {
+	StixID             string    `json:"stix_id"`
+	Name               string    `json:"name"`
+	Description        string    `json:"description"`
+	Pattern            string    `json:"pattern"`
+	PatternType        string    `json:"pattern_type"`
+	MainObservableType string    `json:"x_opencti_main_observable_type"`
+	ValidFrom          time.Time `json:"valid_from"`
+	Score              int       `json:"x_opencti_score"`
+	Detection          bool      `json:"x_opencti_detection"`
+	Labels             []string  `json:"objectLabel"`
+	// Update makes OpenCTI update the indicator when it already exists instead of failing.
+	Update bool `json:"update"`
+}
+
+// ToOpenCTI converts a job into an OpenCTI indicator matching the analyzed observable or file.
+// Its score comes from the verdict of the job, its labels from the job's tags, and its STIX identifier is the one
+// ToSTIXBundle gives to the indicator, so pushing a job again updates its indicator.
+// It returns ErrNotExportable in the same cases as ToSTIXBundle.
+func ToOpenCTI(job *gothreatmatrix.Job) (*OpenCTIIndicator, error) {
+	observable, pattern, err := stixObservable(job)
+	if err != nil {
+		return nil, err
+	}
+	created, _ := jobTimes(job)
+	verdict := jobVerdict(job)
+	labels := []string{}
+	for _, tag := range job.Tags {
+		labels = append(labels, tag.Label)
+	}
+	return &OpenCTIIndicator{
+		StixID:             stixObjectID("indicator", strconv.Itoa(job.ID)),
+		Name:               jobSubject(job),
+		Description:        fmt.Sprintf("ThreatMatrix job %d found %s to be %s.", job.ID, jobSubject(job), verdict),
+		Pattern:            pattern,
+		PatternType:        "stix",
+		MainObservableType: openCTIObservableTypes[observable.Type],
+		ValidFrom:          created.UTC(),
+		Score:              openCTIScores[verdict],
+		Detection:          verdict == verdictMalicious,
+		Labels:             labels,
+		Update:             true,
+	}, nil
+}
+
+// OpenCTIPusher creates the indicators of jobs in OpenCTI through its GraphQL API.
+//
+//	pusher := &export.OpenCTIPusher{URL: "https://opencti.local", Token: apiToken}
+//	indicatorId, err := pusher.Push(ctx, job)
+type OpenCTIPusher struct {
+	// URL is the URL of OpenCTI, without the /graphql route.
+	URL string
+	// Token is the API token of the OpenCTI user the indicators are created by.
+	Token string
+	// HTTPClient sends the requests, http.DefaultClient being used when it is nil.
+	HTTPClient *http.Client
+}
+
+// Push creates the indicator of the job, or updates it when it was already pushed, and returns its OpenCTI identifier.
+//
+//	Endpoint: POST /graphql
+func (pusher *OpenCTIPusher) Push(ctx context.Context, job *gothreatmatrix.Job) (string, error) {
+	indicator, err := ToOpenCTI(job)
+	if err != nil {
+		return "", err
+	}
+	return pusher.PushIndicator(ctx, indicator)
+}
+
+// PushIndicator creates the indicator and returns its OpenCTI identifier.
+func (pusher *OpenCTIPusher) PushIndicator(ctx context.Context, indicator *OpenCTIIndicator) (string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     openCTIIndicatorAdd,
+		"variables": map[string]interface{}{"input": indicator},
+	})
+	if err != nil {
+		return "", err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(pusher.URL, "/")+"/graphql", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+pusher.Token)
+	httpClient := pusher.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: status %d: %s", ErrOpenCTIRequest, response.StatusCode, responseBody)
+	}
+	// GraphQL reports errors with a 200 response
+	result := struct {
+		Data struct {
+			IndicatorAdd *struct {
+				ID string `json:"id"`
+			} `json:"indicatorAdd"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}{}
+	if err := json.Unmarshal(responseBody, &result); err != nil {
+		return "", err
+	}
+	if len(result.Errors) > 0 {
+		messages := make([]string, len(result.Errors))
+		for index, graphqlError := range result.Errors {
+			messages[index] = graphqlError.Message
+		}
+		return "", fmt.Errorf("%w: %s", ErrOpenCTIRequest, strings.Join(messages, "; "))
+	}
+	if result.Data.IndicatorAdd == nil {
+		return "", fmt.Errorf("%w: no indicator in the response", ErrOpenCTIRequest)
+	}
+	return result.Data.IndicatorAdd.ID, nil
+}
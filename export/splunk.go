@@ -0,0 +1,266 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// These are the defaults of a SplunkHECForwarder.
+const (
+	DefaultSplunkBatchSize   = 100
+	DefaultSplunkMaxAttempts = 3
+	DefaultSplunkRetryDelay  = time.Second
+	DefaultSplunkSourceType  = "threatmatrix:job"
+)
+
+// splunkEventPath is the route of the HTTP Event Collector receiving JSON events.
+const splunkEventPath = "/services/collector/event"
+
+// ErrHECFailed is returned when the HTTP Event Collector refused a batch of events, or still failed after the last attempt.
+var ErrHECFailed = errors.New("export: splunk HTTP event collector request failed")
+
+// SplunkJobEvent is the summary of a completed job sent as the event of a Splunk HEC event.
+type SplunkJobEvent struct {
+	ID                       int                       `json:"id"`
+	Status                   string                    `json:"status"`
+	Verdict                  string                    `json:"verdict"`
+	ObservableName           string                    `json:"observable_name,omitempty"`
+	ObservableClassification string                    `json:"observable_classification,omitempty"`
+	FileName                 string                    `json:"file_name,omitempty"`
+	Md5                      string                    `json:"md5,omitempty"`
+	Tlp                      string                    `json:"tlp"`
+	Tags                     []string                  `json:"tags"`
+	User                     string                    `json:"user,omitempty"`
+	ReceivedRequestTime      *gothreatmatrix.Timestamp `json:"received_request_time,omitempty"`
+	FinishedAnalysisTime     *gothreatmatrix.Timestamp `json:"finished_analysis_time,omitempty"`
+	ProcessTime              float64                   `json:"process_time"`
+	Analyzers                []string                  `json:"analyzers"`
+	Connectors               []string                  `json:"connectors"`
+	Errors                   []string                  `json:"errors,omitempty"`
+	Reports                  []SplunkReportSummary     `json:"reports,omitempty"`
+}
+
+// SplunkReportSummary is an analyzer or connector report sent along with its job when IncludeReports is set.
+type SplunkReportSummary struct {
+	Name    string                 `json:"name"`
+	Type    string                 `json:"type"`
+	Status  string                 `json:"status"`
+	Verdict string                 `json:"verdict"`
+	Errors  []string               `json:"errors,omitempty"`
+	Report  map[string]interface{} `json:"report,omitempty"`
+}
+
+// splunkEvent is the envelope of an event sent to the HTTP Event Collector.
+type splunkEvent struct {
+	Time       float64         `json:"time"`
+	Host       string          `json:"host,omitempty"`
+	Source     string          `json:"source,omitempty"`
+	SourceType string          `json:"sourcetype,omitempty"`
+	Index      string          `json:"index,omitempty"`
+	Event      *SplunkJobEvent `json:"event"`
+}
+
+// splunkResponse is the answer of the HTTP Event Collector.
+type splunkResponse struct {
+	Text string `json:"text"`
+	Code int    `json:"code"`
+}
+
+// ToSplunkJobEvent summarizes the job as the event forwarded to Splunk, with the reports of its analyzers and
+// connectors when includeReports is set.
+func ToSplunkJobEvent(job *gothreatmatrix.Job, includeReports bool) *SplunkJobEvent {
+	event := &SplunkJobEvent{
+		ID:                       job.ID,
+		Status:                   string(job.Status),
+		Verdict:                  jobVerdict(job),
+		ObservableName:           job.ObservableName,
+		ObservableClassification: job.ObservableClassification,
+		FileName:                 job.FileName,
+		Md5:                      job.Md5,
+		Tlp:                      job.Tlp.String(),
+		Tags:                     []string{},
+		User:                     job.User.Username,
+		ReceivedRequestTime:      job.ReceivedRequestTime,
+		FinishedAnalysisTime:     job.FinishedAnalysisTime,
+		ProcessTime:              job.ProcessTime,
+		Analyzers:                job.AnalyzersToExecute,
+		Connectors:               job.ConnectorsToExecute,
+		Errors:                   job.Errors,
+	}
+	for _, tag := range job.Tags {
+		event.Tags = append(event.Tags, tag.Label)
+	}
+	if !includeReports {
+		return event
+	}
+	for pluginIndex, reports := range [][]gothreatmatrix.Report{job.AnalyzerReports, job.ConnectorReports} {
+		pluginType := [...]string{"analyzer", "connector"}[pluginIndex]
+		for index := range reports {
+			report := &reports[index]
+			event.Reports = append(event.Reports, SplunkReportSummary{
+				Name:    report.Name,
+				Type:    pluginType,
+				Status:  report.Status,
+				Verdict: reportVerdict(report),
+				Errors:  report.Errors,
+				Report:  report.Report,
+			})
+		}
+	}
+	return event
+}
+
+// SplunkHECForwarder posts the summaries of completed jobs to a Splunk HTTP Event Collector, in batches.
+// Batches failing because of rate limiting, a server error or a network error are retried with exponential backoff.
+//
+//	forwarder := &export.SplunkHECForwarder{URL: "https://splunk.local:8088", Token: hecToken, MaxTLP: gothreatmatrix.TLPAmber}
+//	err := forwarder.Forward(ctx, jobs...)
+type SplunkHECForwarder struct {
+	// URL is the URL of the HTTP Event Collector, without the /services/collector route.
+	URL string
+	// Token is the HEC token the events are sent with.
+	Token string
+	// Index, Source, SourceType and Host are the metadata of the events, Splunk choosing them from the token when empty
+	// but SourceType which defaults to DefaultSplunkSourceType.
+	Index      string
+	Source     string
+	SourceType string
+	Host       string
+	// IncludeReports also sends the full analyzer and connector reports of the jobs.
+	IncludeReports bool
+	// MaxTLP is the most restrictive TLP forwarded, the jobs with a more restrictive, empty or unknown TLP being skipped.
+	// Every job is forwarded when empty.
+	MaxTLP gothreatmatrix.TLP
+	// BatchSize is how many events are sent per request, 0 being DefaultSplunkBatchSize.
+	BatchSize int
+	// MaxAttempts counts the first attempt at sending a batch as well, 0 being DefaultSplunkMaxAttempts.
+	MaxAttempts int
+	// RetryDelay is the delay before the second attempt, doubling at every attempt, 0 being DefaultSplunkRetryDelay.
+	RetryDelay time.Duration
+	// HTTPClient sends the events, http.DefaultClient being used when it is nil.
+	HTTPClient *http.Client
+}
+
+// Forward sends the completed jobs whose TLP isn't more restrictive than MaxTLP, the other jobs being skipped.
+// It stops at the first batch that can't be sent and returns how many jobs were forwarded before.
+func (forwarder *SplunkHECForwarder) Forward(ctx context.Context, jobs ...*gothreatmatrix.Job) (int, error) {
+	events := []splunkEvent{}
+	for _, job := range jobs {
+		if !forwarder.accepts(job) {
+			continue
+		}
+		eventTime := time.Now()
+		if job.FinishedAnalysisTime != nil {
+			eventTime = job.FinishedAnalysisTime.Time
+		}
+		sourceType := forwarder.SourceType
+		if sourceType == "" {
+			sourceType = DefaultSplunkSourceType
+		}
+		events = append(events, splunkEvent{
+			Time:       float64(eventTime.UnixMilli()) / 1000,
+			Host:       forwarder.Host,
+			Source:     forwarder.Source,
+			SourceType: sourceType,
+			Index:      forwarder.Index,
+			Event:      ToSplunkJobEvent(job, forwarder.IncludeReports),
+		})
+	}
+	batchSize := forwarder.BatchSize
+	if batchSize < 1 {
+		batchSize = DefaultSplunkBatchSize
+	}
+	forwarded := 0
+	for start := 0; start < len(events); start += batchSize {
+		end := start + batchSize
+		if end > len(events) {
+			end = len(events)
+		}
+		if err := forwarder.send(ctx, events[start:end]); err != nil {
+			return forwarded, err
+		}
+		forwarded = end
+	}
+	return forwarded, nil
+}
+
+// accepts checks if the job is done being processed and its TLP can be forwarded.
+func (forwarder *SplunkHECForwarder) accepts(job *gothreatmatrix.Job) bool {
+	return job.Status.IsTerminal() && tlpAllowed(job.Tlp, forwarder.MaxTLP)
+}
+
+// send posts a batch of events, retrying it when the failure is temporary.
+func (forwarder *SplunkHECForwarder) send(ctx context.Context, events []splunkEvent) error {
+	body := &bytes.Buffer{}
+	encoder := json.NewEncoder(body)
+	for index := range events {
+		if err := encoder.Encode(&events[index]); err != nil {
+			return err
+		}
+	}
+	maxAttempts := forwarder.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = DefaultSplunkMaxAttempts
+	}
+	delay := forwarder.RetryDelay
+	if delay <= 0 {
+		delay = DefaultSplunkRetryDelay
+	}
+	for attempt := 1; ; attempt++ {
+		retryable, err := forwarder.post(ctx, body.Bytes())
+		if err == nil || !retryable || attempt >= maxAttempts {
+			return err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// post makes a single attempt at sending the events, telling whether a failure is worth another attempt.
+func (forwarder *SplunkHECForwarder) post(ctx context.Context, body []byte) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(forwarder.URL, "/")+splunkEventPath, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Splunk "+forwarder.Token)
+	httpClient := forwarder.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		var netError net.Error
+		return errors.As(err, &netError) && ctx.Err() == nil, err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return true, err
+	}
+	if response.StatusCode == http.StatusOK {
+		return false, nil
+	}
+	hecResponse := splunkResponse{}
+	if json.Unmarshal(responseBody, &hecResponse) != nil {
+		hecResponse.Text = string(responseBody)
+	}
+	retryable := response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError
+	return retryable, fmt.Errorf("%w: status %d: %s (code %d)", ErrHECFailed, response.StatusCode, hecResponse.Text, hecResponse.Code)
+}
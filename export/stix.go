@@ -0,0 +1,314 @@
+package export
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// ErrNotExportable is returned when a job holds nothing the export format can represent.
+var ErrNotExportable = errors.New("threatmatrix: job cannot be exported")
+
+// STIX 2.1 identifiers are UUIDs, version 5 ones are derived from these namespaces so exporting a job again gives the same objects.
+var (
+	// stixCyberObservableNamespace is the namespace the STIX 2.1 specification uses for cyber observable identifiers.
+	stixCyberObservableNamespace = [16]byte{0x00, 0xab, 0xed, 0xb4, 0xaa, 0x42, 0x46, 0x6c, 0x9c, 0x01, 0xfe, 0xd2, 0x33, 0x15, 0xa9, 0xb7}
+	// stixObjectNamespace is the namespace of the identifiers of the domain objects and bundles created from jobs.
+	stixObjectNamespace = [16]byte{0x6b, 0x1f, 0x3e, 0x0c, 0x5a, 0x27, 0x4d, 0x8e, 0x9f, 0x41, 0x2c, 0x7d, 0x0e, 0x93, 0x58, 0xa4}
+)
+
+// stixTLPMarkings holds the identifiers of the TLP marking definitions predefined by STIX 2.1.
+var stixTLPMarkings = map[gothreatmatrix.TLP]string{
+	gothreatmatrix.TLPClear: "marking-definition--613f2e26-407d-48c7-9eca-b8e91df99dc9",
+	gothreatmatrix.TLPWhite: "marking-definition--613f2e26-407d-48c7-9eca-b8e91df99dc9",
+	gothreatmatrix.TLPGreen: "marking-definition--34098fce-860f-48ae-8e50-ebd3cc5e41da",
+	gothreatmatrix.TLPAmber: "marking-definition--f88d31f6-486f-44da-b317-01333bde0b82",
+	gothreatmatrix.TLPRed:   "marking-definition--5e57c739-391a-4eb3-b6be-7d15ca92d5ed",
+}
+
+// stixIndicatorTypes maps the verdicts to the STIX 2.1 indicator type vocabulary.
+var stixIndicatorTypes = map[string]string{
+	verdictUnknown:    "unknown",
+	verdictBenign:     "benign",
+	verdictSuspicious: "anomalous-activity",
+	verdictMalicious:  "malicious-activity",
+}
+
+// stixHashNames maps the length of a hex encoded hash to its STIX 2.1 hash algorithm.
+var stixHashNames = map[int]string{
+	32: "MD5",
+	40: "SHA-1",
+	64: "SHA-256",
+}
+
+// STIXBundle represents a STIX 2.1 bundle, ready to be marshalled into JSON.
+type STIXBundle struct {
+	Type    string        `json:"type"`
+	ID      string        `json:"id"`
+	Objects []interface{} `json:"objects"`
+}
+
+// STIXCyberObservable represents the STIX 2.1 cyber observable a job analyzed: an IP address, a domain, a URL or a file.
+type STIXCyberObservable struct {
+	Type        string            `json:"type"`
+	SpecVersion string            `json:"spec_version"`
+	ID          string            `json:"id"`
+	Value       string            `json:"value,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	MimeType    string            `json:"mime_type,omitempty"`
+	Hashes      map[string]string `json:"hashes,omitempty"`
+}
+
+// STIXIndicator represents a STIX 2.1 indicator matching the analyzed observable or file.
+type STIXIndicator struct {
+	Type              string   `json:"type"`
+	SpecVersion       string   `json:"spec_version"`
+	ID                string   `json:"id"`
+	Created           STIXTime `json:"created"`
+	Modified          STIXTime `json:"modified"`
+	Name              string   `json:"name"`
+	IndicatorTypes    []string `json:"indicator_types"`
+	Pattern           string   `json:"pattern"`
+	PatternType       string   `json:"pattern_type"`
+	ValidFrom         STIXTime `json:"valid_from"`
+	Labels            []string `json:"labels,omitempty"`
+	ObjectMarkingRefs []string `json:"object_marking_refs,omitempty"`
+}
+
+// STIXObservedData represents the STIX 2.1 observed-data recording that ThreatMatrix analyzed the observable or file.
+type STIXObservedData struct {
+	Type              string   `json:"type"`
+	SpecVersion       string   `json:"spec_version"`
+	ID                string   `json:"id"`
+	Created           STIXTime `json:"created"`
+	Modified          STIXTime `json:"modified"`
+	FirstObserved     STIXTime `json:"first_observed"`
+	LastObserved      STIXTime `json:"last_observed"`
+	NumberObserved    int      `json:"number_observed"`
+	ObjectRefs        []string `json:"object_refs"`
+	ObjectMarkingRefs []string `json:"object_marking_refs,omitempty"`
+}
+
+// STIXMalwareAnalysis represents the STIX 2.1 malware-analysis made of the report of one analyzer.
+type STIXMalwareAnalysis struct {
+	Type              string    `json:"type"`
+	SpecVersion       string    `json:"spec_version"`
+	ID                string    `json:"id"`
+	Created           STIXTime  `json:"created"`
+	Modified          STIXTime  `json:"modified"`
+	Product           string    `json:"product"`
+	AnalysisStarted   *STIXTime `json:"analysis_started,omitempty"`
+	AnalysisEnded     *STIXTime `json:"analysis_ended,omitempty"`
+	Result            string    `json:"result"`
+	AnalysisSCORefs   []string  `json:"analysis_sco_refs"`
+	ObjectMarkingRefs []string  `json:"object_marking_refs,omitempty"`
+}
+
+// STIXTime is a timestamp marshalled the way STIX 2.1 expects it: in UTC with millisecond precision.
+type STIXTime time.Time
+
+// MarshalJSON formats the timestamp as a STIX 2.1 timestamp.
+func (stixTime STIXTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Time(stixTime).UTC().Format("2006-01-02T15:04:05.000Z"))
+}
+
+// UnmarshalJSON parses a STIX 2.1 timestamp.
+func (stixTime *STIXTime) UnmarshalJSON(data []byte) error {
+	parsed := time.Time{}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+	*stixTime = STIXTime(parsed)
+	return nil
+}
+
+// ToSTIXBundle converts a job into a STIX 2.1 bundle holding the analyzed observable or file, an indicator matching it,
+// the observed-data of the analysis and a malware-analysis for each analyzer report.
+// The indicator type and the malware-analysis results come from the reports of the analyzers with a registered report type,
+// the job's tags become the indicator's labels and its TLP the objects' marking.
+// It returns ErrNotExportable when the job has neither an observable nor a file hash,
+// or when its observable is generic and has no STIX equivalent.
+func ToSTIXBundle(job *gothreatmatrix.Job) (*STIXBundle, error) {
+	observable, pattern, err := stixObservable(job)
+	if err != nil {
+		return nil, err
+	}
+	created, modified := jobTimes(job)
+	markings := []string{}
+	if marking, ok := stixTLPMarkings[job.Tlp]; ok {
+		markings = append(markings, marking)
+	}
+	jobName := strconv.Itoa(job.ID)
+
+	labels := []string{}
+	for _, tag := range job.Tags {
+		labels = append(labels, tag.Label)
+	}
+	indicator := STIXIndicator{
+		Type:              "indicator",
+		SpecVersion:       "2.1",
+		ID:                stixObjectID("indicator", jobName),
+		Created:           STIXTime(created),
+		Modified:          STIXTime(modified),
+		Name:              jobSubject(job),
+		IndicatorTypes:    []string{stixIndicatorTypes[jobVerdict(job)]},
+		Pattern:           pattern,
+		PatternType:       "stix",
+		ValidFrom:         STIXTime(created),
+		Labels:            labels,
+		ObjectMarkingRefs: markings,
+	}
+	observedData := STIXObservedData{
+		Type:              "observed-data",
+		SpecVersion:       "2.1",
+		ID:                stixObjectID("observed-data", jobName),
+		Created:           STIXTime(created),
+		Modified:          STIXTime(modified),
+		FirstObserved:     STIXTime(created),
+		LastObserved:      STIXTime(modified),
+		NumberObserved:    1,
+		ObjectRefs:        []string{observable.ID},
+		ObjectMarkingRefs: markings,
+	}
+
+	objects := []interface{}{observable, indicator, observedData}
+	for index := range job.AnalyzerReports {
+		report := &job.AnalyzerReports[index]
+		malwareAnalysis := STIXMalwareAnalysis{
+			Type:              "malware-analysis",
+			SpecVersion:       "2.1",
+			ID:                stixObjectID("malware-analysis", jobName+"/"+report.Name),
+			Created:           STIXTime(created),
+			Modified:          STIXTime(modified),
+			Product:           report.Name,
+			Result:            reportVerdict(report),
+			AnalysisSCORefs:   []string{observable.ID},
+			ObjectMarkingRefs: markings,
+		}
+		if !report.StartTime.IsZero() {
+			started := STIXTime(report.StartTime.Time)
+			malwareAnalysis.AnalysisStarted = &started
+		}
+		if !report.EndTime.IsZero() {
+			ended := STIXTime(report.EndTime.Time)
+			malwareAnalysis.AnalysisEnded = &ended
+		}
+		objects = append(objects, malwareAnalysis)
+	}
+
+	return &STIXBundle{
+		Type:    "bundle",
+		ID:      stixObjectID("bundle", jobName),
+		Objects: objects,
+	}, nil
+}
+
+// stixObservable returns the cyber observable analyzed by the job alongside the indicator pattern matching it.
+func stixObservable(job *gothreatmatrix.Job) (STIXCyberObservable, string, error) {
+	observable := STIXCyberObservable{SpecVersion: "2.1"}
+	if job.IsSample || job.ObservableName == "" {
+		if job.Md5 == "" {
+			return observable, "", fmt.Errorf("%w: job %d has no observable or file hash", ErrNotExportable, job.ID)
+		}
+		observable.Type = "file"
+		observable.Name = job.FileName
+		observable.MimeType = job.FileMimetype
+		observable.Hashes = map[string]string{"MD5": job.Md5}
+		observable.ID = stixCyberObservableID("file", map[string]interface{}{"hashes": observable.Hashes})
+		return observable, fmt.Sprintf("[file:hashes.'MD5' = '%s']", stixEscape(job.Md5)), nil
+	}
+
+	value := job.ObservableName
+	classification := job.ObservableClassification
+	if classification == "" {
+		classification = gothreatmatrix.Classify(value)
+	}
+	switch classification {
+	case gothreatmatrix.ClassificationIP:
+		observable.Type = "ipv4-addr"
+		if address, err := netip.ParseAddr(value); err == nil && address.Is6() && !address.Is4In6() {
+			observable.Type = "ipv6-addr"
+		}
+	case gothreatmatrix.ClassificationDomain:
+		observable.Type = "domain-name"
+	case gothreatmatrix.ClassificationURL:
+		observable.Type = "url"
+	case gothreatmatrix.ClassificationHash:
+		hashName, ok := stixHashNames[len(value)]
+		if !ok {
+			hashName = "MD5"
+		}
+		observable.Type = "file"
+		observable.Hashes = map[string]string{hashName: value}
+		observable.ID = stixCyberObservableID("file", map[string]interface{}{"hashes": observable.Hashes})
+		return observable, fmt.Sprintf("[file:hashes.'%s' = '%s']", hashName, stixEscape(value)), nil
+	default:
+		if !strings.Contains(value, "@") {
+			return observable, "", fmt.Errorf("%w: generic observable %q has no STIX equivalent", ErrNotExportable, value)
+		}
+		observable.Type = "email-addr"
+	}
+	observable.Value = value
+	observable.ID = stixCyberObservableID(observable.Type, map[string]interface{}{"value": value})
+	return observable, fmt.Sprintf("[%s:value = '%s']", observable.Type, stixEscape(value)), nil
+}
+
+// stixEscape escapes a string to be used inside a STIX pattern.
+func stixEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+}
+
+// stixCyberObservableID returns the deterministic identifier STIX 2.1 gives to a cyber observable through its contributing properties.
+func stixCyberObservableID(objectType string, contributingProperties map[string]interface{}) string {
+	// json.Marshal sorts map keys, which makes the properties canonical
+	properties, _ := json.Marshal(contributingProperties)
+	return objectType + "--" + uuidV5(stixCyberObservableNamespace, properties)
+}
+
+// stixObjectID returns the identifier of an object created from a job, the same name always giving the same identifier.
+func stixObjectID(objectType string, name string) string {
+	return objectType + "--" + uuidV5(stixObjectNamespace, []byte(objectType+"/"+name))
+}
+
+// uuidV5 returns the name based UUID (version 5) of name in namespace.
+func uuidV5(namespace [16]byte, name []byte) string {
+	hash := sha1.New()
+	hash.Write(namespace[:])
+	hash.Write(name)
+	sum := hash.Sum(nil)
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// jobSubject returns the observable name or the file name the job analyzed.
+func jobSubject(job *gothreatmatrix.Job) string {
+	if job.ObservableName != "" {
+		return job.ObservableName
+	}
+	if job.FileName != "" {
+		return job.FileName
+	}
+	return job.Md5
+}
+
+// jobTimes returns when the job was submitted and when it finished.
+// A running job is considered modified when it was submitted, and a job without any time was submitted now.
+func jobTimes(job *gothreatmatrix.Job) (created time.Time, modified time.Time) {
+	created = time.Now()
+	if job.ReceivedRequestTime != nil {
+		created = job.ReceivedRequestTime.Time
+	}
+	modified = created
+	if job.FinishedAnalysisTime != nil {
+		modified = job.FinishedAnalysisTime.Time
+	}
+	return created, modified
+}
@@ -0,0 +1,232 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// These identify the device in the CEF and LEEF headers.
+const (
+	syslogVendor  = "ThreatMatrix"
+	syslogProduct = "go-threatmatrix"
+	syslogVersion = "1.0"
+)
+
+// SyslogFormat is the format of the messages written by a SyslogExporter.
+type SyslogFormat string
+
+// Values of the SyslogFormat enum.
+const (
+	// FormatCEF is the ArcSight Common Event Format.
+	FormatCEF SyslogFormat = "CEF"
+	// FormatLEEF is the QRadar Log Event Extended Format, in version 2.0.
+	FormatLEEF SyslogFormat = "LEEF"
+)
+
+// cefSeverities maps the verdicts to the CEF severities, from 0 to 10.
+var cefSeverities = map[string]int{
+	verdictUnknown:    0,
+	verdictBenign:     1,
+	verdictSuspicious: 6,
+	verdictMalicious:  10,
+}
+
+// syslogSeverities maps the verdicts to the syslog severities: 4 is warning, 5 notice and 6 informational.
+var syslogSeverities = map[string]int{
+	verdictUnknown:    6,
+	verdictBenign:     6,
+	verdictSuspicious: 5,
+	verdictMalicious:  4,
+}
+
+// syslogField is a key and value of the extension of a CEF or LEEF message.
+type syslogField struct {
+	key   string
+	value string
+}
+
+// verdictFields returns the fields describing the verdict of the job, in the CEF dictionary.
+// The observable goes in the field matching its classification: dst for IPs, dhost for domains, request for URLs and
+// fileHash for hashes, the custom strings holding the verdict, TLP and tags.
+func verdictFields(job *gothreatmatrix.Job, verdict string) []syslogField {
+	_, modified := jobTimes(job)
+	fields := []syslogField{
+		{"rt", strconv.FormatInt(modified.UnixMilli(), 10)},
+		{"externalId", strconv.Itoa(job.ID)},
+		{"outcome", string(job.Status)},
+	}
+	if job.IsSample || job.ObservableName == "" {
+		fields = append(fields, syslogField{"cat", "file"}, syslogField{"fname", job.FileName}, syslogField{"fileHash", job.Md5})
+	} else {
+		classification := job.ObservableClassification
+		if classification == "" {
+			classification = gothreatmatrix.Classify(job.ObservableName)
+		}
+		fields = append(fields, syslogField{"cat", classification})
+		switch classification {
+		case gothreatmatrix.ClassificationIP:
+			fields = append(fields, syslogField{"dst", job.ObservableName})
+		case gothreatmatrix.ClassificationDomain:
+			fields = append(fields, syslogField{"dhost", job.ObservableName})
+		case gothreatmatrix.ClassificationURL:
+			fields = append(fields, syslogField{"request", job.ObservableName})
+		case gothreatmatrix.ClassificationHash:
+			fields = append(fields, syslogField{"fileHash", job.ObservableName})
+		default:
+			fields = append(fields, syslogField{"msg", job.ObservableName})
+		}
+	}
+	tags := make([]string, len(job.Tags))
+	for index, tag := range job.Tags {
+		tags[index] = tag.Label
+	}
+	fields = append(fields,
+		syslogField{"cs1Label", "verdict"}, syslogField{"cs1", verdict},
+		syslogField{"cs2Label", "tlp"}, syslogField{"cs2", job.Tlp.String()},
+		syslogField{"cs3Label", "tags"}, syslogField{"cs3", strings.Join(tags, ",")},
+	)
+	if job.User.Username != "" {
+		fields = append(fields, syslogField{"suser", job.User.Username})
+	}
+	return fields
+}
+
+// ToCEF formats the verdict of the job as a CEF message, without the syslog header.
+// The signature ID is the verdict and the severity goes from 0 for unknown to 10 for malicious.
+func ToCEF(job *gothreatmatrix.Job) string {
+	verdict := jobVerdict(job)
+	message := &strings.Builder{}
+	fmt.Fprintf(message, "CEF:0|%s|%s|%s|%s|%s|%d|",
+		cefHeaderEscape(syslogVendor), cefHeaderEscape(syslogProduct), cefHeaderEscape(syslogVersion),
+		cefHeaderEscape(verdict), cefHeaderEscape(jobSubject(job)+" is "+verdict), cefSeverities[verdict])
+	for index, field := range verdictFields(job, verdict) {
+		if index > 0 {
+			message.WriteByte(' ')
+		}
+		message.WriteString(field.key)
+		message.WriteByte('=')
+		message.WriteString(cefExtensionEscape(field.value))
+	}
+	return message.String()
+}
+
+// leefKeys renames the CEF fields that have a predefined LEEF key.
+var leefKeys = map[string]string{
+	"suser":   "usrName",
+	"request": "url",
+}
+
+// ToLEEF formats the verdict of the job as a LEEF 2.0 message with tab delimited attributes, without the syslog header.
+// The event ID is the verdict and the severity goes from 1 for unknown to 10 for malicious.
+func ToLEEF(job *gothreatmatrix.Job) string {
+	verdict := jobVerdict(job)
+	message := &strings.Builder{}
+	fmt.Fprintf(message, "LEEF:2.0|%s|%s|%s|%s|x09|", syslogVendor, syslogProduct, syslogVersion, verdict)
+	severity := cefSeverities[verdict]
+	if severity < 1 {
+		severity = 1
+	}
+	fmt.Fprintf(message, "sev=%d", severity)
+	for _, field := range verdictFields(job, verdict) {
+		key := field.key
+		value := field.value
+		switch key {
+		case "rt":
+			key = "devTime"
+			value = value + "\tdevTimeFormat=epoch"
+		default:
+			if renamed, ok := leefKeys[key]; ok {
+				key = renamed
+			}
+			value = leefEscape(value)
+		}
+		message.WriteByte('\t')
+		message.WriteString(key)
+		message.WriteByte('=')
+		message.WriteString(value)
+	}
+	return message.String()
+}
+
+// cefHeaderEscape escapes the pipes and backslashes of a CEF header field.
+func cefHeaderEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "|", `\|`, "\n", " ", "\r", " ").Replace(value)
+}
+
+// cefExtensionEscape escapes the equal signs, backslashes and newlines of a CEF extension value.
+func cefExtensionEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "=", `\=`, "\n", `\n`, "\r", `\r`).Replace(value)
+}
+
+// leefEscape replaces the tabs and newlines of a LEEF attribute value, as they would end the attribute.
+func leefEscape(value string) string {
+	return strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(value)
+}
+
+// SyslogExporter writes the verdicts of jobs as RFC 5424 syslog messages carrying a CEF or LEEF payload,
+// such as to a net.Conn dialed to the syslog collector of the SIEM.
+//
+//	conn, err := net.Dial("udp", "siem.local:514")
+//	exporter := &export.SyslogExporter{Writer: conn, Format: export.FormatCEF}
+//	err = exporter.Export(jobs...)
+type SyslogExporter struct {
+	// Writer receives a write per message.
+	Writer io.Writer
+	// Format is the format of the payload, FormatCEF being used when empty.
+	Format SyslogFormat
+	// Facility is the syslog facility of the messages, 0 being kernel so set it, such as to 16 for local0.
+	Facility int
+	// Hostname and AppName go in the syslog header, defaulting to the hostname of the machine and go-threatmatrix.
+	Hostname string
+	AppName  string
+	// OctetCounting prefixes each message with its length as RFC 6587 expects over TCP, instead of ending it with a newline.
+	OctetCounting bool
+}
+
+// Export writes a message per job, stopping at the first that can't be written.
+func (exporter *SyslogExporter) Export(jobs ...*gothreatmatrix.Job) error {
+	hostname := exporter.Hostname
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+		if hostname == "" {
+			hostname = "-"
+		}
+	}
+	appName := exporter.AppName
+	if appName == "" {
+		appName = syslogProduct
+	}
+	for _, job := range jobs {
+		payload := ToCEF(job)
+		if exporter.Format == FormatLEEF {
+			payload = ToLEEF(job)
+		}
+		priority := exporter.Facility*8 + syslogSeverities[jobVerdict(job)]
+		message := &bytes.Buffer{}
+		fmt.Fprintf(message, "<%d>1 %s %s %s - %s - %s", priority, time.Now().UTC().Format(time.RFC3339Nano), hostname, appName, exporter.format(), payload)
+		if exporter.OctetCounting {
+			message = bytes.NewBuffer(append([]byte(strconv.Itoa(message.Len())+" "), message.Bytes()...))
+		} else {
+			message.WriteByte('\n')
+		}
+		if _, err := exporter.Writer.Write(message.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// format returns the format of the payload, which is also the message ID of the syslog header.
+func (exporter *SyslogExporter) format() SyslogFormat {
+	if exporter.Format == "" {
+		return FormatCEF
+	}
+	return exporter.Format
+}
@@ -0,0 +1,198 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// ErrUnknownColumn is returned when a column isn't a field of the jobs being exported.
+var ErrUnknownColumn = errors.New("export: unknown column")
+
+// DefaultJobColumns are the columns JobsToCSV exports when none is given, in that order.
+var DefaultJobColumns = []string{
+	"id",
+	"status",
+	"observable_name",
+	"observable_classification",
+	"file_name",
+	"md5",
+	"tlp",
+	"user",
+	"tags",
+	"received_request_time",
+	"finished_analysis_time",
+	"process_time",
+}
+
+// listSeparator joins the values of the list fields, such as the tags, in a single CSV cell.
+const listSeparator = ";"
+
+// JobsToCSV writes the jobs as CSV to w, a header row first and then a row per job in the given order.
+// columns are the JSON names of the job fields to export, in the order of the CSV columns, DefaultJobColumns
+// being exported when none is given. The user is exported as its username, the tags as their labels, and the
+// lists as their values joined with ";". As observable names and file names are attacker controlled, the cells
+// starting with =, +, -, @, a tab or a carriage return are prefixed with a ' so spreadsheets don't run them as formulas.
+//
+//	jobList, err := client.JobService.List(ctx, params)
+//	err = export.JobsToCSV(os.Stdout, jobList.Results, "id", "observable_name", "status")
+func JobsToCSV(w io.Writer, jobs []gothreatmatrix.JobList, columns ...string) error {
+	if len(columns) == 0 {
+		columns = DefaultJobColumns
+	}
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(columns); err != nil {
+		return err
+	}
+	for index := range jobs {
+		fields, err := jobFields(&jobs[index], columns)
+		if err != nil {
+			return err
+		}
+		row := make([]string, len(columns))
+		for column, field := range fields {
+			if row[column], err = csvCell(columns[column], field); err != nil {
+				return err
+			}
+		}
+		if err := csvWriter.Write(row); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// JobsToJSONL writes the jobs to w as JSON Lines, a JSON object per job in the given order.
+// columns are the JSON names of the job fields to export, in the order of the object keys, every field being
+// exported when none is given. Unlike JobsToCSV the values are kept as ThreatMatrix sent them.
+func JobsToJSONL(w io.Writer, jobs []gothreatmatrix.JobList, columns ...string) error {
+	buffered := bufio.NewWriter(w)
+	for index := range jobs {
+		var line []byte
+		var err error
+		if len(columns) == 0 {
+			line, err = json.Marshal(&jobs[index])
+		} else {
+			line, err = selectedJSON(&jobs[index], columns)
+		}
+		if err != nil {
+			return err
+		}
+		buffered.Write(line)
+		buffered.WriteByte('\n')
+	}
+	return buffered.Flush()
+}
+
+// jobFields returns the JSON values of the given fields of the job, in the same order.
+func jobFields(job *gothreatmatrix.JobList, columns []string) ([]json.RawMessage, error) {
+	jobJson, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+	allFields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(jobJson, &allFields); err != nil {
+		return nil, err
+	}
+	fields := make([]json.RawMessage, len(columns))
+	for index, column := range columns {
+		field, ok := allFields[column]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		fields[index] = field
+	}
+	return fields, nil
+}
+
+// selectedJSON marshals the given fields of the job into a JSON object, keeping the keys in the order of columns.
+func selectedJSON(job *gothreatmatrix.JobList, columns []string) ([]byte, error) {
+	fields, err := jobFields(job, columns)
+	if err != nil {
+		return nil, err
+	}
+	object := &bytes.Buffer{}
+	object.WriteByte('{')
+	for index, field := range fields {
+		if index > 0 {
+			object.WriteByte(',')
+		}
+		key, _ := json.Marshal(columns[index])
+		object.Write(key)
+		object.WriteByte(':')
+		object.Write(field)
+	}
+	object.WriteByte('}')
+	return object.Bytes(), nil
+}
+
+// formulaPrefixes are the first characters spreadsheets take a cell for a formula from.
+const formulaPrefixes = "=+-@\t\r"
+
+// csvCell formats the JSON value of a job field as a CSV cell, neutralizing formulas.
+func csvCell(column string, field json.RawMessage) (string, error) {
+	cell, err := rawCSVCell(column, field)
+	if err != nil {
+		return "", err
+	}
+	if cell != "" && strings.ContainsRune(formulaPrefixes, rune(cell[0])) {
+		cell = "'" + cell
+	}
+	return cell, nil
+}
+
+// rawCSVCell formats the JSON value of a job field as it's written in a CSV cell.
+func rawCSVCell(column string, field json.RawMessage) (string, error) {
+	var value interface{}
+	if err := json.Unmarshal(field, &value); err != nil {
+		return "", err
+	}
+	switch column {
+	case "user":
+		if user, ok := value.(map[string]interface{}); ok {
+			return cellValue(user["username"]), nil
+		}
+	case "tags":
+		if tags, ok := value.([]interface{}); ok {
+			labels := make([]string, 0, len(tags))
+			for _, tag := range tags {
+				if tag, ok := tag.(map[string]interface{}); ok {
+					labels = append(labels, cellValue(tag["label"]))
+				}
+			}
+			return strings.Join(labels, listSeparator), nil
+		}
+	}
+	return cellValue(value), nil
+}
+
+// cellValue formats a decoded JSON value, the objects being kept as JSON.
+func cellValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	case []interface{}:
+		values := make([]string, len(value))
+		for index, item := range value {
+			values[index] = cellValue(item)
+		}
+		return strings.Join(values, listSeparator)
+	}
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
@@ -0,0 +1,240 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// ErrTheHiveRequest is returned when TheHive answers a request with an error.
+var ErrTheHiveRequest = errors.New("export: thehive request failed")
+
+// theHiveTLPs maps the TLPs to the TLP levels of TheHive.
+var theHiveTLPs = map[gothreatmatrix.TLP]int{
+	gothreatmatrix.TLPClear: 0,
+	gothreatmatrix.TLPWhite: 0,
+	gothreatmatrix.TLPGreen: 1,
+	gothreatmatrix.TLPAmber: 2,
+	gothreatmatrix.TLPRed:   3,
+}
+
+// theHiveTLP returns the TheHive TLP level of tlp, an empty or unknown TLP being the most restrictive one, RED,
+// so a job whose TLP can't be told is never shared more widely than it may be meant to.
+func theHiveTLP(tlp gothreatmatrix.TLP) int {
+	level, ok := theHiveTLPs[tlp]
+	if !ok {
+		return theHiveTLPs[gothreatmatrix.TLPRed]
+	}
+	return level
+}
+
+// theHiveSeverities maps the verdicts to the severities of TheHive: 1 is low, 2 medium, 3 high.
+var theHiveSeverities = map[string]int{
+	verdictUnknown:    1,
+	verdictBenign:     1,
+	verdictSuspicious: 2,
+	verdictMalicious:  3,
+}
+
+// theHiveDataTypes maps the observable classifications to the data types of TheHive.
+var theHiveDataTypes = map[string]string{
+	gothreatmatrix.ClassificationIP:      "ip",
+	gothreatmatrix.ClassificationDomain:  "domain",
+	gothreatmatrix.ClassificationURL:     "url",
+	gothreatmatrix.ClassificationHash:    "hash",
+	gothreatmatrix.ClassificationGeneric: "other",
+}
+
+// TheHiveObservable represents an observable of a TheHive alert or case.
+type TheHiveObservable struct {
+	DataType string   `json:"dataType"`
+	Data     string   `json:"data"`
+	Message  string   `json:"message,omitempty"`
+	TLP      int      `json:"tlp"`
+	IOC      bool     `json:"ioc"`
+	Tags     []string `json:"tags"`
+}
+
+// TheHiveAlert represents an alert as the TheHive v1 API expects it when creating alerts.
+type TheHiveAlert struct {
+	Type        string              `json:"type"`
+	Source      string              `json:"source"`
+	SourceRef   string              `json:"sourceRef"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Severity    int                 `json:"severity"`
+	TLP         int                 `json:"tlp"`
+	PAP         int                 `json:"pap"`
+	Tags        []string            `json:"tags"`
+	Observables []TheHiveObservable `json:"observables"`
+}
+
+// ToTheHiveObservables converts the observable or file the job analyzed into TheHive observables.
+// A file gives an observable for its name and one for its md5, the observables being IOCs when the job is malicious.
+func ToTheHiveObservables(job *gothreatmatrix.Job) []TheHiveObservable {
+	verdict := jobVerdict(job)
+	tags := []string{"threatmatrix:verdict=" + verdict}
+	for _, tag := range job.Tags {
+		tags = append(tags, tag.Label)
+	}
+	newObservable := func(dataType string, data string) TheHiveObservable {
+		return TheHiveObservable{
+			DataType: dataType,
+			Data:     data,
+			Message:  fmt.Sprintf("ThreatMatrix job %d", job.ID),
+			TLP:      theHiveTLP(job.Tlp),
+			IOC:      verdict == verdictMalicious,
+			Tags:     tags,
+		}
+	}
+	if !job.IsSample && job.ObservableName != "" {
+		classification := job.ObservableClassification
+		if classification == "" {
+			classification = gothreatmatrix.Classify(job.ObservableName)
+		}
+		dataType, ok := theHiveDataTypes[classification]
+		if !ok {
+			dataType = "other"
+		}
+		if dataType == "other" && strings.Contains(job.ObservableName, "@") {
+			dataType = "mail"
+		}
+		return []TheHiveObservable{newObservable(dataType, job.ObservableName)}
+	}
+	observables := []TheHiveObservable{}
+	if job.FileName != "" {
+		observables = append(observables, newObservable("filename", job.FileName))
+	}
+	if job.Md5 != "" {
+		observables = append(observables, newObservable("hash", job.Md5))
+	}
+	return observables
+}
+
+// ToTheHiveAlert converts the job into a TheHive alert whose observables are given by ToTheHiveObservables.
+// The description lists the verdict of every analyzer report in a Markdown table.
+func ToTheHiveAlert(job *gothreatmatrix.Job) *TheHiveAlert {
+	verdict := jobVerdict(job)
+	description := &strings.Builder{}
+	fmt.Fprintf(description, "ThreatMatrix job %d analyzed **%s**, its verdict is **%s**.\n\n", job.ID, jobSubject(job), verdict)
+	description.WriteString("| Analyzer | Status | Verdict |\n|---|---|---|\n")
+	for index := range job.AnalyzerReports {
+		report := &job.AnalyzerReports[index]
+		fmt.Fprintf(description, "| %s | %s | %s |\n", report.Name, report.Status, reportVerdict(report))
+	}
+	tags := []string{}
+	for _, tag := range job.Tags {
+		tags = append(tags, tag.Label)
+	}
+	tlp := theHiveTLP(job.Tlp)
+	return &TheHiveAlert{
+		Type:        "threatmatrix",
+		Source:      "ThreatMatrix",
+		SourceRef:   fmt.Sprintf("job-%d", job.ID),
+		Title:       fmt.Sprintf("%s is %s", jobSubject(job), verdict),
+		Description: description.String(),
+		Severity:    theHiveSeverities[verdict],
+		TLP:         tlp,
+		PAP:         tlp,
+		Tags:        tags,
+		Observables: ToTheHiveObservables(job),
+	}
+}
+
+// TheHiveClient creates alerts and case observables from jobs through the TheHive v1 API.
+//
+//	theHive := &export.TheHiveClient{URL: "https://thehive.local", APIKey: apiKey}
+//	alertId, created, err := theHive.AlertIfMalicious(ctx, job)
+type TheHiveClient struct {
+	// URL is the URL of TheHive.
+	URL string
+	// APIKey authenticates the requests.
+	APIKey string
+	// Organisation is the organisation the alerts are created in, the default organisation of the user being used when empty.
+	Organisation string
+	// HTTPClient sends the requests, http.DefaultClient being used when it is nil.
+	HTTPClient *http.Client
+}
+
+// CreateAlert creates the alert and returns its identifier.
+//
+//	Endpoint: POST /api/v1/alert
+func (theHive *TheHiveClient) CreateAlert(ctx context.Context, alert *TheHiveAlert) (string, error) {
+	created := struct {
+		ID string `json:"_id"`
+	}{}
+	if err := theHive.send(ctx, "/api/v1/alert", alert, &created); err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+// AlertIfMalicious creates an alert from the job when its verdict is malicious, telling whether it did.
+func (theHive *TheHiveClient) AlertIfMalicious(ctx context.Context, job *gothreatmatrix.Job) (string, bool, error) {
+	if jobVerdict(job) != verdictMalicious {
+		return "", false, nil
+	}
+	alertId, err := theHive.CreateAlert(ctx, ToTheHiveAlert(job))
+	if err != nil {
+		return "", false, err
+	}
+	return alertId, true, nil
+}
+
+// AddCaseObservables adds the observables of the job to an existing case, as artifacts of the case.
+//
+//	Endpoint: POST /api/v1/case/{caseID}/observable
+func (theHive *TheHiveClient) AddCaseObservables(ctx context.Context, caseId string, job *gothreatmatrix.Job) error {
+	route := fmt.Sprintf("/api/v1/case/%s/observable", url.PathEscape(caseId))
+	for _, observable := range ToTheHiveObservables(job) {
+		if err := theHive.send(ctx, route, observable, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// send posts body as JSON to the route of TheHive and decodes the answer into result, unless it is nil.
+func (theHive *TheHiveClient) send(ctx context.Context, route string, body interface{}, result interface{}) error {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(theHive.URL, "/")+route, bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+theHive.APIKey)
+	if theHive.Organisation != "" {
+		request.Header.Set("X-Organisation", theHive.Organisation)
+	}
+	httpClient := theHive.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: status %d: %s", ErrTheHiveRequest, response.StatusCode, responseBody)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(responseBody, result)
+}
@@ -0,0 +1,36 @@
+package export
+
+import (
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// These are the verdicts the exporters give to analyzer reports and jobs, from the least to the most severe.
+const (
+	verdictUnknown    = string(gothreatmatrix.VerdictUnknown)
+	verdictBenign     = string(gothreatmatrix.VerdictBenign)
+	verdictSuspicious = string(gothreatmatrix.VerdictSuspicious)
+	verdictMalicious  = string(gothreatmatrix.VerdictMalicious)
+)
+
+// verdictRanks orders the verdicts by severity.
+var verdictRanks = map[string]int{
+	verdictUnknown:    gothreatmatrix.VerdictUnknown.Rank(),
+	verdictBenign:     gothreatmatrix.VerdictBenign.Rank(),
+	verdictSuspicious: gothreatmatrix.VerdictSuspicious.Rank(),
+	verdictMalicious:  gothreatmatrix.VerdictMalicious.Rank(),
+}
+
+// reportVerdict tells what an analyzer report says about the analyzed observable or file.
+// Only the reports of analyzers with a registered verdict evaluator are understood, every other report is unknown.
+func reportVerdict(report *gothreatmatrix.Report) string {
+	evidence, ok := report.Evidence()
+	if !ok {
+		return verdictUnknown
+	}
+	return string(evidence.Label)
+}
+
+// jobVerdict returns the most severe verdict among the job's analyzer reports.
+func jobVerdict(job *gothreatmatrix.Job) string {
+	return string(job.Verdict().Label)
+}
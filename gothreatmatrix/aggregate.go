@@ -0,0 +1,186 @@
+package gothreatmatrix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// These are the time ranges ThreatMatrix can aggregate jobs over.
+const (
+	AggregateRangeHour  = "1h"
+	AggregateRangeDay   = "1d"
+	AggregateRangeWeek  = "7d"
+	AggregateRangeMonth = "30d"
+	AggregateRangeYear  = "365d"
+)
+
+// AggregateParams represents the time range you can aggregate jobs over.
+// Range left empty lets ThreatMatrix use its default of a week.
+type AggregateParams struct {
+	Range string
+}
+
+// AggregateBucket represents the number of jobs in each aggregated value during a time bucket.
+type AggregateBucket struct {
+	Date   time.Time
+	Counts map[string]int
+}
+
+// Total returns the number of jobs in the bucket, across every aggregated value.
+func (bucket *AggregateBucket) Total() int {
+	total := 0
+	for _, count := range bucket.Counts {
+		total += count
+	}
+	return total
+}
+
+// UnmarshalJSON decodes a bucket sent as a "date" field alongside a count per aggregated value.
+func (bucket *AggregateBucket) UnmarshalJSON(data []byte) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	bucket.Counts = map[string]int{}
+	for key, value := range fields {
+		if key == "date" {
+			if err := json.Unmarshal(value, &bucket.Date); err != nil {
+				return err
+			}
+			continue
+		}
+		count := 0
+		if err := json.Unmarshal(value, &count); err != nil {
+			return fmt.Errorf("threatmatrix: aggregated count %q: %w", key, err)
+		}
+		bucket.Counts[key] = count
+	}
+	return nil
+}
+
+// AggregateResult represents jobs counted over time, grouped by one of their fields.
+type AggregateResult struct {
+	// Values are the aggregated values e.g. the job statuses or the file mimetypes.
+	Values  []string
+	Buckets []AggregateBucket
+}
+
+// Totals returns the number of jobs in each bucket, in the same order as Buckets.
+func (result *AggregateResult) Totals() []int {
+	totals := make([]int, len(result.Buckets))
+	for index := range result.Buckets {
+		totals[index] = result.Buckets[index].Total()
+	}
+	return totals
+}
+
+// AggregateStatus counts the jobs over time by status.
+//
+//	Endpoint: GET /api/jobs/aggregate/status
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_aggregate_status_retrieve
+func (jobService *JobService) AggregateStatus(ctx context.Context, params *AggregateParams, opts ...RequestOption) (*AggregateResult, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.AggregateStatus")
+	defer span.End()
+	return jobService.aggregate(ctx, "status", params)
+}
+
+// AggregateType counts the jobs over time by type, either observable or file.
+//
+//	Endpoint: GET /api/jobs/aggregate/type
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_aggregate_type_retrieve
+func (jobService *JobService) AggregateType(ctx context.Context, params *AggregateParams, opts ...RequestOption) (*AggregateResult, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.AggregateType")
+	defer span.End()
+	return jobService.aggregate(ctx, "type", params)
+}
+
+// AggregateObservableClassification counts the observable jobs over time by classification.
+//
+//	Endpoint: GET /api/jobs/aggregate/observable_classification
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_aggregate_observable_classification_retrieve
+func (jobService *JobService) AggregateObservableClassification(ctx context.Context, params *AggregateParams, opts ...RequestOption) (*AggregateResult, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.AggregateObservableClassification")
+	defer span.End()
+	return jobService.aggregate(ctx, "observable_classification", params)
+}
+
+// AggregateFileMimetype counts the file jobs over time by mimetype.
+//
+//	Endpoint: GET /api/jobs/aggregate/file_mimetype
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_aggregate_file_mimetype_retrieve
+func (jobService *JobService) AggregateFileMimetype(ctx context.Context, params *AggregateParams, opts ...RequestOption) (*AggregateResult, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.AggregateFileMimetype")
+	defer span.End()
+	return jobService.aggregate(ctx, "file_mimetype", params)
+}
+
+// aggregate fetches the jobs aggregated by the given field.
+func (jobService *JobService) aggregate(ctx context.Context, field string, params *AggregateParams) (*AggregateResult, error) {
+	route := fmt.Sprintf(constants.AGGREGATE_JOB_URL, field)
+	if params != nil && params.Range != "" {
+		route += "?" + url.Values{"range": {params.Range}}.Encode()
+	}
+	successResp, err := jobService.client.sendJSON(ctx, "GET", route, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAggregateResult(successResp.Data)
+}
+
+// decodeAggregateResult decodes the aggregation sent either as a plain list of buckets
+// or as an object listing the aggregated values alongside the buckets.
+func decodeAggregateResult(data []byte) (*AggregateResult, error) {
+	result := AggregateResult{Buckets: []AggregateBucket{}}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(data, &result.Buckets); err != nil {
+			return nil, err
+		}
+	} else {
+		aggregation := struct {
+			Values      []string          `json:"values"`
+			Aggregation []AggregateBucket `json:"aggregation"`
+		}{}
+		if err := json.Unmarshal(data, &aggregation); err != nil {
+			return nil, err
+		}
+		result.Values = aggregation.Values
+		if aggregation.Aggregation != nil {
+			result.Buckets = aggregation.Aggregation
+		}
+	}
+	if result.Values == nil {
+		result.Values = aggregatedValues(result.Buckets)
+	}
+	return &result, nil
+}
+
+// aggregatedValues lists the values counted in the buckets, sorted alphabetically.
+func aggregatedValues(buckets []AggregateBucket) []string {
+	seen := map[string]bool{}
+	values := []string{}
+	for _, bucket := range buckets {
+		for value := range bucket.Counts {
+			if !seen[value] {
+				seen[value] = true
+				values = append(values, value)
+			}
+		}
+	}
+	sort.Strings(values)
+	return values
+}
@@ -68,6 +68,13 @@ type MultipleAnalysisResponse struct {
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/analyze_observable
 func (client *ThreatMatrixClient) CreateObservableAnalysis(ctx context.Context, params *ObservableAnalysisParams) (*AnalysisResponse, error) {
+	ctx, span := client.startSpan(ctx, "ThreatMatrixClient.CreateObservableAnalysis")
+	defer span.End()
+	return client.postObservableAnalysis(ctx, params)
+}
+
+// postObservableAnalysis sends an observable analysis to ThreatMatrix.
+func (client *ThreatMatrixClient) postObservableAnalysis(ctx context.Context, params *ObservableAnalysisParams) (*AnalysisResponse, error) {
 	requestUrl := client.options.Url + constants.ANALYZE_OBSERVABLE_URL
 	method := "POST"
 	contentType := "application/json"
@@ -78,6 +85,7 @@ func (client *ThreatMatrixClient) CreateObservableAnalysis(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	setIdempotencyKey(request)
 
 	analysisResponse := AnalysisResponse{}
 	successResp, err := client.newRequest(ctx, request)
@@ -88,7 +96,6 @@ func (client *ThreatMatrixClient) CreateObservableAnalysis(ctx context.Context,
 		return nil, unmarshalError
 	}
 	return &analysisResponse, nil
-
 }
 
 // CreateMultipleObservableAnalysis lets you analyze multiple observables.
@@ -97,6 +104,13 @@ func (client *ThreatMatrixClient) CreateObservableAnalysis(ctx context.Context,
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/analyze_multiple_observables
 func (client *ThreatMatrixClient) CreateMultipleObservableAnalysis(ctx context.Context, params *MultipleObservableAnalysisParams) (*MultipleAnalysisResponse, error) {
+	ctx, span := client.startSpan(ctx, "ThreatMatrixClient.CreateMultipleObservableAnalysis")
+	defer span.End()
+	return client.postMultipleObservableAnalysis(ctx, params)
+}
+
+// postMultipleObservableAnalysis sends a multiple observables analysis to ThreatMatrix.
+func (client *ThreatMatrixClient) postMultipleObservableAnalysis(ctx context.Context, params *MultipleObservableAnalysisParams) (*MultipleAnalysisResponse, error) {
 	requestUrl := client.options.Url + constants.ANALYZE_MULTIPLE_OBSERVABLES_URL
 	method := "POST"
 	contentType := "application/json"
@@ -107,6 +121,7 @@ func (client *ThreatMatrixClient) CreateMultipleObservableAnalysis(ctx context.C
 	if err != nil {
 		return nil, err
 	}
+	setIdempotencyKey(request)
 
 	multipleAnalysisResponse := MultipleAnalysisResponse{}
 	successResp, err := client.newRequest(ctx, request)
@@ -125,6 +140,8 @@ func (client *ThreatMatrixClient) CreateMultipleObservableAnalysis(ctx context.C
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/analyze_file
 func (client *ThreatMatrixClient) CreateFileAnalysis(ctx context.Context, fileAnalysisParams *FileAnalysisParams) (*AnalysisResponse, error) {
+	ctx, span := client.startSpan(ctx, "ThreatMatrixClient.CreateFileAnalysis")
+	defer span.End()
 	requestUrl := client.options.Url + constants.ANALYZE_FILE_URL
 	// * Making the multiform data
 	body := &bytes.Buffer{}
@@ -203,6 +220,8 @@ func (client *ThreatMatrixClient) CreateFileAnalysis(ctx context.Context, fileAn
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/analyze_multiple_files
 func (client *ThreatMatrixClient) CreateMultipleFileAnalysis(ctx context.Context, fileAnalysisParams *MultipleFileAnalysisParams) (*MultipleAnalysisResponse, error) {
+	ctx, span := client.startSpan(ctx, "ThreatMatrixClient.CreateMultipleFileAnalysis")
+	defer span.End()
 	requestUrl := client.options.Url + constants.ANALYZE_MULTIPLE_FILES_URL
 	// * Making the multiform data
 	body := &bytes.Buffer{}
@@ -0,0 +1,394 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// ObservableAnalysisRequest represents an observable to submit to ThreatMatrix for analysis.
+//
+// Classification is guessed through Classify when it is left empty.
+// Leaving Analyzers and Connectors empty runs every analyzer and connector that supports the observable.
+type ObservableAnalysisRequest struct {
+	Value                string
+	Classification       string
+	Analyzers            []string
+	Connectors           []string
+	TLP                  TLP
+	Tags                 []string
+	RuntimeConfiguration map[string]interface{}
+}
+
+// FileAnalysisRequest represents a file to submit to ThreatMatrix for analysis.
+//
+// File is streamed to ThreatMatrix as it is read, so big samples are never held in memory.
+// Leaving Analyzers and Connectors empty runs every analyzer and connector that supports the file.
+type FileAnalysisRequest struct {
+	File                 io.Reader
+	FileName             string
+	Analyzers            []string
+	Connectors           []string
+	TLP                  TLP
+	Tags                 []string
+	RuntimeConfiguration map[string]interface{}
+}
+
+// ObservableAnalysisResult is the outcome of one of the observables submitted through AnalyzeService.AnalyzeObservables.
+// Either Response or Err is set.
+type ObservableAnalysisResult struct {
+	Request  ObservableAnalysisRequest
+	Response *AnalysisResponse
+	Err      error
+}
+
+// FileAnalysisResult is the outcome of one of the files submitted through AnalyzeService.AnalyzeFiles.
+// Either Response or Err is set.
+type FileAnalysisResult struct {
+	Request  FileAnalysisRequest
+	Response *AnalysisResponse
+	Err      error
+}
+
+// analysisFile is a file part of a multipart analysis form.
+type analysisFile struct {
+	name   string
+	reader io.Reader
+}
+
+// AnalyzeService handles the submission of new analyses to ThreatMatrix.
+//
+// ThreatMatrix REST API analyze docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/analyze_observable
+type AnalyzeService struct {
+	client *ThreatMatrixClient
+}
+
+// AnalyzeObservable submits an observable for analysis, the returned AnalysisResponse holds the ID of the created job.
+//
+//	Endpoint: POST /api/analyze_observable
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/analyze_observable
+func (analyzeService *AnalyzeService) AnalyzeObservable(ctx context.Context, analysisRequest ObservableAnalysisRequest, opts ...RequestOption) (*AnalysisResponse, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := analyzeService.client.startSpan(ctx, "AnalyzeService.AnalyzeObservable")
+	defer span.End()
+	if strings.TrimSpace(analysisRequest.Value) == "" {
+		return nil, fmt.Errorf("%w: observable value cannot be empty", ErrValidation)
+	}
+	value := analyzeService.client.observableValue(analysisRequest.Value)
+	params := &ObservableAnalysisParams{
+		BasicAnalysisParams:      newBasicAnalysisParams(analysisRequest.TLP, analysisRequest.Analyzers, analysisRequest.Connectors, analysisRequest.Tags, analysisRequest.RuntimeConfiguration),
+		ObservableName:           value,
+		ObservableClassification: classificationOf(analysisRequest.Classification, value),
+	}
+	return analyzeService.client.postObservableAnalysis(ctx, params)
+}
+
+// AnalyzeObservableAndWait submits an observable for analysis and waits for its job to be done being processed.
+// The job is polled through JobService.WaitForCompletion; a timeout of 0 waits for as long as ctx allows.
+func (analyzeService *AnalyzeService) AnalyzeObservableAndWait(ctx context.Context, analysisRequest ObservableAnalysisRequest, timeout time.Duration, opts ...RequestOption) (*Job, error) {
+	ctx = withRequestOptions(ctx, opts)
+	analysisResponse, err := analyzeService.AnalyzeObservable(ctx, analysisRequest)
+	if err != nil {
+		return nil, err
+	}
+	return analyzeService.waitForJob(ctx, analysisResponse, timeout)
+}
+
+// AnalyzeObservables submits many observables at once, returning one result per request in the same order.
+// Observables sharing the same TLP, analyzers, connectors, tags and runtime configuration go in a single
+// round trip, so a whole feed with the same settings is submitted through one request.
+//
+//	Endpoint: POST /api/analyze_multiple_observables
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/analyze_multiple_observables
+func (analyzeService *AnalyzeService) AnalyzeObservables(ctx context.Context, analysisRequests []ObservableAnalysisRequest, opts ...RequestOption) []ObservableAnalysisResult {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := analyzeService.client.startSpan(ctx, "AnalyzeService.AnalyzeObservables")
+	defer span.End()
+	results := make([]ObservableAnalysisResult, len(analysisRequests))
+	groups := &analysisGroups{}
+	for index, analysisRequest := range analysisRequests {
+		results[index].Request = analysisRequest
+		if strings.TrimSpace(analysisRequest.Value) == "" {
+			results[index].Err = fmt.Errorf("%w: observable value cannot be empty", ErrValidation)
+			continue
+		}
+		params := newBasicAnalysisParams(analysisRequest.TLP, analysisRequest.Analyzers, analysisRequest.Connectors, analysisRequest.Tags, analysisRequest.RuntimeConfiguration)
+		group, err := groups.add(params, index)
+		if err != nil {
+			results[index].Err = err
+			continue
+		}
+		value := analyzeService.client.observableValue(analysisRequest.Value)
+		group.observables = append(group.observables, []string{classificationOf(analysisRequest.Classification, value), value})
+	}
+
+	for _, group := range groups.ordered {
+		response, err := analyzeService.client.postMultipleObservableAnalysis(ctx, &MultipleObservableAnalysisParams{
+			BasicAnalysisParams: group.params,
+			Observables:         group.observables,
+		})
+		group.spread(response, err, func(index int, response *AnalysisResponse, err error) {
+			results[index].Response, results[index].Err = response, err
+		})
+	}
+	return results
+}
+
+// AnalyzeFiles submits many files at once, returning one result per request in the same order.
+// Files sharing the same TLP, analyzers, connectors, tags and runtime configuration are streamed together
+// in a single multipart request. When a request fails every file it carried gets its error.
+//
+//	Endpoint: POST /api/analyze_multiple_files
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/analyze_multiple_files
+func (analyzeService *AnalyzeService) AnalyzeFiles(ctx context.Context, analysisRequests []FileAnalysisRequest, opts ...RequestOption) []FileAnalysisResult {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := analyzeService.client.startSpan(ctx, "AnalyzeService.AnalyzeFiles")
+	defer span.End()
+	results := make([]FileAnalysisResult, len(analysisRequests))
+	groups := &analysisGroups{}
+	for index, analysisRequest := range analysisRequests {
+		results[index].Request = analysisRequest
+		if err := checkFileAnalysisRequest(analysisRequest); err != nil {
+			results[index].Err = err
+			continue
+		}
+		params := newBasicAnalysisParams(analysisRequest.TLP, analysisRequest.Analyzers, analysisRequest.Connectors, analysisRequest.Tags, analysisRequest.RuntimeConfiguration)
+		group, err := groups.add(params, index)
+		if err != nil {
+			results[index].Err = err
+			continue
+		}
+		group.files = append(group.files, analysisFile{name: analysisRequest.FileName, reader: analysisRequest.File})
+	}
+
+	for _, group := range groups.ordered {
+		response := &MultipleAnalysisResponse{}
+		err := analyzeService.client.postAnalysisForm(ctx, constants.ANALYZE_MULTIPLE_FILES_URL, group.params, "files", group.files, response)
+		group.spread(response, err, func(index int, response *AnalysisResponse, err error) {
+			results[index].Response, results[index].Err = response, err
+		})
+	}
+	return results
+}
+
+// analysisGroup is a set of observables or files that can be submitted in a single request as they share their settings.
+type analysisGroup struct {
+	params      BasicAnalysisParams
+	indexes     []int
+	observables [][]string
+	files       []analysisFile
+}
+
+// spread hands every member of the group its own analysis out of the response of the group's request.
+func (group *analysisGroup) spread(response *MultipleAnalysisResponse, err error, set func(index int, response *AnalysisResponse, err error)) {
+	for position, index := range group.indexes {
+		switch {
+		case err != nil:
+			set(index, nil, err)
+		case position >= len(response.Results):
+			set(index, nil, fmt.Errorf("threatmatrix: the response is missing analysis %d of %d", position+1, len(group.indexes)))
+		default:
+			set(index, &response.Results[position], nil)
+		}
+	}
+}
+
+// analysisGroups groups the requests of a batch by their settings, keeping the order in which the groups appeared.
+type analysisGroups struct {
+	byParams map[string]*analysisGroup
+	ordered  []*analysisGroup
+}
+
+// add puts the request at index in the group of its settings.
+func (groups *analysisGroups) add(params BasicAnalysisParams, index int) (*analysisGroup, error) {
+	key, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	if groups.byParams == nil {
+		groups.byParams = map[string]*analysisGroup{}
+	}
+	group, ok := groups.byParams[string(key)]
+	if !ok {
+		group = &analysisGroup{params: params}
+		groups.byParams[string(key)] = group
+		groups.ordered = append(groups.ordered, group)
+	}
+	group.indexes = append(group.indexes, index)
+	return group, nil
+}
+
+// checkFileAnalysisRequest makes sure the request has a file to send.
+func checkFileAnalysisRequest(analysisRequest FileAnalysisRequest) error {
+	if analysisRequest.File == nil {
+		return fmt.Errorf("%w: file cannot be nil", ErrValidation)
+	}
+	if strings.TrimSpace(analysisRequest.FileName) == "" {
+		return fmt.Errorf("%w: file name cannot be empty", ErrValidation)
+	}
+	return nil
+}
+
+// AnalyzeFile submits a file for analysis, the returned AnalysisResponse holds the ID of the created job.
+// The multipart form is streamed, so the request cannot be retried.
+//
+//	Endpoint: POST /api/analyze_file
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/analyze_file
+func (analyzeService *AnalyzeService) AnalyzeFile(ctx context.Context, analysisRequest FileAnalysisRequest, opts ...RequestOption) (*AnalysisResponse, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := analyzeService.client.startSpan(ctx, "AnalyzeService.AnalyzeFile")
+	defer span.End()
+	if err := checkFileAnalysisRequest(analysisRequest); err != nil {
+		return nil, err
+	}
+	params := newBasicAnalysisParams(analysisRequest.TLP, analysisRequest.Analyzers, analysisRequest.Connectors, analysisRequest.Tags, analysisRequest.RuntimeConfiguration)
+	files := []analysisFile{{name: analysisRequest.FileName, reader: analysisRequest.File}}
+
+	analysisResponse := AnalysisResponse{}
+	if err := analyzeService.client.postAnalysisForm(ctx, constants.ANALYZE_FILE_URL, params, "file", files, &analysisResponse); err != nil {
+		return nil, err
+	}
+	return &analysisResponse, nil
+}
+
+// AnalyzeFileAndWait submits a file for analysis and waits for its job to be done being processed.
+// The job is polled through JobService.WaitForCompletion; a timeout of 0 waits for as long as ctx allows.
+func (analyzeService *AnalyzeService) AnalyzeFileAndWait(ctx context.Context, analysisRequest FileAnalysisRequest, timeout time.Duration, opts ...RequestOption) (*Job, error) {
+	ctx = withRequestOptions(ctx, opts)
+	analysisResponse, err := analyzeService.AnalyzeFile(ctx, analysisRequest)
+	if err != nil {
+		return nil, err
+	}
+	return analyzeService.waitForJob(ctx, analysisResponse, timeout)
+}
+
+// waitForJob waits for the job created by an analysis, giving up after timeout when there's one.
+func (analyzeService *AnalyzeService) waitForJob(ctx context.Context, analysisResponse *AnalysisResponse, timeout time.Duration) (*Job, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return analyzeService.client.JobService.WaitForCompletion(ctx, uint64(analysisResponse.JobID))
+}
+
+// postAnalysisForm streams a multipart analysis form to ThreatMatrix and decodes the response into result.
+// The form is written through a pipe while the request is being sent, so the files are never buffered.
+func (client *ThreatMatrixClient) postAnalysisForm(ctx context.Context, route string, fields analysisFormFields, fileField string, files []analysisFile, result interface{}) error {
+	requestUrl := client.options.Url + route
+	bodyReader, bodyWriter := io.Pipe()
+	// closing the reader unblocks the writer if the request ends before the whole form was sent
+	defer bodyReader.Close()
+	writer := multipart.NewWriter(bodyWriter)
+	method := "POST"
+	contentType := writer.FormDataContentType()
+	files = withUploadProgress(ctx, files)
+	go func() {
+		bodyWriter.CloseWithError(writeAnalysisForm(writer, fields, fileField, files))
+	}()
+
+	request, err := client.buildRequest(ctx, method, contentType, bodyReader, requestUrl)
+	if err != nil {
+		return err
+	}
+	setIdempotencyKey(request)
+	successResp, err := client.newRequest(ctx, request)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(successResp.Data, result)
+}
+
+// analysisFormFields is implemented by the settings of an analysis that can be sent as a multipart form.
+type analysisFormFields interface {
+	writeFormFields(writer *multipart.Writer) error
+}
+
+// writeAnalysisForm writes the analysis fields and the files as a multipart form.
+func writeAnalysisForm(writer *multipart.Writer, fields analysisFormFields, fileField string, files []analysisFile) error {
+	if err := fields.writeFormFields(writer); err != nil {
+		return err
+	}
+	for _, file := range files {
+		filePart, err := writer.CreateFormFile(fileField, file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(filePart, file.reader); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// writeFormFields writes the analysis settings as multipart form fields.
+func (params BasicAnalysisParams) writeFormFields(writer *multipart.Writer) error {
+	if err := writeTlpAndRuntimeConfiguration(writer, params.Tlp, params.RuntimeConfiguration); err != nil {
+		return err
+	}
+	if err := writeFormValues(writer, "analyzers_requested", params.AnalyzersRequested); err != nil {
+		return err
+	}
+	if err := writeFormValues(writer, "connectors_requested", params.ConnectorsRequested); err != nil {
+		return err
+	}
+	return writeFormValues(writer, "tags_labels", params.TagsLabels)
+}
+
+// writeTlpAndRuntimeConfiguration writes the form fields every kind of analysis has.
+func writeTlpAndRuntimeConfiguration(writer *multipart.Writer, tlp TLP, runtimeConfiguration map[string]interface{}) error {
+	if err := writer.WriteField("tlp", tlp.String()); err != nil {
+		return err
+	}
+	runtimeConfigurationJson, err := json.Marshal(runtimeConfiguration)
+	if err != nil {
+		return err
+	}
+	return writer.WriteField("runtime_configuration", string(runtimeConfigurationJson))
+}
+
+// writeFormValues writes every value as its own form field.
+func writeFormValues(writer *multipart.Writer, field string, values []string) error {
+	for _, value := range values {
+		if err := writer.WriteField(field, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newBasicAnalysisParams fills the common analysis fields, replacing nil values with empty ones as the API rejects nulls.
+func newBasicAnalysisParams(tlp TLP, analyzers, connectors, tags []string, runtimeConfiguration map[string]interface{}) BasicAnalysisParams {
+	if tlp == "" {
+		tlp = TLPWhite
+	}
+	if analyzers == nil {
+		analyzers = []string{}
+	}
+	if connectors == nil {
+		connectors = []string{}
+	}
+	if tags == nil {
+		tags = []string{}
+	}
+	if runtimeConfiguration == nil {
+		runtimeConfiguration = map[string]interface{}{}
+	}
+	return BasicAnalysisParams{
+		Tlp:                  tlp,
+		RuntimeConfiguration: runtimeConfiguration,
+		AnalyzersRequested:   analyzers,
+		ConnectorsRequested:  connectors,
+		TagsLabels:           tags,
+	}
+}
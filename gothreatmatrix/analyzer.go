@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"sort"
 
 	"github.com/khulnasoft/go-threatmatrix/constants"
@@ -23,6 +24,17 @@ type AnalyzerConfig struct {
 	SupportedFiletypes    []string `json:"supported_filetypes"`
 	NotSupportedFiletypes []string `json:"not_supported_filetypes"`
 	ObservableSupported   []string `json:"observable_supported"`
+	MaximumTlp            TLP      `json:"maximum_tlp"`
+	// Extra holds the fields ThreatMatrix sent that the SDK doesn't model yet, by name.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the analyzer configuration, keeping the fields the SDK doesn't model in Extra.
+func (analyzerConfig *AnalyzerConfig) UnmarshalJSON(data []byte) error {
+	type plainAnalyzerConfig AnalyzerConfig
+	extra, err := decodeWithExtra(data, (*plainAnalyzerConfig)(analyzerConfig))
+	analyzerConfig.Extra = extra
+	return err
 }
 
 // AnalyzerService handles communication with analyzer related methods of the ThreatMatrix API.
@@ -37,7 +49,10 @@ type AnalyzerService struct {
 //	Endpoint: GET /api/get_analyzer_configs
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/get_analyzer_configs
-func (analyzerService *AnalyzerService) GetConfigs(ctx context.Context) (*[]AnalyzerConfig, error) {
+func (analyzerService *AnalyzerService) GetConfigs(ctx context.Context, opts ...RequestOption) (*[]AnalyzerConfig, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := analyzerService.client.startSpan(ctx, "AnalyzerService.GetConfigs")
+	defer span.End()
 	requestUrl := analyzerService.client.options.Url + constants.ANALYZER_CONFIG_URL
 	contentType := "application/json"
 	method := "GET"
@@ -70,27 +85,66 @@ func (analyzerService *AnalyzerService) GetConfigs(ctx context.Context) (*[]Anal
 	return &analyzerConfigurationList, nil
 }
 
-// HealthCheck checks if the specified analyzer is up and running
+// List fetches every analyzer configured in your ThreatMatrix instance.
 //
-//	Endpoint: GET /api/analyzer/{NameOfAnalyzer}/healthcheck
+//	Endpoint: GET /api/analyzer
 //
-// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/analyzer/operation/analyzer_healthcheck_retrieve
-func (analyzerService *AnalyzerService) HealthCheck(ctx context.Context, analyzerName string) (bool, error) {
-	route := analyzerService.client.options.Url + constants.ANALYZER_HEALTHCHECK_URL
-	requestUrl := fmt.Sprintf(route, analyzerName)
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/analyzer/operation/analyzer_list
+func (analyzerService *AnalyzerService) List(ctx context.Context, opts ...RequestOption) ([]AnalyzerConfig, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := analyzerService.client.startSpan(ctx, "AnalyzerService.List")
+	defer span.End()
+	requestUrl := analyzerService.client.options.Url + constants.BASE_ANALYZER_URL
 	contentType := "application/json"
 	method := "GET"
 	request, err := analyzerService.client.buildRequest(ctx, method, contentType, nil, requestUrl)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	status := StatusResponse{}
 	successResp, err := analyzerService.client.newRequest(ctx, request)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	if unmarshalError := json.Unmarshal(successResp.Data, &status); unmarshalError != nil {
-		return false, unmarshalError
+	return decodeConfigList[AnalyzerConfig](successResp.Data)
+}
+
+// Get fetches the configuration of a specific analyzer through its name.
+//
+//	Endpoint: GET /api/analyzer/{NameOfAnalyzer}
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/analyzer/operation/analyzer_retrieve
+func (analyzerService *AnalyzerService) Get(ctx context.Context, analyzerName string, opts ...RequestOption) (*AnalyzerConfig, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := analyzerService.client.startSpan(ctx, "AnalyzerService.Get", pluginAttribute(analyzerName))
+	defer span.End()
+	route := analyzerService.client.options.Url + constants.SPECIFIC_ANALYZER_URL
+	requestUrl := fmt.Sprintf(route, url.PathEscape(analyzerName))
+	contentType := "application/json"
+	method := "GET"
+	request, err := analyzerService.client.buildRequest(ctx, method, contentType, nil, requestUrl)
+	if err != nil {
+		return nil, err
+	}
+	successResp, err := analyzerService.client.newRequest(ctx, request)
+	if err != nil {
+		return nil, err
 	}
-	return status.Status, nil
+	analyzerConfig := AnalyzerConfig{}
+	if unmarshalError := json.Unmarshal(successResp.Data, &analyzerConfig); unmarshalError != nil {
+		return nil, unmarshalError
+	}
+	return &analyzerConfig, nil
+}
+
+// HealthCheck checks if the specified analyzer is up and running.
+// Skipping analyzers that are not HealthStatusUp avoids submitting jobs that are bound to fail.
+//
+//	Endpoint: GET /api/analyzer/{NameOfAnalyzer}/health_check
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/analyzer/operation/analyzer_health_check_retrieve
+func (analyzerService *AnalyzerService) HealthCheck(ctx context.Context, analyzerName string, opts ...RequestOption) (HealthStatus, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := analyzerService.client.startSpan(ctx, "AnalyzerService.HealthCheck", pluginAttribute(analyzerName))
+	defer span.End()
+	return analyzerService.client.healthCheck(ctx, constants.ANALYZER_HEALTHCHECK_URL, analyzerName)
 }
@@ -0,0 +1,121 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultCircuitCooldown is how long a circuit stays open when WithCircuitBreaker is given a cooldown below 1.
+const DefaultCircuitCooldown = 30 * time.Second
+
+// ErrCircuitOpen is returned, without sending the request, while the circuit breaker of its endpoint is open.
+var ErrCircuitOpen = errors.New("threatmatrix: circuit open")
+
+// circuitBreaker keeps a circuit per endpoint, which opens after failureThreshold consecutive failures.
+// An open circuit fails the requests right away until cooldown has passed, then lets a single request through:
+// its success closes the circuit while its failure opens it for another cooldown.
+type circuitBreaker struct {
+	failureThreshold int
+	cooldown         time.Duration
+	mutex            sync.Mutex
+	circuits         map[string]*circuit
+}
+
+// circuit is the state of the circuitBreaker of an endpoint.
+type circuit struct {
+	failures int
+	// openedAt is zero while the circuit is closed
+	openedAt time.Time
+	// probing is true while the request let through once the cooldown passed is in flight
+	probing bool
+}
+
+// WithCircuitBreaker makes the client stop sending requests to an endpoint that failed failureThreshold times
+// in a row, by a network error or a 5xx answer, failing them with ErrCircuitOpen instead so callers don't pile
+// up waiting on an instance that's down. Once cooldown has passed a single request is let through to find out
+// whether the endpoint is back, closing the circuit when it succeeds.
+//
+// Every service method, such as JobService.Get, is an endpoint of its own, the requests sent through Do sharing
+// one. Each attempt of a request counts, and retries stop as soon as the circuit opens.
+func WithCircuitBreaker(failureThreshold int, cooldown time.Duration) Option {
+	return func(config *clientConfig) {
+		if failureThreshold < 1 {
+			config.circuitBreaker = nil
+			return
+		}
+		if cooldown <= 0 {
+			cooldown = DefaultCircuitCooldown
+		}
+		config.circuitBreaker = &circuitBreaker{
+			failureThreshold: failureThreshold,
+			cooldown:         cooldown,
+			circuits:         map[string]*circuit{},
+		}
+	}
+}
+
+// middleware fails the requests whose circuit is open and records the outcome of the others.
+func (breaker *circuitBreaker) middleware(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		endpoint := operationFromContext(request.Context())
+		if err := breaker.allow(endpoint); err != nil {
+			return nil, err
+		}
+		response, err := next.RoundTrip(request)
+		switch {
+		case errors.Is(err, context.Canceled):
+			// giving up on a request says nothing about the endpoint
+			breaker.record(endpoint, nil)
+		case err != nil || response.StatusCode >= http.StatusInternalServerError:
+			failed := true
+			breaker.record(endpoint, &failed)
+		default:
+			failed := false
+			breaker.record(endpoint, &failed)
+		}
+		return response, err
+	})
+}
+
+// allow returns ErrCircuitOpen when the circuit of the endpoint is open, unless the request is the one let through
+// once the cooldown passed.
+func (breaker *circuitBreaker) allow(endpoint string) error {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	state, ok := breaker.circuits[endpoint]
+	if !ok || state.openedAt.IsZero() {
+		return nil
+	}
+	if state.probing || time.Since(state.openedAt) < breaker.cooldown {
+		return fmt.Errorf("%w: %s failed %d times in a row", ErrCircuitOpen, endpoint, state.failures)
+	}
+	state.probing = true
+	return nil
+}
+
+// record updates the circuit of the endpoint with the outcome of a request, nil when it says nothing about it.
+func (breaker *circuitBreaker) record(endpoint string, failed *bool) {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	state, ok := breaker.circuits[endpoint]
+	if !ok {
+		state = &circuit{}
+		breaker.circuits[endpoint] = state
+	}
+	state.probing = false
+	switch {
+	case failed == nil:
+	case *failed:
+		state.failures++
+		if state.failures >= breaker.failureThreshold {
+			state.openedAt = time.Now()
+		}
+	default:
+		state.failures = 0
+		state.openedAt = time.Time{}
+	}
+}
@@ -0,0 +1,58 @@
+package gothreatmatrix
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// maxPooledBufferSize is the largest buffer put back in the pool, so a single huge response doesn't stay in memory for good.
+const maxPooledBufferSize = 1 << 20
+
+// bufferPool holds the buffers the request bodies are marshaled into and the response bodies are read into.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *bytes.Buffer {
+	buffer := bufferPool.Get().(*bytes.Buffer)
+	buffer.Reset()
+	return buffer
+}
+
+// putBuffer puts the buffer back in the pool, nothing may use it or its bytes afterwards.
+func putBuffer(buffer *bytes.Buffer) {
+	if buffer == nil || buffer.Cap() > maxPooledBufferSize {
+		return
+	}
+	bufferPool.Put(buffer)
+}
+
+// encodeJSON marshals value into a pooled buffer, the same way json.Marshal does.
+// Put the buffer back with putBuffer once the request it's the body of was sent.
+func encodeJSON(value interface{}) (*bytes.Buffer, error) {
+	buffer := getBuffer()
+	if err := json.NewEncoder(buffer).Encode(value); err != nil {
+		putBuffer(buffer)
+		return nil, err
+	}
+	// json.Encoder ends the value with a newline json.Marshal doesn't add
+	buffer.Truncate(buffer.Len() - 1)
+	return buffer, nil
+}
+
+// readBody reads the whole body through a pooled buffer, sized from contentLength when it's known,
+// and returns a copy of it so the buffer can go back to the pool.
+func readBody(body io.Reader, contentLength int64) ([]byte, error) {
+	buffer := getBuffer()
+	defer putBuffer(buffer)
+	if contentLength > 0 && contentLength <= maxPooledBufferSize {
+		buffer.Grow(int(contentLength))
+	}
+	_, err := buffer.ReadFrom(body)
+	return append([]byte{}, buffer.Bytes()...), err
+}
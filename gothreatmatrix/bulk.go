@@ -0,0 +1,94 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBulkConcurrency is how many requests the bulk job helpers send at the same time.
+const DefaultBulkConcurrency = 4
+
+// KillResult is the outcome of killing one of the jobs through KillAll.
+// Err is set when the job could not be killed.
+type KillResult struct {
+	JobID  int
+	Killed bool
+	Err    error
+}
+
+// KillAll kills every running job matching filter, DefaultBulkConcurrency at a time, and reports the outcome for each of them.
+// Jobs that are already done are left alone, so a nil filter kills every running job you have access to.
+// The error is only set when the jobs to kill could not be listed.
+func (jobService *JobService) KillAll(ctx context.Context, filter *JobListParams, opts ...RequestOption) ([]KillResult, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.KillAll")
+	defer span.End()
+	jobIds := []int{}
+	iterator := jobService.Iter(ctx, filter)
+	for iterator.Next() {
+		if job := iterator.Job(); !job.Status.IsTerminal() {
+			jobIds = append(jobIds, job.ID)
+		}
+	}
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+	results := make([]KillResult, len(jobIds))
+	forEachConcurrently(len(jobIds), DefaultBulkConcurrency, func(index int) {
+		killed, err := jobService.Kill(ctx, uint64(jobIds[index]))
+		results[index] = KillResult{JobID: jobIds[index], Killed: killed, Err: err}
+	})
+	return results, nil
+}
+
+// RetryResult is the outcome of retrying one of the analyzers through RetryFailedAnalyzers.
+// Err is set when the analyzer could not be retried.
+type RetryResult struct {
+	Retried bool
+	Err     error
+}
+
+// RetryFailedAnalyzers retries every analyzer whose report failed in the given job, DefaultBulkConcurrency at a time.
+// The outcome of each retry is keyed by analyzer name, a job without failed analyzers gives an empty map.
+// The error is only set when the job could not be fetched.
+func (jobService *JobService) RetryFailedAnalyzers(ctx context.Context, jobId uint64, opts ...RequestOption) (map[string]RetryResult, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.RetryFailedAnalyzers", jobIDAttribute(jobId))
+	defer span.End()
+	job, err := jobService.Get(ctx, jobId)
+	if err != nil {
+		return nil, err
+	}
+	failedAnalyzers := job.FailedAnalyzers()
+	retries := make([]RetryResult, len(failedAnalyzers))
+	forEachConcurrently(len(failedAnalyzers), DefaultBulkConcurrency, func(index int) {
+		retried, err := jobService.RetryAnalyzer(ctx, jobId, failedAnalyzers[index])
+		retries[index] = RetryResult{Retried: retried, Err: err}
+	})
+	results := make(map[string]RetryResult, len(failedAnalyzers))
+	for index, analyzerName := range failedAnalyzers {
+		results[analyzerName] = retries[index]
+	}
+	return results, nil
+}
+
+// forEachConcurrently calls fn with every index from 0 to count-1, running at most concurrency calls at the same time.
+// It returns once every call is done.
+func forEachConcurrently(count int, concurrency int, fn func(index int)) {
+	indexes := make(chan int)
+	waitGroup := sync.WaitGroup{}
+	for worker := 0; worker < concurrency && worker < count; worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for index := range indexes {
+				fn(index)
+			}
+		}()
+	}
+	for index := 0; index < count; index++ {
+		indexes <- index
+	}
+	close(indexes)
+	waitGroup.Wait()
+}
@@ -0,0 +1,317 @@
+package gothreatmatrix
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrCassetteMiss is returned when replaying a cassette that has no recorded interaction left for a request.
+var ErrCassetteMiss = errors.New("threatmatrix: no recorded interaction matches the request")
+
+// scrubbedValue replaces the value of the scrubbed headers in cassettes.
+const scrubbedValue = "[SCRUBBED]"
+
+// defaultScrubbedHeaders are the headers holding credentials, they're never written to cassettes.
+var defaultScrubbedHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// credentialFields are the JSON fields of the bodies whose values are credentials, such as the password of a login,
+// the JWTs of a session or an API token, they're never written to cassettes.
+var credentialFields = map[string]bool{
+	"password": true,
+	"access":   true,
+	"refresh":  true,
+	"token":    true,
+	"key":      true,
+	"secret":   true,
+	"api_key":  true,
+}
+
+// credentialPattern matches the bearer tokens and the JWTs found anywhere in a body.
+var credentialPattern = regexp.MustCompile(`(?i)\b(?:bearer|token)\s+[A-Za-z0-9._~+/=-]+|\beyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+
+// credentialFieldPattern matches the credential fields of a JSON body that couldn't be decoded, such as one cut off,
+// along with their string value, even when the value itself is cut off.
+var credentialFieldPattern = regexp.MustCompile(`(?i)"(password|access|refresh|token|key|secret|api_key)"(\s*:\s*)"(?:[^"\\]|\\.)*"?`)
+
+// sessionURLs are the endpoints exchanging credentials for sessions and API tokens, which are never recorded.
+var sessionURLs = []string{constants.LOGIN_URL, constants.LOGOUT_URL, constants.REFRESH_TOKEN_URL, constants.API_TOKEN_URL}
+
+// WithCassette records the client's API interactions into the YAML cassette at path and replays them in tests.
+// When the cassette doesn't exist yet the requests are sent and every interaction is recorded,
+// otherwise nothing is sent and each request gets the response of the first unused interaction with the same method and URL.
+// Delete the cassette to record it again. The credentials in the Authorization, Cookie, Set-Cookie and X-Api-Key headers
+// are scrubbed before being written, scrubHeaders lists any other header to scrub. So are the credential fields of the
+// bodies, such as passwords, JWTs, API tokens and the values of secret plugin configs, along with the bearer tokens and
+// JWTs found anywhere else in them. The bodies of the calls that may hold secrets, the plugin configs' ones, are not
+// written at all, and the login, logout, token refresh and API token calls are not recorded: they're sent but can't
+// be replayed, so clients replaying a cassette have to authenticate with an API token.
+//
+//	client := gothreatmatrix.NewClient(gothreatmatrix.WithCassette("fixtures/job_get.yaml"))
+func WithCassette(path string, scrubHeaders ...string) Option {
+	return func(config *clientConfig) {
+		config.cassette = &cassette{
+			path:           path,
+			scrubbedHeader: append(append([]string{}, defaultScrubbedHeaders...), scrubHeaders...),
+		}
+	}
+}
+
+// cassetteFile is the content of a cassette.
+type cassetteFile struct {
+	Interactions []cassetteInteraction `yaml:"interactions"`
+}
+
+// cassetteInteraction is a recorded request and the response it got.
+type cassetteInteraction struct {
+	Request  cassetteRequest  `yaml:"request"`
+	Response cassetteResponse `yaml:"response"`
+}
+
+// cassetteRequest is a recorded request, its URL being stripped of the scheme and host so cassettes replay against any instance.
+type cassetteRequest struct {
+	Method  string      `yaml:"method"`
+	URL     string      `yaml:"url"`
+	Headers http.Header `yaml:"headers,omitempty"`
+	Body    string      `yaml:"body,omitempty"`
+}
+
+// cassetteResponse is a recorded response.
+type cassetteResponse struct {
+	StatusCode int         `yaml:"status_code"`
+	Headers    http.Header `yaml:"headers,omitempty"`
+	Body       string      `yaml:"body,omitempty"`
+}
+
+// cassette records or replays the interactions of the cassette file at path.
+type cassette struct {
+	path           string
+	scrubbedHeader []string
+	mutex          sync.Mutex
+	loaded         bool
+	replaying      bool
+	loadError      error
+	file           cassetteFile
+	used           []bool
+}
+
+// middleware records the requests going through next, or replays them without calling next.
+func (cassette *cassette) middleware(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		cassette.mutex.Lock()
+		defer cassette.mutex.Unlock()
+		if err := cassette.load(); err != nil {
+			return nil, err
+		}
+		if cassette.replaying {
+			return cassette.replay(request)
+		}
+		return cassette.record(next, request)
+	})
+}
+
+// load reads the cassette file the first time it's called, a missing file meaning the cassette has to be recorded.
+func (cassette *cassette) load() error {
+	if cassette.loaded {
+		return cassette.loadError
+	}
+	cassette.loaded = true
+	data, err := os.ReadFile(cassette.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err == nil {
+		err = yaml.Unmarshal(data, &cassette.file)
+	}
+	if err != nil {
+		cassette.loadError = fmt.Errorf("threatmatrix: could not load cassette %s: %w", cassette.path, err)
+		return cassette.loadError
+	}
+	cassette.replaying = true
+	cassette.used = make([]bool, len(cassette.file.Interactions))
+	return nil
+}
+
+// replay answers the request with the first unused interaction recorded for the same method and URL.
+func (cassette *cassette) replay(request *http.Request) (*http.Response, error) {
+	requestUrl := cassetteURL(request)
+	for index, interaction := range cassette.file.Interactions {
+		if cassette.used[index] || interaction.Request.Method != request.Method || interaction.Request.URL != requestUrl {
+			continue
+		}
+		cassette.used[index] = true
+		if request.Body != nil {
+			request.Body.Close()
+		}
+		headers := interaction.Response.Headers.Clone()
+		if headers == nil {
+			headers = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        headers,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       request,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s in %s", ErrCassetteMiss, request.Method, requestUrl, cassette.path)
+}
+
+// record sends the request through next and saves the interaction to the cassette file,
+// unless it's a call to one of the session endpoints.
+func (cassette *cassette) record(next http.RoundTripper, request *http.Request) (*http.Response, error) {
+	if isSessionRequest(request) {
+		return next.RoundTrip(request)
+	}
+	requestBody := []byte{}
+	if request.Body != nil {
+		var err error
+		if requestBody, err = io.ReadAll(request.Body); err != nil {
+			return nil, err
+		}
+		request.Body.Close()
+		request = request.Clone(request.Context())
+		request.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+	response, err := next.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	recordedRequestBody, recordedResponseBody := redactedBody, redactedBody
+	if !bodiesRedacted(request.Context()) {
+		recordedRequestBody, recordedResponseBody = scrubBody(requestBody), scrubBody(responseBody)
+	}
+	cassette.file.Interactions = append(cassette.file.Interactions, cassetteInteraction{
+		Request: cassetteRequest{
+			Method:  request.Method,
+			URL:     cassetteURL(request),
+			Headers: cassette.scrub(request.Header),
+			Body:    recordedRequestBody,
+		},
+		Response: cassetteResponse{
+			StatusCode: response.StatusCode,
+			Headers:    cassette.scrub(response.Header),
+			Body:       recordedResponseBody,
+		},
+	})
+	if err := cassette.save(); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// save writes every interaction recorded so far to the cassette file.
+func (cassette *cassette) save() error {
+	data, err := yaml.Marshal(&cassette.file)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(cassette.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(cassette.path, data, 0o644)
+}
+
+// scrub returns a copy of headers whose credentials are replaced by scrubbedValue.
+func (cassette *cassette) scrub(headers http.Header) http.Header {
+	return scrubHeaders(headers, cassette.scrubbedHeader)
+}
+
+// scrubHeaders returns a copy of headers where the values of the named headers are replaced by scrubbedValue.
+func scrubHeaders(headers http.Header, names []string) http.Header {
+	scrubbed := headers.Clone()
+	for _, name := range names {
+		if scrubbed.Get(name) != "" {
+			scrubbed.Set(name, scrubbedValue)
+		}
+	}
+	return scrubbed
+}
+
+// scrubBody returns body where the values of the credential fields of a JSON body, and the bearer tokens and JWTs
+// found anywhere in it, are replaced by scrubbedValue. A JSON body is written back only when a field was scrubbed,
+// the credential fields of a body that isn't valid JSON are matched as text.
+func scrubBody(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		body = credentialFieldPattern.ReplaceAll(body, []byte(`"$1"$2"`+scrubbedValue+`"`))
+	} else if scrubJSON(document) {
+		var scrubbed bytes.Buffer
+		encoder := json.NewEncoder(&scrubbed)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(document); err == nil {
+			body = bytes.TrimSuffix(scrubbed.Bytes(), []byte("\n"))
+		}
+	}
+	return credentialPattern.ReplaceAllString(string(body), scrubbedValue)
+}
+
+// scrubJSON replaces in place the values of the credential fields of a decoded JSON document, along with the value of
+// the secret plugin configs, telling if it replaced any.
+func scrubJSON(document interface{}) bool {
+	scrubbed := false
+	switch value := document.(type) {
+	case map[string]interface{}:
+		if isSecret, _ := value["is_secret"].(bool); isSecret {
+			if _, ok := value["value"]; ok {
+				value["value"] = scrubbedValue
+				scrubbed = true
+			}
+		}
+		for field, fieldValue := range value {
+			if _, isString := fieldValue.(string); isString && credentialFields[strings.ToLower(field)] {
+				value[field] = scrubbedValue
+				scrubbed = true
+			} else if scrubJSON(fieldValue) {
+				scrubbed = true
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if scrubJSON(item) {
+				scrubbed = true
+			}
+		}
+	}
+	return scrubbed
+}
+
+// isSessionRequest checks if request is sent to one of the session endpoints.
+func isSessionRequest(request *http.Request) bool {
+	for _, sessionUrl := range sessionURLs {
+		if strings.HasSuffix(strings.TrimSuffix(request.URL.Path, "/"), sessionUrl) {
+			return true
+		}
+	}
+	return false
+}
+
+// cassetteURL returns the path and query of the request's URL.
+func cassetteURL(request *http.Request) string {
+	return request.URL.RequestURI()
+}
@@ -0,0 +1,265 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultCatalogTTL is how long the cached services keep the plugin configurations when they're given a TTL below 1.
+const DefaultCatalogTTL = 10 * time.Minute
+
+// catalogListKey is the ttlCache key of the whole list of plugin configurations.
+const catalogListKey = ""
+
+// ttlEntry is a value kept by a ttlCache until it expires.
+type ttlEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+// ttlFetch is a fetch of a ttlCache in flight, done is closed once value and err are set.
+type ttlFetch[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// ttlCache keeps the values it fetched for ttl, errors are never cached.
+type ttlCache[T any] struct {
+	mutex   sync.Mutex
+	ttl     time.Duration
+	entries map[string]ttlEntry[T]
+	// fetches are the fetches in flight by key, which the calls for the same key wait for
+	fetches map[string]*ttlFetch[T]
+	// generation is bumped by invalidate so the fetches started before aren't cached
+	generation uint64
+}
+
+// newTTLCache returns an empty ttlCache, a ttl below 1 being DefaultCatalogTTL.
+func newTTLCache[T any](ttl time.Duration) *ttlCache[T] {
+	if ttl <= 0 {
+		ttl = DefaultCatalogTTL
+	}
+	return &ttlCache[T]{ttl: ttl, entries: map[string]ttlEntry[T]{}, fetches: map[string]*ttlFetch[T]{}}
+}
+
+// get returns the value cached under key, calling fetch when there's none or it expired. The cache isn't locked
+// while fetching, so the other keys are served meanwhile, and concurrent calls for the same key wait for the one
+// fetching instead of fetching again, until ctx is done. A waiting call whose fetcher was canceled fetches itself.
+func (cache *ttlCache[T]) get(ctx context.Context, key string, fetch func() (T, error)) (T, error) {
+	for {
+		cache.mutex.Lock()
+		if entry, ok := cache.entries[key]; ok && time.Now().Before(entry.expires) {
+			cache.mutex.Unlock()
+			return entry.value, nil
+		}
+		inFlight, waiting := cache.fetches[key]
+		if !waiting {
+			inFlight = &ttlFetch[T]{done: make(chan struct{})}
+			cache.fetches[key] = inFlight
+		}
+		generation := cache.generation
+		cache.mutex.Unlock()
+
+		if waiting {
+			select {
+			case <-ctx.Done():
+				var zero T
+				return zero, ctx.Err()
+			case <-inFlight.done:
+			}
+			if isCancellation(inFlight.err) && ctx.Err() == nil {
+				continue
+			}
+			return inFlight.value, inFlight.err
+		}
+
+		inFlight.value, inFlight.err = fetch()
+		cache.mutex.Lock()
+		if cache.fetches[key] == inFlight {
+			delete(cache.fetches, key)
+		}
+		if inFlight.err == nil && generation == cache.generation {
+			cache.entries[key] = ttlEntry[T]{value: inFlight.value, expires: time.Now().Add(cache.ttl)}
+		}
+		cache.mutex.Unlock()
+		close(inFlight.done)
+		return inFlight.value, inFlight.err
+	}
+}
+
+// invalidate drops every cached value, the calls that follow fetching them again even while a fetch is in flight.
+func (cache *ttlCache[T]) invalidate() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.entries = map[string]ttlEntry[T]{}
+	cache.fetches = map[string]*ttlFetch[T]{}
+	cache.generation++
+}
+
+// isCancellation checks if err comes from a context being canceled or timing out.
+func isCancellation(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// validationCatalog keeps the catalogs Validate checks the analyzers and connectors of the requests against, so
+// validating every submission doesn't fetch them every time.
+type validationCatalog struct {
+	analyzers  *ttlCache[[]AnalyzerConfig]
+	connectors *ttlCache[[]ConnectorConfig]
+}
+
+func newValidationCatalog() *validationCatalog {
+	return &validationCatalog{
+		analyzers:  newTTLCache[[]AnalyzerConfig](DefaultCatalogTTL),
+		connectors: newTTLCache[[]ConnectorConfig](DefaultCatalogTTL),
+	}
+}
+
+// analyzerCatalog returns the configurations of every analyzer, cached for DefaultCatalogTTL unless the analyzer
+// service of the client is cached already. refresh fetches them again, it tells whether there was a cache to refresh.
+func (client *ThreatMatrixClient) analyzerCatalog(ctx context.Context, refresh bool) ([]AnalyzerConfig, bool, error) {
+	if _, cached := client.AnalyzerService.(*CachedAnalyzerService); cached || client.catalog == nil {
+		analyzers, err := client.AnalyzerService.List(ctx)
+		return analyzers, false, err
+	}
+	if refresh {
+		client.catalog.analyzers.invalidate()
+	}
+	analyzers, err := client.catalog.analyzers.get(ctx, catalogListKey, func() ([]AnalyzerConfig, error) {
+		return client.AnalyzerService.List(ctx)
+	})
+	return analyzers, true, err
+}
+
+// connectorCatalog returns the configurations of every connector the way analyzerCatalog does.
+func (client *ThreatMatrixClient) connectorCatalog(ctx context.Context, refresh bool) ([]ConnectorConfig, bool, error) {
+	if _, cached := client.ConnectorService.(*CachedConnectorService); cached || client.catalog == nil {
+		connectors, err := client.ConnectorService.List(ctx)
+		return connectors, false, err
+	}
+	if refresh {
+		client.catalog.connectors.invalidate()
+	}
+	connectors, err := client.catalog.connectors.get(ctx, catalogListKey, func() ([]ConnectorConfig, error) {
+		return client.ConnectorService.List(ctx)
+	})
+	return connectors, true, err
+}
+
+// CachedAnalyzerService wraps an AnalyzerServiceInterface, keeping the analyzer configurations it fetched for a TTL.
+// Analyzer configurations rarely change but are looked up on every submission to check analyzer names, so caching
+// them saves a round trip each time. Health checks and pagers are never cached.
+//
+//	client.AnalyzerService = gothreatmatrix.NewCachedAnalyzerService(client.AnalyzerService, 10*time.Minute)
+type CachedAnalyzerService struct {
+	AnalyzerServiceInterface
+	list    *ttlCache[[]AnalyzerConfig]
+	configs *ttlCache[*AnalyzerConfig]
+}
+
+// NewCachedAnalyzerService returns a CachedAnalyzerService keeping the configurations of service for ttl,
+// a ttl below 1 being DefaultCatalogTTL.
+func NewCachedAnalyzerService(service AnalyzerServiceInterface, ttl time.Duration) *CachedAnalyzerService {
+	return &CachedAnalyzerService{
+		AnalyzerServiceInterface: service,
+		list:                     newTTLCache[[]AnalyzerConfig](ttl),
+		configs:                  newTTLCache[*AnalyzerConfig](ttl),
+	}
+}
+
+// GetConfigs returns the cached configurations of every analyzer, fetching them when they expired.
+func (cachedService *CachedAnalyzerService) GetConfigs(ctx context.Context, opts ...RequestOption) (*[]AnalyzerConfig, error) {
+	analyzers, err := cachedService.List(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &analyzers, nil
+}
+
+// List returns the cached configurations of every analyzer, fetching them when they expired.
+func (cachedService *CachedAnalyzerService) List(ctx context.Context, opts ...RequestOption) ([]AnalyzerConfig, error) {
+	analyzers, err := cachedService.list.get(ctx, catalogListKey, func() ([]AnalyzerConfig, error) {
+		return cachedService.AnalyzerServiceInterface.List(ctx, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]AnalyzerConfig{}, analyzers...), nil
+}
+
+// Get returns the cached configuration of an analyzer, fetching it when it expired.
+func (cachedService *CachedAnalyzerService) Get(ctx context.Context, analyzerName string, opts ...RequestOption) (*AnalyzerConfig, error) {
+	analyzer, err := cachedService.configs.get(ctx, analyzerName, func() (*AnalyzerConfig, error) {
+		return cachedService.AnalyzerServiceInterface.Get(ctx, analyzerName, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	analyzerCopy := *analyzer
+	return &analyzerCopy, nil
+}
+
+// Invalidate drops the cached configurations so the next calls fetch them again, such as after an analyzer was enabled.
+func (cachedService *CachedAnalyzerService) Invalidate() {
+	cachedService.list.invalidate()
+	cachedService.configs.invalidate()
+}
+
+// CachedConnectorService wraps a ConnectorServiceInterface, keeping the connector configurations it fetched for a TTL.
+// It works like CachedAnalyzerService.
+type CachedConnectorService struct {
+	ConnectorServiceInterface
+	list    *ttlCache[[]ConnectorConfig]
+	configs *ttlCache[*ConnectorConfig]
+}
+
+// NewCachedConnectorService returns a CachedConnectorService keeping the configurations of service for ttl,
+// a ttl below 1 being DefaultCatalogTTL.
+func NewCachedConnectorService(service ConnectorServiceInterface, ttl time.Duration) *CachedConnectorService {
+	return &CachedConnectorService{
+		ConnectorServiceInterface: service,
+		list:                      newTTLCache[[]ConnectorConfig](ttl),
+		configs:                   newTTLCache[*ConnectorConfig](ttl),
+	}
+}
+
+// GetConfigs returns the cached configurations of every connector, fetching them when they expired.
+func (cachedService *CachedConnectorService) GetConfigs(ctx context.Context, opts ...RequestOption) (*[]ConnectorConfig, error) {
+	connectors, err := cachedService.List(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &connectors, nil
+}
+
+// List returns the cached configurations of every connector, fetching them when they expired.
+func (cachedService *CachedConnectorService) List(ctx context.Context, opts ...RequestOption) ([]ConnectorConfig, error) {
+	connectors, err := cachedService.list.get(ctx, catalogListKey, func() ([]ConnectorConfig, error) {
+		return cachedService.ConnectorServiceInterface.List(ctx, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]ConnectorConfig{}, connectors...), nil
+}
+
+// Get returns the cached configuration of a connector, fetching it when it expired.
+func (cachedService *CachedConnectorService) Get(ctx context.Context, connectorName string, opts ...RequestOption) (*ConnectorConfig, error) {
+	connector, err := cachedService.configs.get(ctx, connectorName, func() (*ConnectorConfig, error) {
+		return cachedService.ConnectorServiceInterface.Get(ctx, connectorName, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	connectorCopy := *connector
+	return &connectorCopy, nil
+}
+
+// Invalidate drops the cached configurations so the next calls fetch them again, such as after a connector was enabled.
+func (cachedService *CachedConnectorService) Invalidate() {
+	cachedService.list.invalidate()
+	cachedService.configs.invalidate()
+}
@@ -0,0 +1,109 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// ErrChecksumMismatch is returned when a sample downloaded with WithChecksumVerification doesn't match the checksums
+// ThreatMatrix recorded for its job, such as after a truncated or corrupted transfer.
+var ErrChecksumMismatch = errors.New("threatmatrix: checksum mismatch")
+
+// WithChecksumVerification makes JobService.DownloadSample, DownloadSampleTo and DownloadSampleZipped verify the
+// sample against the MD5 of its job, and its SHA-256 when the job records one, failing with ErrChecksumMismatch when
+// they don't match. The job is fetched first to read them. As DownloadSampleTo and DownloadSampleZipped stream the
+// sample, what they wrote to their writer has to be discarded when they fail. It has no effect on the other methods.
+func WithChecksumVerification() RequestOption {
+	return func(options *requestOptions) {
+		options.verifyChecksum = true
+	}
+}
+
+// sampleChecksums are the checksums ThreatMatrix recorded for the sample of a job.
+type sampleChecksums struct {
+	jobId  uint64
+	md5    string
+	sha256 string
+}
+
+// checksumsOf returns the checksums job recorded for its sample.
+func checksumsOf(jobId uint64, job *Job) (*sampleChecksums, error) {
+	checksums := &sampleChecksums{jobId: jobId, md5: strings.ToLower(job.Md5)}
+	if raw, ok := job.Extra["sha256"]; ok {
+		// the SHA-256 isn't part of the job on every ThreatMatrix version
+		var digest string
+		if err := json.Unmarshal(raw, &digest); err == nil {
+			checksums.sha256 = strings.ToLower(digest)
+		}
+	}
+	if !job.IsSample || checksums.md5 == "" {
+		return nil, fmt.Errorf("%w: job %d records no checksum to verify its sample against", ErrValidation, jobId)
+	}
+	return checksums, nil
+}
+
+// checksumWriter computes the checksums of everything written to it.
+type checksumWriter struct {
+	md5    hash.Hash
+	sha256 hash.Hash
+}
+
+func newChecksumWriter() *checksumWriter {
+	return &checksumWriter{md5: md5.New(), sha256: sha256.New()}
+}
+
+// Write hashes p.
+func (writer *checksumWriter) Write(p []byte) (int, error) {
+	writer.md5.Write(p)
+	writer.sha256.Write(p)
+	return len(p), nil
+}
+
+// verify checks the checksums of what was written against the recorded ones.
+func (checksums *sampleChecksums) verify(written int64, writer *checksumWriter) error {
+	if got := hex.EncodeToString(writer.md5.Sum(nil)); got != checksums.md5 {
+		return fmt.Errorf("%w: the sample of job %d has MD5 %s (%d bytes), ThreatMatrix recorded %s", ErrChecksumMismatch, checksums.jobId, got, written, checksums.md5)
+	}
+	if checksums.sha256 == "" {
+		return nil
+	}
+	if got := hex.EncodeToString(writer.sha256.Sum(nil)); got != checksums.sha256 {
+		return fmt.Errorf("%w: the sample of job %d has SHA-256 %s (%d bytes), ThreatMatrix recorded %s", ErrChecksumMismatch, checksums.jobId, got, written, checksums.sha256)
+	}
+	return nil
+}
+
+// downloadSample streams the sample of the given job into writer, resuming it when it's interrupted, and verifying it
+// when the call asked for it through WithChecksumVerification.
+func (jobService *JobService) downloadSample(ctx context.Context, jobId uint64, writer io.Writer) (int64, error) {
+	var checksums *sampleChecksums
+	if options := requestOptionsFrom(ctx); options != nil && options.verifyChecksum {
+		job, err := jobService.Get(ctx, jobId)
+		if err != nil {
+			return 0, err
+		}
+		if checksums, err = checksumsOf(jobId, job); err != nil {
+			return 0, err
+		}
+	}
+	requestUrl := fmt.Sprintf(jobService.client.options.Url+constants.DOWNLOAD_SAMPLE_JOB_URL, jobId)
+	if checksums == nil {
+		return jobService.client.streamDownload(ctx, requestUrl, writer)
+	}
+	hashes := newChecksumWriter()
+	written, err := jobService.client.streamDownload(ctx, requestUrl, io.MultiWriter(writer, hashes))
+	if err != nil {
+		return written, err
+	}
+	return written, checksums.verify(written, hashes)
+}
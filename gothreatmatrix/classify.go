@@ -0,0 +1,50 @@
+package gothreatmatrix
+
+import (
+	"net/netip"
+	"regexp"
+	"strings"
+)
+
+// These are the observable classifications known by ThreatMatrix.
+const (
+	ClassificationIP      = "ip"
+	ClassificationURL     = "url"
+	ClassificationDomain  = "domain"
+	ClassificationHash    = "hash"
+	ClassificationGeneric = "generic"
+)
+
+// These are the patterns ThreatMatrix uses to classify observables.
+var (
+	urlPattern    = regexp.MustCompile(`^.{2,20}://`)
+	domainPattern = regexp.MustCompile(`(?i)^(\.)?[a-z\d-]{1,63}(\.[a-z\d-]{1,63})+$`)
+	hashPattern   = regexp.MustCompile(`(?i)^[a-f\d]{32}$|^[a-f\d]{40}$|^[a-f\d]{64}$`)
+)
+
+// Classify tells which classification ThreatMatrix would give to an observable.
+// It follows the server rules: an IP address first, then a URL, a domain, an MD5/SHA1/SHA256 hash,
+// and anything else is generic.
+func Classify(value string) string {
+	value = strings.TrimSpace(value)
+	if address, err := netip.ParseAddr(value); err == nil && address.Zone() == "" {
+		return ClassificationIP
+	}
+	switch {
+	case urlPattern.MatchString(value):
+		return ClassificationURL
+	case domainPattern.MatchString(value):
+		return ClassificationDomain
+	case hashPattern.MatchString(value):
+		return ClassificationHash
+	}
+	return ClassificationGeneric
+}
+
+// classificationOf returns the given classification or guesses it from the value when there's none.
+func classificationOf(classification string, value string) string {
+	if classification != "" {
+		return classification
+	}
+	return Classify(value)
+}
@@ -5,22 +5,36 @@
 package gothreatmatrix
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"io/ioutil"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ThreatMatrixError represents an error that has occurred when communicating with ThreatMatrix.
+//
+// Use errors.Is with ErrNotFound, ErrUnauthorized, ErrForbidden, ErrValidation, ErrRateLimited or ErrServer
+// to branch on the kind of error, and errors.As to get hold of the ThreatMatrixError itself.
 type ThreatMatrixError struct {
 	StatusCode int
-	Message    string
-	Response   *http.Response
+	// Message is the raw body of the error response.
+	Message string
+	// RequestID is the value of the X-Request-ID header sent back by ThreatMatrix, if any.
+	RequestID string
+	// Detail is the "detail" message of a Django REST Framework error response.
+	Detail string
+	// FieldErrors holds the per field validation errors of a Django REST Framework error response.
+	FieldErrors map[string][]string
+	Response    *http.Response
 }
 
 // Error lets you implement the error interface.
@@ -30,13 +44,40 @@ func (threatMatrixError *ThreatMatrixError) Error() string {
 	return errorMessage
 }
 
+// Is lets errors.Is match a ThreatMatrixError against the sentinel errors through its status code.
+func (threatMatrixError *ThreatMatrixError) Is(target error) bool {
+	statusCode := threatMatrixError.StatusCode
+	switch target {
+	case ErrValidation:
+		return statusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return statusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return statusCode == http.StatusForbidden
+	case ErrNotFound:
+		return statusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return statusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return statusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
 // newThreatMatrixError lets you easily create new ThreatMatrixErrors.
 func newThreatMatrixError(statusCode int, message string, response *http.Response) *ThreatMatrixError {
-	return &ThreatMatrixError{
+	threatMatrixError := &ThreatMatrixError{
 		StatusCode: statusCode,
 		Message:    message,
 		Response:   response,
 	}
+	if response != nil {
+		threatMatrixError.RequestID = response.Header.Get("X-Request-ID")
+		if statusCode >= http.StatusBadRequest && statusCode < http.StatusInternalServerError {
+			threatMatrixError.Detail, threatMatrixError.FieldErrors = parseErrorBody([]byte(message))
+		}
+	}
+	return threatMatrixError
 }
 
 type successResponse struct {
@@ -56,60 +97,100 @@ type ThreatMatrixClientOptions struct {
 
 // ThreatMatrixClient handles all the communication with your ThreatMatrix instance.
 type ThreatMatrixClient struct {
-	options          *ThreatMatrixClientOptions
-	client           *http.Client
-	TagService       *TagService
-	JobService       *JobService
-	AnalyzerService  *AnalyzerService
-	ConnectorService *ConnectorService
-	UserService      *UserService
-	Logger           *ThreatMatrixLogger
+	options              *ThreatMatrixClientOptions
+	client               *http.Client
+	userAgent            string
+	timeout              time.Duration
+	overallTimeout       time.Duration
+	retry                retryPolicy
+	retryBudget          *retryBudget
+	limiter              *rateLimiter
+	tracer               trace.Tracer
+	metrics              *clientMetrics
+	requestLogger        *slog.Logger
+	pollInterval         time.Duration
+	refang               bool
+	keyedRetries         bool
+	session              *sessionAuth
+	tokenProvider        TokenProvider
+	failover             *failover
+	catalog              *validationCatalog
+	TagService           TagServiceInterface
+	JobService           JobServiceInterface
+	AnalyzerService      AnalyzerServiceInterface
+	ConnectorService     ConnectorServiceInterface
+	VisualizerService    VisualizerServiceInterface
+	PivotService         PivotServiceInterface
+	PluginConfigService  PluginConfigServiceInterface
+	IngestorService      IngestorServiceInterface
+	InvestigationService InvestigationServiceInterface
+	UserService          UserServiceInterface
+	AnalyzeService       AnalyzeServiceInterface
+	PlaybookService      PlaybookServiceInterface
+	OrganizationService  OrganizationServiceInterface
+	InvitationService    InvitationServiceInterface
+	CommentService       CommentServiceInterface
+	StatsService         StatsServiceInterface
+	NotificationService  NotificationServiceInterface
+	Logger               *ThreatMatrixLogger
 }
 
 // TLP represents an enum for the TLP attribute used in ThreatMatrix's REST API.
 //
 // ThreatMatrix docs: https://threatmatrix.readthedocs.io/en/latest/Usage.html#tlp-support
-type TLP int
+type TLP string
 
 // Values of the TLP enum.
 const (
-	WHITE TLP = iota + 1
-	GREEN
-	AMBER
-	RED
+	TLPClear TLP = "CLEAR"
+	TLPWhite TLP = "WHITE"
+	TLPGreen TLP = "GREEN"
+	TLPAmber TLP = "AMBER"
+	TLPRed   TLP = "RED"
 )
 
-// TLPVALUES represents a map to easily access the TLP values.
+// Legacy values of the TLP enum.
+const (
+	// Deprecated: use TLPWhite.
+	WHITE = TLPWhite
+	// Deprecated: use TLPGreen.
+	GREEN = TLPGreen
+	// Deprecated: use TLPAmber.
+	AMBER = TLPAmber
+	// Deprecated: use TLPRed.
+	RED = TLPRed
+)
+
+// TLPVALUES represents a map to easily access the TLP values, ranked from the least to the most restrictive.
 var TLPVALUES = map[string]int{
+	"CLEAR": 1,
 	"WHITE": 1,
 	"GREEN": 2,
 	"AMBER": 3,
 	"RED":   4,
 }
 
-// Overriding the String method to get the string representation of the TLP enum
+// Overriding the String method to get the string representation of the TLP enum, an empty TLP being WHITE.
 func (tlp TLP) String() string {
-	switch tlp {
-	case WHITE:
-		return "WHITE"
-	case GREEN:
-		return "GREEN"
-	case AMBER:
-		return "AMBER"
-	case RED:
-		return "RED"
+	if tlp == "" {
+		return string(TLPWhite)
 	}
-	return "WHITE"
+	return string(tlp)
+}
+
+// Valid checks if the TLP is one of the values known by ThreatMatrix.
+func (tlp TLP) Valid() bool {
+	_, ok := TLPVALUES[string(tlp)]
+	return ok
 }
 
-// ParseTLP is used to easily make a TLP enum
+// ParseTLP is used to easily make a TLP enum, it returns an empty TLP when s isn't a known value.
 func ParseTLP(s string) TLP {
-	s = strings.TrimSpace(s)
-	value, ok := TLPVALUES[s]
-	if !ok {
-		return TLP(0)
+	tlp := TLP(strings.ToUpper(strings.TrimSpace(s)))
+	if !tlp.Valid() {
+		return TLP("")
 	}
-	return TLP(value)
+	return tlp
 }
 
 // Implementing the MarshalJSON interface to make our custom Marshal for the enum
@@ -117,62 +198,152 @@ func (tlp TLP) MarshalJSON() ([]byte, error) {
 	return json.Marshal(tlp.String())
 }
 
-// Implementing the UnmarshalJSON interface to make our custom Unmarshal for the enum
-func (tlp *TLP) UnmarshalJSON(data []byte) (err error) {
-	var tlpString string
-	if err := json.Unmarshal(data, &tlpString); err != nil {
-		return err
-	}
-	if *tlp = ParseTLP(tlpString); err != nil {
-		return err
-	}
-	return nil
-}
-
 // NewThreatMatrixClient lets you easily create a new ThreatMatrixClient by providing ThreatMatrixClientOptions, http.Clients, and LoggerParams.
 func NewThreatMatrixClient(options *ThreatMatrixClientOptions, httpClient *http.Client, loggerParams *LoggerParams) ThreatMatrixClient {
 
 	var timeout time.Duration
 
 	if options.Timeout == 0 {
-		timeout = time.Duration(10) * time.Second
+		timeout = DefaultTimeout
 	} else {
 		timeout = time.Duration(options.Timeout) * time.Second
 	}
 
+	config := &clientConfig{
+		options:      options,
+		httpClient:   httpClient,
+		timeout:      timeout,
+		userAgent:    DefaultUserAgent,
+		loggerParams: loggerParams,
+		pollInterval: DefaultPollInterval,
+	}
+
+	return *newClient(config)
+}
+
+// newClient builds a ThreatMatrixClient from a fully populated clientConfig.
+func newClient(config *clientConfig) *ThreatMatrixClient {
 	// configuring the http.Client
+	httpClient := config.httpClient
+	var timeout time.Duration
 	if httpClient == nil {
-		httpClient = &http.Client{
-			Timeout: timeout,
+		// the timeout is enforced per attempt by sendAttempt, so a RequestOption can override it
+		httpClient = &http.Client{}
+		timeout = config.timeout
+		if config.tlsConfig != nil || config.proxy != nil {
+			transport := http.DefaultTransport.(*http.Transport).Clone()
+			if config.tlsConfig != nil {
+				transport.TLSClientConfig = config.tlsConfig
+			}
+			if config.proxy != nil {
+				transport.Proxy = config.proxy
+			}
+			httpClient.Transport = transport
 		}
 	}
+	middlewares := append([]Middleware{}, config.middlewares...)
+	if config.responseCache != nil {
+		middlewares = append(middlewares, config.responseCache.middleware)
+	}
+	if config.circuitBreaker != nil {
+		// above the failover so an endpoint failing on every instance opens its circuit once
+		middlewares = append(middlewares, config.circuitBreaker.middleware)
+	}
+	if config.failover != nil {
+		config.failover.keyedRetries = config.keyedRetries
+		middlewares = append(middlewares, config.failover.middleware)
+	}
+	if config.debugOutput != nil {
+		middlewares = append(middlewares, newDebugDumper(config.debugOutput, config.debugBodyLimit).middleware)
+	}
+	if config.cassette != nil {
+		// the cassette is the innermost middleware so the other ones still run when replaying
+		middlewares = append(middlewares, config.cassette.middleware)
+	}
+	if config.compression != nil {
+		// below the cassette and the debug dumps so they deal with plain bodies
+		middlewares = append(middlewares, config.compression.middleware)
+	}
+	httpClient = applyMiddlewares(httpClient, middlewares)
 
 	// configuring the client
-	client := ThreatMatrixClient{
-		options: options,
-		client:  httpClient,
+	client := &ThreatMatrixClient{
+		options:        config.options,
+		client:         httpClient,
+		userAgent:      config.userAgent,
+		timeout:        timeout,
+		overallTimeout: config.overallTimeout,
+		retry:          config.retry,
+		retryBudget:    config.retryBudget,
+		limiter:        config.limiter,
+		tracer:         newTracer(config.tracerProvider),
+		metrics:        newClientMetrics(config.metricsRegisterer),
+		requestLogger:  newRequestLogger(config.logger),
+		pollInterval:   config.pollInterval,
+		refang:         config.refang,
+		keyedRetries:   config.keyedRetries,
+		session:        &sessionAuth{credentials: config.credentials},
+		tokenProvider:  config.tokenProvider,
+		failover:       config.failover,
+		catalog:        newValidationCatalog(),
 	}
 
 	// Adding the services
 	client.TagService = &TagService{
-		client: &client,
+		client: client,
 	}
 	client.JobService = &JobService{
-		client: &client,
+		client: client,
 	}
 	client.AnalyzerService = &AnalyzerService{
-		client: &client,
+		client: client,
 	}
 	client.ConnectorService = &ConnectorService{
-		client: &client,
+		client: client,
+	}
+	client.VisualizerService = &VisualizerService{
+		client: client,
+	}
+	client.PivotService = &PivotService{
+		client: client,
+	}
+	client.PluginConfigService = &PluginConfigService{
+		client: client,
+	}
+	client.IngestorService = &IngestorService{
+		client: client,
+	}
+	client.InvestigationService = &InvestigationService{
+		client: client,
 	}
 	client.UserService = &UserService{
-		client: &client,
+		client: client,
+	}
+	client.AnalyzeService = &AnalyzeService{
+		client: client,
+	}
+	client.PlaybookService = &PlaybookService{
+		client: client,
+	}
+	client.OrganizationService = &OrganizationService{
+		client: client,
+	}
+	client.InvitationService = &InvitationService{
+		client: client,
+	}
+	client.CommentService = &CommentService{
+		client: client,
+	}
+	client.StatsService = &StatsService{
+		client: client,
+	}
+	client.NotificationService = &NotificationService{
+		client: client,
 	}
 
 	// configuring the logger!
 	client.Logger = &ThreatMatrixLogger{}
-	client.Logger.Init(loggerParams)
+	client.Logger.Init(config.loggerParams)
 
 	return client
 }
@@ -204,39 +375,121 @@ func (client *ThreatMatrixClient) buildRequest(ctx context.Context, method strin
 	}
 	request.Header.Set("Content-Type", contentType)
 
-	tokenString := fmt.Sprintf("token %s", client.options.Token)
-
-	request.Header.Set("Authorization", tokenString)
+	authorization, err := client.authorization(ctx)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Authorization", authorization)
+	request.Header.Set("User-Agent", client.userAgent)
+	applyRequestOptions(request)
 	return request, nil
 }
 
+// do sends the request and returns the raw response, retrying it according to the client's retryPolicy.
+// Requests are throttled by the client's rateLimiter and 429 responses are retried once their Retry-After has passed.
+// The caller is responsible for closing the response body.
+func (client *ThreatMatrixClient) do(ctx context.Context, request *http.Request) (response *http.Response, err error) {
+	attempts := 0
+	start := time.Now()
+	defer func() {
+		traceAttempts(ctx, request, response, attempts)
+		client.metrics.observe(ctx, request, response, err, attempts, time.Since(start))
+	}()
+	if client.overallTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, client.overallTimeout)
+		request = request.WithContext(ctx)
+		defer func() {
+			// the deadline keeps bounding the body until it's closed
+			if err != nil {
+				cancel()
+				return
+			}
+			response.Body = &cancelOnClose{ReadCloser: response.Body, cancel: cancel}
+		}()
+	}
+	rewindable := canRewindBody(request)
+	maxAttempts := client.retry.maxAttempts
+	if maxAttempts < 1 || !isIdempotent(request, client.keyedRetries) || !rewindable {
+		maxAttempts = 1
+	}
+	client.retryBudget.deposit()
+	attempt, rateLimitedAttempts := 1, 0
+	attemptRequest := request
+	for {
+		if client.limiter != nil {
+			if err := client.limiter.wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		attempts++
+		response, err = client.sendAttempt(attemptRequest)
+
+		var delay time.Duration
+		// Checking for context errors such as reaching the deadline and/or Timeout
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+			if attempt >= maxAttempts || !isRetryableError(err) || !client.retryBudget.withdraw() {
+				return nil, err
+			}
+			delay = client.retry.backoff(attempt)
+			attempt++
+		} else if response.StatusCode == http.StatusTooManyRequests {
+			// the request was never processed so it is safe to send it again no matter the method
+			retryDelay, ok := retryAfter(ctx, response)
+			if !ok || !rewindable || rateLimitedAttempts >= maxRateLimitedAttempts {
+				return response, nil
+			}
+			drainAndClose(response)
+			delay = retryDelay
+			rateLimitedAttempts++
+		} else if attempt >= maxAttempts || response.StatusCode < http.StatusInternalServerError || !client.retryBudget.withdraw() {
+			return response, nil
+		} else {
+			drainAndClose(response)
+			delay = client.retry.backoff(attempt)
+			attempt++
+		}
+
+		if sleepError := sleepContext(ctx, delay); sleepError != nil {
+			return nil, sleepError
+		}
+		if attemptRequest, err = rewindBody(request); err != nil {
+			return nil, err
+		}
+	}
+}
+
 // newRequest is used for making requests.
 func (client *ThreatMatrixClient) newRequest(ctx context.Context, request *http.Request) (*successResponse, error) {
-	response, err := client.client.Do(request)
-
-	// Checking for context errors such as reaching the deadline and/or Timeout
+	start := time.Now()
+	response, err := client.do(ctx, request)
 	if err != nil {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
+		client.logRequest(ctx, request, 0, nil, time.Since(start), err)
+		traceError(ctx, err)
 		return nil, err
 	}
 
 	defer response.Body.Close()
 
-	msgBytes, err := ioutil.ReadAll(response.Body)
+	msgBytes, err := readBody(response.Body, response.ContentLength)
 	statusCode := response.StatusCode
+	client.logRequest(ctx, request, statusCode, msgBytes, time.Since(start), err)
 	if err != nil {
 		errorMessage := fmt.Sprintf("Could not convert JSON response. Status code: %d", statusCode)
 		threatMatrixError := newThreatMatrixError(statusCode, errorMessage, response)
+		traceError(ctx, threatMatrixError)
 		return nil, threatMatrixError
 	}
 
 	if statusCode < http.StatusOK || statusCode >= http.StatusBadRequest {
 		errorMessage := string(msgBytes)
 		threatMatrixError := newThreatMatrixError(statusCode, errorMessage, response)
+		traceError(ctx, threatMatrixError)
 		return nil, threatMatrixError
 	}
 
@@ -247,3 +500,178 @@ func (client *ThreatMatrixClient) newRequest(ctx context.Context, request *http.
 
 	return &sucessResp, nil
 }
+
+// streamRequest sends the request and copies the body of a successful response into writer without buffering it.
+// offset is how many bytes of the body an earlier, interrupted request already wrote: the body of a 206 response
+// has to start there, while the first offset bytes of a 200 response, whose server ignored the Range, are skipped
+// unless its validator isn't the one of the first response anymore.
+// Error responses are read and returned as a ThreatMatrixError, the same way newRequest does. Failing to read the
+// body to the end is returned as an *interruptedDownload, so the download can be resumed.
+func (client *ThreatMatrixClient) streamRequest(ctx context.Context, request *http.Request, writer io.Writer, offset int64, validator string) (int64, *http.Response, error) {
+	start := time.Now()
+	response, err := client.do(ctx, request)
+	if err != nil {
+		client.logRequest(ctx, request, 0, nil, time.Since(start), err)
+		traceError(ctx, err)
+		return 0, nil, err
+	}
+
+	defer response.Body.Close()
+
+	statusCode := response.StatusCode
+	if statusCode < http.StatusOK || statusCode >= http.StatusBadRequest {
+		msgBytes, readError := io.ReadAll(response.Body)
+		client.logRequest(ctx, request, statusCode, msgBytes, time.Since(start), readError)
+		threatMatrixError := newThreatMatrixError(statusCode, string(msgBytes), response)
+		traceError(ctx, threatMatrixError)
+		return 0, response, threatMatrixError
+	}
+
+	body, total, err := resumedBody(response, offset, validator)
+	if err != nil {
+		client.logRequest(ctx, request, statusCode, nil, time.Since(start), err)
+		traceError(ctx, err)
+		return 0, response, err
+	}
+	destination := &downloadWriter{writer: writer}
+	written, err := io.Copy(destination, withDownloadProgress(ctx, body, offset, total))
+	client.logRequest(ctx, request, statusCode, nil, time.Since(start), err)
+	if err != nil {
+		traceError(ctx, err)
+		if destination.err == nil {
+			err = &interruptedDownload{err: err}
+		}
+		return written, response, err
+	}
+	return written, response, nil
+}
+
+// Do sends a request to any endpoint of the ThreatMatrix REST API, for the ones the SDK doesn't cover yet.
+// path is relative to the URL of your instance, such as "/api/jobs?page=2". body is sent as JSON unless it's nil,
+// a []byte being sent as is. A successful response is decoded from JSON into into unless it's nil, a *[]byte
+// getting the raw body instead. The request goes through the same authentication, retries, middlewares
+// and error mapping as the service methods.
+//
+//	var analyzables []map[string]interface{}
+//	err := client.Do(ctx, http.MethodGet, "/api/analyzable", nil, &analyzables)
+func (client *ThreatMatrixClient) Do(ctx context.Context, method string, path string, body interface{}, into interface{}, opts ...RequestOption) error {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := client.startSpan(ctx, "ThreatMatrixClient.Do")
+	defer span.End()
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	var requestBody io.Reader
+	switch body := body.(type) {
+	case nil:
+	case []byte:
+		requestBody = bytes.NewReader(body)
+	default:
+		jsonData, err := encodeJSON(body)
+		if err != nil {
+			return err
+		}
+		defer putBuffer(jsonData)
+		requestBody = bytes.NewReader(jsonData.Bytes())
+	}
+	request, err := client.buildRequest(ctx, method, "application/json", requestBody, client.options.Url+path)
+	if err != nil {
+		return err
+	}
+	successResp, err := client.newRequest(ctx, request)
+	if err != nil {
+		return err
+	}
+	switch into := into.(type) {
+	case nil:
+	case *[]byte:
+		*into = successResp.Data
+	default:
+		if len(successResp.Data) > 0 {
+			return json.Unmarshal(successResp.Data, into)
+		}
+	}
+	return nil
+}
+
+// Request describes a call to an endpoint of the ThreatMatrix REST API sent through the generic Do.
+type Request struct {
+	// Method is the HTTP method, GET when it's empty.
+	Method string
+	// Path is relative to the URL of your instance, such as "/api/analyzable".
+	Path string
+	// Query is added to the query parameters of Path.
+	Query url.Values
+	// Body is sent as JSON unless it's nil, a []byte being sent as is.
+	Body interface{}
+	// Options apply to this call only.
+	Options []RequestOption
+}
+
+// Do sends the request through the client and decodes its JSON answer into a new T, so covering an endpoint
+// the SDK doesn't support yet only takes declaring the struct it answers with. It goes through the same
+// authentication, retries, middlewares and error mapping as ThreatMatrixClient.Do. An empty answer returns
+// the zero T, and Do[[]byte] returns the raw answer.
+//
+//	type analyzable struct {
+//		ID   int    `json:"id"`
+//		Name string `json:"name"`
+//	}
+//	analyzables, err := gothreatmatrix.Do[[]analyzable](ctx, client, gothreatmatrix.Request{Path: "/api/analyzable"})
+func Do[T any](ctx context.Context, client *ThreatMatrixClient, request Request) (*T, error) {
+	method := request.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	path := request.Path
+	if len(request.Query) > 0 {
+		separator := "?"
+		if strings.Contains(path, "?") {
+			separator = "&"
+		}
+		path += separator + request.Query.Encode()
+	}
+	result := new(T)
+	if err := client.Do(ctx, method, path, request.Body, result, request.Options...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// sendJSON sends params, if any, as JSON to the given route and decodes the answer into result, if any.
+func (client *ThreatMatrixClient) sendJSON(ctx context.Context, method string, route string, params interface{}, result interface{}) (*successResponse, error) {
+	requestUrl := client.options.Url + route
+	var body io.Reader
+	if params != nil {
+		jsonData, err := encodeJSON(params)
+		if err != nil {
+			return nil, err
+		}
+		defer putBuffer(jsonData)
+		body = bytes.NewReader(jsonData.Bytes())
+	}
+	contentType := "application/json"
+	request, err := client.buildRequest(ctx, method, contentType, body, requestUrl)
+	if err != nil {
+		return nil, err
+	}
+	successResp, err := client.newRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if result != nil && len(successResp.Data) > 0 {
+		if unmarshalError := json.Unmarshal(successResp.Data, result); unmarshalError != nil {
+			return nil, unmarshalError
+		}
+	}
+	return successResp, nil
+}
+
+// sendAction sends a request that only acts on the server, reporting whether it went through.
+func (client *ThreatMatrixClient) sendAction(ctx context.Context, method string, route string, params interface{}) (bool, error) {
+	successResp, err := client.sendJSON(ctx, method, route, params, nil)
+	if err != nil {
+		return false, err
+	}
+	return successResp.StatusCode == http.StatusNoContent || successResp.StatusCode == http.StatusOK, nil
+}
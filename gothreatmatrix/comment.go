@@ -0,0 +1,99 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// Comment represents a comment left on a job.
+type Comment struct {
+	ID        uint64      `json:"id"`
+	User      UserDetails `json:"user"`
+	Content   string      `json:"content"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// CommentParams represents the fields needed for commenting a job.
+type CommentParams struct {
+	JobID   uint64 `json:"job_id"`
+	Content string `json:"content"`
+}
+
+// CommentService handles communication with comment related methods of the ThreatMatrix API.
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/comments
+type CommentService struct {
+	client *ThreatMatrixClient
+}
+
+// checkCommentID is used to check if a comment ID is valid (id should be greater than zero).
+func checkCommentID(id uint64) error {
+	if id > 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: Comment ID cannot be 0", ErrValidation)
+}
+
+// List fetches the comments of a job.
+//
+//	Endpoint: GET /api/comments?job_id={jobID}
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/comments/operation/comments_list
+func (commentService *CommentService) List(ctx context.Context, jobId uint64, opts ...RequestOption) ([]Comment, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := commentService.client.startSpan(ctx, "CommentService.List", jobIDAttribute(jobId))
+	defer span.End()
+	query := url.Values{}
+	query.Set("job_id", strconv.FormatUint(jobId, 10))
+	comments := []Comment{}
+	if _, err := commentService.client.sendJSON(ctx, "GET", constants.BASE_COMMENT_URL+"?"+query.Encode(), nil, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// Create leaves a comment on a job.
+//
+//	Endpoint: POST /api/comments
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/comments/operation/comments_create
+func (commentService *CommentService) Create(ctx context.Context, commentParams *CommentParams, opts ...RequestOption) (*Comment, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if commentParams == nil {
+		return nil, fmt.Errorf("%w: comment params cannot be nil", ErrValidation)
+	}
+	ctx, span := commentService.client.startSpan(ctx, "CommentService.Create", jobIDAttribute(commentParams.JobID))
+	defer span.End()
+	if commentParams.JobID == 0 {
+		return nil, fmt.Errorf("%w: Job ID cannot be 0", ErrValidation)
+	}
+	if strings.TrimSpace(commentParams.Content) == "" {
+		return nil, fmt.Errorf("%w: comment content cannot be empty", ErrValidation)
+	}
+	comment := Comment{}
+	if _, err := commentService.client.sendJSON(ctx, "POST", constants.BASE_COMMENT_URL, commentParams, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// Delete removes a comment through its comment ID.
+//
+//	Endpoint: DELETE /api/comments/{id}
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/comments/operation/comments_destroy
+func (commentService *CommentService) Delete(ctx context.Context, commentId uint64, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := commentService.client.startSpan(ctx, "CommentService.Delete")
+	defer span.End()
+	if err := checkCommentID(commentId); err != nil {
+		return false, err
+	}
+	return commentService.client.sendAction(ctx, "DELETE", fmt.Sprintf(constants.SPECIFIC_COMMENT_URL, commentId), nil)
+}
@@ -0,0 +1,114 @@
+package gothreatmatrix
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// DefaultCompressionThreshold is the size from which WithCompression gzips the JSON bodies when it's given a negative size.
+const DefaultCompressionThreshold = 8 << 10
+
+// WithCompression asks ThreatMatrix for gzip compressed responses, which are decompressed transparently, and gzips the
+// JSON bodies of at least minBodySize bytes, such as the bulk multi observable submissions, to save bandwidth.
+// A negative minBodySize is DefaultCompressionThreshold. Make sure the server in front of ThreatMatrix accepts
+// gzip encoded requests before enabling this, as Django doesn't decompress them on its own.
+func WithCompression(minBodySize int) Option {
+	return func(config *clientConfig) {
+		if minBodySize < 0 {
+			minBodySize = DefaultCompressionThreshold
+		}
+		config.compression = &compression{minBodySize: minBodySize}
+	}
+}
+
+// compression gzips the large JSON request bodies and decompresses the gzip encoded responses.
+type compression struct {
+	minBodySize int
+}
+
+// middleware compresses the request body when it's worth it and decompresses the response body.
+func (compression *compression) middleware(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		request = request.Clone(request.Context())
+		// the caller asking for an encoding of its own gets the body the way it's sent, and so does a request for a
+		// Range, whose offsets are the ones of the encoded body
+		acceptsGzip := request.Header.Get("Accept-Encoding") == "" && request.Header.Get("Range") == ""
+		if acceptsGzip {
+			request.Header.Set("Accept-Encoding", "gzip")
+		}
+		if compression.compresses(request) {
+			if err := compressBody(request); err != nil {
+				return nil, err
+			}
+		}
+		response, err := next.RoundTrip(request)
+		if err != nil || !acceptsGzip || !strings.EqualFold(response.Header.Get("Content-Encoding"), "gzip") {
+			return response, err
+		}
+		reader, err := gzip.NewReader(response.Body)
+		if err == io.EOF {
+			// an empty body, such as the one of a 204 No Content, has nothing to decompress
+			return response, nil
+		}
+		if err != nil {
+			response.Body.Close()
+			return nil, err
+		}
+		response.Body = &gzipReadCloser{reader: reader, body: response.Body}
+		response.Header.Del("Content-Encoding")
+		response.Header.Del("Content-Length")
+		response.ContentLength = -1
+		response.Uncompressed = true
+		return response, nil
+	})
+}
+
+// compresses tells whether the request carries a JSON body large enough to be gzipped, which can be read again.
+func (compression *compression) compresses(request *http.Request) bool {
+	if request.Body == nil || request.Body == http.NoBody || request.GetBody == nil || request.Header.Get("Content-Encoding") != "" {
+		return false
+	}
+	mediaType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	return mediaType == "application/json" && request.ContentLength >= int64(compression.minBodySize)
+}
+
+// compressBody replaces the body of the request with its gzip compressed version.
+func compressBody(request *http.Request) error {
+	compressed := &bytes.Buffer{}
+	writer := gzip.NewWriter(compressed)
+	if _, err := io.Copy(writer, request.Body); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	request.Body.Close()
+	data := compressed.Bytes()
+	request.Body = io.NopCloser(bytes.NewReader(data))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	request.ContentLength = int64(len(data))
+	request.Header.Set("Content-Encoding", "gzip")
+	return nil
+}
+
+// gzipReadCloser is a response body decompressed while it's read, closing it closes the underlying body.
+type gzipReadCloser struct {
+	reader *gzip.Reader
+	body   io.ReadCloser
+}
+
+// Read reads the decompressed body.
+func (body *gzipReadCloser) Read(p []byte) (int, error) {
+	return body.reader.Read(p)
+}
+
+// Close closes the underlying body.
+func (body *gzipReadCloser) Close() error {
+	return body.body.Close()
+}
@@ -0,0 +1,179 @@
+package gothreatmatrix
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvConfig overrides the path of the config file read by NewClientFromConfig.
+const EnvConfig = "THREATMATRIX_CONFIG"
+
+// DefaultProfile is the profile used when neither the caller nor the config file picks one.
+const DefaultProfile = "default"
+
+// configFileNames are the config files looked for in ~/.threatmatrix, in order.
+var configFileNames = []string{"config.yaml", "config.yml", "config.toml"}
+
+// configFile represents a config file holding the settings of one or more ThreatMatrix instances.
+//
+//	default_profile: prod
+//	profiles:
+//	  prod:
+//	    url: https://threatmatrix.example.com
+//	    api_key: your-super-secret-token
+//	    timeout: 30s
+//	  staging:
+//	    url: https://staging.threatmatrix.example.com
+//	    api_key: your-other-token
+//	    insecure_tls: true
+type configFile struct {
+	DefaultProfile string                   `yaml:"default_profile" toml:"default_profile"`
+	Profiles       map[string]configProfile `yaml:"profiles" toml:"profiles"`
+}
+
+// configProfile represents the settings of a ThreatMatrix instance in a config file.
+// Timeout is either a duration such as "30s" or a number of seconds.
+// CACert, ClientCert and ClientKey are PEM files, see WithRootCAs and WithClientCertificate.
+type configProfile struct {
+	URL         string      `yaml:"url" toml:"url"`
+	APIKey      string      `yaml:"api_key" toml:"api_key"`
+	Timeout     interface{} `yaml:"timeout" toml:"timeout"`
+	InsecureTLS bool        `yaml:"insecure_tls" toml:"insecure_tls"`
+	UserAgent   string      `yaml:"user_agent" toml:"user_agent"`
+	CACert      string      `yaml:"ca_cert" toml:"ca_cert"`
+	ClientCert  string      `yaml:"client_cert" toml:"client_cert"`
+	ClientKey   string      `yaml:"client_key" toml:"client_key"`
+	Proxy       string      `yaml:"proxy" toml:"proxy"`
+}
+
+// DefaultConfigPath returns the config file read by NewClientFromConfig: the THREATMATRIX_CONFIG environment variable
+// when it's set, otherwise the first of config.yaml, config.yml and config.toml found in ~/.threatmatrix.
+func DefaultConfigPath() (string, error) {
+	if path := strings.TrimSpace(os.Getenv(EnvConfig)); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	for _, name := range configFileNames {
+		path := filepath.Join(home, ".threatmatrix", name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%w: no config file in %s", os.ErrNotExist, filepath.Join(home, ".threatmatrix"))
+}
+
+// NewClientFromConfig creates a new ThreatMatrixClient from a profile of the config file at DefaultConfigPath,
+// so a single machine can talk to several instances such as prod and staging:
+//
+//	client, err := gothreatmatrix.NewClientFromConfig("staging")
+//
+// An empty profile picks the default_profile of the file, or DefaultProfile. opts are applied after the profile
+// so they take precedence over it.
+func NewClientFromConfig(profile string, opts ...Option) (*ThreatMatrixClient, error) {
+	path, err := DefaultConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return NewClientFromConfigFile(path, profile, opts...)
+}
+
+// NewClientFromConfigFile creates a new ThreatMatrixClient from a profile of the YAML or TOML config file at path,
+// files ending in .toml being read as TOML. It works like NewClientFromConfig otherwise.
+func NewClientFromConfigFile(path string, profile string, opts ...Option) (*ThreatMatrixClient, error) {
+	file, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	profileOptions, err := file.options(profile)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return NewClient(append(profileOptions, opts...)...), nil
+}
+
+// readConfigFile reads and decodes the config file at path.
+func readConfigFile(path string) (*configFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file := configFile{}
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		err = toml.Unmarshal(data, &file)
+	} else {
+		err = yaml.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("threatmatrix: could not decode config file %s: %w", path, err)
+	}
+	return &file, nil
+}
+
+// options turns the settings of the given profile into Options.
+func (file *configFile) options(profile string) ([]Option, error) {
+	if profile == "" {
+		profile = file.DefaultProfile
+	}
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	settings, ok := file.Profiles[profile]
+	if !ok {
+		names := make([]string, 0, len(file.Profiles))
+		for name := range file.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("%w: profile %q not found, available profiles: %s", ErrValidation, profile, strings.Join(names, ", "))
+	}
+
+	opts := []Option{}
+	errs := []error{}
+	if strings.TrimSpace(settings.URL) == "" {
+		errs = append(errs, fmt.Errorf("%w: profile %q has no url", ErrValidation, profile))
+	}
+	if strings.TrimSpace(settings.APIKey) == "" {
+		errs = append(errs, fmt.Errorf("%w: profile %q has no api_key", ErrValidation, profile))
+	}
+	opts = append(opts, WithURL(strings.TrimSpace(settings.URL)), WithToken(strings.TrimSpace(settings.APIKey)))
+	if settings.Timeout != nil {
+		timeout, err := parseDuration(fmt.Sprint(settings.Timeout))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: timeout of profile %q must be a duration such as 30s or a number of seconds, got %v", ErrValidation, profile, settings.Timeout))
+		} else {
+			opts = append(opts, WithTimeout(timeout))
+		}
+	}
+	if settings.InsecureTLS {
+		opts = append(opts, withInsecureTLS())
+	}
+	if settings.UserAgent != "" {
+		opts = append(opts, WithUserAgent(settings.UserAgent))
+	}
+	if settings.Proxy != "" {
+		if _, err := parseProxyURL(settings.Proxy); err != nil {
+			errs = append(errs, fmt.Errorf("proxy of profile %q: %w", profile, err))
+		} else {
+			opts = append(opts, WithProxy(settings.Proxy))
+		}
+	}
+	tlsOptions, err := tlsFileOptions(settings.CACert, settings.ClientCert, settings.ClientKey)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("TLS files of profile %q: %w", profile, err))
+	}
+	opts = append(opts, tlsOptions...)
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
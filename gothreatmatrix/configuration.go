@@ -1,5 +1,18 @@
 package gothreatmatrix
 
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DefaultHealthCheckConcurrency is how many health checks HealthCheckAll runs at the same time by default.
+const DefaultHealthCheckConcurrency = 4
+
 type ConfigType struct {
 	Queue         string `json:"queue"`
 	SoftTimeLimit int    `json:"soft_time_limit"`
@@ -35,7 +48,97 @@ type BaseConfigurationType struct {
 	Verification VerificationType     `json:"verification"`
 }
 
+// HealthStatus represents the outcome of an analyzer or connector health check.
+type HealthStatus string
+
+// Values of the HealthStatus enum.
+const (
+	// HealthStatusUp means the plugin is working.
+	HealthStatusUp HealthStatus = "up"
+	// HealthStatusDown means the plugin is not working, jobs using it would fail.
+	HealthStatusDown HealthStatus = "down"
+	// HealthStatusUnsupported means the plugin has no health check, so there's no telling whether it works.
+	HealthStatusUnsupported HealthStatus = "unsupported"
+)
+
+// IsUp tells if the plugin is known to be working.
+func (status HealthStatus) IsUp() bool {
+	return status == HealthStatusUp
+}
+
+// HealthCheckResult is the health check outcome of one of the plugins checked through HealthCheckAll.
+// Err is set when the health check itself failed.
+type HealthCheckResult struct {
+	Name   string
+	Status HealthStatus
+	Err    error
+}
+
 // StatusResponse represents the status of an analyzer or connector i.e are they working or not.
 type StatusResponse struct {
 	Status bool `json:"status"`
 }
+
+// decodeConfigList decodes a list of plugin configurations, whether it comes as a plain list or as a paginated one.
+func decodeConfigList[T any](data []byte) ([]T, error) {
+	page, err := decodePage[T](bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return page.Results, nil
+}
+
+// checkPluginName is used to check if the name of a plugin was given.
+func checkPluginName(pluginName string) error {
+	if strings.TrimSpace(pluginName) == "" {
+		return fmt.Errorf("%w: plugin name cannot be empty", ErrValidation)
+	}
+	return nil
+}
+
+// healthCheck runs the health check of an analyzer or connector through the given endpoint route.
+// Plugins without a health check are answered by ThreatMatrix with a validation error, they're reported as HealthStatusUnsupported.
+func (client *ThreatMatrixClient) healthCheck(ctx context.Context, route string, pluginName string) (HealthStatus, error) {
+	requestUrl := fmt.Sprintf(client.options.Url+route, url.PathEscape(pluginName))
+	contentType := "application/json"
+	method := "GET"
+	request, err := client.buildRequest(ctx, method, contentType, nil, requestUrl)
+	if err != nil {
+		return "", err
+	}
+	successResp, err := client.newRequest(ctx, request)
+	if err != nil {
+		var threatMatrixError *ThreatMatrixError
+		if errors.As(err, &threatMatrixError) && errors.Is(err, ErrValidation) && isMissingHealthCheck(threatMatrixError.Detail) {
+			return HealthStatusUnsupported, nil
+		}
+		return "", err
+	}
+	status := StatusResponse{}
+	if unmarshalError := json.Unmarshal(successResp.Data, &status); unmarshalError != nil {
+		return "", unmarshalError
+	}
+	if status.Status {
+		return HealthStatusUp, nil
+	}
+	return HealthStatusDown, nil
+}
+
+// isMissingHealthCheck tells if the error detail says the plugin has no health check.
+func isMissingHealthCheck(detail string) bool {
+	detail = strings.ToLower(detail)
+	return strings.Contains(detail, "no healthcheck") || strings.Contains(detail, "no health check")
+}
+
+// healthCheckAll fans the health checks out to a bounded pool of workers.
+func healthCheckAll(ctx context.Context, pluginNames []string, concurrency int, check func(ctx context.Context, pluginName string, opts ...RequestOption) (HealthStatus, error)) []HealthCheckResult {
+	if concurrency < 1 {
+		concurrency = DefaultHealthCheckConcurrency
+	}
+	results := make([]HealthCheckResult, len(pluginNames))
+	forEachConcurrently(len(pluginNames), concurrency, func(index int) {
+		status, err := check(ctx, pluginNames[index])
+		results[index] = HealthCheckResult{Name: pluginNames[index], Status: status, Err: err}
+	})
+	return results
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"sort"
 
 	"github.com/khulnasoft/go-threatmatrix/constants"
@@ -14,7 +15,18 @@ import (
 // ThreatMatrix docs: https://threatmatrix.readthedocs.io/en/latest/Usage.html#connectors-customization
 type ConnectorConfig struct {
 	BaseConfigurationType
-	MaximumTlp TLP `json:"maximum_tlp"`
+	MaximumTlp   TLP  `json:"maximum_tlp"`
+	RunOnFailure bool `json:"run_on_failure"`
+	// Extra holds the fields ThreatMatrix sent that the SDK doesn't model yet, by name.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the connector configuration, keeping the fields the SDK doesn't model in Extra.
+func (connectorConfig *ConnectorConfig) UnmarshalJSON(data []byte) error {
+	type plainConnectorConfig ConnectorConfig
+	extra, err := decodeWithExtra(data, (*plainConnectorConfig)(connectorConfig))
+	connectorConfig.Extra = extra
+	return err
 }
 
 // ConnectorService handles communication with connector related methods of the ThreatMatrix API.
@@ -29,7 +41,10 @@ type ConnectorService struct {
 //	Endpoint: GET /api/get_connector_configs
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/get_connector_configs
-func (connectorService *ConnectorService) GetConfigs(ctx context.Context) (*[]ConnectorConfig, error) {
+func (connectorService *ConnectorService) GetConfigs(ctx context.Context, opts ...RequestOption) (*[]ConnectorConfig, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := connectorService.client.startSpan(ctx, "ConnectorService.GetConfigs")
+	defer span.End()
 	requestUrl := connectorService.client.options.Url + constants.CONNECTOR_CONFIG_URL
 	contentType := "application/json"
 	method := "GET"
@@ -62,27 +77,74 @@ func (connectorService *ConnectorService) GetConfigs(ctx context.Context) (*[]Co
 	return &connectorConfigurationList, nil
 }
 
-// HealthCheck checks if the specified connector is up and running
+// List fetches every connector configured in your ThreatMatrix instance.
 //
-//	Endpoint: GET /api/connector/{NameOfConnector}/healthcheck
+//	Endpoint: GET /api/connector
 //
-// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/connector/operation/connector_healthcheck_retrieve
-func (connectorService *ConnectorService) HealthCheck(ctx context.Context, connectorName string) (bool, error) {
-	route := connectorService.client.options.Url + constants.CONNECTOR_HEALTHCHECK_URL
-	requestUrl := fmt.Sprintf(route, connectorName)
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/connector/operation/connector_list
+func (connectorService *ConnectorService) List(ctx context.Context, opts ...RequestOption) ([]ConnectorConfig, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := connectorService.client.startSpan(ctx, "ConnectorService.List")
+	defer span.End()
+	requestUrl := connectorService.client.options.Url + constants.BASE_CONNECTOR_URL
 	contentType := "application/json"
 	method := "GET"
 	request, err := connectorService.client.buildRequest(ctx, method, contentType, nil, requestUrl)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	status := StatusResponse{}
 	successResp, err := connectorService.client.newRequest(ctx, request)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	if unmarshalError := json.Unmarshal(successResp.Data, &status); unmarshalError != nil {
-		return false, unmarshalError
+	return decodeConfigList[ConnectorConfig](successResp.Data)
+}
+
+// Get fetches the configuration of a specific connector through its name.
+//
+//	Endpoint: GET /api/connector/{NameOfConnector}
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/connector/operation/connector_retrieve
+func (connectorService *ConnectorService) Get(ctx context.Context, connectorName string, opts ...RequestOption) (*ConnectorConfig, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := connectorService.client.startSpan(ctx, "ConnectorService.Get", pluginAttribute(connectorName))
+	defer span.End()
+	route := connectorService.client.options.Url + constants.SPECIFIC_CONNECTOR_URL
+	requestUrl := fmt.Sprintf(route, url.PathEscape(connectorName))
+	contentType := "application/json"
+	method := "GET"
+	request, err := connectorService.client.buildRequest(ctx, method, contentType, nil, requestUrl)
+	if err != nil {
+		return nil, err
 	}
-	return status.Status, nil
+	successResp, err := connectorService.client.newRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	connectorConfig := ConnectorConfig{}
+	if unmarshalError := json.Unmarshal(successResp.Data, &connectorConfig); unmarshalError != nil {
+		return nil, unmarshalError
+	}
+	return &connectorConfig, nil
+}
+
+// HealthCheck checks if the specified connector is up and running.
+//
+//	Endpoint: GET /api/connector/{NameOfConnector}/health_check
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/connector/operation/connector_health_check_retrieve
+func (connectorService *ConnectorService) HealthCheck(ctx context.Context, connectorName string, opts ...RequestOption) (HealthStatus, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := connectorService.client.startSpan(ctx, "ConnectorService.HealthCheck", pluginAttribute(connectorName))
+	defer span.End()
+	return connectorService.client.healthCheck(ctx, constants.CONNECTOR_HEALTHCHECK_URL, connectorName)
+}
+
+// HealthCheckAll checks many connectors at once, running at most concurrency health checks at the same time.
+// The results come in the same order as connectorNames. A concurrency below 1 uses DefaultHealthCheckConcurrency.
+func (connectorService *ConnectorService) HealthCheckAll(ctx context.Context, connectorNames []string, concurrency int, opts ...RequestOption) []HealthCheckResult {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := connectorService.client.startSpan(ctx, "ConnectorService.HealthCheckAll")
+	defer span.End()
+	return healthCheckAll(ctx, connectorNames, concurrency, connectorService.HealthCheck)
 }
@@ -0,0 +1,160 @@
+package gothreatmatrix
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultDebugBodyLimit is how much of a body WithDebug dumps when WithDebugBodyLimit wasn't given, the rest is cut off.
+const DefaultDebugBodyLimit = 4096
+
+// WithDebug dumps every request the client sends and the response it gets to w, such as os.Stderr,
+// to troubleshoot what ThreatMatrix is sent and why it rejects it. Bodies are cut off after DefaultDebugBodyLimit
+// bytes, see WithDebugBodyLimit. Credentials are scrubbed from the headers and, as for the cassettes, from the bodies:
+// the values of JSON fields such as password, token or key, the secret plugin configs, bearer tokens and JWTs.
+// Streamed bodies, such as the files sent for analysis, are left out so they're never buffered.
+func WithDebug(w io.Writer) Option {
+	return func(config *clientConfig) {
+		config.debugOutput = w
+	}
+}
+
+// WithDebugBodyLimit sets how many bytes of each body WithDebug dumps, a limit of 0 leaving the bodies out.
+func WithDebugBodyLimit(limit int) Option {
+	return func(config *clientConfig) {
+		config.debugBodyLimit = &limit
+	}
+}
+
+// debugDumper writes the requests and responses going through it.
+type debugDumper struct {
+	mutex     sync.Mutex
+	output    io.Writer
+	bodyLimit int
+}
+
+// newDebugDumper returns a debugDumper writing to output, a nil bodyLimit being DefaultDebugBodyLimit.
+func newDebugDumper(output io.Writer, bodyLimit *int) *debugDumper {
+	dumper := &debugDumper{output: output, bodyLimit: DefaultDebugBodyLimit}
+	if bodyLimit != nil {
+		dumper.bodyLimit = *bodyLimit
+	}
+	return dumper
+}
+
+// middleware dumps every request and its response.
+func (dumper *debugDumper) middleware(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		dump := &bytes.Buffer{}
+		fmt.Fprintf(dump, "--> %s %s\n", request.Method, request.URL)
+		dumper.writeHeaders(dump, request.Header)
+		dumper.writeRequestBody(dump, request)
+
+		start := time.Now()
+		response, err := next.RoundTrip(request)
+		if err != nil {
+			fmt.Fprintf(dump, "<-- error after %s: %v\n\n", time.Since(start).Round(time.Millisecond), err)
+			dumper.write(dump)
+			return nil, err
+		}
+		fmt.Fprintf(dump, "<-- %s (%s)\n", response.Status, time.Since(start).Round(time.Millisecond))
+		dumper.writeHeaders(dump, response.Header)
+		if bodiesRedacted(request.Context()) {
+			dump.WriteString(redactedBody + "\n\n")
+		} else if err := dumper.writeResponseBody(dump, response); err != nil {
+			response.Body.Close()
+			fmt.Fprintf(dump, "error reading the body: %v\n\n", err)
+			dumper.write(dump)
+			return nil, err
+		}
+		dumper.write(dump)
+		return response, nil
+	})
+}
+
+// write writes a whole dump at once so the dumps of concurrent requests don't interleave.
+func (dumper *debugDumper) write(dump *bytes.Buffer) {
+	dumper.mutex.Lock()
+	defer dumper.mutex.Unlock()
+	_, _ = dumper.output.Write(dump.Bytes())
+}
+
+// writeHeaders writes the headers with their credentials scrubbed.
+func (dumper *debugDumper) writeHeaders(dump *bytes.Buffer, headers http.Header) {
+	scrubbed := scrubHeaders(headers, defaultScrubbedHeaders)
+	names := make([]string, 0, len(scrubbed))
+	for name := range scrubbed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range scrubbed[name] {
+			fmt.Fprintf(dump, "%s: %s\n", name, value)
+		}
+	}
+	dump.WriteString("\n")
+}
+
+// writeRequestBody writes the beginning of the request body, read from a copy so the request is left untouched.
+func (dumper *debugDumper) writeRequestBody(dump *bytes.Buffer, request *http.Request) {
+	if request.Body == nil || request.Body == http.NoBody || dumper.bodyLimit <= 0 {
+		return
+	}
+	if bodiesRedacted(request.Context()) {
+		dump.WriteString(redactedBody + "\n\n")
+		return
+	}
+	if request.GetBody == nil {
+		dump.WriteString("(streamed body left out)\n\n")
+		return
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return
+	}
+	defer body.Close()
+	head, err := io.ReadAll(io.LimitReader(body, int64(dumper.bodyLimit)+1))
+	if err != nil {
+		return
+	}
+	dumper.writeBody(dump, head)
+}
+
+// writeResponseBody writes the beginning of the response body, putting what it read back in front of the rest.
+func (dumper *debugDumper) writeResponseBody(dump *bytes.Buffer, response *http.Response) error {
+	if dumper.bodyLimit <= 0 {
+		return nil
+	}
+	head := make([]byte, dumper.bodyLimit+1)
+	read, err := io.ReadFull(response.Body, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+	head = head[:read]
+	response.Body = &struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), response.Body), response.Body}
+	dumper.writeBody(dump, head)
+	return nil
+}
+
+// writeBody writes head, cut off at bodyLimit bytes, with its credentials scrubbed.
+func (dumper *debugDumper) writeBody(dump *bytes.Buffer, head []byte) {
+	if len(head) == 0 {
+		return
+	}
+	if len(head) > dumper.bodyLimit {
+		dump.WriteString(scrubBody(head[:dumper.bodyLimit]))
+		dump.WriteString("...(truncated)")
+	} else {
+		dump.WriteString(scrubBody(head))
+	}
+	dump.WriteString("\n\n")
+}
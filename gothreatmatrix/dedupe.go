@@ -0,0 +1,126 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// dedupeCache remembers the analyses a Submitter created during the last window, keyed by observable or file hash.
+type dedupeCache struct {
+	window    time.Duration
+	mutex     sync.Mutex
+	entries   map[string]*dedupeEntry
+	lastSweep time.Time
+}
+
+// dedupeEntry is an analysis of the cache, done is closed once it's known whether it was created.
+type dedupeEntry struct {
+	done chan struct{}
+	// response is nil while the analysis is being submitted and when it failed
+	response    *AnalysisResponse
+	submittedAt time.Time
+}
+
+func newDedupeCache(window time.Duration) *dedupeCache {
+	return &dedupeCache{window: window, entries: map[string]*dedupeEntry{}}
+}
+
+// claim returns the analysis already submitted or being submitted under key, or reserves key for the caller
+// when there's none, in which case owner is true and the caller has to complete the returned entry.
+func (cache *dedupeCache) claim(key string) (entry *dedupeEntry, owner bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	now := time.Now()
+	if now.Sub(cache.lastSweep) >= cache.window {
+		for entryKey, entry := range cache.entries {
+			if entry.expired(now, cache.window) {
+				delete(cache.entries, entryKey)
+			}
+		}
+		cache.lastSweep = now
+	}
+	if entry, ok := cache.entries[key]; ok && !entry.expired(now, cache.window) {
+		return entry, false
+	}
+	entry = &dedupeEntry{done: make(chan struct{})}
+	cache.entries[key] = entry
+	return entry, true
+}
+
+// complete records the outcome of the analysis reserved under key, a failed one being forgotten so it's submitted again.
+func (cache *dedupeCache) complete(key string, entry *dedupeEntry, response *AnalysisResponse) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	entry.response = response
+	entry.submittedAt = time.Now()
+	if response == nil && cache.entries[key] == entry {
+		delete(cache.entries, key)
+	}
+	close(entry.done)
+}
+
+// expired checks if the entry was submitted more than window ago, an entry still being submitted never is.
+func (entry *dedupeEntry) expired(now time.Time, window time.Duration) bool {
+	select {
+	case <-entry.done:
+		return entry.response == nil || now.Sub(entry.submittedAt) >= window
+	default:
+		return false
+	}
+}
+
+// dedupeKey returns the key submission is deduplicated under: the classification and the value of an observable,
+// or the SHA-256 of a file. It returns an empty key when the submission can't be deduplicated, a file whose reader
+// isn't an io.Seeker being only read once.
+func (submitter *Submitter) dedupeKey(submission Submission) string {
+	if submission.Observable != nil {
+		value := submitter.client.observableValue(submission.Observable.Value)
+		return "observable:" + classificationOf(submission.Observable.Classification, value) + ":" + value
+	}
+	seeker, ok := submission.File.File.(io.Seeker)
+	if !ok {
+		return ""
+	}
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return ""
+	}
+	hashes, err := HashReader(submission.File.File)
+	if _, seekErr := seeker.Seek(start, io.SeekStart); err != nil || seekErr != nil {
+		return ""
+	}
+	return "file:" + hashes.SHA256
+}
+
+// deduplicate sends submission unless the same observable or file was submitted during the dedupe window, in which
+// case the result holds the analysis created back then and Deduplicated is true. A submission made while the same one
+// is still being sent waits for its outcome.
+func (submitter *Submitter) deduplicate(ctx context.Context, submission Submission) SubmissionResult {
+	key := submitter.dedupeKey(submission)
+	if key == "" {
+		return submitter.send(ctx, submission)
+	}
+	for {
+		entry, owner := submitter.dedupe.claim(key)
+		if owner {
+			result := submitter.send(ctx, submission)
+			var response *AnalysisResponse
+			if result.Err == nil {
+				response = result.Response
+			}
+			submitter.dedupe.complete(key, entry, response)
+			return result
+		}
+		select {
+		case <-ctx.Done():
+			return SubmissionResult{Submission: submission, Err: ctx.Err()}
+		case <-entry.done:
+		}
+		if entry.response != nil {
+			return SubmissionResult{Submission: submission, Response: entry.response, Deduplicated: true}
+		}
+		// the submission it waited on failed, it's up to this one to try again
+	}
+}
@@ -0,0 +1,73 @@
+package gothreatmatrix
+
+import (
+	"regexp"
+	"strings"
+)
+
+// These are the patterns Refang undoes, the brackets being any of [], () or {}.
+var (
+	defangedDotPattern       = regexp.MustCompile(`(?i)[\[({]\s*(?:\.|dot)\s*[\])}]|\\\.`)
+	defangedAtPattern        = regexp.MustCompile(`(?i)[\[({]\s*(?:@|at)\s*[\])}]`)
+	defangedSeparatorPattern = regexp.MustCompile(`[\[({]\s*(://|:|/)\s*[\])}]`)
+	defangedSchemePattern    = regexp.MustCompile(`(?i)\b(?:h(?:xx|\*\*)p(s?)|fxp(s?))://`)
+)
+
+// Refang turns a defanged observable, as most intel feeds share them, back into the real one:
+// Refang("hxxp://evil[.]com") gives "http://evil.com". Values that aren't defanged are returned unchanged.
+// WithRefang refangs every observable before it's submitted.
+func Refang(value string) string {
+	value = defangedSeparatorPattern.ReplaceAllString(value, "$1")
+	value = defangedDotPattern.ReplaceAllString(value, ".")
+	value = defangedAtPattern.ReplaceAllString(value, "@")
+	return defangedSchemePattern.ReplaceAllStringFunc(value, func(scheme string) string {
+		if strings.EqualFold(scheme[:1], "f") {
+			return "ftp" + scheme[3:]
+		}
+		return "http" + scheme[4:]
+	})
+}
+
+// Defang makes an IP address, a domain or a URL safe to share, so it can't be clicked or resolved by mistake:
+// Defang("http://evil.com/a.php") gives "hxxp://evil[.]com/a.php". Only the scheme and the host of URLs are
+// defanged, other observables such as hashes are returned unchanged. Defanging a defanged value is harmless.
+func Defang(value string) string {
+	value = Refang(value)
+	switch Classify(value) {
+	case ClassificationIP, ClassificationDomain:
+		return strings.ReplaceAll(value, ".", "[.]")
+	case ClassificationURL:
+		scheme, rest, _ := strings.Cut(value, "://")
+		host, path, found := strings.Cut(rest, "/")
+		switch strings.ToLower(scheme) {
+		case "http":
+			scheme = "hxxp"
+		case "https":
+			scheme = "hxxps"
+		case "ftp":
+			scheme = "fxp"
+		}
+		defanged := scheme + "://" + strings.ReplaceAll(host, ".", "[.]")
+		if found {
+			defanged += "/" + path
+		}
+		return defanged
+	}
+	return value
+}
+
+// WithRefang refangs every observable before it's submitted for analysis, see Refang, so values
+// coming straight from a feed such as "evil[.]com" are analyzed as the real "evil.com".
+func WithRefang() Option {
+	return func(config *clientConfig) {
+		config.refang = true
+	}
+}
+
+// observableValue returns the value of an observable as it's submitted, refanged when WithRefang was given.
+func (client *ThreatMatrixClient) observableValue(value string) string {
+	if client.refang {
+		return Refang(value)
+	}
+	return value
+}
@@ -0,0 +1,168 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultDownloadResumes is how many times a sample download interrupted by a transient failure is resumed by default.
+const DefaultDownloadResumes = 3
+
+// DefaultDownloadResumeDelay is the base delay of the backoff between the resumes of a download when the client has
+// none, see WithRetry.
+const DefaultDownloadResumeDelay = time.Second
+
+// WithDownloadResumes sets how many times JobService.DownloadSample, DownloadSampleTo and DownloadSampleZipped resume
+// a sample download interrupted by a network error or a timeout, DefaultDownloadResumes being used otherwise and 0
+// disabling it. A download is resumed with a Range request from the byte it stopped at, so very large samples over
+// unreliable links don't restart from zero, or restarts while skipping the bytes already written when the server
+// doesn't support ranges. It has no effect on the other methods.
+func WithDownloadResumes(resumes int) RequestOption {
+	return func(options *requestOptions) {
+		if resumes < 0 {
+			resumes = 0
+		}
+		options.downloadResumes = &resumes
+	}
+}
+
+// interruptedDownload is the error of a download whose body couldn't be read to the end, which can be resumed.
+type interruptedDownload struct {
+	err error
+}
+
+func (interrupted *interruptedDownload) Error() string {
+	return interrupted.err.Error()
+}
+
+func (interrupted *interruptedDownload) Unwrap() error {
+	return interrupted.err
+}
+
+// downloadWriter remembers whether writing the download failed, as opposed to reading it, which can't be resumed.
+type downloadWriter struct {
+	writer io.Writer
+	err    error
+}
+
+// Write writes p to the underlying writer, recording its error.
+func (writer *downloadWriter) Write(p []byte) (int, error) {
+	written, err := writer.writer.Write(p)
+	if err != nil {
+		writer.err = err
+	}
+	return written, err
+}
+
+// streamDownload downloads requestUrl into writer, resuming it from the byte it stopped at when reading it fails with
+// a transient error, up to the resumes set through WithDownloadResumes. The resumes carry an If-Range with the
+// validator of the first response, so a download whose content changed in between fails rather than mixing both.
+// The sample is asked for without any content encoding, so the byte the download stopped at is the one to resume from.
+func (client *ThreatMatrixClient) streamDownload(ctx context.Context, requestUrl string, writer io.Writer) (int64, error) {
+	resumes := DefaultDownloadResumes
+	if options := requestOptionsFrom(ctx); options != nil && options.downloadResumes != nil {
+		resumes = *options.downloadResumes
+	}
+	backoff := client.retry
+	if backoff.baseDelay <= 0 {
+		backoff.baseDelay = DefaultDownloadResumeDelay
+	}
+	var written int64
+	validator := ""
+	for resume := 0; ; resume++ {
+		request, err := client.buildRequest(ctx, "GET", "application/json", nil, requestUrl)
+		if err != nil {
+			return written, err
+		}
+		// the offsets of the resumes are the ones of the sample, not of a compressed body
+		request.Header.Set("Accept-Encoding", "identity")
+		if written > 0 {
+			request.Header.Set("Range", fmt.Sprintf("bytes=%d-", written))
+			if validator != "" {
+				request.Header.Set("If-Range", validator)
+			}
+		}
+		segment, response, err := client.streamRequest(ctx, request, writer, written, validator)
+		written += segment
+		if response != nil && resume == 0 {
+			validator = rangeValidator(response)
+		}
+		interrupted := &interruptedDownload{}
+		if err == nil || !errors.As(err, &interrupted) {
+			return written, err
+		}
+		if resume >= resumes || !isRetryableError(interrupted.err) || ctx.Err() != nil {
+			return written, interrupted.err
+		}
+		if sleepError := sleepContext(ctx, backoff.backoff(resume+1)); sleepError != nil {
+			return written, interrupted.err
+		}
+	}
+}
+
+// rangeValidator returns the validator a resumed download sends as its If-Range, the strong ETag of response or else
+// its Last-Modified, empty when it has neither.
+func rangeValidator(response *http.Response) string {
+	if etag := response.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return response.Header.Get("Last-Modified")
+}
+
+// resumedBody returns the part of the body of response that follows the offset bytes already downloaded, and how
+// many bytes the download has in total, -1 when it isn't known. validator is the one of the first response.
+func resumedBody(response *http.Response, offset int64, validator string) (io.Reader, int64, error) {
+	if offset == 0 {
+		return response.Body, response.ContentLength, nil
+	}
+	if response.StatusCode != http.StatusPartialContent {
+		if validator != "" && rangeValidator(response) != validator {
+			return nil, 0, fmt.Errorf("threatmatrix: the download changed while being resumed at byte %d", offset)
+		}
+		// the Range was ignored, the body starts over
+		if _, err := io.CopyN(io.Discard, response.Body, offset); err != nil {
+			return nil, 0, &interruptedDownload{err: err}
+		}
+		return response.Body, response.ContentLength, nil
+	}
+	start, total, ok := parseContentRange(response.Header.Get("Content-Range"))
+	if !ok || start != offset {
+		return nil, 0, fmt.Errorf("threatmatrix: the resumed download answered with the range %q instead of starting at byte %d", response.Header.Get("Content-Range"), offset)
+	}
+	return response.Body, total, nil
+}
+
+// parseContentRange reads the first byte and the total size out of a "bytes first-last/total" Content-Range,
+// the total being -1 when it's "*".
+func parseContentRange(contentRange string) (int64, int64, bool) {
+	byteRange, found := strings.CutPrefix(contentRange, "bytes ")
+	if !found {
+		return 0, 0, false
+	}
+	span, size, found := strings.Cut(byteRange, "/")
+	if !found {
+		return 0, 0, false
+	}
+	first, _, found := strings.Cut(span, "-")
+	if !found {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if size == "*" {
+		return start, -1, true
+	}
+	total, err := strconv.ParseInt(size, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, total, true
+}
@@ -0,0 +1,112 @@
+package gothreatmatrix
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// These are the environment variables read by NewClientFromEnv.
+const (
+	// EnvURL is the URL of your ThreatMatrix instance, it's required.
+	EnvURL = "THREATMATRIX_URL"
+	// EnvAPIKey is the API token used to authenticate every request, it's required.
+	EnvAPIKey = "THREATMATRIX_API_KEY"
+	// EnvTimeout is the timeout of the http.Client, either a duration such as "30s" or a number of seconds.
+	EnvTimeout = "THREATMATRIX_TIMEOUT"
+	// EnvInsecureTLS disables the verification of the certificate of ThreatMatrix when set to true, only use it for testing.
+	EnvInsecureTLS = "THREATMATRIX_INSECURE_TLS"
+	// EnvUserAgent is the User-Agent header sent with every request.
+	EnvUserAgent = "THREATMATRIX_USER_AGENT"
+	// EnvCACert is a PEM file holding the certificate authorities to trust on top of the system ones.
+	EnvCACert = "THREATMATRIX_CA_CERT"
+	// EnvClientCert is a PEM file holding the client certificate presented to ThreatMatrix, it goes with EnvClientKey.
+	EnvClientCert = "THREATMATRIX_CLIENT_CERT"
+	// EnvClientKey is a PEM file holding the private key of the client certificate.
+	EnvClientKey = "THREATMATRIX_CLIENT_KEY"
+	// EnvProxy is the URL of the proxy to reach ThreatMatrix through, see WithProxy.
+	EnvProxy = "THREATMATRIX_PROXY"
+)
+
+// NewClientFromEnv creates a new ThreatMatrixClient configured through the THREATMATRIX_ environment variables,
+// which suits containerized deployments. THREATMATRIX_URL and THREATMATRIX_API_KEY are required, the other
+// variables fall back to the defaults of NewClient when they're unset. opts are applied after the environment
+// so they take precedence over it.
+//
+// Every missing or invalid variable is reported in the returned error, which wraps ErrValidation.
+func NewClientFromEnv(opts ...Option) (*ThreatMatrixClient, error) {
+	envOptions, err := envOptions()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(append(envOptions, opts...)...), nil
+}
+
+// envOptions turns the THREATMATRIX_ environment variables into Options.
+func envOptions() ([]Option, error) {
+	opts := []Option{}
+	errs := []error{}
+	for _, variable := range []string{EnvURL, EnvAPIKey} {
+		if strings.TrimSpace(os.Getenv(variable)) == "" {
+			errs = append(errs, fmt.Errorf("%w: %s is not set", ErrValidation, variable))
+		}
+	}
+	opts = append(opts, WithURL(strings.TrimSpace(os.Getenv(EnvURL))), WithToken(strings.TrimSpace(os.Getenv(EnvAPIKey))))
+
+	if value := strings.TrimSpace(os.Getenv(EnvTimeout)); value != "" {
+		timeout, err := parseDuration(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: %s must be a duration such as 30s or a number of seconds, got %q", ErrValidation, EnvTimeout, value))
+		} else {
+			opts = append(opts, WithTimeout(timeout))
+		}
+	}
+	if value := strings.TrimSpace(os.Getenv(EnvInsecureTLS)); value != "" {
+		insecure, err := strconv.ParseBool(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: %s must be a boolean, got %q", ErrValidation, EnvInsecureTLS, value))
+		} else if insecure {
+			opts = append(opts, withInsecureTLS())
+		}
+	}
+	if value := strings.TrimSpace(os.Getenv(EnvUserAgent)); value != "" {
+		opts = append(opts, WithUserAgent(value))
+	}
+	if value := strings.TrimSpace(os.Getenv(EnvProxy)); value != "" {
+		if _, err := parseProxyURL(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", EnvProxy, err))
+		} else {
+			opts = append(opts, WithProxy(value))
+		}
+	}
+	tlsOptions, err := tlsFileOptions(strings.TrimSpace(os.Getenv(EnvCACert)), strings.TrimSpace(os.Getenv(EnvClientCert)), strings.TrimSpace(os.Getenv(EnvClientKey)))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%s, %s and %s: %w", EnvCACert, EnvClientCert, EnvClientKey, err))
+	}
+	opts = append(opts, tlsOptions...)
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// parseDuration parses a positive duration, a bare number being a number of seconds.
+func parseDuration(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds <= 0 {
+			return 0, errors.New("duration must be positive")
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if duration <= 0 {
+		return 0, errors.New("duration must be positive")
+	}
+	return duration, nil
+}
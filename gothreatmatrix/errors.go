@@ -0,0 +1,63 @@
+package gothreatmatrix
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// These are the sentinel errors a ThreatMatrixError can be matched against with errors.Is.
+var (
+	ErrValidation   = errors.New("threatmatrix: validation failed")
+	ErrUnauthorized = errors.New("threatmatrix: unauthorized")
+	ErrForbidden    = errors.New("threatmatrix: forbidden")
+	ErrNotFound     = errors.New("threatmatrix: not found")
+	ErrRateLimited  = errors.New("threatmatrix: rate limited")
+	ErrServer       = errors.New("threatmatrix: server error")
+)
+
+// parseErrorBody extracts the detail message and the field errors out of a Django REST Framework error response.
+// ThreatMatrix sometimes wraps them in an "errors" object so that's unwrapped first.
+// Bodies that are not a JSON object (like an HTML error page) give back nothing.
+func parseErrorBody(body []byte) (string, map[string][]string) {
+	errorBody := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &errorBody); err != nil {
+		return "", nil
+	}
+	if wrapped, ok := errorBody["errors"]; ok {
+		innerBody := map[string]json.RawMessage{}
+		if err := json.Unmarshal(wrapped, &innerBody); err == nil {
+			errorBody = innerBody
+		}
+	}
+
+	detail := ""
+	var fieldErrors map[string][]string
+	for field, rawValue := range errorBody {
+		messages := parseErrorMessages(rawValue)
+		if field == "detail" {
+			if len(messages) > 0 {
+				detail = messages[0]
+			}
+			continue
+		}
+		if fieldErrors == nil {
+			fieldErrors = map[string][]string{}
+		}
+		fieldErrors[field] = messages
+	}
+	return detail, fieldErrors
+}
+
+// parseErrorMessages turns a field's error value into a list of messages.
+// DRF uses either a string or a list of strings, anything else is kept as raw JSON.
+func parseErrorMessages(rawValue json.RawMessage) []string {
+	var message string
+	if err := json.Unmarshal(rawValue, &message); err == nil {
+		return []string{message}
+	}
+	var messages []string
+	if err := json.Unmarshal(rawValue, &messages); err == nil {
+		return messages
+	}
+	return []string{string(rawValue)}
+}
@@ -0,0 +1,160 @@
+package gothreatmatrix
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// DefaultResponseCacheSize is how many responses WithResponseCache keeps when it's given a size below 1.
+const DefaultResponseCacheSize = 256
+
+// WithResponseCache caches the responses of GET requests carrying an ETag or a Last-Modified header, keeping up to
+// size of them. The next time the same URL is requested the client asks ThreatMatrix whether the response changed
+// through If-None-Match and If-Modified-Since, and serves the cached body when it's answered 304 Not Modified.
+// Pollers refreshing the plugin catalogs or the job lists then only transfer what changed.
+// As cached responses are always revalidated, they're never stale. They're kept per credential, under a hash of it.
+// Only JSON responses are cached, so sample downloads keep streaming, and requests for a Range are left alone.
+func WithResponseCache(size int) Option {
+	return func(config *clientConfig) {
+		if size < 1 {
+			size = DefaultResponseCacheSize
+		}
+		config.responseCache = &responseCache{
+			size:    size,
+			entries: map[string]*list.Element{},
+			order:   list.New(),
+		}
+	}
+}
+
+// cachedResponse is a response kept by the responseCache along with the validators to revalidate it.
+type cachedResponse struct {
+	key          string
+	etag         string
+	lastModified string
+	statusCode   int
+	header       http.Header
+	body         []byte
+}
+
+// responseCache is a least recently used cache of responses to GET requests.
+type responseCache struct {
+	mutex   sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+// middleware caches the responses of GET requests and revalidates them.
+func (cache *responseCache) middleware(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		// the caller revalidating on its own or resuming a download is left be
+		if request.Method != http.MethodGet || request.Header.Get("If-None-Match") != "" || request.Header.Get("If-Modified-Since") != "" || request.Header.Get("Range") != "" {
+			return next.RoundTrip(request)
+		}
+
+		key := responseCacheKey(request)
+		cached := cache.get(key)
+		if cached != nil {
+			request = request.Clone(request.Context())
+			if cached.etag != "" {
+				request.Header.Set("If-None-Match", cached.etag)
+			}
+			if cached.lastModified != "" {
+				request.Header.Set("If-Modified-Since", cached.lastModified)
+			}
+		}
+		response, err := next.RoundTrip(request)
+		if err != nil {
+			return nil, err
+		}
+		if cached != nil && response.StatusCode == http.StatusNotModified {
+			drainAndClose(response)
+			return cached.response(request), nil
+		}
+		etag, lastModified := response.Header.Get("ETag"), response.Header.Get("Last-Modified")
+		if response.StatusCode != http.StatusOK || (etag == "" && lastModified == "") || !isJSONResponse(response) {
+			return response, nil
+		}
+		body, err := io.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		response.Body = io.NopCloser(bytes.NewReader(body))
+		cache.put(&cachedResponse{
+			key:          key,
+			etag:         etag,
+			lastModified: lastModified,
+			statusCode:   response.StatusCode,
+			header:       response.Header.Clone(),
+			body:         body,
+		})
+		return response, nil
+	})
+}
+
+// responseCacheKey returns the key the response to request is cached under: its URL and a hash of its credential,
+// so responses are never shared between users and the credential isn't kept around.
+func responseCacheKey(request *http.Request) string {
+	identity := sha256.Sum256([]byte(request.Header.Get("Authorization")))
+	return hex.EncodeToString(identity[:]) + " " + request.URL.String()
+}
+
+// isJSONResponse checks if the body of response is JSON, the only kind of body the responseCache keeps.
+func isJSONResponse(response *http.Response) bool {
+	mediaType, _, err := mime.ParseMediaType(response.Header.Get("Content-Type"))
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// get returns the response cached under key, marking it as the most recently used one.
+func (cache *responseCache) get(key string) *cachedResponse {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	element, ok := cache.entries[key]
+	if !ok {
+		return nil
+	}
+	cache.order.MoveToFront(element)
+	return element.Value.(*cachedResponse)
+}
+
+// put caches a response, evicting the least recently used one when the cache is full.
+func (cache *responseCache) put(cached *cachedResponse) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if element, ok := cache.entries[cached.key]; ok {
+		element.Value = cached
+		cache.order.MoveToFront(element)
+		return
+	}
+	cache.entries[cached.key] = cache.order.PushFront(cached)
+	for cache.order.Len() > cache.size {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*cachedResponse).key)
+	}
+}
+
+// response rebuilds the cached response as the answer to request.
+func (cached *cachedResponse) response(request *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cached.statusCode, http.StatusText(cached.statusCode)),
+		StatusCode:    cached.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cached.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(cached.body)),
+		ContentLength: int64(len(cached.body)),
+		Request:       request,
+	}
+}
@@ -0,0 +1,72 @@
+package gothreatmatrix
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// knownFieldsCache holds the knownFields of every type decodeWithExtra decoded, by reflect.Type.
+var knownFieldsCache sync.Map
+
+// decodeWithExtra decodes the JSON object in data into value, a pointer to a struct without an UnmarshalJSON
+// method, and returns the fields of the object the struct has no field for, nil when there are none.
+// It's what fills the Extra field of the types ThreatMatrix keeps adding fields to, so they can be read
+// before the SDK models them.
+func decodeWithExtra(data []byte, value interface{}) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, value); err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	known := knownFields(reflect.TypeOf(value).Elem())
+	for name := range fields {
+		// encoding/json matches the fields case insensitively
+		if known[strings.ToLower(name)] {
+			delete(fields, name)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields, nil
+}
+
+// knownFields returns the lower cased names of the JSON fields encoding/json decodes into the struct type,
+// including the ones of its embedded structs.
+func knownFields(structType reflect.Type) map[string]bool {
+	if known, ok := knownFieldsCache.Load(structType); ok {
+		return known.(map[string]bool)
+	}
+	known := map[string]bool{}
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			for embedded := range knownFields(fieldType) {
+				known[embedded] = true
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		known[strings.ToLower(name)] = true
+	}
+	knownFieldsCache.Store(structType, known)
+	return known
+}
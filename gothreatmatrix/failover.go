@@ -0,0 +1,306 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultProbeInterval is how often an endpoint that went down is probed when WithHealthProbe is given an interval below 1.
+const DefaultProbeInterval = 30 * time.Second
+
+// FailoverPolicy picks the endpoint a request is sent to first when the client was given several of them.
+type FailoverPolicy int
+
+// Values of the FailoverPolicy enum.
+const (
+	// FailoverPrimaryBackup sends every request to the first endpoint that is up, in the order they were given.
+	FailoverPrimaryBackup FailoverPolicy = iota
+	// FailoverRoundRobin spreads the requests over the endpoints that are up.
+	FailoverRoundRobin
+)
+
+// WithEndpoints sets several URLs serving the same ThreatMatrix instance, such as the ingress nodes of an HA deployment.
+// The first one replaces the URL set through WithURL. Requests are sent according to the FailoverPolicy,
+// FailoverPrimaryBackup by default, and fail over to the next endpoint when one can't be reached or answers
+// 502, 503 or 504. Only idempotent requests, and the submissions when WithIdempotentSubmissions is given, fail over
+// once they may have reached ThreatMatrix, others only when the connection couldn't be made so that no job is ever
+// created twice.
+//
+// An endpoint that failed is skipped until a health probe finds it up again, see WithHealthProbe.
+// When every endpoint is down they're all tried anyway. Close the client to stop the health probes.
+// A WithURL given after WithEndpoints replaces the endpoints, disabling the failover.
+func WithEndpoints(endpoints []string) Option {
+	return func(config *clientConfig) {
+		failover := config.failoverConfig()
+		failover.endpoints = nil
+		for _, endpoint := range endpoints {
+			endpoint = strings.TrimSuffix(endpoint, "/")
+			// an invalid endpoint is reported by the requests sent to it
+			location, _ := url.Parse(endpoint)
+			failover.endpoints = append(failover.endpoints, &endpointState{url: endpoint, location: location})
+		}
+		if len(failover.endpoints) > 0 {
+			config.options.Url = failover.endpoints[0].url
+		}
+	}
+}
+
+// WithFailoverPolicy sets how WithEndpoints picks the endpoint a request is sent to first.
+func WithFailoverPolicy(policy FailoverPolicy) Option {
+	return func(config *clientConfig) {
+		config.failoverConfig().policy = policy
+	}
+}
+
+// WithHealthProbe sets how an endpoint that went down is probed: every interval a GET request is sent
+// to path on that endpoint, any answer below 500 bringing it back up. It defaults to the root of the
+// endpoint every DefaultProbeInterval. An endpoint is only probed while it's down, probing stops as soon as it's up
+// again or the client is closed.
+func WithHealthProbe(path string, interval time.Duration) Option {
+	return func(config *clientConfig) {
+		if interval <= 0 {
+			interval = DefaultProbeInterval
+		}
+		failover := config.failoverConfig()
+		failover.probePath = path
+		failover.probeInterval = interval
+	}
+}
+
+// failoverConfig returns the failover being configured, creating it on first use.
+func (config *clientConfig) failoverConfig() *failover {
+	if config.failover == nil {
+		probeContext, stopProbes := context.WithCancel(context.Background())
+		config.failover = &failover{probeInterval: DefaultProbeInterval, probeContext: probeContext, stopProbes: stopProbes}
+	}
+	return config.failover
+}
+
+// Close stops the health probes of the endpoints set through WithEndpoints, waiting for them to return. The client can
+// still be used afterwards, the endpoints that fail then being tried again on every request. It does nothing for
+// a client without several endpoints.
+func (client *ThreatMatrixClient) Close() {
+	if client.failover != nil {
+		client.failover.close()
+	}
+}
+
+// endpointState is an endpoint of a failover along with whether it's down.
+type endpointState struct {
+	url string
+	// location is the parsed url, nil when it's invalid
+	location *url.URL
+	down     bool
+}
+
+// failover sends the requests to the endpoints given through WithEndpoints.
+type failover struct {
+	mutex         sync.Mutex
+	policy        FailoverPolicy
+	endpoints     []*endpointState
+	probePath     string
+	probeInterval time.Duration
+	// keyedRetries is set through WithIdempotentSubmissions
+	keyedRetries bool
+	// probeContext is canceled by close, stopping the probes
+	probeContext context.Context
+	stopProbes   context.CancelFunc
+	probes       sync.WaitGroup
+	// next is the round robin counter
+	next atomic.Uint64
+}
+
+// middleware rewrites the requests made to the primary endpoint so they're sent to the one picked by the policy,
+// failing over to the other endpoints.
+func (failover *failover) middleware(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		if len(failover.endpoints) < 2 {
+			return next.RoundTrip(request)
+		}
+		route, ok := failover.endpoints[0].route(request.URL)
+		if !ok {
+			return next.RoundTrip(request)
+		}
+
+		var response *http.Response
+		var err error
+		endpoints := failover.order()
+		for i, endpoint := range endpoints {
+			if i > 0 {
+				if request, err = rewindBody(request); err != nil {
+					return nil, err
+				}
+			}
+			endpointRequest := request.Clone(request.Context())
+			if endpointRequest.URL, err = endpoint.resolve(route, request.URL); err != nil {
+				return nil, err
+			}
+			endpointRequest.Host = ""
+			response, err = next.RoundTrip(endpointRequest)
+
+			if err == nil && !isGatewayError(response) {
+				return response, nil
+			}
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return nil, err
+			}
+			failover.markDown(endpoint, next)
+			last := i == len(endpoints)-1
+			if last || !canRewindBody(request) || !(isIdempotent(request, failover.keyedRetries) || isDialError(err)) {
+				return response, err
+			}
+			if response != nil {
+				drainAndClose(response)
+			}
+		}
+		return response, err
+	})
+}
+
+// route returns the escaped path of requestURL below the endpoint, and false when requestURL isn't on the endpoint:
+// the scheme and the host must be the same and the path must be below the path of the endpoint, on a / boundary.
+func (endpoint *endpointState) route(requestURL *url.URL) (string, bool) {
+	if endpoint.location == nil || !strings.EqualFold(requestURL.Scheme, endpoint.location.Scheme) ||
+		!strings.EqualFold(requestURL.Hostname(), endpoint.location.Hostname()) ||
+		urlPort(requestURL) != urlPort(endpoint.location) {
+		return "", false
+	}
+	base := strings.TrimSuffix(endpoint.location.EscapedPath(), "/")
+	path := requestURL.EscapedPath()
+	if path != base && !strings.HasPrefix(path, base+"/") {
+		return "", false
+	}
+	return strings.TrimPrefix(path, base), true
+}
+
+// resolve returns the URL of the route on the endpoint, with the query of requestURL.
+func (endpoint *endpointState) resolve(route string, requestURL *url.URL) (*url.URL, error) {
+	if endpoint.location == nil {
+		return nil, fmt.Errorf("%w: invalid endpoint %q", ErrValidation, endpoint.url)
+	}
+	resolved := *endpoint.location
+	resolved.RawPath = strings.TrimSuffix(endpoint.location.EscapedPath(), "/") + route
+	path, err := url.PathUnescape(resolved.RawPath)
+	if err != nil {
+		return nil, err
+	}
+	resolved.Path = path
+	resolved.RawQuery = requestURL.RawQuery
+	resolved.Fragment = requestURL.Fragment
+	return &resolved, nil
+}
+
+// urlPort returns the port of u, the default one of its scheme when it has none.
+func urlPort(u *url.URL) string {
+	if port := u.Port(); port != "" {
+		return port
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "ws":
+		return "80"
+	case "https", "wss":
+		return "443"
+	}
+	return ""
+}
+
+// order returns the endpoints in the order a request tries them: the ones up first, as picked by the policy.
+func (failover *failover) order() []*endpointState {
+	failover.mutex.Lock()
+	defer failover.mutex.Unlock()
+	start := 0
+	if failover.policy == FailoverRoundRobin {
+		start = int((failover.next.Add(1) - 1) % uint64(len(failover.endpoints)))
+	}
+	up := make([]*endpointState, 0, len(failover.endpoints))
+	down := []*endpointState{}
+	for i := range failover.endpoints {
+		endpoint := failover.endpoints[(start+i)%len(failover.endpoints)]
+		if endpoint.down {
+			down = append(down, endpoint)
+		} else {
+			up = append(up, endpoint)
+		}
+	}
+	return append(up, down...)
+}
+
+// markDown marks the endpoint as down and starts probing it through next until it's up again. Once the failover is
+// closed endpoints aren't marked down anymore, as no probe would bring them back up.
+func (failover *failover) markDown(endpoint *endpointState, next http.RoundTripper) {
+	failover.mutex.Lock()
+	defer failover.mutex.Unlock()
+	if endpoint.down || failover.probeContext.Err() != nil {
+		return
+	}
+	endpoint.down = true
+	failover.probes.Add(1)
+	go failover.probe(endpoint, next)
+}
+
+// probe checks the endpoint every probeInterval, marking it up once it answers below 500, until the failover is closed.
+func (failover *failover) probe(endpoint *endpointState, next http.RoundTripper) {
+	defer failover.probes.Done()
+	ticker := time.NewTicker(failover.probeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-failover.probeContext.Done():
+			return
+		case <-ticker.C:
+		}
+		if failover.isUp(endpoint, next) {
+			failover.mutex.Lock()
+			endpoint.down = false
+			failover.mutex.Unlock()
+			return
+		}
+	}
+}
+
+// close stops the probes and waits for them to return.
+func (failover *failover) close() {
+	failover.mutex.Lock()
+	failover.stopProbes()
+	failover.mutex.Unlock()
+	failover.probes.Wait()
+}
+
+// isUp sends a health probe to the endpoint.
+func (failover *failover) isUp(endpoint *endpointState, next http.RoundTripper) bool {
+	ctx, cancel := context.WithTimeout(failover.probeContext, failover.probeInterval)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.url+failover.probePath, nil)
+	if err != nil {
+		return false
+	}
+	response, err := next.RoundTrip(request)
+	if err != nil {
+		return false
+	}
+	drainAndClose(response)
+	return response.StatusCode < http.StatusInternalServerError
+}
+
+// isGatewayError checks if the response comes from a proxy that couldn't reach ThreatMatrix.
+func isGatewayError(response *http.Response) bool {
+	switch response.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isDialError checks if the request failed before the connection was made, so it never reached ThreatMatrix.
+func isDialError(err error) bool {
+	var opError *net.OpError
+	return errors.As(err, &opError) && opError.Op == "dial"
+}
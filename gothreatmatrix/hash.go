@@ -0,0 +1,140 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// FileHashes holds the hex encoded digests of a file.
+type FileHashes struct {
+	MD5    string
+	SHA1   string
+	SHA256 string
+}
+
+// HashReader computes the digests of everything read from reader, reading it only once.
+func HashReader(reader io.Reader) (*FileHashes, error) {
+	md5Hash, sha1Hash, sha256Hash := md5.New(), sha1.New(), sha256.New()
+	if _, err := io.Copy(io.MultiWriter(md5Hash, sha1Hash, sha256Hash), reader); err != nil {
+		return nil, err
+	}
+	return &FileHashes{
+		MD5:    hex.EncodeToString(md5Hash.Sum(nil)),
+		SHA1:   hex.EncodeToString(sha1Hash.Sum(nil)),
+		SHA256: hex.EncodeToString(sha256Hash.Sum(nil)),
+	}, nil
+}
+
+// HashFile computes the digests of a local file.
+func HashFile(path string) (*FileHashes, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return HashReader(file)
+}
+
+// AnalysisAvailabilityParams represents what an existing analysis has to match to be reused.
+type AnalysisAvailabilityParams struct {
+	// Md5 is the MD5 of the observable or the file.
+	Md5 string `json:"md5"`
+	// Analyzers the analysis has to have run, any analysis matches when it's empty.
+	Analyzers []string `json:"analyzers"`
+	// Playbooks the analysis has to have run.
+	Playbooks []string `json:"playbooks,omitempty"`
+	// RunningOnly only matches the analyses still running.
+	RunningOnly bool `json:"running_only"`
+	// MinutesAgo only matches the analyses made during the last minutes, any analysis matches when it's 0.
+	MinutesAgo int `json:"minutes_ago,omitempty"`
+}
+
+// AnalysisAvailability represents the answer of ThreatMatrix to whether an analysis is available.
+type AnalysisAvailability struct {
+	// Status is the status of the matching job, or "not_available" when there's none.
+	Status string `json:"status"`
+	JobID  int    `json:"job_id"`
+	// AnalyzersToExecute are the analyzers of the matching job.
+	AnalyzersToExecute []string `json:"analyzers_to_execute"`
+}
+
+// Available checks if ThreatMatrix has an analysis matching the params.
+func (availability *AnalysisAvailability) Available() bool {
+	return availability.JobID != 0 && availability.Status != "not_available"
+}
+
+// CheckAvailability asks ThreatMatrix whether an observable or a file was already analyzed, through its MD5.
+//
+//	Endpoint: POST /api/ask_analysis_availability
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/ask_analysis_availability
+func (analyzeService *AnalyzeService) CheckAvailability(ctx context.Context, params *AnalysisAvailabilityParams, opts ...RequestOption) (*AnalysisAvailability, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := analyzeService.client.startSpan(ctx, "AnalyzeService.CheckAvailability")
+	defer span.End()
+	if params == nil || !md5Pattern.MatchString(params.Md5) {
+		return nil, fmt.Errorf("%w: an MD5 is needed to check the availability of an analysis", ErrValidation)
+	}
+	body := *params
+	if body.Analyzers == nil {
+		body.Analyzers = []string{}
+	}
+	availability := AnalysisAvailability{}
+	if _, err := analyzeService.client.sendJSON(ctx, "POST", constants.ASK_ANALYSIS_AVAILABILITY_URL, &body, &availability); err != nil {
+		return nil, err
+	}
+	return &availability, nil
+}
+
+// AnalyzeFileIfNew hashes the file and only uploads it when ThreatMatrix hasn't analyzed it with the requested
+// analyzers yet, saving the bandwidth of big samples. The bool is true when the returned AnalysisResponse is the
+// one of the existing job, its Status being the status of that job.
+// The File has to be an io.Seeker, as it's read once to be hashed and read again to be uploaded.
+func (analyzeService *AnalyzeService) AnalyzeFileIfNew(ctx context.Context, analysisRequest FileAnalysisRequest, opts ...RequestOption) (*AnalysisResponse, bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := analyzeService.client.startSpan(ctx, "AnalyzeService.AnalyzeFileIfNew")
+	defer span.End()
+	if err := checkFileAnalysisRequest(analysisRequest); err != nil {
+		return nil, false, err
+	}
+	seeker, ok := analysisRequest.File.(io.Seeker)
+	if !ok {
+		return nil, false, fmt.Errorf("%w: the file has to be an io.Seeker to be hashed before being uploaded", ErrValidation)
+	}
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, false, err
+	}
+	hashes, err := HashReader(analysisRequest.File)
+	if err != nil {
+		return nil, false, err
+	}
+	if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+		return nil, false, err
+	}
+
+	availability, err := analyzeService.CheckAvailability(ctx, &AnalysisAvailabilityParams{Md5: hashes.MD5, Analyzers: analysisRequest.Analyzers})
+	if err != nil {
+		return nil, false, err
+	}
+	if availability.Available() {
+		return &AnalysisResponse{
+			JobID:            availability.JobID,
+			Status:           availability.Status,
+			AnalyzersRunning: availability.AnalyzersToExecute,
+		}, true, nil
+	}
+	analysisResponse, err := analyzeService.AnalyzeFile(ctx, analysisRequest)
+	if err != nil {
+		return nil, false, err
+	}
+	return analysisResponse, false, nil
+}
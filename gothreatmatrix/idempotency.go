@@ -0,0 +1,46 @@
+package gothreatmatrix
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of a submission.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// WithIdempotencyKey sets the idempotency key of the submission made by the call, in place of the random one
+// the client generates. When ThreatMatrix, or a proxy in front of it, deduplicates the submissions by that header,
+// reusing the key when submitting again after a timeout, even from another process, never creates a duplicate job.
+// It's meant for calls making a single submission, such as AnalyzeObservable or AnalyzeFile.
+func WithIdempotencyKey(key string) RequestOption {
+	return WithHeader(IdempotencyKeyHeader, key)
+}
+
+// NewIdempotencyKey returns a random idempotency key, formatted as a version 4 UUID.
+func NewIdempotencyKey() string {
+	key := make([]byte, 16)
+	// crypto/rand.Read never fails on the supported platforms
+	_, _ = rand.Read(key)
+	key[6] = key[6]&0x0f | 0x40
+	key[8] = key[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", key[0:4], key[4:6], key[6:8], key[8:10], key[10:])
+}
+
+// WithIdempotentSubmissions makes WithRetry retry the submissions, and WithEndpoints fail them over, like the
+// idempotent requests, as every attempt carries the same Idempotency-Key header. Only enable it when ThreatMatrix,
+// or a proxy in front of it, is known to deduplicate the submissions by that header: otherwise a retried submission
+// that already reached ThreatMatrix creates a second job. Without it the submissions are sent once.
+func WithIdempotentSubmissions() Option {
+	return func(config *clientConfig) {
+		config.keyedRetries = true
+	}
+}
+
+// setIdempotencyKey gives a submission a random idempotency key unless the call set one through WithIdempotencyKey.
+// Every attempt at sending the request carries the same key, see WithIdempotentSubmissions.
+func setIdempotencyKey(request *http.Request) {
+	if request.Header.Get(IdempotencyKeyHeader) == "" {
+		request.Header.Set(IdempotencyKeyHeader, NewIdempotencyKey())
+	}
+}
@@ -0,0 +1,149 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// IngestorSchedule represents the crontab an ingestor runs on.
+type IngestorSchedule struct {
+	Minute      string `json:"minute"`
+	Hour        string `json:"hour"`
+	DayOfWeek   string `json:"day_of_week"`
+	DayOfMonth  string `json:"day_of_month"`
+	MonthOfYear string `json:"month_of_year"`
+}
+
+// IngestorConfig represents how an ingestor is configured in ThreatMatrix: on its schedule, the ingestor pulls
+// observables from a feed (e.g. ThreatFox) and creates a job for each of them through its playbook.
+//
+// ThreatMatrix docs: https://threatmatrix.readthedocs.io/en/latest/Usage.html#ingestors
+type IngestorConfig struct {
+	BaseConfigurationType
+	Schedule          IngestorSchedule `json:"schedule"`
+	PlaybookToExecute string           `json:"playbook_to_execute"`
+	// MaximumJobs is how many jobs the ingestor creates at most every time it runs.
+	MaximumJobs int `json:"maximum_jobs"`
+	// Extra holds the fields ThreatMatrix sent that the SDK doesn't model yet, by name.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the ingestor configuration, keeping the fields the SDK doesn't model in Extra.
+func (ingestorConfig *IngestorConfig) UnmarshalJSON(data []byte) error {
+	type plainIngestorConfig IngestorConfig
+	extra, err := decodeWithExtra(data, (*plainIngestorConfig)(ingestorConfig))
+	ingestorConfig.Extra = extra
+	return err
+}
+
+// ingestorDisabledParams represents the body used to enable or disable an ingestor.
+type ingestorDisabledParams struct {
+	Disabled bool `json:"disabled"`
+}
+
+// IngestorService handles communication with ingestor related methods of the ThreatMatrix API.
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/ingestor
+type IngestorService struct {
+	client *ThreatMatrixClient
+}
+
+// List fetches every ingestor configured in your ThreatMatrix instance.
+//
+//	Endpoint: GET /api/ingestor
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/ingestor/operation/ingestor_list
+func (ingestorService *IngestorService) List(ctx context.Context, opts ...RequestOption) ([]IngestorConfig, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := ingestorService.client.startSpan(ctx, "IngestorService.List")
+	defer span.End()
+	requestUrl := ingestorService.client.options.Url + constants.BASE_INGESTOR_URL
+	contentType := "application/json"
+	method := "GET"
+	request, err := ingestorService.client.buildRequest(ctx, method, contentType, nil, requestUrl)
+	if err != nil {
+		return nil, err
+	}
+	successResp, err := ingestorService.client.newRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return decodeConfigList[IngestorConfig](successResp.Data)
+}
+
+// Get fetches the configuration of a specific ingestor through its name.
+//
+//	Endpoint: GET /api/ingestor/{NameOfIngestor}
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/ingestor/operation/ingestor_retrieve
+func (ingestorService *IngestorService) Get(ctx context.Context, ingestorName string, opts ...RequestOption) (*IngestorConfig, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := ingestorService.client.startSpan(ctx, "IngestorService.Get", pluginAttribute(ingestorName))
+	defer span.End()
+	route := ingestorService.client.options.Url + constants.SPECIFIC_INGESTOR_URL
+	requestUrl := fmt.Sprintf(route, url.PathEscape(ingestorName))
+	contentType := "application/json"
+	method := "GET"
+	request, err := ingestorService.client.buildRequest(ctx, method, contentType, nil, requestUrl)
+	if err != nil {
+		return nil, err
+	}
+	successResp, err := ingestorService.client.newRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	ingestorConfig := IngestorConfig{}
+	if unmarshalError := json.Unmarshal(successResp.Data, &ingestorConfig); unmarshalError != nil {
+		return nil, unmarshalError
+	}
+	return &ingestorConfig, nil
+}
+
+// Enable lets the ingestor run on its schedule again.
+//
+//	Endpoint: PATCH /api/ingestor/{NameOfIngestor}
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/ingestor/operation/ingestor_partial_update
+func (ingestorService *IngestorService) Enable(ctx context.Context, ingestorName string, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := ingestorService.client.startSpan(ctx, "IngestorService.Enable", pluginAttribute(ingestorName))
+	defer span.End()
+	return ingestorService.setDisabled(ctx, ingestorName, false)
+}
+
+// Disable stops the ingestor from running, the jobs it already created are kept.
+//
+//	Endpoint: PATCH /api/ingestor/{NameOfIngestor}
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/ingestor/operation/ingestor_partial_update
+func (ingestorService *IngestorService) Disable(ctx context.Context, ingestorName string, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := ingestorService.client.startSpan(ctx, "IngestorService.Disable", pluginAttribute(ingestorName))
+	defer span.End()
+	return ingestorService.setDisabled(ctx, ingestorName, true)
+}
+
+// setDisabled updates the disabled flag of an ingestor.
+func (ingestorService *IngestorService) setDisabled(ctx context.Context, ingestorName string, disabled bool) (bool, error) {
+	if err := checkPluginName(ingestorName); err != nil {
+		return false, err
+	}
+	route := fmt.Sprintf(constants.SPECIFIC_INGESTOR_URL, url.PathEscape(ingestorName))
+	return ingestorService.client.sendAction(ctx, "PATCH", route, &ingestorDisabledParams{Disabled: disabled})
+}
+
+// HealthCheck checks if the feed behind the specified ingestor is reachable.
+//
+//	Endpoint: GET /api/ingestor/{NameOfIngestor}/health_check
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/ingestor/operation/ingestor_health_check_retrieve
+func (ingestorService *IngestorService) HealthCheck(ctx context.Context, ingestorName string, opts ...RequestOption) (HealthStatus, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := ingestorService.client.startSpan(ctx, "IngestorService.HealthCheck", pluginAttribute(ingestorName))
+	defer span.End()
+	return ingestorService.client.healthCheck(ctx, constants.INGESTOR_HEALTHCHECK_URL, ingestorName)
+}
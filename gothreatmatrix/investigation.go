@@ -0,0 +1,199 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// InvestigationStatus represents the status of an investigation in ThreatMatrix.
+type InvestigationStatus string
+
+// Values of the InvestigationStatus enum.
+const (
+	InvestigationCreated   InvestigationStatus = "created"
+	InvestigationRunning   InvestigationStatus = "running"
+	InvestigationConcluded InvestigationStatus = "concluded"
+)
+
+// Investigation represents an investigation in ThreatMatrix: a set of jobs, along with the jobs their pivots created,
+// gathered while looking into the same incident.
+//
+// ThreatMatrix docs: https://threatmatrix.readthedocs.io/en/latest/Usage.html#investigations-framework
+type Investigation struct {
+	ID          uint64              `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Owner       UserDetails         `json:"owner"`
+	Tags        []string            `json:"tags"`
+	Tlp         TLP                 `json:"tlp"`
+	Status      InvestigationStatus `json:"status"`
+	TotalJobs   int                 `json:"total_jobs"`
+	// Jobs holds the IDs of the jobs directly added to the investigation.
+	Jobs      []int      `json:"jobs"`
+	StartTime *time.Time `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+}
+
+// InvestigationParams represents the fields needed for creating an investigation.
+type InvestigationParams struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Tlp         TLP      `json:"tlp,omitempty"`
+}
+
+// InvestigationTreeJob is a job of an investigation tree along with the child jobs its pivots created.
+type InvestigationTreeJob struct {
+	ID                 int                    `json:"pk"`
+	AnalyzedObjectName string                 `json:"analyzed_object_name"`
+	Playbook           string                 `json:"playbook"`
+	Status             JobStatus              `json:"status"`
+	IsSample           bool                   `json:"is_sample"`
+	Children           []InvestigationTreeJob `json:"children"`
+}
+
+// InvestigationTree represents the jobs of an investigation as the tree ThreatMatrix draws in its UI.
+type InvestigationTree struct {
+	Name        string                 `json:"name"`
+	Owner       int                    `json:"owner"`
+	Description string                 `json:"description"`
+	Jobs        []InvestigationTreeJob `json:"jobs"`
+}
+
+// investigationJobParams represents the body used to add a job to or remove a job from an investigation.
+type investigationJobParams struct {
+	Job uint64 `json:"job"`
+}
+
+// InvestigationService handles communication with investigation related methods of the ThreatMatrix API.
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/investigation
+type InvestigationService struct {
+	client *ThreatMatrixClient
+}
+
+// checkInvestigationID is used to check if an investigation ID is valid (id should be greater than zero).
+func checkInvestigationID(id uint64) error {
+	if id > 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: Investigation ID cannot be 0", ErrValidation)
+}
+
+// List fetches the investigations you have access to.
+//
+//	Endpoint: GET /api/investigation
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/investigation/operation/investigation_list
+func (investigationService *InvestigationService) List(ctx context.Context, opts ...RequestOption) ([]Investigation, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := investigationService.client.startSpan(ctx, "InvestigationService.List")
+	defer span.End()
+	successResp, err := investigationService.client.sendJSON(ctx, "GET", constants.BASE_INVESTIGATION_URL, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeConfigList[Investigation](successResp.Data)
+}
+
+// Get fetches a specific investigation through its ID.
+//
+//	Endpoint: GET /api/investigation/{id}
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/investigation/operation/investigation_retrieve
+func (investigationService *InvestigationService) Get(ctx context.Context, investigationId uint64, opts ...RequestOption) (*Investigation, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := investigationService.client.startSpan(ctx, "InvestigationService.Get")
+	defer span.End()
+	if err := checkInvestigationID(investigationId); err != nil {
+		return nil, err
+	}
+	investigation := Investigation{}
+	if _, err := investigationService.client.sendJSON(ctx, "GET", fmt.Sprintf(constants.SPECIFIC_INVESTIGATION_URL, investigationId), nil, &investigation); err != nil {
+		return nil, err
+	}
+	return &investigation, nil
+}
+
+// Create creates an investigation, jobs are then added to it through AddJob.
+//
+//	Endpoint: POST /api/investigation
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/investigation/operation/investigation_create
+func (investigationService *InvestigationService) Create(ctx context.Context, investigationParams *InvestigationParams, opts ...RequestOption) (*Investigation, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := investigationService.client.startSpan(ctx, "InvestigationService.Create")
+	defer span.End()
+	if investigationParams == nil || strings.TrimSpace(investigationParams.Name) == "" {
+		return nil, fmt.Errorf("%w: investigation name cannot be empty", ErrValidation)
+	}
+	if investigationParams.Tlp != "" && !investigationParams.Tlp.Valid() {
+		return nil, fmt.Errorf("%w: unknown TLP %q", ErrValidation, investigationParams.Tlp)
+	}
+	investigation := Investigation{}
+	if _, err := investigationService.client.sendJSON(ctx, "POST", constants.BASE_INVESTIGATION_URL, investigationParams, &investigation); err != nil {
+		return nil, err
+	}
+	return &investigation, nil
+}
+
+// AddJob adds a job to an investigation, a job belonging to a single investigation at a time.
+//
+//	Endpoint: POST /api/investigation/{id}/add_job
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/investigation/operation/investigation_add_job_create
+func (investigationService *InvestigationService) AddJob(ctx context.Context, investigationId uint64, jobId uint64, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := investigationService.client.startSpan(ctx, "InvestigationService.AddJob", jobIDAttribute(jobId))
+	defer span.End()
+	if err := checkInvestigationID(investigationId); err != nil {
+		return false, err
+	}
+	if jobId == 0 {
+		return false, fmt.Errorf("%w: Job ID cannot be 0", ErrValidation)
+	}
+	route := fmt.Sprintf(constants.ADD_JOB_INVESTIGATION_URL, investigationId)
+	return investigationService.client.sendAction(ctx, "POST", route, &investigationJobParams{Job: jobId})
+}
+
+// RemoveJob removes a job from an investigation, the job itself is kept.
+//
+//	Endpoint: POST /api/investigation/{id}/remove_job
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/investigation/operation/investigation_remove_job_create
+func (investigationService *InvestigationService) RemoveJob(ctx context.Context, investigationId uint64, jobId uint64, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := investigationService.client.startSpan(ctx, "InvestigationService.RemoveJob", jobIDAttribute(jobId))
+	defer span.End()
+	if err := checkInvestigationID(investigationId); err != nil {
+		return false, err
+	}
+	if jobId == 0 {
+		return false, fmt.Errorf("%w: Job ID cannot be 0", ErrValidation)
+	}
+	route := fmt.Sprintf(constants.REMOVE_JOB_INVESTIGATION_URL, investigationId)
+	return investigationService.client.sendAction(ctx, "POST", route, &investigationJobParams{Job: jobId})
+}
+
+// Tree fetches the jobs of an investigation along with the jobs their pivots created.
+//
+//	Endpoint: GET /api/investigation/{id}/tree
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/investigation/operation/investigation_tree_retrieve
+func (investigationService *InvestigationService) Tree(ctx context.Context, investigationId uint64, opts ...RequestOption) (*InvestigationTree, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := investigationService.client.startSpan(ctx, "InvestigationService.Tree")
+	defer span.End()
+	if err := checkInvestigationID(investigationId); err != nil {
+		return nil, err
+	}
+	tree := InvestigationTree{}
+	if _, err := investigationService.client.sendJSON(ctx, "GET", fmt.Sprintf(constants.TREE_INVESTIGATION_URL, investigationId), nil, &tree); err != nil {
+		return nil, err
+	}
+	return &tree, nil
+}
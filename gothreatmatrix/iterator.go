@@ -0,0 +1,71 @@
+package gothreatmatrix
+
+import (
+	"context"
+)
+
+// JobIterator walks through every page of jobs matching some JobListParams, fetching pages as it goes.
+//
+//	iterator := client.JobService.Iter(ctx, &gothreatmatrix.JobListParams{Status: "running"})
+//	for iterator.Next() {
+//		job := iterator.Job()
+//		// ...
+//	}
+//	if err := iterator.Err(); err != nil {
+//		// ...
+//	}
+type JobIterator struct {
+	ctx     context.Context
+	pager   *Pager[JobList]
+	page    []JobList
+	index   int
+	current JobList
+	err     error
+}
+
+// Iter returns a JobIterator over every job matching params, starting from params.Page (or the first page).
+// The iterator stops as soon as ctx is canceled.
+func (jobService *JobService) Iter(ctx context.Context, params *JobListParams, opts ...RequestOption) *JobIterator {
+	ctx = withRequestOptions(ctx, opts)
+	return &JobIterator{
+		ctx:   ctx,
+		pager: jobService.Pager(ctx, params),
+	}
+}
+
+// Next advances the iterator to the next job, fetching the next page when needed.
+// It returns false once every job was visited or an error occurred, check Err to tell them apart.
+func (iterator *JobIterator) Next() bool {
+	if iterator.err != nil {
+		return false
+	}
+	if err := iterator.ctx.Err(); err != nil {
+		iterator.err = err
+		return false
+	}
+	for iterator.index >= len(iterator.page) {
+		if !iterator.pager.HasNextPage() {
+			return false
+		}
+		page, err := iterator.pager.NextPage()
+		if err != nil {
+			iterator.err = err
+			return false
+		}
+		iterator.page = page.Results
+		iterator.index = 0
+	}
+	iterator.current = iterator.page[iterator.index]
+	iterator.index++
+	return true
+}
+
+// Job returns the job the iterator is currently at.
+func (iterator *JobIterator) Job() JobList {
+	return iterator.current
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (iterator *JobIterator) Err() error {
+	return iterator.err
+}
@@ -1,13 +1,21 @@
 package gothreatmatrix
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/yeka/zip"
 )
 
 // UserDetails represents user details in an ThreatMatrix job.
@@ -22,10 +30,20 @@ type Report struct {
 	Report               map[string]interface{} `json:"report"`
 	Errors               []string               `json:"errors"`
 	ProcessTime          float64                `json:"process_time"`
-	StartTime            time.Time              `json:"start_time"`
-	EndTime              time.Time              `json:"end_time"`
+	StartTime            Timestamp              `json:"start_time"`
+	EndTime              Timestamp              `json:"end_time"`
 	RuntimeConfiguration map[string]interface{} `json:"runtime_configuration"`
 	Type                 string                 `json:"type"`
+	// Extra holds the fields ThreatMatrix sent that the SDK doesn't model yet, by name.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the report, keeping the fields the SDK doesn't model in Extra.
+func (report *Report) UnmarshalJSON(data []byte) error {
+	type plainReport Report
+	extra, err := decodeWithExtra(data, (*plainReport)(report))
+	report.Extra = extra
+	return err
 }
 
 // BaseJob respresents all the common fields in a Job and JobList.
@@ -40,23 +58,40 @@ type BaseJob struct {
 	ObservableClassification string      `json:"observable_classification"`
 	FileName                 string      `json:"file_name"`
 	FileMimetype             string      `json:"file_mimetype"`
-	Status                   string      `json:"status"`
+	Status                   JobStatus   `json:"status"`
 	AnalyzersRequested       []string    `json:"analyzers_requested" `
 	ConnectorsRequested      []string    `json:"connectors_requested"`
 	AnalyzersToExecute       []string    `json:"analyzers_to_execute"`
 	ConnectorsToExecute      []string    `json:"connectors_to_execute"`
-	ReceivedRequestTime      *time.Time  `json:"received_request_time"`
-	FinishedAnalysisTime     *time.Time  `json:"finished_analysis_time"`
-	Tlp                      string      `json:"tlp"`
+	ReceivedRequestTime      *Timestamp  `json:"received_request_time"`
+	FinishedAnalysisTime     *Timestamp  `json:"finished_analysis_time"`
+	Tlp                      TLP         `json:"tlp"`
 	Errors                   []string    `json:"errors"`
 }
 
 // Job represents a job that is being processed in ThreatMatrix.
 type Job struct {
 	BaseJob
-	AnalyzerReports  []Report               `json:"analyzer_reports"`
-	ConnectorReports []Report               `json:"connector_reports"`
-	Permission       map[string]interface{} `json:"permission"`
+	AnalyzerReports  []Report `json:"analyzer_reports"`
+	ConnectorReports []Report `json:"connector_reports"`
+	// VisualizerReports holds the pages rendered by the visualizers of the job's playbook.
+	VisualizerReports []VisualizerReport `json:"visualizer_reports"`
+	// PivotReports holds the reports of the pivots that ran for the job, see Job.PivotJobIDs.
+	PivotReports []Report `json:"pivot_reports"`
+	// Investigation is the ID of the investigation the job belongs to, nil when it doesn't belong to any.
+	Investigation *uint64 `json:"investigation"`
+	// Permissions is what the user who fetched the job is allowed to do with it, nil when ThreatMatrix didn't tell.
+	Permissions *Permissions `json:"permissions"`
+	// Extra holds the fields ThreatMatrix sent that the SDK doesn't model yet, by name.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the job, keeping the fields the SDK doesn't model in Extra.
+func (job *Job) UnmarshalJSON(data []byte) error {
+	type plainJob Job
+	extra, err := decodeWithExtra(data, (*plainJob)(job))
+	job.Extra = extra
+	return err
 }
 
 // JobList represents a list of jobs in ThreatMatrix.
@@ -64,12 +99,102 @@ type JobList struct {
 	BaseJob
 }
 
+// md5Pattern matches the MD5 ThreatMatrix identifies observables and files with.
+var md5Pattern = regexp.MustCompile(`(?i)^[a-f\d]{32}$`)
+
+// JobListParams represents the filters, ordering and pagination you can use when listing jobs.
+// Fields left as their zero value are not sent.
+type JobListParams struct {
+	Status                   JobStatus
+	Tlp                      TLP
+	ObservableClassification string
+	ObservableName           string
+	FileName                 string
+	Md5                      string
+	// Analyzer only keeps the jobs that executed the given analyzer.
+	Analyzer string
+	// Tag only keeps the jobs tagged with the given label.
+	Tag string
+	// User only keeps the jobs submitted by the given username.
+	User                      string
+	ReceivedRequestTimeAfter  time.Time
+	ReceivedRequestTimeBefore time.Time
+	// Ordering is the field to sort jobs by, prefix it with "-" for a descending order e.g "-received_request_time".
+	Ordering string
+	Page     int
+	PageSize int
+}
+
+// values encodes the JobListParams as URL query parameters.
+func (params *JobListParams) values() url.Values {
+	query := url.Values{}
+	if params == nil {
+		return query
+	}
+	stringParams := map[string]string{
+		"status":                    string(params.Status),
+		"tlp":                       string(params.Tlp),
+		"observable_classification": params.ObservableClassification,
+		"observable_name":           params.ObservableName,
+		"file_name":                 params.FileName,
+		"md5":                       params.Md5,
+		"analyzers_to_execute":      params.Analyzer,
+		"tags__label":               params.Tag,
+		"user__username":            params.User,
+		"ordering":                  params.Ordering,
+	}
+	for key, value := range stringParams {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	if !params.ReceivedRequestTimeAfter.IsZero() {
+		query.Set("received_request_time__gte", params.ReceivedRequestTimeAfter.Format(time.RFC3339))
+	}
+	if !params.ReceivedRequestTimeBefore.IsZero() {
+		query.Set("received_request_time__lte", params.ReceivedRequestTimeBefore.Format(time.RFC3339))
+	}
+	if params.Page > 0 {
+		query.Set("page", strconv.Itoa(params.Page))
+	}
+	if params.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(params.PageSize))
+	}
+	return query
+}
+
+// JobListResponse represents a page of jobs returned by ThreatMatrix.
 type JobListResponse struct {
 	Count      int       `json:"count"`
 	TotalPages int       `json:"total_pages"`
 	Results    []JobList `json:"results"`
 }
 
+// RecentScan represents a job that already analyzed an observable or a file.
+type RecentScan struct {
+	ID                   int        `json:"pk"`
+	Tlp                  string     `json:"tlp"`
+	User                 string     `json:"user"`
+	ObservableName       string     `json:"observable_name"`
+	FileName             string     `json:"file_name"`
+	FinishedAnalysisTime *Timestamp `json:"finished_analysis_time"`
+}
+
+// JobSearchQuery represents what JobService.Search looks jobs up by, the jobs matching every field set being found.
+type JobSearchQuery struct {
+	ObservableName string
+	// Md5 is the MD5 of a file or of an observable value.
+	Md5      string
+	FileName string
+	Page     int
+	PageSize int
+}
+
+// recentScansParams represents the body of a recent scans lookup.
+type recentScansParams struct {
+	Md5 string `json:"md5"`
+}
+
 // JobService handles communication with job related methods of ThreatMatrix API.
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs
@@ -77,30 +202,87 @@ type JobService struct {
 	client *ThreatMatrixClient
 }
 
-// List fetches all the jobs in your ThreatMatrix instance.
+// List fetches the jobs in your ThreatMatrix instance.
+// Use JobListParams to filter, order and paginate them, nil fetches the first page of every job.
 //
 //	Endpoint: GET /api/jobs
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_list
-func (jobService *JobService) List(ctx context.Context) (*JobListResponse, error) {
+func (jobService *JobService) List(ctx context.Context, params *JobListParams, opts ...RequestOption) (*JobListResponse, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.List")
+	defer span.End()
 	requestUrl := jobService.client.options.Url + constants.BASE_JOB_URL
+	if query := params.values(); len(query) > 0 {
+		requestUrl += "?" + query.Encode()
+	}
 	contentType := "application/json"
 	method := "GET"
 	request, err := jobService.client.buildRequest(ctx, method, contentType, nil, requestUrl)
 	if err != nil {
 		return nil, err
 	}
-	successResp, err := jobService.client.newRequest(ctx, request)
-	if err != nil {
+	jobList := JobListResponse{}
+	if err := jobService.client.decodeJSON(ctx, request, &jobList); err != nil {
 		return nil, err
 	}
-	jobList := JobListResponse{}
-	marashalError := json.Unmarshal(successResp.Data, &jobList)
-	if marashalError != nil {
-		return nil, marashalError
+	return &jobList, nil
+}
+
+// RecentScans fetches the jobs that recently analyzed an observable or a file, newest first.
+// value is either the MD5 of a file or an observable, or the observable value itself,
+// letting you link to an existing job instead of resubmitting.
+//
+//	Endpoint: POST /api/jobs/recent_scans
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_recent_scans_create
+func (jobService *JobService) RecentScans(ctx context.Context, value string, opts ...RequestOption) ([]RecentScan, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.RecentScans")
+	defer span.End()
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, fmt.Errorf("%w: md5 or observable value cannot be empty", ErrValidation)
 	}
+	params := recentScansParams{Md5: value}
+	if !md5Pattern.MatchString(value) {
+		params.Md5 = fmt.Sprintf("%x", md5.Sum([]byte(value)))
+	}
+	recentScans := []RecentScan{}
+	if _, err := jobService.client.sendJSON(ctx, "POST", constants.RECENT_SCANS_JOB_URL, &params, &recentScans); err != nil {
+		return nil, err
+	}
+	return recentScans, nil
+}
 
-	return &jobList, nil
+// Search looks up the jobs that analyzed an observable or a file, newest first, answering "have we ever seen this IOC?"
+// in one call. Use Page and PageSize to go through the jobs found, or JobService.Pager with the same filters.
+//
+//	Endpoint: GET /api/jobs
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_list
+func (jobService *JobService) Search(ctx context.Context, query *JobSearchQuery, opts ...RequestOption) (*JobListResponse, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.Search")
+	defer span.End()
+	if query == nil {
+		return nil, fmt.Errorf("%w: search query cannot be nil", ErrValidation)
+	}
+	params := &JobListParams{
+		ObservableName: jobService.client.observableValue(strings.TrimSpace(query.ObservableName)),
+		FileName:       strings.TrimSpace(query.FileName),
+		Md5:            strings.ToLower(strings.TrimSpace(query.Md5)),
+		Ordering:       "-received_request_time",
+		Page:           query.Page,
+		PageSize:       query.PageSize,
+	}
+	if params.ObservableName == "" && params.FileName == "" && params.Md5 == "" {
+		return nil, fmt.Errorf("%w: an observable name, an md5 or a file name is needed to search jobs", ErrValidation)
+	}
+	if params.Md5 != "" && !md5Pattern.MatchString(params.Md5) {
+		return nil, fmt.Errorf("%w: %q is not an md5", ErrValidation, query.Md5)
+	}
+	return jobService.List(ctx, params)
 }
 
 // Get fetches a specific job through its job ID.
@@ -108,7 +290,10 @@ func (jobService *JobService) List(ctx context.Context) (*JobListResponse, error
 //	Endpoint: GET /api/jobs/{jobID}
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_retrieve
-func (jobService *JobService) Get(ctx context.Context, jobId uint64) (*Job, error) {
+func (jobService *JobService) Get(ctx context.Context, jobId uint64, opts ...RequestOption) (*Job, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.Get", jobIDAttribute(jobId))
+	defer span.End()
 	route := jobService.client.options.Url + constants.SPECIFIC_JOB_URL
 	requestUrl := fmt.Sprintf(route, jobId)
 	contentType := "application/json"
@@ -134,20 +319,56 @@ func (jobService *JobService) Get(ctx context.Context, jobId uint64) (*Job, erro
 //	Endpoint: GET /api/jobs/{jobID}/download_sample
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_download_sample_retrieve
-func (jobService *JobService) DownloadSample(ctx context.Context, jobId uint64) ([]byte, error) {
-	route := jobService.client.options.Url + constants.DOWNLOAD_SAMPLE_JOB_URL
-	requestUrl := fmt.Sprintf(route, jobId)
-	contentType := "application/json"
-	method := "GET"
-	request, err := jobService.client.buildRequest(ctx, method, contentType, nil, requestUrl)
-	if err != nil {
+func (jobService *JobService) DownloadSample(ctx context.Context, jobId uint64, opts ...RequestOption) ([]byte, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.DownloadSample", jobIDAttribute(jobId))
+	defer span.End()
+	sample := bytes.Buffer{}
+	if _, err := jobService.downloadSample(ctx, jobId, &sample); err != nil {
 		return nil, err
 	}
-	successResp, err := jobService.client.newRequest(ctx, request)
+	return sample.Bytes(), nil
+}
+
+// DownloadSampleTo streams the File sample of the given job into writer and returns the number of bytes written.
+// Unlike DownloadSample the sample is never held in memory as a whole, which suits large samples.
+//
+//	Endpoint: GET /api/jobs/{jobID}/download_sample
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_download_sample_retrieve
+func (jobService *JobService) DownloadSampleTo(ctx context.Context, jobId uint64, writer io.Writer, opts ...RequestOption) (int64, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.DownloadSampleTo", jobIDAttribute(jobId))
+	defer span.End()
+	return jobService.downloadSample(ctx, jobId, writer)
+}
+
+// DefaultSamplePassword is the password DownloadSampleZipped encrypts samples with when none is given.
+const DefaultSamplePassword = "infected"
+
+// DownloadSampleZipped streams the File sample of the given job into writer as an AES-256 encrypted zip archive.
+// The sample is stored in the archive as "job_{jobID}_sample" and is encrypted with password,
+// or DefaultSamplePassword when password is empty, so it can be moved between systems safely.
+//
+//	Endpoint: GET /api/jobs/{jobID}/download_sample
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_download_sample_retrieve
+func (jobService *JobService) DownloadSampleZipped(ctx context.Context, jobId uint64, password string, writer io.Writer, opts ...RequestOption) error {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.DownloadSampleZipped", jobIDAttribute(jobId))
+	defer span.End()
+	if password == "" {
+		password = DefaultSamplePassword
+	}
+	zipWriter := zip.NewWriter(writer)
+	entry, err := zipWriter.Encrypt(fmt.Sprintf("job_%d_sample", jobId), password, zip.AES256Encryption)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if _, err := jobService.downloadSample(ctx, jobId, entry); err != nil {
+		return err
 	}
-	return successResp.Data, nil
+	return zipWriter.Close()
 }
 
 // Delete removes the given job from your ThreatMatrix instance.
@@ -155,7 +376,10 @@ func (jobService *JobService) DownloadSample(ctx context.Context, jobId uint64)
 //	Endpoint: DELETE /api/jobs/{jobID}
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_destroy
-func (jobService *JobService) Delete(ctx context.Context, jobId uint64) (bool, error) {
+func (jobService *JobService) Delete(ctx context.Context, jobId uint64, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.Delete", jobIDAttribute(jobId))
+	defer span.End()
 	route := jobService.client.options.Url + constants.SPECIFIC_JOB_URL
 	requestUrl := fmt.Sprintf(route, jobId)
 	contentType := "application/json"
@@ -179,7 +403,10 @@ func (jobService *JobService) Delete(ctx context.Context, jobId uint64) (bool, e
 //	Endpoint: PATCH /api/jobs/{jobID}/kill
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_kill_partial_update
-func (jobService *JobService) Kill(ctx context.Context, jobId uint64) (bool, error) {
+func (jobService *JobService) Kill(ctx context.Context, jobId uint64, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.Kill", jobIDAttribute(jobId))
+	defer span.End()
 	route := jobService.client.options.Url + constants.KILL_JOB_URL
 	requestUrl := fmt.Sprintf(route, jobId)
 	contentType := "application/json"
@@ -203,7 +430,10 @@ func (jobService *JobService) Kill(ctx context.Context, jobId uint64) (bool, err
 //	Endpoint: PATCH /api/jobs/{jobID}/analyzer/{nameOfAnalyzer}/kill
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_analyzer_kill_partial_update
-func (jobService *JobService) KillAnalyzer(ctx context.Context, jobId uint64, analyzerName string) (bool, error) {
+func (jobService *JobService) KillAnalyzer(ctx context.Context, jobId uint64, analyzerName string, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.KillAnalyzer", jobIDAttribute(jobId), pluginAttribute(analyzerName))
+	defer span.End()
 	route := jobService.client.options.Url + constants.KILL_ANALYZER_JOB_URL
 	requestUrl := fmt.Sprintf(route, jobId, analyzerName)
 	contentType := "application/json"
@@ -227,7 +457,10 @@ func (jobService *JobService) KillAnalyzer(ctx context.Context, jobId uint64, an
 //	Endpoint: PATCH /api/jobs/{jobID}/analyzer/{nameOfAnalyzer}/retry
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_analyzer_retry_partial_update
-func (jobService *JobService) RetryAnalyzer(ctx context.Context, jobId uint64, analyzerName string) (bool, error) {
+func (jobService *JobService) RetryAnalyzer(ctx context.Context, jobId uint64, analyzerName string, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.RetryAnalyzer", jobIDAttribute(jobId), pluginAttribute(analyzerName))
+	defer span.End()
 	route := jobService.client.options.Url + constants.RETRY_ANALYZER_JOB_URL
 	requestUrl := fmt.Sprintf(route, jobId, analyzerName)
 	contentType := "application/json"
@@ -251,7 +484,10 @@ func (jobService *JobService) RetryAnalyzer(ctx context.Context, jobId uint64, a
 //	Endpoint: PATCH /api/jobs/{jobID}/connector/{nameOfConnector}/kill
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_connector_kill_partial_update
-func (jobService *JobService) KillConnector(ctx context.Context, jobId uint64, connectorName string) (bool, error) {
+func (jobService *JobService) KillConnector(ctx context.Context, jobId uint64, connectorName string, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.KillConnector", jobIDAttribute(jobId), pluginAttribute(connectorName))
+	defer span.End()
 	route := jobService.client.options.Url + constants.KILL_CONNECTOR_JOB_URL
 	requestUrl := fmt.Sprintf(route, jobId, connectorName)
 	contentType := "application/json"
@@ -275,7 +511,10 @@ func (jobService *JobService) KillConnector(ctx context.Context, jobId uint64, c
 //	Endpoint: PATCH /api/jobs/{jobID}/connector/{nameOfConnector}/retry
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_connector_retry_partial_update
-func (jobService *JobService) RetryConnector(ctx context.Context, jobId uint64, connectorName string) (bool, error) {
+func (jobService *JobService) RetryConnector(ctx context.Context, jobId uint64, connectorName string, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.RetryConnector", jobIDAttribute(jobId), pluginAttribute(connectorName))
+	defer span.End()
 	route := jobService.client.options.Url + constants.RETRY_CONNECTOR_JOB_URL
 	requestUrl := fmt.Sprintf(route, jobId, connectorName)
 	contentType := "application/json"
@@ -1,12 +1,19 @@
 package gothreatmatrix
 
 import (
+	"context"
 	"io"
+	"log/slog"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
+// maxLoggedBodySize is how much of a response body is logged, the rest is cut off.
+const maxLoggedBodySize = 1024
+
 // LoggerParams represents the fields to configure your logger.
 type LoggerParams struct {
 	File      io.Writer
@@ -40,3 +47,70 @@ func (threatMatrixLogger *ThreatMatrixLogger) Init(loggerParams *LoggerParams) {
 	logger.SetLevel(loggerParams.Level)
 	threatMatrixLogger.Logger = logger
 }
+
+// WithLogger sets the slog.Logger the client uses to log every request it makes.
+// Requests are logged at debug level with their method, URL, duration, status code and
+// the response body (truncated to 1KB). Without this option the client logs nothing.
+func WithLogger(logger *slog.Logger) Option {
+	return func(config *clientConfig) {
+		config.logger = logger
+	}
+}
+
+// newRequestLogger returns the given logger or one that discards everything when there's no logger.
+func newRequestLogger(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return logger
+}
+
+// logRequest logs a finished request at debug level.
+// statusCode and body are only logged when a response was received, err is only logged when it is not nil.
+func (client *ThreatMatrixClient) logRequest(ctx context.Context, request *http.Request, statusCode int, body []byte, duration time.Duration, err error) {
+	if !client.requestLogger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	attributes := []slog.Attr{
+		slog.String("operation", operationFromContext(ctx)),
+		slog.String("method", request.Method),
+		slog.String("url", request.URL.String()),
+		slog.Duration("duration", duration),
+	}
+	if statusCode != 0 {
+		loggedBody := truncateBody(body)
+		if bodiesRedacted(ctx) {
+			loggedBody = redactedBody
+		}
+		attributes = append(attributes, slog.Int("status", statusCode), slog.String("body", loggedBody))
+	}
+	if err != nil {
+		attributes = append(attributes, slog.String("error", err.Error()))
+	}
+	client.requestLogger.LogAttrs(ctx, slog.LevelDebug, "threatmatrix request", attributes...)
+}
+
+// redactedBody replaces the bodies of the requests and responses that may hold secrets.
+const redactedBody = "(redacted)"
+
+// redactBodiesKey is the context key marking the calls whose bodies may hold secrets.
+type redactBodiesKey struct{}
+
+// withRedactedBodies marks the calls made with the returned context so their bodies are never logged or dumped.
+func withRedactedBodies(ctx context.Context) context.Context {
+	return context.WithValue(ctx, redactBodiesKey{}, true)
+}
+
+// bodiesRedacted tells if the bodies of the calls made with ctx must be kept out of the logs.
+func bodiesRedacted(ctx context.Context) bool {
+	redacted, _ := ctx.Value(redactBodiesKey{}).(bool)
+	return redacted
+}
+
+// truncateBody cuts the body down to maxLoggedBodySize bytes.
+func truncateBody(body []byte) string {
+	if len(body) > maxLoggedBodySize {
+		return string(body[:maxLoggedBodySize]) + "...(truncated)"
+	}
+	return string(body)
+}
@@ -44,6 +44,7 @@ type Organization struct {
 	IsUserOwner  bool       `json:"is_user_owner,omitempty"`
 	CreatedAt    *time.Time `json:"created_at,omitempty"`
 	Name         string     `json:"name"`
+	Members      []Member   `json:"members,omitempty"`
 }
 
 type OrganizationParams struct {
@@ -70,12 +71,21 @@ type InvitationParams struct {
 	Status       string             `json:"status"`
 }
 
+// APIToken represents the API token of the authenticated user.
+type APIToken struct {
+	Key     string    `json:"key"`
+	Created time.Time `json:"created"`
+}
+
 // Access retrieves user details
 //
 //	Endpoint: GET /api/me/access
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me/operation/me_access_retrieve
-func (userService *UserService) Access(ctx context.Context) (*User, error) {
+func (userService *UserService) Access(ctx context.Context, opts ...RequestOption) (*User, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := userService.client.startSpan(ctx, "UserService.Access")
+	defer span.End()
 	requestUrl := userService.client.options.Url + constants.USER_DETAILS_URL
 	contentType := "application/json"
 	method := "GET"
@@ -99,7 +109,12 @@ func (userService *UserService) Access(ctx context.Context) (*User, error) {
 //	Endpoint: GET /api/me/organization
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me/operation/me_organization_list
-func (userService *UserService) Organization(ctx context.Context) (*Organization, error) {
+//
+// Deprecated: use OrganizationService.Get.
+func (userService *UserService) Organization(ctx context.Context, opts ...RequestOption) (*Organization, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := userService.client.startSpan(ctx, "UserService.Organization")
+	defer span.End()
 	requestUrl := userService.client.options.Url + constants.ORGANIZATION_URL
 	contentType := "application/json"
 	method := "GET"
@@ -124,7 +139,12 @@ func (userService *UserService) Organization(ctx context.Context) (*Organization
 //	Endpoint: POST /api/me/organization
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me/operation/me_organization_create
-func (userService *UserService) CreateOrganization(ctx context.Context, organizationParams *OrganizationParams) (*Organization, error) {
+//
+// Deprecated: use OrganizationService.Create.
+func (userService *UserService) CreateOrganization(ctx context.Context, organizationParams *OrganizationParams, opts ...RequestOption) (*Organization, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := userService.client.startSpan(ctx, "UserService.CreateOrganization")
+	defer span.End()
 	requestUrl := userService.client.options.Url + constants.ORGANIZATION_URL
 	// Getting the relevant JSON data
 	orgJson, err := json.Marshal(organizationParams)
@@ -156,7 +176,12 @@ func (userService *UserService) CreateOrganization(ctx context.Context, organiza
 //	Endpoint: POST /api/me/organization/invite
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me/operation/me_organization_invite_create
-func (userService *UserService) InviteToOrganization(ctx context.Context, memberParams *MemberParams) (*Invite, error) {
+//
+// Deprecated: use InvitationService.Invite.
+func (userService *UserService) InviteToOrganization(ctx context.Context, memberParams *MemberParams, opts ...RequestOption) (*Invite, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := userService.client.startSpan(ctx, "UserService.InviteToOrganization")
+	defer span.End()
 	requestUrl := userService.client.options.Url + constants.INVITE_TO_ORGANIZATION_URL
 	// Getting the relevant JSON data
 	memberJson, err := json.Marshal(memberParams)
@@ -188,7 +213,12 @@ func (userService *UserService) InviteToOrganization(ctx context.Context, member
 //	Endpoint: POST /api/me/organization/remove_member
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me/operation/me_organization_create
-func (userService *UserService) RemoveMemberFromOrganization(ctx context.Context, memberParams *MemberParams) (bool, error) {
+//
+// Deprecated: use OrganizationService.RemoveMember.
+func (userService *UserService) RemoveMemberFromOrganization(ctx context.Context, memberParams *MemberParams, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := userService.client.startSpan(ctx, "UserService.RemoveMemberFromOrganization")
+	defer span.End()
 	requestUrl := userService.client.options.Url + constants.REMOVE_MEMBER_FROM_ORGANIZATION_URL
 	// Getting the relevant JSON data
 	memberJson, err := json.Marshal(memberParams)
@@ -213,3 +243,74 @@ func (userService *UserService) RemoveMemberFromOrganization(ctx context.Context
 	}
 	return false, nil
 }
+
+// APITokenGet retrieves the API token of the authenticated user.
+//
+//	Endpoint: GET /api/auth/apiaccess
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/auth/operation/auth_apiaccess_retrieve
+func (userService *UserService) APITokenGet(ctx context.Context, opts ...RequestOption) (*APIToken, error) {
+	ctx = withRedactedBodies(withRequestOptions(ctx, opts))
+	ctx, span := userService.client.startSpan(ctx, "UserService.APITokenGet")
+	defer span.End()
+	return userService.sendAPIToken(ctx, "GET")
+}
+
+// APITokenCreate creates a new API token for the authenticated user.
+// ThreatMatrix only allows one token per user, so delete the current one first when rotating it.
+//
+//	Endpoint: POST /api/auth/apiaccess
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/auth/operation/auth_apiaccess_create
+func (userService *UserService) APITokenCreate(ctx context.Context, opts ...RequestOption) (*APIToken, error) {
+	ctx = withRedactedBodies(withRequestOptions(ctx, opts))
+	ctx, span := userService.client.startSpan(ctx, "UserService.APITokenCreate")
+	defer span.End()
+	return userService.sendAPIToken(ctx, "POST")
+}
+
+// APITokenDelete deletes the API token of the authenticated user.
+// If it is the token the client is using, the client can't make any more requests with it.
+//
+//	Endpoint: DELETE /api/auth/apiaccess
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/auth/operation/auth_apiaccess_destroy
+func (userService *UserService) APITokenDelete(ctx context.Context, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := userService.client.startSpan(ctx, "UserService.APITokenDelete")
+	defer span.End()
+	requestUrl := userService.client.options.Url + constants.API_TOKEN_URL
+	contentType := "application/json"
+	method := "DELETE"
+	request, err := userService.client.buildRequest(ctx, method, contentType, nil, requestUrl)
+	if err != nil {
+		return false, err
+	}
+	successResp, err := userService.client.newRequest(ctx, request)
+	if err != nil {
+		return false, err
+	}
+	if successResp.StatusCode == http.StatusNoContent {
+		return true, nil
+	}
+	return false, nil
+}
+
+// sendAPIToken sends a request to the API token endpoint and decodes the token it answers with.
+func (userService *UserService) sendAPIToken(ctx context.Context, method string) (*APIToken, error) {
+	requestUrl := userService.client.options.Url + constants.API_TOKEN_URL
+	contentType := "application/json"
+	request, err := userService.client.buildRequest(ctx, method, contentType, nil, requestUrl)
+	if err != nil {
+		return nil, err
+	}
+	successResp, err := userService.client.newRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	apiToken := APIToken{}
+	if unmarshalError := json.Unmarshal(successResp.Data, &apiToken); unmarshalError != nil {
+		return nil, unmarshalError
+	}
+	return &apiToken, nil
+}
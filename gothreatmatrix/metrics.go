@@ -0,0 +1,108 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metricsNamespace prefixes every metric exported by the client.
+const metricsNamespace = "threatmatrix_client"
+
+// clientMetrics holds the Prometheus collectors updated by the client.
+// Every collector is labeled with the service call (operation) it was made for, for example "JobService.Get".
+type clientMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+	retries  *prometheus.CounterVec
+}
+
+// WithMetricsRegisterer exports Prometheus metrics about the client through the given registerer:
+//
+//	threatmatrix_client_requests_total{operation,method,code}
+//	threatmatrix_client_request_duration_seconds{operation,method}
+//	threatmatrix_client_errors_total{operation,kind}
+//	threatmatrix_client_retries_total{operation}
+//
+// Several clients can share the same registerer, their metrics are then added up.
+func WithMetricsRegisterer(registerer prometheus.Registerer) Option {
+	return func(config *clientConfig) {
+		config.metricsRegisterer = registerer
+	}
+}
+
+// newClientMetrics registers the client's collectors, reusing the ones already registered by another client.
+func newClientMetrics(registerer prometheus.Registerer) *clientMetrics {
+	if registerer == nil {
+		return nil
+	}
+	return &clientMetrics{
+		requests: registerCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "requests_total",
+			Help:      "Number of requests sent to ThreatMatrix by status code.",
+		}, []string{"operation", "method", "code"})),
+		duration: registerCollector(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "request_duration_seconds",
+			Help:      "Time taken by requests to ThreatMatrix including retries.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "method"})),
+		errors: registerCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "errors_total",
+			Help:      "Number of failed requests to ThreatMatrix by kind of failure (network, client or server).",
+		}, []string{"operation", "kind"})),
+		retries: registerCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "retries_total",
+			Help:      "Number of times a request to ThreatMatrix was sent again.",
+		}, []string{"operation"})),
+	}
+}
+
+// registerCollector registers the collector or returns the identical one that was already registered.
+func registerCollector[C prometheus.Collector](registerer prometheus.Registerer, collector C) C {
+	if err := registerer.Register(collector); err != nil {
+		var alreadyRegistered prometheus.AlreadyRegisteredError
+		if errors.As(err, &alreadyRegistered) {
+			if existing, ok := alreadyRegistered.ExistingCollector.(C); ok {
+				return existing
+			}
+		}
+	}
+	return collector
+}
+
+// observe records the outcome of a request once all of its attempts are done.
+func (metrics *clientMetrics) observe(ctx context.Context, request *http.Request, response *http.Response, err error, attempts int, duration time.Duration) {
+	if metrics == nil {
+		return
+	}
+	operation := operationFromContext(ctx)
+	code := "error"
+	kind := ""
+	if err != nil {
+		kind = "network"
+	} else if response != nil {
+		code = strconv.Itoa(response.StatusCode)
+		if response.StatusCode >= http.StatusInternalServerError {
+			kind = "server"
+		} else if response.StatusCode >= http.StatusBadRequest {
+			kind = "client"
+		}
+	}
+	metrics.requests.WithLabelValues(operation, request.Method, code).Inc()
+	metrics.duration.WithLabelValues(operation, request.Method).Observe(duration.Seconds())
+	if kind != "" {
+		metrics.errors.WithLabelValues(operation, kind).Inc()
+	}
+	if attempts > 1 {
+		metrics.retries.WithLabelValues(operation).Add(float64(attempts - 1))
+	}
+}
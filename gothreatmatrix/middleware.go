@@ -0,0 +1,77 @@
+package gothreatmatrix
+
+import (
+	"net/http"
+)
+
+// RoundTripperFunc lets you use an ordinary function as an http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls roundTripperFunc(request).
+func (roundTripperFunc RoundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return roundTripperFunc(request)
+}
+
+// Middleware wraps the transport used by the ThreatMatrixClient.
+// It is handed the next http.RoundTripper in the chain and returns the one to use instead.
+// Middlewares run for every attempt of a request so retried requests go through them again.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// WithMiddleware adds middlewares to the client's transport.
+// The first middleware is the outermost one: it sees the request first and the response last.
+// If you also use WithHTTPClient your http.Client is copied so it is never modified.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(config *clientConfig) {
+		config.middlewares = append(config.middlewares, middlewares...)
+	}
+}
+
+// OnRequest makes a Middleware that calls hook with a copy of every request before it is sent.
+// The hook can change the request's headers (for example to inject an auth or tracing header)
+// or return an error to stop the request from being sent.
+func OnRequest(hook func(*http.Request) error) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			request = request.Clone(request.Context())
+			if err := hook(request); err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(request)
+		})
+	}
+}
+
+// OnResponse makes a Middleware that calls hook with every response that was received.
+// Returning an error discards the response and fails the attempt with that error.
+func OnResponse(hook func(*http.Response) error) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			response, err := next.RoundTrip(request)
+			if err != nil {
+				return nil, err
+			}
+			if hookError := hook(response); hookError != nil {
+				response.Body.Close()
+				return nil, hookError
+			}
+			return response, nil
+		})
+	}
+}
+
+// applyMiddlewares returns a copy of httpClient whose transport is wrapped by the middlewares.
+func applyMiddlewares(httpClient *http.Client, middlewares []Middleware) *http.Client {
+	if len(middlewares) == 0 {
+		return httpClient
+	}
+	wrappedClient := *httpClient
+	transport := wrappedClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		transport = middlewares[i](transport)
+	}
+	wrappedClient.Transport = transport
+	return &wrappedClient
+}
@@ -0,0 +1,2681 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services.go
+//
+// Generated by this command:
+//
+//	mockgen -source=services.go -destination=mocks/services.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	gothreatmatrix "github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTagServiceInterface is a mock of TagServiceInterface interface.
+type MockTagServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagServiceInterfaceMockRecorder
+}
+
+// MockTagServiceInterfaceMockRecorder is the mock recorder for MockTagServiceInterface.
+type MockTagServiceInterfaceMockRecorder struct {
+	mock *MockTagServiceInterface
+}
+
+// NewMockTagServiceInterface creates a new mock instance.
+func NewMockTagServiceInterface(ctrl *gomock.Controller) *MockTagServiceInterface {
+	mock := &MockTagServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockTagServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagServiceInterface) EXPECT() *MockTagServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTagServiceInterface) Create(ctx context.Context, tagParams *gothreatmatrix.TagParams, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Tag, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, tagParams}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTagServiceInterfaceMockRecorder) Create(ctx, tagParams any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, tagParams}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTagServiceInterface)(nil).Create), varargs...)
+}
+
+// Delete mocks base method.
+func (m *MockTagServiceInterface) Delete(ctx context.Context, tagId uint64, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, tagId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTagServiceInterfaceMockRecorder) Delete(ctx, tagId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, tagId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTagServiceInterface)(nil).Delete), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockTagServiceInterface) Get(ctx context.Context, tagId uint64, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Tag, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, tagId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTagServiceInterfaceMockRecorder) Get(ctx, tagId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, tagId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTagServiceInterface)(nil).Get), varargs...)
+}
+
+// List mocks base method.
+func (m *MockTagServiceInterface) List(ctx context.Context, opts ...gothreatmatrix.RequestOption) (*[]gothreatmatrix.Tag, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].(*[]gothreatmatrix.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTagServiceInterfaceMockRecorder) List(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTagServiceInterface)(nil).List), varargs...)
+}
+
+// Pager mocks base method.
+func (m *MockTagServiceInterface) Pager(ctx context.Context, pageSize int, opts ...gothreatmatrix.RequestOption) *gothreatmatrix.Pager[gothreatmatrix.Tag] {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pageSize}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Pager", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Pager[gothreatmatrix.Tag])
+	return ret0
+}
+
+// Pager indicates an expected call of Pager.
+func (mr *MockTagServiceInterfaceMockRecorder) Pager(ctx, pageSize any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pageSize}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pager", reflect.TypeOf((*MockTagServiceInterface)(nil).Pager), varargs...)
+}
+
+// Update mocks base method.
+func (m *MockTagServiceInterface) Update(ctx context.Context, tagId uint64, tagParams *gothreatmatrix.TagParams, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Tag, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, tagId, tagParams}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Update", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTagServiceInterfaceMockRecorder) Update(ctx, tagId, tagParams any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, tagId, tagParams}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTagServiceInterface)(nil).Update), varargs...)
+}
+
+// MockJobServiceInterface is a mock of JobServiceInterface interface.
+type MockJobServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobServiceInterfaceMockRecorder
+}
+
+// MockJobServiceInterfaceMockRecorder is the mock recorder for MockJobServiceInterface.
+type MockJobServiceInterfaceMockRecorder struct {
+	mock *MockJobServiceInterface
+}
+
+// NewMockJobServiceInterface creates a new mock instance.
+func NewMockJobServiceInterface(ctrl *gomock.Controller) *MockJobServiceInterface {
+	mock := &MockJobServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockJobServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobServiceInterface) EXPECT() *MockJobServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AggregateFileMimetype mocks base method.
+func (m *MockJobServiceInterface) AggregateFileMimetype(ctx context.Context, params *gothreatmatrix.AggregateParams, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.AggregateResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AggregateFileMimetype", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.AggregateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateFileMimetype indicates an expected call of AggregateFileMimetype.
+func (mr *MockJobServiceInterfaceMockRecorder) AggregateFileMimetype(ctx, params any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateFileMimetype", reflect.TypeOf((*MockJobServiceInterface)(nil).AggregateFileMimetype), varargs...)
+}
+
+// AggregateObservableClassification mocks base method.
+func (m *MockJobServiceInterface) AggregateObservableClassification(ctx context.Context, params *gothreatmatrix.AggregateParams, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.AggregateResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AggregateObservableClassification", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.AggregateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateObservableClassification indicates an expected call of AggregateObservableClassification.
+func (mr *MockJobServiceInterfaceMockRecorder) AggregateObservableClassification(ctx, params any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateObservableClassification", reflect.TypeOf((*MockJobServiceInterface)(nil).AggregateObservableClassification), varargs...)
+}
+
+// AggregateStatus mocks base method.
+func (m *MockJobServiceInterface) AggregateStatus(ctx context.Context, params *gothreatmatrix.AggregateParams, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.AggregateResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AggregateStatus", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.AggregateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateStatus indicates an expected call of AggregateStatus.
+func (mr *MockJobServiceInterfaceMockRecorder) AggregateStatus(ctx, params any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateStatus", reflect.TypeOf((*MockJobServiceInterface)(nil).AggregateStatus), varargs...)
+}
+
+// AggregateType mocks base method.
+func (m *MockJobServiceInterface) AggregateType(ctx context.Context, params *gothreatmatrix.AggregateParams, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.AggregateResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AggregateType", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.AggregateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateType indicates an expected call of AggregateType.
+func (mr *MockJobServiceInterfaceMockRecorder) AggregateType(ctx, params any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateType", reflect.TypeOf((*MockJobServiceInterface)(nil).AggregateType), varargs...)
+}
+
+// ApplyRetention mocks base method.
+func (m *MockJobServiceInterface) ApplyRetention(ctx context.Context, policy gothreatmatrix.RetentionPolicy, dryRun bool, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.RetentionReport, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, policy, dryRun}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ApplyRetention", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.RetentionReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyRetention indicates an expected call of ApplyRetention.
+func (mr *MockJobServiceInterfaceMockRecorder) ApplyRetention(ctx, policy, dryRun any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, policy, dryRun}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyRetention", reflect.TypeOf((*MockJobServiceInterface)(nil).ApplyRetention), varargs...)
+}
+
+// Delete mocks base method.
+func (m *MockJobServiceInterface) Delete(ctx context.Context, jobId uint64, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockJobServiceInterfaceMockRecorder) Delete(ctx, jobId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockJobServiceInterface)(nil).Delete), varargs...)
+}
+
+// DownloadSample mocks base method.
+func (m *MockJobServiceInterface) DownloadSample(ctx context.Context, jobId uint64, opts ...gothreatmatrix.RequestOption) ([]byte, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadSample", varargs...)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadSample indicates an expected call of DownloadSample.
+func (mr *MockJobServiceInterfaceMockRecorder) DownloadSample(ctx, jobId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSample", reflect.TypeOf((*MockJobServiceInterface)(nil).DownloadSample), varargs...)
+}
+
+// DownloadSampleTo mocks base method.
+func (m *MockJobServiceInterface) DownloadSampleTo(ctx context.Context, jobId uint64, writer io.Writer, opts ...gothreatmatrix.RequestOption) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId, writer}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadSampleTo", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadSampleTo indicates an expected call of DownloadSampleTo.
+func (mr *MockJobServiceInterfaceMockRecorder) DownloadSampleTo(ctx, jobId, writer any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId, writer}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSampleTo", reflect.TypeOf((*MockJobServiceInterface)(nil).DownloadSampleTo), varargs...)
+}
+
+// DownloadSampleZipped mocks base method.
+func (m *MockJobServiceInterface) DownloadSampleZipped(ctx context.Context, jobId uint64, password string, writer io.Writer, opts ...gothreatmatrix.RequestOption) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId, password, writer}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadSampleZipped", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DownloadSampleZipped indicates an expected call of DownloadSampleZipped.
+func (mr *MockJobServiceInterfaceMockRecorder) DownloadSampleZipped(ctx, jobId, password, writer any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId, password, writer}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSampleZipped", reflect.TypeOf((*MockJobServiceInterface)(nil).DownloadSampleZipped), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockJobServiceInterface) Get(ctx context.Context, jobId uint64, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Job, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockJobServiceInterfaceMockRecorder) Get(ctx, jobId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockJobServiceInterface)(nil).Get), varargs...)
+}
+
+// Iter mocks base method.
+func (m *MockJobServiceInterface) Iter(ctx context.Context, params *gothreatmatrix.JobListParams, opts ...gothreatmatrix.RequestOption) *gothreatmatrix.JobIterator {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Iter", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.JobIterator)
+	return ret0
+}
+
+// Iter indicates an expected call of Iter.
+func (mr *MockJobServiceInterfaceMockRecorder) Iter(ctx, params any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Iter", reflect.TypeOf((*MockJobServiceInterface)(nil).Iter), varargs...)
+}
+
+// Kill mocks base method.
+func (m *MockJobServiceInterface) Kill(ctx context.Context, jobId uint64, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Kill", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Kill indicates an expected call of Kill.
+func (mr *MockJobServiceInterfaceMockRecorder) Kill(ctx, jobId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Kill", reflect.TypeOf((*MockJobServiceInterface)(nil).Kill), varargs...)
+}
+
+// KillAll mocks base method.
+func (m *MockJobServiceInterface) KillAll(ctx context.Context, filter *gothreatmatrix.JobListParams, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.KillResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, filter}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "KillAll", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.KillResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KillAll indicates an expected call of KillAll.
+func (mr *MockJobServiceInterfaceMockRecorder) KillAll(ctx, filter any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, filter}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KillAll", reflect.TypeOf((*MockJobServiceInterface)(nil).KillAll), varargs...)
+}
+
+// KillAnalyzer mocks base method.
+func (m *MockJobServiceInterface) KillAnalyzer(ctx context.Context, jobId uint64, analyzerName string, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId, analyzerName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "KillAnalyzer", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KillAnalyzer indicates an expected call of KillAnalyzer.
+func (mr *MockJobServiceInterfaceMockRecorder) KillAnalyzer(ctx, jobId, analyzerName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId, analyzerName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KillAnalyzer", reflect.TypeOf((*MockJobServiceInterface)(nil).KillAnalyzer), varargs...)
+}
+
+// KillConnector mocks base method.
+func (m *MockJobServiceInterface) KillConnector(ctx context.Context, jobId uint64, connectorName string, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId, connectorName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "KillConnector", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KillConnector indicates an expected call of KillConnector.
+func (mr *MockJobServiceInterfaceMockRecorder) KillConnector(ctx, jobId, connectorName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId, connectorName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KillConnector", reflect.TypeOf((*MockJobServiceInterface)(nil).KillConnector), varargs...)
+}
+
+// List mocks base method.
+func (m *MockJobServiceInterface) List(ctx context.Context, params *gothreatmatrix.JobListParams, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.JobListResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.JobListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockJobServiceInterfaceMockRecorder) List(ctx, params any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockJobServiceInterface)(nil).List), varargs...)
+}
+
+// ListAll mocks base method.
+func (m *MockJobServiceInterface) ListAll(ctx context.Context, params *gothreatmatrix.JobListParams, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.JobList, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAll", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.JobList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockJobServiceInterfaceMockRecorder) ListAll(ctx, params any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockJobServiceInterface)(nil).ListAll), varargs...)
+}
+
+// Pager mocks base method.
+func (m *MockJobServiceInterface) Pager(ctx context.Context, params *gothreatmatrix.JobListParams, opts ...gothreatmatrix.RequestOption) *gothreatmatrix.Pager[gothreatmatrix.JobList] {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Pager", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Pager[gothreatmatrix.JobList])
+	return ret0
+}
+
+// Pager indicates an expected call of Pager.
+func (mr *MockJobServiceInterfaceMockRecorder) Pager(ctx, params any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pager", reflect.TypeOf((*MockJobServiceInterface)(nil).Pager), varargs...)
+}
+
+// RecentScans mocks base method.
+func (m *MockJobServiceInterface) RecentScans(ctx context.Context, value string, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.RecentScan, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, value}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RecentScans", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.RecentScan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecentScans indicates an expected call of RecentScans.
+func (mr *MockJobServiceInterfaceMockRecorder) RecentScans(ctx, value any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, value}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecentScans", reflect.TypeOf((*MockJobServiceInterface)(nil).RecentScans), varargs...)
+}
+
+// RetryAnalyzer mocks base method.
+func (m *MockJobServiceInterface) RetryAnalyzer(ctx context.Context, jobId uint64, analyzerName string, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId, analyzerName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RetryAnalyzer", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryAnalyzer indicates an expected call of RetryAnalyzer.
+func (mr *MockJobServiceInterfaceMockRecorder) RetryAnalyzer(ctx, jobId, analyzerName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId, analyzerName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryAnalyzer", reflect.TypeOf((*MockJobServiceInterface)(nil).RetryAnalyzer), varargs...)
+}
+
+// RetryConnector mocks base method.
+func (m *MockJobServiceInterface) RetryConnector(ctx context.Context, jobId uint64, connectorName string, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId, connectorName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RetryConnector", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryConnector indicates an expected call of RetryConnector.
+func (mr *MockJobServiceInterfaceMockRecorder) RetryConnector(ctx, jobId, connectorName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId, connectorName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryConnector", reflect.TypeOf((*MockJobServiceInterface)(nil).RetryConnector), varargs...)
+}
+
+// RetryFailedAnalyzers mocks base method.
+func (m *MockJobServiceInterface) RetryFailedAnalyzers(ctx context.Context, jobId uint64, opts ...gothreatmatrix.RequestOption) (map[string]gothreatmatrix.RetryResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RetryFailedAnalyzers", varargs...)
+	ret0, _ := ret[0].(map[string]gothreatmatrix.RetryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryFailedAnalyzers indicates an expected call of RetryFailedAnalyzers.
+func (mr *MockJobServiceInterfaceMockRecorder) RetryFailedAnalyzers(ctx, jobId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryFailedAnalyzers", reflect.TypeOf((*MockJobServiceInterface)(nil).RetryFailedAnalyzers), varargs...)
+}
+
+// Search mocks base method.
+func (m *MockJobServiceInterface) Search(ctx context.Context, query *gothreatmatrix.JobSearchQuery, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.JobListResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, query}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Search", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.JobListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockJobServiceInterfaceMockRecorder) Search(ctx, query any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, query}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockJobServiceInterface)(nil).Search), varargs...)
+}
+
+// Stream mocks base method.
+func (m *MockJobServiceInterface) Stream(ctx context.Context, params *gothreatmatrix.JobListParams, fn func(*gothreatmatrix.JobList) error, opts ...gothreatmatrix.RequestOption) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params, fn}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Stream", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockJobServiceInterfaceMockRecorder) Stream(ctx, params, fn any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params, fn}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockJobServiceInterface)(nil).Stream), varargs...)
+}
+
+// WaitForCompletion mocks base method.
+func (m *MockJobServiceInterface) WaitForCompletion(ctx context.Context, jobId uint64, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Job, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WaitForCompletion", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForCompletion indicates an expected call of WaitForCompletion.
+func (mr *MockJobServiceInterfaceMockRecorder) WaitForCompletion(ctx, jobId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForCompletion", reflect.TypeOf((*MockJobServiceInterface)(nil).WaitForCompletion), varargs...)
+}
+
+// Watch mocks base method.
+func (m *MockJobServiceInterface) Watch(ctx context.Context, jobId uint64, opts ...gothreatmatrix.RequestOption) <-chan gothreatmatrix.JobUpdate {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Watch", varargs...)
+	ret0, _ := ret[0].(<-chan gothreatmatrix.JobUpdate)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockJobServiceInterfaceMockRecorder) Watch(ctx, jobId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockJobServiceInterface)(nil).Watch), varargs...)
+}
+
+// MockAnalyzerServiceInterface is a mock of AnalyzerServiceInterface interface.
+type MockAnalyzerServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyzerServiceInterfaceMockRecorder
+}
+
+// MockAnalyzerServiceInterfaceMockRecorder is the mock recorder for MockAnalyzerServiceInterface.
+type MockAnalyzerServiceInterfaceMockRecorder struct {
+	mock *MockAnalyzerServiceInterface
+}
+
+// NewMockAnalyzerServiceInterface creates a new mock instance.
+func NewMockAnalyzerServiceInterface(ctrl *gomock.Controller) *MockAnalyzerServiceInterface {
+	mock := &MockAnalyzerServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockAnalyzerServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyzerServiceInterface) EXPECT() *MockAnalyzerServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockAnalyzerServiceInterface) Get(ctx context.Context, analyzerName string, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.AnalyzerConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, analyzerName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.AnalyzerConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAnalyzerServiceInterfaceMockRecorder) Get(ctx, analyzerName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, analyzerName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAnalyzerServiceInterface)(nil).Get), varargs...)
+}
+
+// GetConfigs mocks base method.
+func (m *MockAnalyzerServiceInterface) GetConfigs(ctx context.Context, opts ...gothreatmatrix.RequestOption) (*[]gothreatmatrix.AnalyzerConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetConfigs", varargs...)
+	ret0, _ := ret[0].(*[]gothreatmatrix.AnalyzerConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigs indicates an expected call of GetConfigs.
+func (mr *MockAnalyzerServiceInterfaceMockRecorder) GetConfigs(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigs", reflect.TypeOf((*MockAnalyzerServiceInterface)(nil).GetConfigs), varargs...)
+}
+
+// HealthCheck mocks base method.
+func (m *MockAnalyzerServiceInterface) HealthCheck(ctx context.Context, analyzerName string, opts ...gothreatmatrix.RequestOption) (gothreatmatrix.HealthStatus, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, analyzerName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "HealthCheck", varargs...)
+	ret0, _ := ret[0].(gothreatmatrix.HealthStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HealthCheck indicates an expected call of HealthCheck.
+func (mr *MockAnalyzerServiceInterfaceMockRecorder) HealthCheck(ctx, analyzerName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, analyzerName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockAnalyzerServiceInterface)(nil).HealthCheck), varargs...)
+}
+
+// List mocks base method.
+func (m *MockAnalyzerServiceInterface) List(ctx context.Context, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.AnalyzerConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.AnalyzerConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAnalyzerServiceInterfaceMockRecorder) List(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAnalyzerServiceInterface)(nil).List), varargs...)
+}
+
+// Pager mocks base method.
+func (m *MockAnalyzerServiceInterface) Pager(ctx context.Context, pageSize int, opts ...gothreatmatrix.RequestOption) *gothreatmatrix.Pager[gothreatmatrix.AnalyzerConfig] {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pageSize}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Pager", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Pager[gothreatmatrix.AnalyzerConfig])
+	return ret0
+}
+
+// Pager indicates an expected call of Pager.
+func (mr *MockAnalyzerServiceInterfaceMockRecorder) Pager(ctx, pageSize any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pageSize}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pager", reflect.TypeOf((*MockAnalyzerServiceInterface)(nil).Pager), varargs...)
+}
+
+// MockConnectorServiceInterface is a mock of ConnectorServiceInterface interface.
+type MockConnectorServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockConnectorServiceInterfaceMockRecorder
+}
+
+// MockConnectorServiceInterfaceMockRecorder is the mock recorder for MockConnectorServiceInterface.
+type MockConnectorServiceInterfaceMockRecorder struct {
+	mock *MockConnectorServiceInterface
+}
+
+// NewMockConnectorServiceInterface creates a new mock instance.
+func NewMockConnectorServiceInterface(ctrl *gomock.Controller) *MockConnectorServiceInterface {
+	mock := &MockConnectorServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockConnectorServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConnectorServiceInterface) EXPECT() *MockConnectorServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockConnectorServiceInterface) Get(ctx context.Context, connectorName string, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.ConnectorConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, connectorName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.ConnectorConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockConnectorServiceInterfaceMockRecorder) Get(ctx, connectorName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, connectorName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockConnectorServiceInterface)(nil).Get), varargs...)
+}
+
+// GetConfigs mocks base method.
+func (m *MockConnectorServiceInterface) GetConfigs(ctx context.Context, opts ...gothreatmatrix.RequestOption) (*[]gothreatmatrix.ConnectorConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetConfigs", varargs...)
+	ret0, _ := ret[0].(*[]gothreatmatrix.ConnectorConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigs indicates an expected call of GetConfigs.
+func (mr *MockConnectorServiceInterfaceMockRecorder) GetConfigs(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigs", reflect.TypeOf((*MockConnectorServiceInterface)(nil).GetConfigs), varargs...)
+}
+
+// HealthCheck mocks base method.
+func (m *MockConnectorServiceInterface) HealthCheck(ctx context.Context, connectorName string, opts ...gothreatmatrix.RequestOption) (gothreatmatrix.HealthStatus, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, connectorName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "HealthCheck", varargs...)
+	ret0, _ := ret[0].(gothreatmatrix.HealthStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HealthCheck indicates an expected call of HealthCheck.
+func (mr *MockConnectorServiceInterfaceMockRecorder) HealthCheck(ctx, connectorName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, connectorName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockConnectorServiceInterface)(nil).HealthCheck), varargs...)
+}
+
+// HealthCheckAll mocks base method.
+func (m *MockConnectorServiceInterface) HealthCheckAll(ctx context.Context, connectorNames []string, concurrency int, opts ...gothreatmatrix.RequestOption) []gothreatmatrix.HealthCheckResult {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, connectorNames, concurrency}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "HealthCheckAll", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.HealthCheckResult)
+	return ret0
+}
+
+// HealthCheckAll indicates an expected call of HealthCheckAll.
+func (mr *MockConnectorServiceInterfaceMockRecorder) HealthCheckAll(ctx, connectorNames, concurrency any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, connectorNames, concurrency}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheckAll", reflect.TypeOf((*MockConnectorServiceInterface)(nil).HealthCheckAll), varargs...)
+}
+
+// List mocks base method.
+func (m *MockConnectorServiceInterface) List(ctx context.Context, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.ConnectorConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.ConnectorConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockConnectorServiceInterfaceMockRecorder) List(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockConnectorServiceInterface)(nil).List), varargs...)
+}
+
+// Pager mocks base method.
+func (m *MockConnectorServiceInterface) Pager(ctx context.Context, pageSize int, opts ...gothreatmatrix.RequestOption) *gothreatmatrix.Pager[gothreatmatrix.ConnectorConfig] {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pageSize}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Pager", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Pager[gothreatmatrix.ConnectorConfig])
+	return ret0
+}
+
+// Pager indicates an expected call of Pager.
+func (mr *MockConnectorServiceInterfaceMockRecorder) Pager(ctx, pageSize any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pageSize}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pager", reflect.TypeOf((*MockConnectorServiceInterface)(nil).Pager), varargs...)
+}
+
+// MockVisualizerServiceInterface is a mock of VisualizerServiceInterface interface.
+type MockVisualizerServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockVisualizerServiceInterfaceMockRecorder
+}
+
+// MockVisualizerServiceInterfaceMockRecorder is the mock recorder for MockVisualizerServiceInterface.
+type MockVisualizerServiceInterfaceMockRecorder struct {
+	mock *MockVisualizerServiceInterface
+}
+
+// NewMockVisualizerServiceInterface creates a new mock instance.
+func NewMockVisualizerServiceInterface(ctrl *gomock.Controller) *MockVisualizerServiceInterface {
+	mock := &MockVisualizerServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockVisualizerServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVisualizerServiceInterface) EXPECT() *MockVisualizerServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockVisualizerServiceInterface) Get(ctx context.Context, visualizerName string, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.VisualizerConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, visualizerName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.VisualizerConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockVisualizerServiceInterfaceMockRecorder) Get(ctx, visualizerName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, visualizerName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockVisualizerServiceInterface)(nil).Get), varargs...)
+}
+
+// JobReport mocks base method.
+func (m *MockVisualizerServiceInterface) JobReport(ctx context.Context, jobId uint64, visualizerName string, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.VisualizerReport, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId, visualizerName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "JobReport", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.VisualizerReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JobReport indicates an expected call of JobReport.
+func (mr *MockVisualizerServiceInterfaceMockRecorder) JobReport(ctx, jobId, visualizerName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId, visualizerName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JobReport", reflect.TypeOf((*MockVisualizerServiceInterface)(nil).JobReport), varargs...)
+}
+
+// JobReports mocks base method.
+func (m *MockVisualizerServiceInterface) JobReports(ctx context.Context, jobId uint64, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.VisualizerReport, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "JobReports", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.VisualizerReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JobReports indicates an expected call of JobReports.
+func (mr *MockVisualizerServiceInterfaceMockRecorder) JobReports(ctx, jobId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JobReports", reflect.TypeOf((*MockVisualizerServiceInterface)(nil).JobReports), varargs...)
+}
+
+// List mocks base method.
+func (m *MockVisualizerServiceInterface) List(ctx context.Context, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.VisualizerConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.VisualizerConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockVisualizerServiceInterfaceMockRecorder) List(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockVisualizerServiceInterface)(nil).List), varargs...)
+}
+
+// MockPivotServiceInterface is a mock of PivotServiceInterface interface.
+type MockPivotServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPivotServiceInterfaceMockRecorder
+}
+
+// MockPivotServiceInterfaceMockRecorder is the mock recorder for MockPivotServiceInterface.
+type MockPivotServiceInterfaceMockRecorder struct {
+	mock *MockPivotServiceInterface
+}
+
+// NewMockPivotServiceInterface creates a new mock instance.
+func NewMockPivotServiceInterface(ctrl *gomock.Controller) *MockPivotServiceInterface {
+	mock := &MockPivotServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockPivotServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPivotServiceInterface) EXPECT() *MockPivotServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Children mocks base method.
+func (m *MockPivotServiceInterface) Children(ctx context.Context, jobId uint64, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.Job, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Children", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Children indicates an expected call of Children.
+func (mr *MockPivotServiceInterfaceMockRecorder) Children(ctx, jobId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Children", reflect.TypeOf((*MockPivotServiceInterface)(nil).Children), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockPivotServiceInterface) Get(ctx context.Context, pivotName string, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.PivotConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pivotName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.PivotConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPivotServiceInterfaceMockRecorder) Get(ctx, pivotName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pivotName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPivotServiceInterface)(nil).Get), varargs...)
+}
+
+// List mocks base method.
+func (m *MockPivotServiceInterface) List(ctx context.Context, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.PivotConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.PivotConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPivotServiceInterfaceMockRecorder) List(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPivotServiceInterface)(nil).List), varargs...)
+}
+
+// Tree mocks base method.
+func (m *MockPivotServiceInterface) Tree(ctx context.Context, jobId uint64, maxDepth int, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.PivotNode, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId, maxDepth}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Tree", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.PivotNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tree indicates an expected call of Tree.
+func (mr *MockPivotServiceInterfaceMockRecorder) Tree(ctx, jobId, maxDepth any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId, maxDepth}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tree", reflect.TypeOf((*MockPivotServiceInterface)(nil).Tree), varargs...)
+}
+
+// MockPluginConfigServiceInterface is a mock of PluginConfigServiceInterface interface.
+type MockPluginConfigServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPluginConfigServiceInterfaceMockRecorder
+}
+
+// MockPluginConfigServiceInterfaceMockRecorder is the mock recorder for MockPluginConfigServiceInterface.
+type MockPluginConfigServiceInterfaceMockRecorder struct {
+	mock *MockPluginConfigServiceInterface
+}
+
+// NewMockPluginConfigServiceInterface creates a new mock instance.
+func NewMockPluginConfigServiceInterface(ctrl *gomock.Controller) *MockPluginConfigServiceInterface {
+	mock := &MockPluginConfigServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockPluginConfigServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPluginConfigServiceInterface) EXPECT() *MockPluginConfigServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPluginConfigServiceInterface) Create(ctx context.Context, params []gothreatmatrix.PluginConfigParams, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.PluginConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.PluginConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPluginConfigServiceInterfaceMockRecorder) Create(ctx, params any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPluginConfigServiceInterface)(nil).Create), varargs...)
+}
+
+// Delete mocks base method.
+func (m *MockPluginConfigServiceInterface) Delete(ctx context.Context, pluginConfigId uint64, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pluginConfigId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPluginConfigServiceInterfaceMockRecorder) Delete(ctx, pluginConfigId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pluginConfigId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPluginConfigServiceInterface)(nil).Delete), varargs...)
+}
+
+// List mocks base method.
+func (m *MockPluginConfigServiceInterface) List(ctx context.Context, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.PluginConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.PluginConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPluginConfigServiceInterfaceMockRecorder) List(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPluginConfigServiceInterface)(nil).List), varargs...)
+}
+
+// ListForPlugin mocks base method.
+func (m *MockPluginConfigServiceInterface) ListForPlugin(ctx context.Context, pluginType gothreatmatrix.PluginType, pluginName string, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.PluginConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pluginType, pluginName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListForPlugin", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.PluginConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListForPlugin indicates an expected call of ListForPlugin.
+func (mr *MockPluginConfigServiceInterfaceMockRecorder) ListForPlugin(ctx, pluginType, pluginName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pluginType, pluginName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForPlugin", reflect.TypeOf((*MockPluginConfigServiceInterface)(nil).ListForPlugin), varargs...)
+}
+
+// Update mocks base method.
+func (m *MockPluginConfigServiceInterface) Update(ctx context.Context, pluginConfigId uint64, value any, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.PluginConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pluginConfigId, value}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Update", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.PluginConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPluginConfigServiceInterfaceMockRecorder) Update(ctx, pluginConfigId, value any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pluginConfigId, value}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPluginConfigServiceInterface)(nil).Update), varargs...)
+}
+
+// MockIngestorServiceInterface is a mock of IngestorServiceInterface interface.
+type MockIngestorServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockIngestorServiceInterfaceMockRecorder
+}
+
+// MockIngestorServiceInterfaceMockRecorder is the mock recorder for MockIngestorServiceInterface.
+type MockIngestorServiceInterfaceMockRecorder struct {
+	mock *MockIngestorServiceInterface
+}
+
+// NewMockIngestorServiceInterface creates a new mock instance.
+func NewMockIngestorServiceInterface(ctrl *gomock.Controller) *MockIngestorServiceInterface {
+	mock := &MockIngestorServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockIngestorServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIngestorServiceInterface) EXPECT() *MockIngestorServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Disable mocks base method.
+func (m *MockIngestorServiceInterface) Disable(ctx context.Context, ingestorName string, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, ingestorName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Disable", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockIngestorServiceInterfaceMockRecorder) Disable(ctx, ingestorName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, ingestorName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockIngestorServiceInterface)(nil).Disable), varargs...)
+}
+
+// Enable mocks base method.
+func (m *MockIngestorServiceInterface) Enable(ctx context.Context, ingestorName string, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, ingestorName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Enable", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enable indicates an expected call of Enable.
+func (mr *MockIngestorServiceInterfaceMockRecorder) Enable(ctx, ingestorName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, ingestorName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enable", reflect.TypeOf((*MockIngestorServiceInterface)(nil).Enable), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockIngestorServiceInterface) Get(ctx context.Context, ingestorName string, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.IngestorConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, ingestorName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.IngestorConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIngestorServiceInterfaceMockRecorder) Get(ctx, ingestorName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, ingestorName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIngestorServiceInterface)(nil).Get), varargs...)
+}
+
+// HealthCheck mocks base method.
+func (m *MockIngestorServiceInterface) HealthCheck(ctx context.Context, ingestorName string, opts ...gothreatmatrix.RequestOption) (gothreatmatrix.HealthStatus, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, ingestorName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "HealthCheck", varargs...)
+	ret0, _ := ret[0].(gothreatmatrix.HealthStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HealthCheck indicates an expected call of HealthCheck.
+func (mr *MockIngestorServiceInterfaceMockRecorder) HealthCheck(ctx, ingestorName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, ingestorName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockIngestorServiceInterface)(nil).HealthCheck), varargs...)
+}
+
+// List mocks base method.
+func (m *MockIngestorServiceInterface) List(ctx context.Context, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.IngestorConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.IngestorConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIngestorServiceInterfaceMockRecorder) List(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIngestorServiceInterface)(nil).List), varargs...)
+}
+
+// MockInvestigationServiceInterface is a mock of InvestigationServiceInterface interface.
+type MockInvestigationServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvestigationServiceInterfaceMockRecorder
+}
+
+// MockInvestigationServiceInterfaceMockRecorder is the mock recorder for MockInvestigationServiceInterface.
+type MockInvestigationServiceInterfaceMockRecorder struct {
+	mock *MockInvestigationServiceInterface
+}
+
+// NewMockInvestigationServiceInterface creates a new mock instance.
+func NewMockInvestigationServiceInterface(ctrl *gomock.Controller) *MockInvestigationServiceInterface {
+	mock := &MockInvestigationServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockInvestigationServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvestigationServiceInterface) EXPECT() *MockInvestigationServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddJob mocks base method.
+func (m *MockInvestigationServiceInterface) AddJob(ctx context.Context, investigationId, jobId uint64, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, investigationId, jobId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddJob", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddJob indicates an expected call of AddJob.
+func (mr *MockInvestigationServiceInterfaceMockRecorder) AddJob(ctx, investigationId, jobId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, investigationId, jobId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddJob", reflect.TypeOf((*MockInvestigationServiceInterface)(nil).AddJob), varargs...)
+}
+
+// Create mocks base method.
+func (m *MockInvestigationServiceInterface) Create(ctx context.Context, investigationParams *gothreatmatrix.InvestigationParams, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Investigation, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, investigationParams}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Investigation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInvestigationServiceInterfaceMockRecorder) Create(ctx, investigationParams any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, investigationParams}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInvestigationServiceInterface)(nil).Create), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockInvestigationServiceInterface) Get(ctx context.Context, investigationId uint64, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Investigation, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, investigationId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Investigation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInvestigationServiceInterfaceMockRecorder) Get(ctx, investigationId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, investigationId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInvestigationServiceInterface)(nil).Get), varargs...)
+}
+
+// List mocks base method.
+func (m *MockInvestigationServiceInterface) List(ctx context.Context, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.Investigation, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.Investigation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockInvestigationServiceInterfaceMockRecorder) List(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockInvestigationServiceInterface)(nil).List), varargs...)
+}
+
+// RemoveJob mocks base method.
+func (m *MockInvestigationServiceInterface) RemoveJob(ctx context.Context, investigationId, jobId uint64, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, investigationId, jobId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveJob", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveJob indicates an expected call of RemoveJob.
+func (mr *MockInvestigationServiceInterfaceMockRecorder) RemoveJob(ctx, investigationId, jobId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, investigationId, jobId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveJob", reflect.TypeOf((*MockInvestigationServiceInterface)(nil).RemoveJob), varargs...)
+}
+
+// Tree mocks base method.
+func (m *MockInvestigationServiceInterface) Tree(ctx context.Context, investigationId uint64, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.InvestigationTree, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, investigationId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Tree", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.InvestigationTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tree indicates an expected call of Tree.
+func (mr *MockInvestigationServiceInterfaceMockRecorder) Tree(ctx, investigationId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, investigationId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tree", reflect.TypeOf((*MockInvestigationServiceInterface)(nil).Tree), varargs...)
+}
+
+// MockUserServiceInterface is a mock of UserServiceInterface interface.
+type MockUserServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceInterfaceMockRecorder
+}
+
+// MockUserServiceInterfaceMockRecorder is the mock recorder for MockUserServiceInterface.
+type MockUserServiceInterfaceMockRecorder struct {
+	mock *MockUserServiceInterface
+}
+
+// NewMockUserServiceInterface creates a new mock instance.
+func NewMockUserServiceInterface(ctrl *gomock.Controller) *MockUserServiceInterface {
+	mock := &MockUserServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockUserServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserServiceInterface) EXPECT() *MockUserServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// APITokenCreate mocks base method.
+func (m *MockUserServiceInterface) APITokenCreate(ctx context.Context, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.APIToken, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "APITokenCreate", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APITokenCreate indicates an expected call of APITokenCreate.
+func (mr *MockUserServiceInterfaceMockRecorder) APITokenCreate(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APITokenCreate", reflect.TypeOf((*MockUserServiceInterface)(nil).APITokenCreate), varargs...)
+}
+
+// APITokenDelete mocks base method.
+func (m *MockUserServiceInterface) APITokenDelete(ctx context.Context, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "APITokenDelete", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APITokenDelete indicates an expected call of APITokenDelete.
+func (mr *MockUserServiceInterfaceMockRecorder) APITokenDelete(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APITokenDelete", reflect.TypeOf((*MockUserServiceInterface)(nil).APITokenDelete), varargs...)
+}
+
+// APITokenGet mocks base method.
+func (m *MockUserServiceInterface) APITokenGet(ctx context.Context, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.APIToken, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "APITokenGet", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APITokenGet indicates an expected call of APITokenGet.
+func (mr *MockUserServiceInterfaceMockRecorder) APITokenGet(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APITokenGet", reflect.TypeOf((*MockUserServiceInterface)(nil).APITokenGet), varargs...)
+}
+
+// Access mocks base method.
+func (m *MockUserServiceInterface) Access(ctx context.Context, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.User, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Access", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Access indicates an expected call of Access.
+func (mr *MockUserServiceInterfaceMockRecorder) Access(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Access", reflect.TypeOf((*MockUserServiceInterface)(nil).Access), varargs...)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockUserServiceInterface) CreateOrganization(ctx context.Context, organizationParams *gothreatmatrix.OrganizationParams, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Organization, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, organizationParams}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateOrganization", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockUserServiceInterfaceMockRecorder) CreateOrganization(ctx, organizationParams any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, organizationParams}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockUserServiceInterface)(nil).CreateOrganization), varargs...)
+}
+
+// InviteToOrganization mocks base method.
+func (m *MockUserServiceInterface) InviteToOrganization(ctx context.Context, memberParams *gothreatmatrix.MemberParams, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Invite, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, memberParams}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InviteToOrganization", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Invite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteToOrganization indicates an expected call of InviteToOrganization.
+func (mr *MockUserServiceInterfaceMockRecorder) InviteToOrganization(ctx, memberParams any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, memberParams}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteToOrganization", reflect.TypeOf((*MockUserServiceInterface)(nil).InviteToOrganization), varargs...)
+}
+
+// Login mocks base method.
+func (m *MockUserServiceInterface) Login(ctx context.Context, username, password string, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Session, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, username, password}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Login", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockUserServiceInterfaceMockRecorder) Login(ctx, username, password any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, username, password}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserServiceInterface)(nil).Login), varargs...)
+}
+
+// Logout mocks base method.
+func (m *MockUserServiceInterface) Logout(ctx context.Context, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Logout", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockUserServiceInterfaceMockRecorder) Logout(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUserServiceInterface)(nil).Logout), varargs...)
+}
+
+// Organization mocks base method.
+func (m *MockUserServiceInterface) Organization(ctx context.Context, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Organization, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Organization", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Organization indicates an expected call of Organization.
+func (mr *MockUserServiceInterfaceMockRecorder) Organization(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Organization", reflect.TypeOf((*MockUserServiceInterface)(nil).Organization), varargs...)
+}
+
+// RefreshToken mocks base method.
+func (m *MockUserServiceInterface) RefreshToken(ctx context.Context, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Session, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RefreshToken", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockUserServiceInterfaceMockRecorder) RefreshToken(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockUserServiceInterface)(nil).RefreshToken), varargs...)
+}
+
+// RemoveMemberFromOrganization mocks base method.
+func (m *MockUserServiceInterface) RemoveMemberFromOrganization(ctx context.Context, memberParams *gothreatmatrix.MemberParams, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, memberParams}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveMemberFromOrganization", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveMemberFromOrganization indicates an expected call of RemoveMemberFromOrganization.
+func (mr *MockUserServiceInterfaceMockRecorder) RemoveMemberFromOrganization(ctx, memberParams any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, memberParams}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMemberFromOrganization", reflect.TypeOf((*MockUserServiceInterface)(nil).RemoveMemberFromOrganization), varargs...)
+}
+
+// MockAnalyzeServiceInterface is a mock of AnalyzeServiceInterface interface.
+type MockAnalyzeServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyzeServiceInterfaceMockRecorder
+}
+
+// MockAnalyzeServiceInterfaceMockRecorder is the mock recorder for MockAnalyzeServiceInterface.
+type MockAnalyzeServiceInterfaceMockRecorder struct {
+	mock *MockAnalyzeServiceInterface
+}
+
+// NewMockAnalyzeServiceInterface creates a new mock instance.
+func NewMockAnalyzeServiceInterface(ctrl *gomock.Controller) *MockAnalyzeServiceInterface {
+	mock := &MockAnalyzeServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockAnalyzeServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyzeServiceInterface) EXPECT() *MockAnalyzeServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AnalyzeFile mocks base method.
+func (m *MockAnalyzeServiceInterface) AnalyzeFile(ctx context.Context, analysisRequest gothreatmatrix.FileAnalysisRequest, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.AnalysisResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, analysisRequest}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AnalyzeFile", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.AnalysisResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnalyzeFile indicates an expected call of AnalyzeFile.
+func (mr *MockAnalyzeServiceInterfaceMockRecorder) AnalyzeFile(ctx, analysisRequest any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, analysisRequest}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnalyzeFile", reflect.TypeOf((*MockAnalyzeServiceInterface)(nil).AnalyzeFile), varargs...)
+}
+
+// AnalyzeFileAndWait mocks base method.
+func (m *MockAnalyzeServiceInterface) AnalyzeFileAndWait(ctx context.Context, analysisRequest gothreatmatrix.FileAnalysisRequest, timeout time.Duration, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Job, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, analysisRequest, timeout}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AnalyzeFileAndWait", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnalyzeFileAndWait indicates an expected call of AnalyzeFileAndWait.
+func (mr *MockAnalyzeServiceInterfaceMockRecorder) AnalyzeFileAndWait(ctx, analysisRequest, timeout any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, analysisRequest, timeout}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnalyzeFileAndWait", reflect.TypeOf((*MockAnalyzeServiceInterface)(nil).AnalyzeFileAndWait), varargs...)
+}
+
+// AnalyzeFileIfNew mocks base method.
+func (m *MockAnalyzeServiceInterface) AnalyzeFileIfNew(ctx context.Context, analysisRequest gothreatmatrix.FileAnalysisRequest, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.AnalysisResponse, bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, analysisRequest}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AnalyzeFileIfNew", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.AnalysisResponse)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AnalyzeFileIfNew indicates an expected call of AnalyzeFileIfNew.
+func (mr *MockAnalyzeServiceInterfaceMockRecorder) AnalyzeFileIfNew(ctx, analysisRequest any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, analysisRequest}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnalyzeFileIfNew", reflect.TypeOf((*MockAnalyzeServiceInterface)(nil).AnalyzeFileIfNew), varargs...)
+}
+
+// AnalyzeFiles mocks base method.
+func (m *MockAnalyzeServiceInterface) AnalyzeFiles(ctx context.Context, analysisRequests []gothreatmatrix.FileAnalysisRequest, opts ...gothreatmatrix.RequestOption) []gothreatmatrix.FileAnalysisResult {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, analysisRequests}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AnalyzeFiles", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.FileAnalysisResult)
+	return ret0
+}
+
+// AnalyzeFiles indicates an expected call of AnalyzeFiles.
+func (mr *MockAnalyzeServiceInterfaceMockRecorder) AnalyzeFiles(ctx, analysisRequests any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, analysisRequests}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnalyzeFiles", reflect.TypeOf((*MockAnalyzeServiceInterface)(nil).AnalyzeFiles), varargs...)
+}
+
+// AnalyzeObservable mocks base method.
+func (m *MockAnalyzeServiceInterface) AnalyzeObservable(ctx context.Context, analysisRequest gothreatmatrix.ObservableAnalysisRequest, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.AnalysisResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, analysisRequest}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AnalyzeObservable", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.AnalysisResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnalyzeObservable indicates an expected call of AnalyzeObservable.
+func (mr *MockAnalyzeServiceInterfaceMockRecorder) AnalyzeObservable(ctx, analysisRequest any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, analysisRequest}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnalyzeObservable", reflect.TypeOf((*MockAnalyzeServiceInterface)(nil).AnalyzeObservable), varargs...)
+}
+
+// AnalyzeObservableAndWait mocks base method.
+func (m *MockAnalyzeServiceInterface) AnalyzeObservableAndWait(ctx context.Context, analysisRequest gothreatmatrix.ObservableAnalysisRequest, timeout time.Duration, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Job, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, analysisRequest, timeout}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AnalyzeObservableAndWait", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnalyzeObservableAndWait indicates an expected call of AnalyzeObservableAndWait.
+func (mr *MockAnalyzeServiceInterfaceMockRecorder) AnalyzeObservableAndWait(ctx, analysisRequest, timeout any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, analysisRequest, timeout}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnalyzeObservableAndWait", reflect.TypeOf((*MockAnalyzeServiceInterface)(nil).AnalyzeObservableAndWait), varargs...)
+}
+
+// AnalyzeObservables mocks base method.
+func (m *MockAnalyzeServiceInterface) AnalyzeObservables(ctx context.Context, analysisRequests []gothreatmatrix.ObservableAnalysisRequest, opts ...gothreatmatrix.RequestOption) []gothreatmatrix.ObservableAnalysisResult {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, analysisRequests}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AnalyzeObservables", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.ObservableAnalysisResult)
+	return ret0
+}
+
+// AnalyzeObservables indicates an expected call of AnalyzeObservables.
+func (mr *MockAnalyzeServiceInterfaceMockRecorder) AnalyzeObservables(ctx, analysisRequests any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, analysisRequests}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnalyzeObservables", reflect.TypeOf((*MockAnalyzeServiceInterface)(nil).AnalyzeObservables), varargs...)
+}
+
+// CheckAvailability mocks base method.
+func (m *MockAnalyzeServiceInterface) CheckAvailability(ctx context.Context, params *gothreatmatrix.AnalysisAvailabilityParams, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.AnalysisAvailability, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckAvailability", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.AnalysisAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckAvailability indicates an expected call of CheckAvailability.
+func (mr *MockAnalyzeServiceInterfaceMockRecorder) CheckAvailability(ctx, params any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAvailability", reflect.TypeOf((*MockAnalyzeServiceInterface)(nil).CheckAvailability), varargs...)
+}
+
+// MockPlaybookServiceInterface is a mock of PlaybookServiceInterface interface.
+type MockPlaybookServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlaybookServiceInterfaceMockRecorder
+}
+
+// MockPlaybookServiceInterfaceMockRecorder is the mock recorder for MockPlaybookServiceInterface.
+type MockPlaybookServiceInterfaceMockRecorder struct {
+	mock *MockPlaybookServiceInterface
+}
+
+// NewMockPlaybookServiceInterface creates a new mock instance.
+func NewMockPlaybookServiceInterface(ctrl *gomock.Controller) *MockPlaybookServiceInterface {
+	mock := &MockPlaybookServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockPlaybookServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlaybookServiceInterface) EXPECT() *MockPlaybookServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AnalyzeFile mocks base method.
+func (m *MockPlaybookServiceInterface) AnalyzeFile(ctx context.Context, analysisRequest gothreatmatrix.PlaybookFileAnalysisRequest, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.AnalysisResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, analysisRequest}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AnalyzeFile", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.AnalysisResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnalyzeFile indicates an expected call of AnalyzeFile.
+func (mr *MockPlaybookServiceInterfaceMockRecorder) AnalyzeFile(ctx, analysisRequest any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, analysisRequest}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnalyzeFile", reflect.TypeOf((*MockPlaybookServiceInterface)(nil).AnalyzeFile), varargs...)
+}
+
+// AnalyzeObservable mocks base method.
+func (m *MockPlaybookServiceInterface) AnalyzeObservable(ctx context.Context, analysisRequest gothreatmatrix.PlaybookObservableAnalysisRequest, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.AnalysisResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, analysisRequest}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AnalyzeObservable", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.AnalysisResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnalyzeObservable indicates an expected call of AnalyzeObservable.
+func (mr *MockPlaybookServiceInterfaceMockRecorder) AnalyzeObservable(ctx, analysisRequest any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, analysisRequest}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnalyzeObservable", reflect.TypeOf((*MockPlaybookServiceInterface)(nil).AnalyzeObservable), varargs...)
+}
+
+// Create mocks base method.
+func (m *MockPlaybookServiceInterface) Create(ctx context.Context, playbookParams *gothreatmatrix.PlaybookParams, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.PlaybookConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, playbookParams}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.PlaybookConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPlaybookServiceInterfaceMockRecorder) Create(ctx, playbookParams any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, playbookParams}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPlaybookServiceInterface)(nil).Create), varargs...)
+}
+
+// Delete mocks base method.
+func (m *MockPlaybookServiceInterface) Delete(ctx context.Context, playbookName string, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, playbookName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPlaybookServiceInterfaceMockRecorder) Delete(ctx, playbookName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, playbookName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPlaybookServiceInterface)(nil).Delete), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockPlaybookServiceInterface) Get(ctx context.Context, playbookName string, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.PlaybookConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, playbookName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.PlaybookConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPlaybookServiceInterfaceMockRecorder) Get(ctx, playbookName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, playbookName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPlaybookServiceInterface)(nil).Get), varargs...)
+}
+
+// List mocks base method.
+func (m *MockPlaybookServiceInterface) List(ctx context.Context, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.PlaybookConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.PlaybookConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPlaybookServiceInterfaceMockRecorder) List(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPlaybookServiceInterface)(nil).List), varargs...)
+}
+
+// Update mocks base method.
+func (m *MockPlaybookServiceInterface) Update(ctx context.Context, playbookName string, playbookParams *gothreatmatrix.PlaybookParams, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.PlaybookConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, playbookName, playbookParams}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Update", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.PlaybookConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPlaybookServiceInterfaceMockRecorder) Update(ctx, playbookName, playbookParams any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, playbookName, playbookParams}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPlaybookServiceInterface)(nil).Update), varargs...)
+}
+
+// MockOrganizationServiceInterface is a mock of OrganizationServiceInterface interface.
+type MockOrganizationServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationServiceInterfaceMockRecorder
+}
+
+// MockOrganizationServiceInterfaceMockRecorder is the mock recorder for MockOrganizationServiceInterface.
+type MockOrganizationServiceInterfaceMockRecorder struct {
+	mock *MockOrganizationServiceInterface
+}
+
+// NewMockOrganizationServiceInterface creates a new mock instance.
+func NewMockOrganizationServiceInterface(ctrl *gomock.Controller) *MockOrganizationServiceInterface {
+	mock := &MockOrganizationServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockOrganizationServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationServiceInterface) EXPECT() *MockOrganizationServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrganizationServiceInterface) Create(ctx context.Context, organizationParams *gothreatmatrix.OrganizationParams, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Organization, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, organizationParams}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrganizationServiceInterfaceMockRecorder) Create(ctx, organizationParams any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, organizationParams}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrganizationServiceInterface)(nil).Create), varargs...)
+}
+
+// Delete mocks base method.
+func (m *MockOrganizationServiceInterface) Delete(ctx context.Context, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockOrganizationServiceInterfaceMockRecorder) Delete(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrganizationServiceInterface)(nil).Delete), varargs...)
+}
+
+// DisablePlugin mocks base method.
+func (m *MockOrganizationServiceInterface) DisablePlugin(ctx context.Context, pluginType gothreatmatrix.PluginType, pluginName string, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pluginType, pluginName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DisablePlugin", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisablePlugin indicates an expected call of DisablePlugin.
+func (mr *MockOrganizationServiceInterfaceMockRecorder) DisablePlugin(ctx, pluginType, pluginName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pluginType, pluginName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisablePlugin", reflect.TypeOf((*MockOrganizationServiceInterface)(nil).DisablePlugin), varargs...)
+}
+
+// EnablePlugin mocks base method.
+func (m *MockOrganizationServiceInterface) EnablePlugin(ctx context.Context, pluginType gothreatmatrix.PluginType, pluginName string, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pluginType, pluginName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EnablePlugin", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnablePlugin indicates an expected call of EnablePlugin.
+func (mr *MockOrganizationServiceInterfaceMockRecorder) EnablePlugin(ctx, pluginType, pluginName any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pluginType, pluginName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnablePlugin", reflect.TypeOf((*MockOrganizationServiceInterface)(nil).EnablePlugin), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockOrganizationServiceInterface) Get(ctx context.Context, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Organization, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOrganizationServiceInterfaceMockRecorder) Get(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOrganizationServiceInterface)(nil).Get), varargs...)
+}
+
+// Leave mocks base method.
+func (m *MockOrganizationServiceInterface) Leave(ctx context.Context, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Leave", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Leave indicates an expected call of Leave.
+func (mr *MockOrganizationServiceInterfaceMockRecorder) Leave(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Leave", reflect.TypeOf((*MockOrganizationServiceInterface)(nil).Leave), varargs...)
+}
+
+// ListMembers mocks base method.
+func (m *MockOrganizationServiceInterface) ListMembers(ctx context.Context, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.Member, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListMembers", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockOrganizationServiceInterfaceMockRecorder) ListMembers(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockOrganizationServiceInterface)(nil).ListMembers), varargs...)
+}
+
+// RemoveMember mocks base method.
+func (m *MockOrganizationServiceInterface) RemoveMember(ctx context.Context, username string, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, username}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveMember", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockOrganizationServiceInterfaceMockRecorder) RemoveMember(ctx, username any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, username}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockOrganizationServiceInterface)(nil).RemoveMember), varargs...)
+}
+
+// MockInvitationServiceInterface is a mock of InvitationServiceInterface interface.
+type MockInvitationServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvitationServiceInterfaceMockRecorder
+}
+
+// MockInvitationServiceInterfaceMockRecorder is the mock recorder for MockInvitationServiceInterface.
+type MockInvitationServiceInterfaceMockRecorder struct {
+	mock *MockInvitationServiceInterface
+}
+
+// NewMockInvitationServiceInterface creates a new mock instance.
+func NewMockInvitationServiceInterface(ctrl *gomock.Controller) *MockInvitationServiceInterface {
+	mock := &MockInvitationServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockInvitationServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvitationServiceInterface) EXPECT() *MockInvitationServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockInvitationServiceInterface) Accept(ctx context.Context, invitationId uint64, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, invitationId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Accept", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockInvitationServiceInterfaceMockRecorder) Accept(ctx, invitationId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, invitationId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockInvitationServiceInterface)(nil).Accept), varargs...)
+}
+
+// Decline mocks base method.
+func (m *MockInvitationServiceInterface) Decline(ctx context.Context, invitationId uint64, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, invitationId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Decline", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decline indicates an expected call of Decline.
+func (mr *MockInvitationServiceInterfaceMockRecorder) Decline(ctx, invitationId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, invitationId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decline", reflect.TypeOf((*MockInvitationServiceInterface)(nil).Decline), varargs...)
+}
+
+// Invite mocks base method.
+func (m *MockInvitationServiceInterface) Invite(ctx context.Context, username string, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Invite, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, username}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Invite", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Invite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockInvitationServiceInterfaceMockRecorder) Invite(ctx, username any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, username}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockInvitationServiceInterface)(nil).Invite), varargs...)
+}
+
+// List mocks base method.
+func (m *MockInvitationServiceInterface) List(ctx context.Context, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.Invitation, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockInvitationServiceInterfaceMockRecorder) List(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockInvitationServiceInterface)(nil).List), varargs...)
+}
+
+// MockCommentServiceInterface is a mock of CommentServiceInterface interface.
+type MockCommentServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentServiceInterfaceMockRecorder
+}
+
+// MockCommentServiceInterfaceMockRecorder is the mock recorder for MockCommentServiceInterface.
+type MockCommentServiceInterfaceMockRecorder struct {
+	mock *MockCommentServiceInterface
+}
+
+// NewMockCommentServiceInterface creates a new mock instance.
+func NewMockCommentServiceInterface(ctrl *gomock.Controller) *MockCommentServiceInterface {
+	mock := &MockCommentServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockCommentServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentServiceInterface) EXPECT() *MockCommentServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCommentServiceInterface) Create(ctx context.Context, commentParams *gothreatmatrix.CommentParams, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.Comment, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, commentParams}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
+	ret0, _ := ret[0].(*gothreatmatrix.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentServiceInterfaceMockRecorder) Create(ctx, commentParams any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, commentParams}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentServiceInterface)(nil).Create), varargs...)
+}
+
+// Delete mocks base method.
+func (m *MockCommentServiceInterface) Delete(ctx context.Context, commentId uint64, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, commentId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentServiceInterfaceMockRecorder) Delete(ctx, commentId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, commentId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommentServiceInterface)(nil).Delete), varargs...)
+}
+
+// List mocks base method.
+func (m *MockCommentServiceInterface) List(ctx context.Context, jobId uint64, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.Comment, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, jobId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCommentServiceInterfaceMockRecorder) List(ctx, jobId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, jobId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCommentServiceInterface)(nil).List), varargs...)
+}
+
+// MockStatsServiceInterface is a mock of StatsServiceInterface interface.
+type MockStatsServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsServiceInterfaceMockRecorder
+}
+
+// MockStatsServiceInterfaceMockRecorder is the mock recorder for MockStatsServiceInterface.
+type MockStatsServiceInterfaceMockRecorder struct {
+	mock *MockStatsServiceInterface
+}
+
+// NewMockStatsServiceInterface creates a new mock instance.
+func NewMockStatsServiceInterface(ctrl *gomock.Controller) *MockStatsServiceInterface {
+	mock := &MockStatsServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockStatsServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsServiceInterface) EXPECT() *MockStatsServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// PluginFailureRates mocks base method.
+func (m *MockStatsServiceInterface) PluginFailureRates(ctx context.Context, params *gothreatmatrix.StatsParams, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.PluginFailureRate, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PluginFailureRates", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.PluginFailureRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PluginFailureRates indicates an expected call of PluginFailureRates.
+func (mr *MockStatsServiceInterfaceMockRecorder) PluginFailureRates(ctx, params any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginFailureRates", reflect.TypeOf((*MockStatsServiceInterface)(nil).PluginFailureRates), varargs...)
+}
+
+// Submissions mocks base method.
+func (m *MockStatsServiceInterface) Submissions(ctx context.Context, months int, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.MonthlySubmissions, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, months}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Submissions", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.MonthlySubmissions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Submissions indicates an expected call of Submissions.
+func (mr *MockStatsServiceInterfaceMockRecorder) Submissions(ctx, months any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, months}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submissions", reflect.TypeOf((*MockStatsServiceInterface)(nil).Submissions), varargs...)
+}
+
+// TopAnalyzers mocks base method.
+func (m *MockStatsServiceInterface) TopAnalyzers(ctx context.Context, params *gothreatmatrix.StatsParams, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.AnalyzerUsage, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TopAnalyzers", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.AnalyzerUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopAnalyzers indicates an expected call of TopAnalyzers.
+func (mr *MockStatsServiceInterfaceMockRecorder) TopAnalyzers(ctx, params any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopAnalyzers", reflect.TypeOf((*MockStatsServiceInterface)(nil).TopAnalyzers), varargs...)
+}
+
+// Users mocks base method.
+func (m *MockStatsServiceInterface) Users(ctx context.Context, params *gothreatmatrix.StatsParams, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.UserUsage, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Users", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.UserUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Users indicates an expected call of Users.
+func (mr *MockStatsServiceInterfaceMockRecorder) Users(ctx, params any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Users", reflect.TypeOf((*MockStatsServiceInterface)(nil).Users), varargs...)
+}
+
+// MockNotificationServiceInterface is a mock of NotificationServiceInterface interface.
+type MockNotificationServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationServiceInterfaceMockRecorder
+}
+
+// MockNotificationServiceInterfaceMockRecorder is the mock recorder for MockNotificationServiceInterface.
+type MockNotificationServiceInterfaceMockRecorder struct {
+	mock *MockNotificationServiceInterface
+}
+
+// NewMockNotificationServiceInterface creates a new mock instance.
+func NewMockNotificationServiceInterface(ctrl *gomock.Controller) *MockNotificationServiceInterface {
+	mock := &MockNotificationServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockNotificationServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationServiceInterface) EXPECT() *MockNotificationServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockNotificationServiceInterface) List(ctx context.Context, params *gothreatmatrix.NotificationListParams, opts ...gothreatmatrix.RequestOption) ([]gothreatmatrix.Notification, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].([]gothreatmatrix.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockNotificationServiceInterfaceMockRecorder) List(ctx, params any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNotificationServiceInterface)(nil).List), varargs...)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationServiceInterface) MarkRead(ctx context.Context, notificationId uint64, opts ...gothreatmatrix.RequestOption) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, notificationId}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MarkRead", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationServiceInterfaceMockRecorder) MarkRead(ctx, notificationId any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, notificationId}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationServiceInterface)(nil).MarkRead), varargs...)
+}
@@ -0,0 +1,84 @@
+package gothreatmatrix
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// Notification represents a notification of the ThreatMatrix platform, such as a new plugin release or an invite
+// to an organization.
+type Notification struct {
+	ID    uint64 `json:"id"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	// BaseColor is the color the GUI shows the notification with e.g "info".
+	BaseColor string    `json:"base_color"`
+	Read      bool      `json:"read"`
+	CreatedAt Timestamp `json:"created_at"`
+}
+
+// NotificationListParams represents the filters you can use when listing notifications.
+type NotificationListParams struct {
+	// Unread only keeps the notifications you didn't mark as read yet.
+	Unread bool
+}
+
+// NotificationService handles communication with notification related methods of the ThreatMatrix API.
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/notification
+type NotificationService struct {
+	client *ThreatMatrixClient
+}
+
+// checkNotificationID is used to check if a notification ID is valid (id should be greater than zero).
+func checkNotificationID(id uint64) error {
+	if id > 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: Notification ID cannot be 0", ErrValidation)
+}
+
+// List fetches your notifications, the newest first, going through every page of them. params can be nil to fetch
+// every one of them.
+//
+//	Endpoint: GET /api/notification
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/notification/operation/notification_list
+func (notificationService *NotificationService) List(ctx context.Context, params *NotificationListParams, opts ...RequestOption) ([]Notification, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := notificationService.client.startSpan(ctx, "NotificationService.List")
+	defer span.End()
+	query := url.Values{}
+	if params != nil && params.Unread {
+		query.Set("read", "false")
+	}
+	pager := NewPager(ctx, 1, func(ctx context.Context, number int) (*Page[Notification], error) {
+		query.Set("page", strconv.Itoa(number))
+		successResp, err := notificationService.client.sendJSON(ctx, "GET", constants.BASE_NOTIFICATION_URL+"?"+query.Encode(), nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		return decodePage[Notification](bytes.NewReader(successResp.Data))
+	})
+	return pager.AllPages()
+}
+
+// MarkRead marks a notification as read through its notification ID, so it's left out of the unread ones.
+//
+//	Endpoint: POST /api/notification/{id}/mark-as-read
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/notification/operation/notification_mark_as_read_create
+func (notificationService *NotificationService) MarkRead(ctx context.Context, notificationId uint64, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := notificationService.client.startSpan(ctx, "NotificationService.MarkRead")
+	defer span.End()
+	if err := checkNotificationID(notificationId); err != nil {
+		return false, err
+	}
+	return notificationService.client.sendAction(ctx, "POST", fmt.Sprintf(constants.MARK_READ_NOTIFICATION_URL, notificationId), nil)
+}
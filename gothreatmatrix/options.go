@@ -0,0 +1,157 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// These are the defaults applied by NewClient when the matching Option is not given.
+const (
+	DefaultTimeout   = 10 * time.Second
+	DefaultUserAgent = "go-threatmatrix"
+)
+
+// clientConfig collects everything an Option can customize before the ThreatMatrixClient is built.
+type clientConfig struct {
+	options    *ThreatMatrixClientOptions
+	httpClient *http.Client
+	timeout    time.Duration
+	// overallTimeout is 0 to only bound the requests per attempt
+	overallTimeout time.Duration
+	// tlsConfig is used by the default http.Client, it's nil to keep Go's default TLS settings
+	tlsConfig *tls.Config
+	// proxy is used by the default http.Client, it's nil to honor the proxy environment variables
+	proxy        func(*http.Request) (*url.URL, error)
+	userAgent    string
+	loggerParams *LoggerParams
+	retry        retryPolicy
+	// tokenProvider is nil unless the token is resolved per request through WithTokenProvider
+	tokenProvider TokenProvider
+	// credentials is nil unless the client logs in by itself through WithCredentials
+	credentials *LoginParams
+	// retryBudget is nil unless the retries were bounded through WithRetryBudget
+	retryBudget *retryBudget
+	limiter     *rateLimiter
+	middlewares []Middleware
+	// tracerProvider is nil unless tracing was enabled through WithTracerProvider
+	tracerProvider trace.TracerProvider
+	// metricsRegisterer is nil unless metrics were enabled through WithMetricsRegisterer
+	metricsRegisterer prometheus.Registerer
+	// logger is nil unless request logging was enabled through WithLogger
+	logger       *slog.Logger
+	pollInterval time.Duration
+	// cassette is nil unless recording or replaying was enabled through WithCassette
+	cassette *cassette
+	// responseCache is nil unless caching was enabled through WithResponseCache
+	responseCache *responseCache
+	// circuitBreaker is nil unless enabled through WithCircuitBreaker
+	circuitBreaker *circuitBreaker
+	// failover is nil unless several endpoints were given through WithEndpoints
+	failover *failover
+	// debugOutput is nil unless dumping was enabled through WithDebug
+	debugOutput io.Writer
+	// debugBodyLimit is nil to dump DefaultDebugBodyLimit bytes of each body
+	debugBodyLimit *int
+	// compression is nil unless it was enabled through WithCompression
+	compression *compression
+	refang      bool
+	// keyedRetries is set through WithIdempotentSubmissions
+	keyedRetries bool
+}
+
+// Option configures a ThreatMatrixClient made through NewClient.
+type Option func(*clientConfig)
+
+// WithURL sets the URL of your ThreatMatrix instance. It replaces the endpoints given through an earlier WithEndpoints.
+func WithURL(url string) Option {
+	return func(config *clientConfig) {
+		config.options.Url = url
+		if config.failover != nil {
+			config.failover.endpoints = nil
+		}
+	}
+}
+
+// WithToken sets the API token used to authenticate every request.
+func WithToken(token string) Option {
+	return func(config *clientConfig) {
+		config.options.Token = token
+	}
+}
+
+// TokenProvider returns the API token to authenticate a request with, such as the current one in a secrets manager.
+type TokenProvider func(ctx context.Context) (string, error)
+
+// WithTokenProvider resolves the API token of every request through provider rather than using the one given through
+// WithToken, so a token rotated in a secrets manager such as Vault is picked up without recreating the client.
+// provider is called for every request so it should cache the token it fetches. A request fails with the error
+// provider returns, without being sent. Logging in through UserService.Login or WithCredentials takes precedence.
+func WithTokenProvider(provider TokenProvider) Option {
+	return func(config *clientConfig) {
+		config.tokenProvider = provider
+	}
+}
+
+// WithHTTPClient lets you bring your own http.Client.
+// When it is given, WithTimeout is ignored as the timeout of your http.Client is used instead.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(config *clientConfig) {
+		config.httpClient = httpClient
+	}
+}
+
+// WithTimeout sets how long each attempt at a request of the default http.Client may take, WithRequestTimeout overrides it
+// for a single call. See WithOverallDeadline to bound a request along with its retries.
+func WithTimeout(timeout time.Duration) Option {
+	return func(config *clientConfig) {
+		config.timeout = timeout
+		config.options.Timeout = uint64(timeout / time.Second)
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(config *clientConfig) {
+		config.userAgent = userAgent
+	}
+}
+
+// WithLoggerParams configures the ThreatMatrixLogger of the client.
+func WithLoggerParams(loggerParams *LoggerParams) Option {
+	return func(config *clientConfig) {
+		config.loggerParams = loggerParams
+	}
+}
+
+// NewClient lets you create a new ThreatMatrixClient through functional options.
+// Anything that is not configured falls back to a sensible default:
+// a DefaultTimeout http.Client, the DefaultUserAgent and an info level logger writing to stdout.
+//
+//	client := gothreatmatrix.NewClient(
+//		gothreatmatrix.WithURL("https://threatmatrix.example.com"),
+//		gothreatmatrix.WithToken("your-super-secret-token"),
+//	)
+func NewClient(opts ...Option) *ThreatMatrixClient {
+	config := &clientConfig{
+		options:      &ThreatMatrixClientOptions{},
+		timeout:      DefaultTimeout,
+		userAgent:    DefaultUserAgent,
+		pollInterval: DefaultPollInterval,
+		loggerParams: &LoggerParams{
+			Level: logrus.InfoLevel,
+		},
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return newClient(config)
+}
@@ -0,0 +1,255 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// Member represents a member of an organization.
+type Member struct {
+	Username string    `json:"username"`
+	FullName string    `json:"full_name"`
+	Joined   time.Time `json:"joined"`
+	IsOwner  bool      `json:"is_owner"`
+	IsAdmin  bool      `json:"is_admin"`
+}
+
+// OrganizationService handles communication with the organization related methods of the ThreatMatrix API.
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me
+type OrganizationService struct {
+	client *ThreatMatrixClient
+}
+
+// InvitationService handles the invitations to join an organization.
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me
+type InvitationService struct {
+	client *ThreatMatrixClient
+}
+
+// Get returns the details of your organization.
+//
+//	Endpoint: GET /api/me/organization
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me/operation/me_organization_list
+func (organizationService *OrganizationService) Get(ctx context.Context, opts ...RequestOption) (*Organization, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := organizationService.client.startSpan(ctx, "OrganizationService.Get")
+	defer span.End()
+	organization := Organization{}
+	if _, err := organizationService.client.sendJSON(ctx, "GET", constants.ORGANIZATION_URL, nil, &organization); err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// Create creates an organization owned by you.
+//
+//	Endpoint: POST /api/me/organization
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me/operation/me_organization_create
+func (organizationService *OrganizationService) Create(ctx context.Context, organizationParams *OrganizationParams, opts ...RequestOption) (*Organization, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := organizationService.client.startSpan(ctx, "OrganizationService.Create")
+	defer span.End()
+	if organizationParams == nil || strings.TrimSpace(organizationParams.Name) == "" {
+		return nil, fmt.Errorf("%w: organization name cannot be empty", ErrValidation)
+	}
+	organization := Organization{}
+	if _, err := organizationService.client.sendJSON(ctx, "POST", constants.ORGANIZATION_URL, organizationParams, &organization); err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// Delete deletes your organization, this is only accessible to the organization's owner.
+//
+//	Endpoint: DELETE /api/me/organization
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me/operation/me_organization_destroy
+func (organizationService *OrganizationService) Delete(ctx context.Context, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := organizationService.client.startSpan(ctx, "OrganizationService.Delete")
+	defer span.End()
+	return organizationService.client.sendAction(ctx, "DELETE", constants.ORGANIZATION_URL, nil)
+}
+
+// ListMembers returns the members of your organization.
+//
+//	Endpoint: GET /api/me/organization
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me/operation/me_organization_list
+func (organizationService *OrganizationService) ListMembers(ctx context.Context, opts ...RequestOption) ([]Member, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := organizationService.client.startSpan(ctx, "OrganizationService.ListMembers")
+	defer span.End()
+	organization := Organization{}
+	if _, err := organizationService.client.sendJSON(ctx, "GET", constants.ORGANIZATION_URL, nil, &organization); err != nil {
+		return nil, err
+	}
+	return organization.Members, nil
+}
+
+// RemoveMember removes someone from your organization, this is only accessible to the organization's owner.
+//
+//	Endpoint: POST /api/me/organization/remove_member
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me/operation/me_organization_remove_member_create
+func (organizationService *OrganizationService) RemoveMember(ctx context.Context, username string, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := organizationService.client.startSpan(ctx, "OrganizationService.RemoveMember")
+	defer span.End()
+	if err := checkUsername(username); err != nil {
+		return false, err
+	}
+	return organizationService.client.sendAction(ctx, "POST", constants.REMOVE_MEMBER_FROM_ORGANIZATION_URL, &MemberParams{Username: username})
+}
+
+// Leave makes you leave your organization.
+//
+//	Endpoint: POST /api/me/organization/leave
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me/operation/me_organization_leave_create
+func (organizationService *OrganizationService) Leave(ctx context.Context, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := organizationService.client.startSpan(ctx, "OrganizationService.Leave")
+	defer span.End()
+	return organizationService.client.sendAction(ctx, "POST", constants.LEAVE_ORGANIZATION_URL, nil)
+}
+
+// DisablePlugin disables an analyzer, connector, visualizer, ingestor, pivot or playbook for every member of your
+// organization, this is only accessible to the organization's owner and admins.
+//
+//	Endpoint: POST /api/{pluginType}/{NameOfPlugin}/organization
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/analyzer/operation/analyzer_organization_create
+func (organizationService *OrganizationService) DisablePlugin(ctx context.Context, pluginType PluginType, pluginName string, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := organizationService.client.startSpan(ctx, "OrganizationService.DisablePlugin", pluginAttribute(pluginName))
+	defer span.End()
+	route, err := pluginOrganizationRoute(pluginType, pluginName)
+	if err != nil {
+		return false, err
+	}
+	successResp, err := organizationService.client.sendJSON(ctx, "POST", route, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	return successResp.StatusCode == http.StatusCreated || successResp.StatusCode == http.StatusNoContent, nil
+}
+
+// EnablePlugin enables again a plugin disabled for your organization through DisablePlugin,
+// this is only accessible to the organization's owner and admins.
+//
+//	Endpoint: DELETE /api/{pluginType}/{NameOfPlugin}/organization
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/analyzer/operation/analyzer_organization_destroy
+func (organizationService *OrganizationService) EnablePlugin(ctx context.Context, pluginType PluginType, pluginName string, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := organizationService.client.startSpan(ctx, "OrganizationService.EnablePlugin", pluginAttribute(pluginName))
+	defer span.End()
+	route, err := pluginOrganizationRoute(pluginType, pluginName)
+	if err != nil {
+		return false, err
+	}
+	return organizationService.client.sendAction(ctx, "DELETE", route, nil)
+}
+
+// pluginOrganizationRoute returns the route enabling or disabling a plugin for your organization.
+func pluginOrganizationRoute(pluginType PluginType, pluginName string) (string, error) {
+	if !pluginType.Valid() {
+		return "", fmt.Errorf("%w: unknown plugin type %q", ErrValidation, pluginType)
+	}
+	if err := checkPluginName(pluginName); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(constants.PLUGIN_ORGANIZATION_URL, pluginType, url.PathEscape(pluginName)), nil
+}
+
+// Invite invites someone to your organization, this is only accessible to the organization's owner.
+//
+//	Endpoint: POST /api/me/organization/invite
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me/operation/me_organization_invite_create
+func (invitationService *InvitationService) Invite(ctx context.Context, username string, opts ...RequestOption) (*Invite, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := invitationService.client.startSpan(ctx, "InvitationService.Invite")
+	defer span.End()
+	if err := checkUsername(username); err != nil {
+		return nil, err
+	}
+	invite := Invite{}
+	if _, err := invitationService.client.sendJSON(ctx, "POST", constants.INVITE_TO_ORGANIZATION_URL, &MemberParams{Username: username}, &invite); err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// List returns the invitations you received.
+//
+//	Endpoint: GET /api/me/invitations
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me/operation/me_invitations_list
+func (invitationService *InvitationService) List(ctx context.Context, opts ...RequestOption) ([]Invitation, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := invitationService.client.startSpan(ctx, "InvitationService.List")
+	defer span.End()
+	invitations := []Invitation{}
+	if _, err := invitationService.client.sendJSON(ctx, "GET", constants.INVITATIONS_URL, nil, &invitations); err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// Accept accepts an invitation, making you join its organization.
+//
+//	Endpoint: POST /api/me/invitations/{id}/accept
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me/operation/me_invitations_accept_create
+func (invitationService *InvitationService) Accept(ctx context.Context, invitationId uint64, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := invitationService.client.startSpan(ctx, "InvitationService.Accept")
+	defer span.End()
+	if err := checkInvitationID(invitationId); err != nil {
+		return false, err
+	}
+	return invitationService.client.sendAction(ctx, "POST", fmt.Sprintf(constants.ACCEPT_INVITATION_URL, invitationId), nil)
+}
+
+// Decline declines an invitation.
+//
+//	Endpoint: POST /api/me/invitations/{id}/decline
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/me/operation/me_invitations_decline_create
+func (invitationService *InvitationService) Decline(ctx context.Context, invitationId uint64, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := invitationService.client.startSpan(ctx, "InvitationService.Decline")
+	defer span.End()
+	if err := checkInvitationID(invitationId); err != nil {
+		return false, err
+	}
+	return invitationService.client.sendAction(ctx, "POST", fmt.Sprintf(constants.DECLINE_INVITATION_URL, invitationId), nil)
+}
+
+// checkUsername is used to check if a username was given.
+func checkUsername(username string) error {
+	if strings.TrimSpace(username) == "" {
+		return fmt.Errorf("%w: username cannot be empty", ErrValidation)
+	}
+	return nil
+}
+
+// checkInvitationID is used to check if an invitation ID is valid (id should be greater than zero).
+func checkInvitationID(id uint64) error {
+	if id > 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: Invitation ID cannot be 0", ErrValidation)
+}
@@ -0,0 +1,296 @@
+package gothreatmatrix
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// ErrNoMorePages is returned by Pager.NextPage once every page was fetched.
+var ErrNoMorePages = errors.New("threatmatrix: no more pages")
+
+// Page is a page of results of one of ThreatMatrix list endpoints.
+// Number starts at 1, Count is the number of results across every page.
+type Page[T any] struct {
+	Number     int `json:"-"`
+	Count      int `json:"count"`
+	TotalPages int `json:"total_pages"`
+	Results    []T `json:"results"`
+}
+
+// PageFetcher fetches the page with the given number, starting at 1.
+type PageFetcher[T any] func(ctx context.Context, number int) (*Page[T], error)
+
+// Pager walks through the pages of a list endpoint, fetching them one at a time.
+// Endpoints that aren't paginated are served as a single page.
+//
+//	pager := client.JobService.Pager(ctx, &gothreatmatrix.JobListParams{PageSize: 100})
+//	err := pager.EachPage(func(page *gothreatmatrix.Page[gothreatmatrix.JobList]) error {
+//		// ...
+//		return nil
+//	})
+type Pager[T any] struct {
+	ctx   context.Context
+	fetch PageFetcher[T]
+	next  int
+	seen  int
+	done  bool
+}
+
+// NewPager returns a Pager over the pages given by fetch, starting from firstPage (or the first page).
+func NewPager[T any](ctx context.Context, firstPage int, fetch PageFetcher[T]) *Pager[T] {
+	if firstPage < 1 {
+		firstPage = 1
+	}
+	return &Pager[T]{ctx: ctx, fetch: fetch, next: firstPage}
+}
+
+// HasNextPage tells if NextPage has a page left to fetch.
+func (pager *Pager[T]) HasNextPage() bool {
+	return !pager.done
+}
+
+// NextPage fetches the next page, ErrNoMorePages is returned once every page was fetched.
+// A failed page is fetched again by the next call.
+func (pager *Pager[T]) NextPage() (*Page[T], error) {
+	if pager.done {
+		return nil, ErrNoMorePages
+	}
+	if err := pager.ctx.Err(); err != nil {
+		return nil, err
+	}
+	page, err := pager.fetch(pager.ctx, pager.next)
+	if err != nil {
+		return nil, err
+	}
+	page.Number = pager.next
+	pager.seen += len(page.Results)
+	// without a number of pages, the count of results tells when the last one was reached
+	lastPage := page.Number >= page.TotalPages
+	if page.TotalPages == 0 {
+		lastPage = pager.seen >= page.Count
+	}
+	if len(page.Results) == 0 || lastPage {
+		pager.done = true
+	}
+	pager.next++
+	return page, nil
+}
+
+// EachPage calls fn with every page left, stopping at the first error fn or the fetching returns.
+func (pager *Pager[T]) EachPage(fn func(page *Page[T]) error) error {
+	for pager.HasNextPage() {
+		page, err := pager.NextPage()
+		if err != nil {
+			return err
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AllPages fetches every page left and returns their results, stopping at the first error.
+func (pager *Pager[T]) AllPages() ([]T, error) {
+	results := []T{}
+	err := pager.EachPage(func(page *Page[T]) error {
+		results = append(results, page.Results...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Pager returns a Pager over the pages of jobs matching params, starting from params.Page (or the first page).
+func (jobService *JobService) Pager(ctx context.Context, params *JobListParams, opts ...RequestOption) *Pager[JobList] {
+	ctx = withRequestOptions(ctx, opts)
+	pageParams := JobListParams{}
+	if params != nil {
+		pageParams = *params
+	}
+	return NewPager(ctx, pageParams.Page, func(ctx context.Context, number int) (*Page[JobList], error) {
+		pageParams.Page = number
+		jobList, err := jobService.List(ctx, &pageParams)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[JobList]{Count: jobList.Count, TotalPages: jobList.TotalPages, Results: jobList.Results}, nil
+	})
+}
+
+// ListAll fetches every page of jobs matching params, starting from params.Page (or the first page), and returns their
+// jobs in order. Once the first page tells how many pages there are, the next ones are fetched DefaultBulkConcurrency
+// at a time, or as many as WithPageConcurrency allows. Without a number of pages they're fetched one after the other.
+// The first page that fails stops the fetching of the others.
+//
+//	jobs, err := client.JobService.ListAll(ctx, &gothreatmatrix.JobListParams{PageSize: 100}, gothreatmatrix.WithPageConcurrency(8))
+func (jobService *JobService) ListAll(ctx context.Context, params *JobListParams, opts ...RequestOption) ([]JobList, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.ListAll")
+	defer span.End()
+	concurrency := DefaultBulkConcurrency
+	if options := requestOptionsFrom(ctx); options != nil && options.pageConcurrency > 0 {
+		concurrency = options.pageConcurrency
+	}
+	pageParams := JobListParams{}
+	if params != nil {
+		pageParams = *params
+	}
+	if pageParams.Page < 1 {
+		pageParams.Page = 1
+	}
+	firstPage, err := jobService.List(ctx, &pageParams)
+	if err != nil {
+		return nil, err
+	}
+	if firstPage.TotalPages == 0 {
+		// without a number of pages, the count of results tells when the last one was reached
+		jobs := append([]JobList{}, firstPage.Results...)
+		for page := firstPage; len(page.Results) > 0 && len(jobs) < firstPage.Count; {
+			pageParams.Page++
+			if page, err = jobService.List(ctx, &pageParams); err != nil {
+				return nil, err
+			}
+			jobs = append(jobs, page.Results...)
+		}
+		return jobs, nil
+	}
+
+	pagesLeft := firstPage.TotalPages - pageParams.Page
+	if pagesLeft < 0 {
+		pagesLeft = 0
+	}
+	pages := make([][]JobList, pagesLeft)
+	errs := make([]error, pagesLeft)
+	pagesCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	forEachConcurrently(pagesLeft, concurrency, func(index int) {
+		if pagesCtx.Err() != nil {
+			errs[index] = pagesCtx.Err()
+			return
+		}
+		params := pageParams
+		params.Page = pageParams.Page + 1 + index
+		page, err := jobService.List(pagesCtx, &params)
+		if err != nil {
+			errs[index] = err
+			cancel()
+			return
+		}
+		pages[index] = page.Results
+	})
+	jobs := append([]JobList{}, firstPage.Results...)
+	for index, page := range pages {
+		if errs[index] != nil {
+			return nil, firstError(errs)
+		}
+		jobs = append(jobs, page...)
+	}
+	return jobs, nil
+}
+
+// firstError returns the first error that isn't a cancellation caused by another error, or the first error when all of them are.
+func firstError(errs []error) error {
+	var first error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if first == nil {
+			first = err
+		}
+		if !errors.Is(err, context.Canceled) {
+			return err
+		}
+	}
+	return first
+}
+
+// Pager returns a Pager over the tags, pageSize of 0 keeps the page size of ThreatMatrix.
+func (tagService *TagService) Pager(ctx context.Context, pageSize int, opts ...RequestOption) *Pager[Tag] {
+	ctx = withRequestOptions(ctx, opts)
+	return NewPager(ctx, 1, func(ctx context.Context, number int) (*Page[Tag], error) {
+		return fetchPage[Tag](ctx, tagService.client, "TagService.Pager", constants.BASE_TAG_URL, number, pageSize)
+	})
+}
+
+// Pager returns a Pager over the analyzer configurations, pageSize of 0 keeps the page size of ThreatMatrix.
+func (analyzerService *AnalyzerService) Pager(ctx context.Context, pageSize int, opts ...RequestOption) *Pager[AnalyzerConfig] {
+	ctx = withRequestOptions(ctx, opts)
+	return NewPager(ctx, 1, func(ctx context.Context, number int) (*Page[AnalyzerConfig], error) {
+		return fetchPage[AnalyzerConfig](ctx, analyzerService.client, "AnalyzerService.Pager", constants.BASE_ANALYZER_URL, number, pageSize)
+	})
+}
+
+// Pager returns a Pager over the connector configurations, pageSize of 0 keeps the page size of ThreatMatrix.
+func (connectorService *ConnectorService) Pager(ctx context.Context, pageSize int, opts ...RequestOption) *Pager[ConnectorConfig] {
+	ctx = withRequestOptions(ctx, opts)
+	return NewPager(ctx, 1, func(ctx context.Context, number int) (*Page[ConnectorConfig], error) {
+		return fetchPage[ConnectorConfig](ctx, connectorService.client, "ConnectorService.Pager", constants.BASE_CONNECTOR_URL, number, pageSize)
+	})
+}
+
+// fetchPage fetches a page of the list endpoint at route.
+func fetchPage[T any](ctx context.Context, client *ThreatMatrixClient, spanName string, route string, number int, pageSize int) (*Page[T], error) {
+	ctx, span := client.startSpan(ctx, spanName)
+	defer span.End()
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(number))
+	if pageSize > 0 {
+		query.Set("page_size", strconv.Itoa(pageSize))
+	}
+	request, err := client.buildRequest(ctx, "GET", "application/json", nil, client.options.Url+route+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	var page *Page[T]
+	err = client.decodeRequest(ctx, request, func(body io.Reader) error {
+		page, err = decodePage[T](body)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// decodePage decodes a page of results as it's read, whether it comes as a plain list or as a paginated one.
+// A plain list is the only page there is.
+func decodePage[T any](body io.Reader) (*Page[T], error) {
+	page := Page[T]{Results: []T{}}
+	buffered := bufio.NewReader(body)
+	var first []byte
+	for {
+		var err error
+		if first, err = buffered.Peek(1); err != nil {
+			return nil, err
+		}
+		if !strings.ContainsRune(" \t\r\n", rune(first[0])) {
+			break
+		}
+		_, _ = buffered.ReadByte()
+	}
+	decoder := json.NewDecoder(buffered)
+	if first[0] == '[' {
+		if err := decoder.Decode(&page.Results); err != nil {
+			return nil, err
+		}
+		page.Count = len(page.Results)
+		page.TotalPages = 1
+		return &page, nil
+	}
+	if err := decoder.Decode(&page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
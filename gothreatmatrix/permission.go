@@ -0,0 +1,28 @@
+package gothreatmatrix
+
+// Permissions represents what the user who fetched a job is allowed to do with it.
+type Permissions struct {
+	// CanKill allows stopping the job while it's running, see JobService.Kill.
+	CanKill bool `json:"kill"`
+	// CanDelete allows removing the job, see JobService.Delete.
+	CanDelete bool `json:"delete"`
+	// CanRetry allows killing and retrying the analyzers and the connectors of the job one by one,
+	// see JobService.RetryAnalyzer.
+	CanRetry bool `json:"plugin_actions"`
+}
+
+// CanKill checks if the user who fetched the job is allowed to stop it, false when ThreatMatrix didn't tell.
+func (job *Job) CanKill() bool {
+	return job.Permissions != nil && job.Permissions.CanKill
+}
+
+// CanDelete checks if the user who fetched the job is allowed to remove it, false when ThreatMatrix didn't tell.
+func (job *Job) CanDelete() bool {
+	return job.Permissions != nil && job.Permissions.CanDelete
+}
+
+// CanRetry checks if the user who fetched the job is allowed to kill and retry its analyzers and connectors,
+// false when ThreatMatrix didn't tell.
+func (job *Job) CanRetry() bool {
+	return job.Permissions != nil && job.Permissions.CanRetry
+}
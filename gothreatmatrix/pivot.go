@@ -0,0 +1,176 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// DefaultPivotDepth is how many levels of child jobs PivotService.Tree follows when it's given a depth below 1.
+const DefaultPivotDepth = 5
+
+// PivotConfig represents how a pivot is configured in ThreatMatrix: once the related analyzers or connectors
+// of a job are done, the pivot creates a child job analyzing what they found through one of its playbooks.
+//
+// ThreatMatrix docs: https://threatmatrix.readthedocs.io/en/latest/Usage.html#pivots
+type PivotConfig struct {
+	BaseConfigurationType
+	RelatedAnalyzerConfigs  []string `json:"related_analyzer_configs"`
+	RelatedConnectorConfigs []string `json:"related_connector_configs"`
+	PlaybooksChoice         []string `json:"playbooks_choice"`
+	// Extra holds the fields ThreatMatrix sent that the SDK doesn't model yet, by name.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the pivot configuration, keeping the fields the SDK doesn't model in Extra.
+func (pivotConfig *PivotConfig) UnmarshalJSON(data []byte) error {
+	type plainPivotConfig PivotConfig
+	extra, err := decodeWithExtra(data, (*plainPivotConfig)(pivotConfig))
+	pivotConfig.Extra = extra
+	return err
+}
+
+// PivotNode is a job along with the child jobs its pivots created, as returned by PivotService.Tree.
+type PivotNode struct {
+	Job      *Job
+	Children []*PivotNode
+}
+
+// PivotJobIDs returns the IDs of the child jobs the pivots of the job created.
+func (job *Job) PivotJobIDs() []int {
+	jobIds := []int{}
+	for _, report := range job.PivotReports {
+		switch jobId := report.Report["job_id"].(type) {
+		case float64:
+			jobIds = append(jobIds, int(jobId))
+		case []interface{}:
+			for _, id := range jobId {
+				if id, ok := id.(float64); ok {
+					jobIds = append(jobIds, int(id))
+				}
+			}
+		}
+	}
+	return jobIds
+}
+
+// PivotService handles communication with pivot related methods of the ThreatMatrix API.
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/pivot
+type PivotService struct {
+	client *ThreatMatrixClient
+}
+
+// List fetches every pivot configured in your ThreatMatrix instance.
+//
+//	Endpoint: GET /api/pivot
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/pivot/operation/pivot_list
+func (pivotService *PivotService) List(ctx context.Context, opts ...RequestOption) ([]PivotConfig, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := pivotService.client.startSpan(ctx, "PivotService.List")
+	defer span.End()
+	requestUrl := pivotService.client.options.Url + constants.BASE_PIVOT_URL
+	contentType := "application/json"
+	method := "GET"
+	request, err := pivotService.client.buildRequest(ctx, method, contentType, nil, requestUrl)
+	if err != nil {
+		return nil, err
+	}
+	successResp, err := pivotService.client.newRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return decodeConfigList[PivotConfig](successResp.Data)
+}
+
+// Get fetches the configuration of a specific pivot through its name.
+//
+//	Endpoint: GET /api/pivot/{NameOfPivot}
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/pivot/operation/pivot_retrieve
+func (pivotService *PivotService) Get(ctx context.Context, pivotName string, opts ...RequestOption) (*PivotConfig, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := pivotService.client.startSpan(ctx, "PivotService.Get", pluginAttribute(pivotName))
+	defer span.End()
+	route := pivotService.client.options.Url + constants.SPECIFIC_PIVOT_URL
+	requestUrl := fmt.Sprintf(route, url.PathEscape(pivotName))
+	contentType := "application/json"
+	method := "GET"
+	request, err := pivotService.client.buildRequest(ctx, method, contentType, nil, requestUrl)
+	if err != nil {
+		return nil, err
+	}
+	successResp, err := pivotService.client.newRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	pivotConfig := PivotConfig{}
+	if unmarshalError := json.Unmarshal(successResp.Data, &pivotConfig); unmarshalError != nil {
+		return nil, unmarshalError
+	}
+	return &pivotConfig, nil
+}
+
+// Children fetches the jobs the pivots of a job created, in the order of its pivot reports.
+//
+//	Endpoint: GET /api/jobs/{jobID}
+func (pivotService *PivotService) Children(ctx context.Context, jobId uint64, opts ...RequestOption) ([]Job, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := pivotService.client.startSpan(ctx, "PivotService.Children", jobIDAttribute(jobId))
+	defer span.End()
+	job, err := pivotService.client.JobService.Get(ctx, jobId)
+	if err != nil {
+		return nil, err
+	}
+	children := []Job{}
+	for _, childId := range job.PivotJobIDs() {
+		child, err := pivotService.client.JobService.Get(ctx, uint64(childId))
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, *child)
+	}
+	return children, nil
+}
+
+// Tree fetches a job along with every job its pivots created, following the pivots of the child jobs as well
+// down to maxDepth levels, a maxDepth below 1 being DefaultPivotDepth. A job reached twice is only fetched once.
+//
+//	Endpoint: GET /api/jobs/{jobID}
+func (pivotService *PivotService) Tree(ctx context.Context, jobId uint64, maxDepth int, opts ...RequestOption) (*PivotNode, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := pivotService.client.startSpan(ctx, "PivotService.Tree", jobIDAttribute(jobId))
+	defer span.End()
+	if maxDepth < 1 {
+		maxDepth = DefaultPivotDepth
+	}
+	return pivotService.tree(ctx, jobId, maxDepth, map[uint64]bool{})
+}
+
+// tree fetches the job and its children down to depth levels, skipping the jobs already visited.
+func (pivotService *PivotService) tree(ctx context.Context, jobId uint64, depth int, visited map[uint64]bool) (*PivotNode, error) {
+	visited[jobId] = true
+	job, err := pivotService.client.JobService.Get(ctx, jobId)
+	if err != nil {
+		return nil, err
+	}
+	node := &PivotNode{Job: job, Children: []*PivotNode{}}
+	if depth == 0 {
+		return node, nil
+	}
+	for _, childId := range job.PivotJobIDs() {
+		if visited[uint64(childId)] {
+			continue
+		}
+		child, err := pivotService.tree(ctx, uint64(childId), depth-1, visited)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, child)
+	}
+	return node, nil
+}
@@ -0,0 +1,344 @@
+package gothreatmatrix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// PlaybookObservableAnalysisRequest represents an observable to analyze through a playbook.
+//
+// Classification is guessed through Classify when it is left empty.
+type PlaybookObservableAnalysisRequest struct {
+	Playbook             string
+	Value                string
+	Classification       string
+	TLP                  TLP
+	Tags                 []string
+	RuntimeConfiguration map[string]interface{}
+}
+
+// PlaybookFileAnalysisRequest represents a file to analyze through a playbook.
+//
+// File is streamed to ThreatMatrix as it is read, so big samples are never held in memory.
+type PlaybookFileAnalysisRequest struct {
+	Playbook             string
+	File                 io.Reader
+	FileName             string
+	TLP                  TLP
+	Tags                 []string
+	RuntimeConfiguration map[string]interface{}
+}
+
+// PlaybookConfig represents a playbook configured in ThreatMatrix.
+//
+// ThreatMatrix docs: https://threatmatrix.readthedocs.io/en/latest/Usage.html#playbooks
+type PlaybookConfig struct {
+	Name                 string                 `json:"name"`
+	Description          string                 `json:"description"`
+	Type                 []string               `json:"type"`
+	Analyzers            []string               `json:"analyzers"`
+	Connectors           []string               `json:"connectors"`
+	RuntimeConfiguration map[string]interface{} `json:"runtime_configuration"`
+	Disabled             bool                   `json:"disabled"`
+	Tlp                  TLP                    `json:"tlp"`
+	Owner                string                 `json:"owner"`
+	IsEditable           bool                   `json:"is_editable"`
+	// Extra holds the fields ThreatMatrix sent that the SDK doesn't model yet, by name.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the playbook configuration, keeping the fields the SDK doesn't model in Extra.
+func (playbookConfig *PlaybookConfig) UnmarshalJSON(data []byte) error {
+	type plainPlaybookConfig PlaybookConfig
+	extra, err := decodeWithExtra(data, (*plainPlaybookConfig)(playbookConfig))
+	playbookConfig.Extra = extra
+	return err
+}
+
+// PlaybookParams represents the fields needed for creating and updating playbooks.
+// Type lists the observable classifications and "file" the playbook supports.
+type PlaybookParams struct {
+	Name                 string                 `json:"name"`
+	Description          string                 `json:"description"`
+	Type                 []string               `json:"type"`
+	Analyzers            []string               `json:"analyzers"`
+	Connectors           []string               `json:"connectors"`
+	RuntimeConfiguration map[string]interface{} `json:"runtime_configuration,omitempty"`
+	Disabled             bool                   `json:"disabled"`
+}
+
+// playbookAnalysisParams represents the fields needed to make an analysis through a playbook.
+type playbookAnalysisParams struct {
+	PlaybookRequested    string                 `json:"playbook_requested"`
+	Tlp                  TLP                    `json:"tlp"`
+	RuntimeConfiguration map[string]interface{} `json:"runtime_configuration"`
+	TagsLabels           []string               `json:"tags_labels"`
+	Observables          [][]string             `json:"observables,omitempty"`
+}
+
+// PlaybookService handles communication with playbook related methods of the ThreatMatrix API.
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/playbook
+type PlaybookService struct {
+	client *ThreatMatrixClient
+}
+
+// List fetches every playbook configured in your ThreatMatrix instance.
+//
+//	Endpoint: GET /api/playbook
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/playbook/operation/playbook_list
+func (playbookService *PlaybookService) List(ctx context.Context, opts ...RequestOption) ([]PlaybookConfig, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := playbookService.client.startSpan(ctx, "PlaybookService.List")
+	defer span.End()
+	requestUrl := playbookService.client.options.Url + constants.BASE_PLAYBOOK_URL
+	contentType := "application/json"
+	method := "GET"
+	request, err := playbookService.client.buildRequest(ctx, method, contentType, nil, requestUrl)
+	if err != nil {
+		return nil, err
+	}
+	successResp, err := playbookService.client.newRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return decodeConfigList[PlaybookConfig](successResp.Data)
+}
+
+// Get fetches a specific playbook through its name.
+//
+//	Endpoint: GET /api/playbook/{NameOfPlaybook}
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/playbook/operation/playbook_retrieve
+func (playbookService *PlaybookService) Get(ctx context.Context, playbookName string, opts ...RequestOption) (*PlaybookConfig, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := playbookService.client.startSpan(ctx, "PlaybookService.Get", pluginAttribute(playbookName))
+	defer span.End()
+	if err := checkPlaybookName(playbookName); err != nil {
+		return nil, err
+	}
+	requestUrl := fmt.Sprintf(playbookService.client.options.Url+constants.SPECIFIC_PLAYBOOK_URL, url.PathEscape(playbookName))
+	return playbookService.sendPlaybook(ctx, "GET", requestUrl, nil)
+}
+
+// Create adds a new playbook to your ThreatMatrix instance.
+//
+//	Endpoint: POST /api/playbook
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/playbook/operation/playbook_create
+func (playbookService *PlaybookService) Create(ctx context.Context, playbookParams *PlaybookParams, opts ...RequestOption) (*PlaybookConfig, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := playbookService.client.startSpan(ctx, "PlaybookService.Create")
+	defer span.End()
+	if err := checkPlaybookParams(playbookParams); err != nil {
+		return nil, err
+	}
+	requestUrl := playbookService.client.options.Url + constants.BASE_PLAYBOOK_URL
+	return playbookService.sendPlaybook(ctx, "POST", requestUrl, playbookParams)
+}
+
+// Update edits a playbook through its name.
+//
+//	Endpoint: PATCH /api/playbook/{NameOfPlaybook}
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/playbook/operation/playbook_partial_update
+func (playbookService *PlaybookService) Update(ctx context.Context, playbookName string, playbookParams *PlaybookParams, opts ...RequestOption) (*PlaybookConfig, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := playbookService.client.startSpan(ctx, "PlaybookService.Update", pluginAttribute(playbookName))
+	defer span.End()
+	if err := checkPlaybookName(playbookName); err != nil {
+		return nil, err
+	}
+	if err := checkPlaybookParams(playbookParams); err != nil {
+		return nil, err
+	}
+	requestUrl := fmt.Sprintf(playbookService.client.options.Url+constants.SPECIFIC_PLAYBOOK_URL, url.PathEscape(playbookName))
+	return playbookService.sendPlaybook(ctx, "PATCH", requestUrl, playbookParams)
+}
+
+// Delete removes the given playbook from your ThreatMatrix instance.
+//
+//	Endpoint: DELETE /api/playbook/{NameOfPlaybook}
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/playbook/operation/playbook_destroy
+func (playbookService *PlaybookService) Delete(ctx context.Context, playbookName string, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := playbookService.client.startSpan(ctx, "PlaybookService.Delete", pluginAttribute(playbookName))
+	defer span.End()
+	if err := checkPlaybookName(playbookName); err != nil {
+		return false, err
+	}
+	requestUrl := fmt.Sprintf(playbookService.client.options.Url+constants.SPECIFIC_PLAYBOOK_URL, url.PathEscape(playbookName))
+	contentType := "application/json"
+	method := "DELETE"
+	request, err := playbookService.client.buildRequest(ctx, method, contentType, nil, requestUrl)
+	if err != nil {
+		return false, err
+	}
+	successResp, err := playbookService.client.newRequest(ctx, request)
+	if err != nil {
+		return false, err
+	}
+	if successResp.StatusCode == http.StatusNoContent {
+		return true, nil
+	}
+	return false, nil
+}
+
+// sendPlaybook sends the playbook params, if any, and decodes the playbook ThreatMatrix answers with.
+func (playbookService *PlaybookService) sendPlaybook(ctx context.Context, method string, requestUrl string, playbookParams *PlaybookParams) (*PlaybookConfig, error) {
+	var body io.Reader
+	if playbookParams != nil {
+		playbookJson, err := json.Marshal(playbookParams)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewBuffer(playbookJson)
+	}
+	contentType := "application/json"
+	request, err := playbookService.client.buildRequest(ctx, method, contentType, body, requestUrl)
+	if err != nil {
+		return nil, err
+	}
+	successResp, err := playbookService.client.newRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	playbookConfig := PlaybookConfig{}
+	if unmarshalError := json.Unmarshal(successResp.Data, &playbookConfig); unmarshalError != nil {
+		return nil, unmarshalError
+	}
+	return &playbookConfig, nil
+}
+
+// AnalyzeObservable runs a playbook on an observable, the returned AnalysisResponse holds the ID of the created job.
+//
+//	Endpoint: POST /api/playbook/analyze_multiple_observables
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/playbook/operation/playbook_analyze_multiple_observables_create
+func (playbookService *PlaybookService) AnalyzeObservable(ctx context.Context, analysisRequest PlaybookObservableAnalysisRequest, opts ...RequestOption) (*AnalysisResponse, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := playbookService.client.startSpan(ctx, "PlaybookService.AnalyzeObservable", pluginAttribute(analysisRequest.Playbook))
+	defer span.End()
+	if err := checkPlaybookName(analysisRequest.Playbook); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(analysisRequest.Value) == "" {
+		return nil, fmt.Errorf("%w: observable value cannot be empty", ErrValidation)
+	}
+	params := newPlaybookAnalysisParams(analysisRequest.Playbook, analysisRequest.TLP, analysisRequest.Tags, analysisRequest.RuntimeConfiguration)
+	value := playbookService.client.observableValue(analysisRequest.Value)
+	params.Observables = [][]string{{classificationOf(analysisRequest.Classification, value), value}}
+
+	requestUrl := playbookService.client.options.Url + constants.PLAYBOOK_ANALYZE_MULTIPLE_OBSERVABLES_URL
+	contentType := "application/json"
+	method := "POST"
+	jsonData, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	request, err := playbookService.client.buildRequest(ctx, method, contentType, bytes.NewBuffer(jsonData), requestUrl)
+	if err != nil {
+		return nil, err
+	}
+	successResp, err := playbookService.client.newRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	multipleAnalysisResponse := MultipleAnalysisResponse{}
+	if unmarshalError := json.Unmarshal(successResp.Data, &multipleAnalysisResponse); unmarshalError != nil {
+		return nil, unmarshalError
+	}
+	return singleAnalysis(&multipleAnalysisResponse)
+}
+
+// AnalyzeFile runs a playbook on a file, the returned AnalysisResponse holds the ID of the created job.
+// The multipart form is streamed, so the request cannot be retried.
+//
+//	Endpoint: POST /api/playbook/analyze_multiple_files
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/playbook/operation/playbook_analyze_multiple_files_create
+func (playbookService *PlaybookService) AnalyzeFile(ctx context.Context, analysisRequest PlaybookFileAnalysisRequest, opts ...RequestOption) (*AnalysisResponse, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := playbookService.client.startSpan(ctx, "PlaybookService.AnalyzeFile", pluginAttribute(analysisRequest.Playbook))
+	defer span.End()
+	if err := checkPlaybookName(analysisRequest.Playbook); err != nil {
+		return nil, err
+	}
+	if err := checkFileAnalysisRequest(FileAnalysisRequest{File: analysisRequest.File, FileName: analysisRequest.FileName}); err != nil {
+		return nil, err
+	}
+	params := newPlaybookAnalysisParams(analysisRequest.Playbook, analysisRequest.TLP, analysisRequest.Tags, analysisRequest.RuntimeConfiguration)
+	files := []analysisFile{{name: analysisRequest.FileName, reader: analysisRequest.File}}
+
+	multipleAnalysisResponse := MultipleAnalysisResponse{}
+	if err := playbookService.client.postAnalysisForm(ctx, constants.PLAYBOOK_ANALYZE_MULTIPLE_FILES_URL, params, "files", files, &multipleAnalysisResponse); err != nil {
+		return nil, err
+	}
+	return singleAnalysis(&multipleAnalysisResponse)
+}
+
+// writeFormFields writes the playbook analysis settings as multipart form fields.
+func (params playbookAnalysisParams) writeFormFields(writer *multipart.Writer) error {
+	if err := writer.WriteField("playbook_requested", params.PlaybookRequested); err != nil {
+		return err
+	}
+	if err := writeTlpAndRuntimeConfiguration(writer, params.Tlp, params.RuntimeConfiguration); err != nil {
+		return err
+	}
+	return writeFormValues(writer, "tags_labels", params.TagsLabels)
+}
+
+// checkPlaybookName is used to check if a playbook was given.
+func checkPlaybookName(playbook string) error {
+	if strings.TrimSpace(playbook) == "" {
+		return fmt.Errorf("%w: playbook name cannot be empty", ErrValidation)
+	}
+	return nil
+}
+
+// checkPlaybookParams is used to check that a playbook has a name and something to run.
+func checkPlaybookParams(playbookParams *PlaybookParams) error {
+	if playbookParams == nil {
+		return fmt.Errorf("%w: playbook params cannot be nil", ErrValidation)
+	}
+	if err := checkPlaybookName(playbookParams.Name); err != nil {
+		return err
+	}
+	if len(playbookParams.Type) == 0 {
+		return fmt.Errorf("%w: playbook %s must support at least one type", ErrValidation, playbookParams.Name)
+	}
+	if len(playbookParams.Analyzers) == 0 && len(playbookParams.Connectors) == 0 {
+		return fmt.Errorf("%w: playbook %s must run at least one analyzer or connector", ErrValidation, playbookParams.Name)
+	}
+	return nil
+}
+
+// newPlaybookAnalysisParams fills the playbook analysis fields, replacing nil values with empty ones as the API rejects nulls.
+func newPlaybookAnalysisParams(playbook string, tlp TLP, tags []string, runtimeConfiguration map[string]interface{}) playbookAnalysisParams {
+	basicParams := newBasicAnalysisParams(tlp, nil, nil, tags, runtimeConfiguration)
+	return playbookAnalysisParams{
+		PlaybookRequested:    playbook,
+		Tlp:                  basicParams.Tlp,
+		RuntimeConfiguration: basicParams.RuntimeConfiguration,
+		TagsLabels:           basicParams.TagsLabels,
+	}
+}
+
+// singleAnalysis returns the only analysis of a multiple analysis response.
+func singleAnalysis(multipleAnalysisResponse *MultipleAnalysisResponse) (*AnalysisResponse, error) {
+	if len(multipleAnalysisResponse.Results) == 0 {
+		return nil, fmt.Errorf("threatmatrix: the response is missing the analysis")
+	}
+	return &multipleAnalysisResponse.Results[0], nil
+}
@@ -0,0 +1,248 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// PluginType represents the kinds of plugin ThreatMatrix runs.
+type PluginType string
+
+// Values of the PluginType enum.
+const (
+	PluginTypeAnalyzer   PluginType = "analyzer"
+	PluginTypeConnector  PluginType = "connector"
+	PluginTypeVisualizer PluginType = "visualizer"
+	PluginTypeIngestor   PluginType = "ingestor"
+	PluginTypePivot      PluginType = "pivot"
+	PluginTypePlaybook   PluginType = "playbook"
+)
+
+// Valid checks if the PluginType is one of the values known by ThreatMatrix.
+func (pluginType PluginType) Valid() bool {
+	switch pluginType {
+	case PluginTypeAnalyzer, PluginTypeConnector, PluginTypeVisualizer, PluginTypeIngestor, PluginTypePivot, PluginTypePlaybook:
+		return true
+	}
+	return false
+}
+
+// redactedValue replaces the values of secrets when a PluginConfig is logged.
+const redactedValue = "**********"
+
+// PluginConfig represents the value of a parameter or a secret of a plugin, set for you or for your whole organization.
+// It overrides the default value of the parameter in every analysis.
+//
+// ThreatMatrix docs: https://threatmatrix.readthedocs.io/en/latest/Advanced-Configuration.html#organization-and-user-configuration
+type PluginConfig struct {
+	ID        uint64      `json:"id"`
+	Attribute string      `json:"attribute"`
+	Value     interface{} `json:"value"`
+	// Type is the type of the value, such as "str" or "int".
+	Type            string `json:"type"`
+	IsSecret        bool   `json:"is_secret"`
+	ForOrganization bool   `json:"for_organization"`
+	Owner           string `json:"owner"`
+	Organization    string `json:"organization"`
+	// Parameter is the ID of the parameter the value is set for.
+	Parameter uint64 `json:"parameter"`
+	// Only the field of the plugin the value is set for is filled, see PluginConfig.Plugin.
+	Analyzer   string `json:"analyzer_config,omitempty"`
+	Connector  string `json:"connector_config,omitempty"`
+	Visualizer string `json:"visualizer_config,omitempty"`
+	Ingestor   string `json:"ingestor_config,omitempty"`
+	Pivot      string `json:"pivot_config,omitempty"`
+}
+
+// Plugin returns the type and the name of the plugin the value is set for.
+func (pluginConfig PluginConfig) Plugin() (PluginType, string) {
+	switch {
+	case pluginConfig.Analyzer != "":
+		return PluginTypeAnalyzer, pluginConfig.Analyzer
+	case pluginConfig.Connector != "":
+		return PluginTypeConnector, pluginConfig.Connector
+	case pluginConfig.Visualizer != "":
+		return PluginTypeVisualizer, pluginConfig.Visualizer
+	case pluginConfig.Ingestor != "":
+		return PluginTypeIngestor, pluginConfig.Ingestor
+	case pluginConfig.Pivot != "":
+		return PluginTypePivot, pluginConfig.Pivot
+	}
+	return "", ""
+}
+
+// LogValue lets slog log a PluginConfig without the value of its secret.
+func (pluginConfig PluginConfig) LogValue() slog.Value {
+	pluginType, pluginName := pluginConfig.Plugin()
+	var value interface{} = pluginConfig.Value
+	if pluginConfig.IsSecret {
+		value = redactedValue
+	}
+	return slog.GroupValue(
+		slog.Uint64("id", pluginConfig.ID),
+		slog.String("plugin_type", string(pluginType)),
+		slog.String("plugin", pluginName),
+		slog.String("attribute", pluginConfig.Attribute),
+		slog.Any("value", value),
+		slog.Bool("for_organization", pluginConfig.ForOrganization),
+	)
+}
+
+// PluginConfigParams represents the fields needed for setting the value of a parameter or a secret of a plugin.
+type PluginConfigParams struct {
+	PluginType PluginType
+	PluginName string
+	Attribute  string
+	Value      interface{}
+	// ForOrganization sets the value for your whole organization instead of you only, this is only
+	// accessible to the organization's owner and admins.
+	ForOrganization bool
+}
+
+// MarshalJSON sends the plugin under the field ThreatMatrix expects for its type.
+func (params PluginConfigParams) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		string(params.PluginType) + "_config": params.PluginName,
+		"attribute":                           params.Attribute,
+		"value":                               params.Value,
+		"for_organization":                    params.ForOrganization,
+	})
+}
+
+// checkPluginConfigParams is used to check that the value is set for a known plugin and attribute.
+func checkPluginConfigParams(params PluginConfigParams) error {
+	if !params.PluginType.Valid() {
+		return fmt.Errorf("%w: unknown plugin type %q", ErrValidation, params.PluginType)
+	}
+	if err := checkPluginName(params.PluginName); err != nil {
+		return err
+	}
+	if strings.TrimSpace(params.Attribute) == "" {
+		return fmt.Errorf("%w: plugin config attribute cannot be empty", ErrValidation)
+	}
+	return nil
+}
+
+// checkPluginConfigID is used to check if a plugin config ID is valid (id should be greater than zero).
+func checkPluginConfigID(id uint64) error {
+	if id > 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: Plugin config ID cannot be 0", ErrValidation)
+}
+
+// pluginConfigValueParams represents the body used to update the value of a plugin config.
+type pluginConfigValueParams struct {
+	Value interface{} `json:"value"`
+}
+
+// PluginConfigService handles communication with the plugin config related methods of the ThreatMatrix API,
+// letting you manage the parameters and secrets of the plugins as code.
+// The bodies of its calls are never logged nor dumped by WithDebug, as they may hold secrets.
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/plugin-config
+type PluginConfigService struct {
+	client *ThreatMatrixClient
+}
+
+// List fetches the values you and your organization set for the parameters and secrets of the plugins.
+//
+//	Endpoint: GET /api/plugin-config
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/plugin-config/operation/plugin_config_list
+func (pluginConfigService *PluginConfigService) List(ctx context.Context, opts ...RequestOption) ([]PluginConfig, error) {
+	ctx = withRedactedBodies(withRequestOptions(ctx, opts))
+	ctx, span := pluginConfigService.client.startSpan(ctx, "PluginConfigService.List")
+	defer span.End()
+	successResp, err := pluginConfigService.client.sendJSON(ctx, "GET", constants.BASE_PLUGIN_CONFIG_URL, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeConfigList[PluginConfig](successResp.Data)
+}
+
+// ListForPlugin fetches the values set for the parameters and secrets of a specific plugin.
+//
+//	Endpoint: GET /api/{pluginType}/{NameOfPlugin}/plugin_config
+func (pluginConfigService *PluginConfigService) ListForPlugin(ctx context.Context, pluginType PluginType, pluginName string, opts ...RequestOption) ([]PluginConfig, error) {
+	ctx = withRedactedBodies(withRequestOptions(ctx, opts))
+	ctx, span := pluginConfigService.client.startSpan(ctx, "PluginConfigService.ListForPlugin", pluginAttribute(pluginName))
+	defer span.End()
+	if !pluginType.Valid() {
+		return nil, fmt.Errorf("%w: unknown plugin type %q", ErrValidation, pluginType)
+	}
+	if err := checkPluginName(pluginName); err != nil {
+		return nil, err
+	}
+	route := fmt.Sprintf(constants.PLUGIN_CONFIG_OF_PLUGIN_URL, pluginType, url.PathEscape(pluginName))
+	successResp, err := pluginConfigService.client.sendJSON(ctx, "GET", route, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeConfigList[PluginConfig](successResp.Data)
+}
+
+// Create sets the values of parameters and secrets of the plugins, all of them being rejected when one is invalid.
+//
+//	Endpoint: POST /api/plugin-config
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/plugin-config/operation/plugin_config_create
+func (pluginConfigService *PluginConfigService) Create(ctx context.Context, params []PluginConfigParams, opts ...RequestOption) ([]PluginConfig, error) {
+	ctx = withRedactedBodies(withRequestOptions(ctx, opts))
+	ctx, span := pluginConfigService.client.startSpan(ctx, "PluginConfigService.Create")
+	defer span.End()
+	if len(params) == 0 {
+		return nil, fmt.Errorf("%w: plugin configs cannot be empty", ErrValidation)
+	}
+	for _, pluginConfigParams := range params {
+		if err := checkPluginConfigParams(pluginConfigParams); err != nil {
+			return nil, err
+		}
+	}
+	pluginConfigs := []PluginConfig{}
+	if _, err := pluginConfigService.client.sendJSON(ctx, "POST", constants.BASE_PLUGIN_CONFIG_URL, params, &pluginConfigs); err != nil {
+		return nil, err
+	}
+	return pluginConfigs, nil
+}
+
+// Update changes the value of a parameter or a secret through the ID of its plugin config.
+//
+//	Endpoint: PATCH /api/plugin-config/{id}
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/plugin-config/operation/plugin_config_partial_update
+func (pluginConfigService *PluginConfigService) Update(ctx context.Context, pluginConfigId uint64, value interface{}, opts ...RequestOption) (*PluginConfig, error) {
+	ctx = withRedactedBodies(withRequestOptions(ctx, opts))
+	ctx, span := pluginConfigService.client.startSpan(ctx, "PluginConfigService.Update")
+	defer span.End()
+	if err := checkPluginConfigID(pluginConfigId); err != nil {
+		return nil, err
+	}
+	pluginConfig := PluginConfig{}
+	route := fmt.Sprintf(constants.SPECIFIC_PLUGIN_CONFIG_URL, pluginConfigId)
+	if _, err := pluginConfigService.client.sendJSON(ctx, "PATCH", route, &pluginConfigValueParams{Value: value}, &pluginConfig); err != nil {
+		return nil, err
+	}
+	return &pluginConfig, nil
+}
+
+// Delete removes a value set for a parameter or a secret, the plugin falling back to its default value.
+//
+//	Endpoint: DELETE /api/plugin-config/{id}
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/plugin-config/operation/plugin_config_destroy
+func (pluginConfigService *PluginConfigService) Delete(ctx context.Context, pluginConfigId uint64, opts ...RequestOption) (bool, error) {
+	ctx = withRedactedBodies(withRequestOptions(ctx, opts))
+	ctx, span := pluginConfigService.client.startSpan(ctx, "PluginConfigService.Delete")
+	defer span.End()
+	if err := checkPluginConfigID(pluginConfigId); err != nil {
+		return false, err
+	}
+	return pluginConfigService.client.sendAction(ctx, "DELETE", fmt.Sprintf(constants.SPECIFIC_PLUGIN_CONFIG_URL, pluginConfigId), nil)
+}
@@ -0,0 +1,121 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"io"
+	"os"
+)
+
+// WithProgress calls progress as the files of AnalyzeFile, AnalyzeFiles and PlaybookService.AnalyzeFile are uploaded,
+// and as the sample of JobService.DownloadSample, DownloadSampleTo and DownloadSampleZipped is downloaded, so a CLI
+// or a UI can render a progress bar for huge samples such as memory dumps. sent is how many bytes were transferred
+// so far and total how many there are, -1 when it isn't known: a file reader whose size can't be told without
+// reading it, or a response without a Content-Length. progress is called from the goroutine doing the transfer,
+// so it has to return quickly. It has no effect on the other methods.
+//
+//	_, err := client.AnalyzeService.AnalyzeFile(ctx, request, gothreatmatrix.WithProgress(func(sent int64, total int64) {
+//		fmt.Printf("\r%d/%d bytes", sent, total)
+//	}))
+func WithProgress(progress func(sent int64, total int64)) RequestOption {
+	return func(options *requestOptions) {
+		options.progress = progress
+	}
+}
+
+// progressCounter counts the bytes of a transfer, possibly spread over several readers, and reports them.
+type progressCounter struct {
+	progress func(sent int64, total int64)
+	sent     int64
+	total    int64
+}
+
+// progressReader reports the bytes read from reader to its counter.
+type progressReader struct {
+	reader  io.Reader
+	counter *progressCounter
+}
+
+// Read reads from the underlying reader and reports how many bytes were transferred so far.
+func (reader *progressReader) Read(p []byte) (int, error) {
+	read, err := reader.reader.Read(p)
+	if read > 0 {
+		reader.counter.sent += int64(read)
+		reader.counter.progress(reader.counter.sent, reader.counter.total)
+	}
+	return read, err
+}
+
+// progressFrom returns the progress callback the call's RequestOptions carry, nil when there's none.
+func progressFrom(ctx context.Context) func(sent int64, total int64) {
+	if options := requestOptionsFrom(ctx); options != nil {
+		return options.progress
+	}
+	return nil
+}
+
+// withUploadProgress wraps the readers of files so that reading them reports the progress of the call, leaving them
+// untouched when the call has no progress callback. The total is the sum of the sizes of the files.
+func withUploadProgress(ctx context.Context, files []analysisFile) []analysisFile {
+	progress := progressFrom(ctx)
+	if progress == nil {
+		return files
+	}
+	counter := &progressCounter{progress: progress}
+	wrapped := make([]analysisFile, len(files))
+	for index, file := range files {
+		size := readerSize(file.reader)
+		if size < 0 || counter.total < 0 {
+			counter.total = -1
+		} else {
+			counter.total += size
+		}
+		wrapped[index] = analysisFile{name: file.name, reader: &progressReader{reader: file.reader, counter: counter}}
+	}
+	return wrapped
+}
+
+// withDownloadProgress wraps body so that reading it reports the progress of the call, leaving it untouched when the
+// call has no progress callback. offset is how many bytes were downloaded before body, by an interrupted request.
+func withDownloadProgress(ctx context.Context, body io.Reader, offset int64, total int64) io.Reader {
+	progress := progressFrom(ctx)
+	if progress == nil {
+		return body
+	}
+	if total < 0 {
+		total = -1
+	}
+	return &progressReader{reader: body, counter: &progressCounter{progress: progress, sent: offset, total: total}}
+}
+
+// readerSize returns how many bytes are left to read from reader, -1 when it can't be told without reading it.
+func readerSize(reader io.Reader) int64 {
+	switch sized := reader.(type) {
+	case interface{ Len() int }:
+		// bytes.Reader, bytes.Buffer and strings.Reader
+		return int64(sized.Len())
+	case *os.File:
+		info, err := sized.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return -1
+		}
+		offset, err := sized.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		return info.Size() - offset
+	case io.Seeker:
+		offset, err := sized.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		end, err := sized.Seek(0, io.SeekEnd)
+		if err != nil {
+			return -1
+		}
+		if _, err := sized.Seek(offset, io.SeekStart); err != nil {
+			return -1
+		}
+		return end - offset
+	}
+	return -1
+}
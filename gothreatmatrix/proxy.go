@@ -0,0 +1,45 @@
+package gothreatmatrix
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// proxySchemes are the proxy URL schemes supported by WithProxy.
+var proxySchemes = map[string]bool{"http": true, "https": true, "socks5": true, "socks5h": true}
+
+// WithProxy sends every request of the default http.Client through the proxy at proxyUrl, such as
+// "http://proxy.corp:3128" or "socks5://127.0.0.1:1080", credentials going in the URL's user info.
+// An empty proxyUrl connects to ThreatMatrix directly, ignoring the proxy environment variables.
+//
+// Without WithProxy, the client honors the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+// An invalid proxyUrl makes every request fail with an error wrapping ErrValidation.
+// Like the TLS options, it's ignored when WithHTTPClient is given.
+func WithProxy(proxyUrl string) Option {
+	return func(config *clientConfig) {
+		if strings.TrimSpace(proxyUrl) == "" {
+			config.proxy = func(*http.Request) (*url.URL, error) {
+				return nil, nil
+			}
+			return
+		}
+		parsedUrl, err := parseProxyURL(proxyUrl)
+		config.proxy = func(*http.Request) (*url.URL, error) {
+			return parsedUrl, err
+		}
+	}
+}
+
+// parseProxyURL parses and checks the URL of a proxy.
+func parseProxyURL(proxyUrl string) (*url.URL, error) {
+	parsedUrl, err := url.Parse(strings.TrimSpace(proxyUrl))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid proxy URL: %v", ErrValidation, err)
+	}
+	if !proxySchemes[parsedUrl.Scheme] || parsedUrl.Host == "" {
+		return nil, fmt.Errorf("%w: invalid proxy URL %q, expected a http, https, socks5 or socks5h URL with a host", ErrValidation, parsedUrl.Redacted())
+	}
+	return parsedUrl, nil
+}
@@ -0,0 +1,166 @@
+package gothreatmatrix
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// QueryResult is the value a path points to in the reports of a job.
+type QueryResult struct {
+	// Value is the decoded JSON value: a map, a slice, a string, a float64, a bool or nil.
+	Value interface{}
+	// Exists tells whether the path points to a value, which is nil when the value is JSON null.
+	Exists bool
+}
+
+// QueryReports looks up a value in the untyped reports of the job's analyzers and connectors through a path,
+// such as "VirusTotal_v3_Get_Observable.data.attributes.last_analysis_stats.malicious".
+// The first key of the path is the name of the analyzer or connector, the next ones the keys of its report:
+//   - the keys are separated by dots, a dot in a key being escaped as "\.";
+//   - a number is the index of an array item, such as "Classic_DNS.resolutions.0.data";
+//   - "#" is the length of an array, and "#.key" gives the array of the key of every item, such as "Classic_DNS.resolutions.#.data".
+//
+// The result doesn't exist when the plugin didn't run or the path doesn't match its report.
+//
+//	malicious := job.QueryReports("VirusTotal_v3_Get_Observable.data.attributes.last_analysis_stats.malicious").Int()
+func (job *Job) QueryReports(path string) QueryResult {
+	keys := splitQueryPath(path)
+	if len(keys) == 0 {
+		return QueryResult{}
+	}
+	report, ok := job.ReportByName(keys[0])
+	if !ok {
+		return QueryResult{}
+	}
+	return queryValue(report.Report, keys[1:])
+}
+
+// splitQueryPath splits the path into its keys, unescaping the escaped dots.
+func splitQueryPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	keys := []string{}
+	key := strings.Builder{}
+	for index := 0; index < len(path); index++ {
+		switch {
+		case path[index] == '\\' && index+1 < len(path) && path[index+1] == '.':
+			key.WriteByte('.')
+			index++
+		case path[index] == '.':
+			keys = append(keys, key.String())
+			key.Reset()
+		default:
+			key.WriteByte(path[index])
+		}
+	}
+	return append(keys, key.String())
+}
+
+// queryValue follows the keys from value.
+func queryValue(value interface{}, keys []string) QueryResult {
+	for index, key := range keys {
+		switch typedValue := value.(type) {
+		case map[string]interface{}:
+			child, ok := typedValue[key]
+			if !ok {
+				return QueryResult{}
+			}
+			value = child
+		case []interface{}:
+			if key == "#" {
+				if index == len(keys)-1 {
+					return QueryResult{Value: float64(len(typedValue)), Exists: true}
+				}
+				values := []interface{}{}
+				for _, item := range typedValue {
+					if result := queryValue(item, keys[index+1:]); result.Exists {
+						values = append(values, result.Value)
+					}
+				}
+				return QueryResult{Value: values, Exists: true}
+			}
+			itemIndex, err := strconv.Atoi(key)
+			if err != nil || itemIndex < 0 || itemIndex >= len(typedValue) {
+				return QueryResult{}
+			}
+			value = typedValue[itemIndex]
+		default:
+			return QueryResult{}
+		}
+	}
+	return QueryResult{Value: value, Exists: true}
+}
+
+// String returns the value as a string, numbers and booleans being formatted and the maps and arrays being
+// encoded into JSON. It's empty when the result doesn't exist or is null.
+func (result QueryResult) String() string {
+	switch value := result.Value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	encoded, _ := json.Marshal(result.Value)
+	return string(encoded)
+}
+
+// Float returns the value as a float64, strings being parsed. It's 0 when the value isn't a number.
+func (result QueryResult) Float() float64 {
+	switch value := result.Value.(type) {
+	case float64:
+		return value
+	case int:
+		return float64(value)
+	case json.Number:
+		number, _ := value.Float64()
+		return number
+	case string:
+		number, _ := strconv.ParseFloat(value, 64)
+		return number
+	}
+	return 0
+}
+
+// Int returns the value as an int, truncating the decimals. It's 0 when the value isn't a number.
+func (result QueryResult) Int() int {
+	if value, ok := result.Value.(string); ok {
+		if number, err := strconv.Atoi(value); err == nil {
+			return number
+		}
+	}
+	return int(result.Float())
+}
+
+// Bool returns the value as a bool, strings such as "true" being parsed. It's false when the value isn't a boolean.
+func (result QueryResult) Bool() bool {
+	switch value := result.Value.(type) {
+	case bool:
+		return value
+	case string:
+		parsed, _ := strconv.ParseBool(value)
+		return parsed
+	}
+	return false
+}
+
+// Array returns the items of an array value, a single value being returned as the only item.
+// It's empty when the result doesn't exist or is null.
+func (result QueryResult) Array() []QueryResult {
+	switch value := result.Value.(type) {
+	case nil:
+		return []QueryResult{}
+	case []interface{}:
+		items := make([]QueryResult, len(value))
+		for index, item := range value {
+			items[index] = QueryResult{Value: item, Exists: true}
+		}
+		return items
+	}
+	return []QueryResult{result}
+}
@@ -0,0 +1,426 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultQueueFlushInterval is how often Queue.Run flushes the queue when it's given an interval below 1.
+const DefaultQueueFlushInterval = 30 * time.Second
+
+// These are the extensions of the files of a Queue journal.
+const (
+	queueEntryExtension  = ".json"
+	queueSampleExtension = ".sample"
+	queueErrorExtension  = ".error"
+	queueTempExtension   = ".tmp"
+	// queueRejectedDirectory holds the entries ThreatMatrix rejected, so they're not lost
+	queueRejectedDirectory = "rejected"
+)
+
+// Queue is a durable queue of submissions journaled in a directory, for collectors that can't always reach
+// ThreatMatrix: the submissions are written to disk as they're enqueued and sent through a Submitter when the queue
+// is flushed, surviving restarts of the process until ThreatMatrix accepts them.
+//
+// Every submission is journaled as a JSON file, alongside a copy of the sample for the files, written to a temporary
+// file then renamed, both synced to disk, so a crash never leaves half an entry behind. The journal is a plain
+// directory rather than an embedded database such as bolt or badger, so the queue adds no dependency and its entries
+// can be inspected, or moved back from the rejected directory, with the usual tools. The idempotency key of a
+// submission is journaled as well and every attempt at sending the entry carries it as the Idempotency-Key header. The queue doesn't
+// deduplicate by itself though: unless the server deduplicates the submissions by that header, an entry accepted
+// right before a crash, and so not removed from the journal yet, creates a second job when it's sent again.
+//
+// The submissions ThreatMatrix definitively rejects, with a 400, 404 or 422 response or because they failed the checks
+// of the client, are moved to the rejected directory of the journal along with the error. Any other failure, such as
+// ThreatMatrix being unreachable, an open circuit breaker or a refused token, leaves them journaled.
+//
+//	queue, err := gothreatmatrix.OpenQueue("/var/lib/collector/queue", gothreatmatrix.NewSubmitter(client, nil))
+//	err = queue.Enqueue(gothreatmatrix.Submission{Observable: &gothreatmatrix.ObservableAnalysisRequest{Value: "8.8.8.8"}})
+//	go queue.Run(ctx, time.Minute)
+type Queue struct {
+	directory string
+	submitter *Submitter
+	// flushMutex lets a single flush run at a time
+	flushMutex sync.Mutex
+}
+
+// queueEntry is a submission as it's journaled.
+type queueEntry struct {
+	Observable     *ObservableAnalysisRequest `json:"observable,omitempty"`
+	File           *queuedFile                `json:"file,omitempty"`
+	IdempotencyKey string                     `json:"idempotency_key"`
+	EnqueuedAt     time.Time                  `json:"enqueued_at"`
+}
+
+// queuedFile is a FileAnalysisRequest as it's journaled, the content of the file being journaled on its own.
+type queuedFile struct {
+	FileName             string                 `json:"file_name"`
+	Analyzers            []string               `json:"analyzers"`
+	Connectors           []string               `json:"connectors"`
+	TLP                  TLP                    `json:"tlp"`
+	Tags                 []string               `json:"tags"`
+	RuntimeConfiguration map[string]interface{} `json:"runtime_configuration"`
+}
+
+// OpenQueue opens the queue journaled in directory, creating it when it doesn't exist, to send its submissions
+// through submitter. The entries a crash left half written are cleaned up.
+func OpenQueue(directory string, submitter *Submitter) (*Queue, error) {
+	if submitter == nil {
+		return nil, fmt.Errorf("%w: the queue needs a submitter", ErrValidation)
+	}
+	if err := os.MkdirAll(filepath.Join(directory, queueRejectedDirectory), 0o700); err != nil {
+		return nil, err
+	}
+	queue := &Queue{directory: directory, submitter: submitter}
+	if err := queue.cleanUp(); err != nil {
+		return nil, err
+	}
+	return queue, nil
+}
+
+// Enqueue journals the submission, which is sent on the next flush. The file of a file submission is read whole
+// and copied into the journal, it can be closed once Enqueue returns.
+func (queue *Queue) Enqueue(submission Submission) error {
+	if (submission.Observable == nil) == (submission.File == nil) {
+		return fmt.Errorf("%w: a submission needs either an observable or a file", ErrValidation)
+	}
+	entry := queueEntry{
+		Observable:     submission.Observable,
+		IdempotencyKey: submission.IdempotencyKey,
+		EnqueuedAt:     time.Now().UTC(),
+	}
+	if entry.IdempotencyKey == "" {
+		entry.IdempotencyKey = NewIdempotencyKey()
+	}
+	// the names sort in the order the submissions were enqueued, the key being hashed as it's up to the caller
+	keyHash := sha256.Sum256([]byte(entry.IdempotencyKey))
+	name := fmt.Sprintf("%019d-%s", entry.EnqueuedAt.UnixNano(), hex.EncodeToString(keyHash[:16]))
+	if submission.File != nil {
+		if submission.File.File == nil {
+			return fmt.Errorf("%w: file cannot be nil", ErrValidation)
+		}
+		request := submission.File
+		entry.File = &queuedFile{
+			FileName:             request.FileName,
+			Analyzers:            request.Analyzers,
+			Connectors:           request.Connectors,
+			TLP:                  request.TLP,
+			Tags:                 request.Tags,
+			RuntimeConfiguration: request.RuntimeConfiguration,
+		}
+		if err := writeFileAtomically(queue.path(name+queueSampleExtension), func(writer io.Writer) error {
+			_, err := io.Copy(writer, request.File)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	// the entry is written last, a sample without its entry being cleaned up by the next OpenQueue
+	return writeFileAtomically(queue.path(name+queueEntryExtension), func(writer io.Writer) error {
+		return json.NewEncoder(writer).Encode(entry)
+	})
+}
+
+// Len returns how many submissions are waiting to be sent.
+func (queue *Queue) Len() (int, error) {
+	names, err := queue.entryNames()
+	return len(names), err
+}
+
+// Flush sends every journaled submission through the Submitter and returns their outcome, in no particular order.
+// The accepted submissions are removed from the journal and the rejected ones moved to its rejected directory.
+// When one fails for any other reason, such as ThreatMatrix not being reachable even after the retries of the
+// Submitter, the flush stops: that submission and the ones not sent yet stay journaled, for the next flush.
+// The error is only set when the journal can't be read or written.
+func (queue *Queue) Flush(ctx context.Context) ([]SubmissionResult, error) {
+	queue.flushMutex.Lock()
+	defer queue.flushMutex.Unlock()
+	names, err := queue.entryNames()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	mutex := sync.Mutex{}
+	// the entries are found back from the request of their submission, the idempotency key being shared by any
+	// entries the caller enqueued with the same one
+	loadedEntries := map[interface{}]loadedQueueEntry{}
+	defer func() {
+		for _, entry := range loadedEntries {
+			if entry.file != nil {
+				entry.file.Close()
+			}
+		}
+	}()
+	var loadError error
+	submissions := make(chan Submission)
+	loaded := make(chan struct{})
+	go func() {
+		defer close(loaded)
+		defer close(submissions)
+		for _, name := range names {
+			submission, file, err := queue.load(name)
+			mutex.Lock()
+			if err != nil {
+				loadError = err
+				mutex.Unlock()
+				cancel()
+				return
+			}
+			loadedEntries[submissionRequest(submission)] = loadedQueueEntry{name: name, file: file}
+			mutex.Unlock()
+			select {
+			case <-ctx.Done():
+				return
+			case submissions <- submission:
+			}
+		}
+	}()
+
+	results := []SubmissionResult{}
+	var journalError error
+	for result := range queue.submitter.Submit(ctx, submissions) {
+		results = append(results, result)
+		mutex.Lock()
+		entry := loadedEntries[submissionRequest(result.Submission)]
+		delete(loadedEntries, submissionRequest(result.Submission))
+		mutex.Unlock()
+		if entry.file != nil {
+			entry.file.Close()
+		}
+		name := entry.name
+		var err error
+		switch {
+		case result.Err == nil:
+			err = queue.remove(name)
+		case ctx.Err() == nil && isQueueRejection(result.Err):
+			err = queue.reject(name, result.Err)
+		default:
+			// the failure isn't specific to the submission, there's no point in sending the other ones
+			cancel()
+		}
+		if err != nil && journalError == nil {
+			journalError = err
+			cancel()
+		}
+	}
+	// the submissions are all loaded, or the loading stopped, before the open files are closed
+	<-loaded
+	if loadError != nil {
+		return results, loadError
+	}
+	return results, journalError
+}
+
+// Run flushes the queue every interval, starting right away, until ctx is done. It only returns early when the
+// journal can't be read or written.
+func (queue *Queue) Run(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultQueueFlushInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := queue.Flush(ctx); err != nil && ctx.Err() == nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// loadedQueueEntry is an entry loaded by Flush, along with its open sample for the files.
+type loadedQueueEntry struct {
+	name string
+	file *os.File
+}
+
+// submissionRequest returns the request of a submission, which the result of the submission points to as well.
+func submissionRequest(submission Submission) interface{} {
+	if submission.File != nil {
+		return submission.File
+	}
+	return submission.Observable
+}
+
+// load reads the journaled entry with the given name back into a submission, opening its sample for the files.
+func (queue *Queue) load(name string) (Submission, *os.File, error) {
+	data, err := os.ReadFile(queue.path(name + queueEntryExtension))
+	if err != nil {
+		return Submission{}, nil, err
+	}
+	entry := queueEntry{}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return Submission{}, nil, fmt.Errorf("threatmatrix: queue entry %s: %w", name, err)
+	}
+	submission := Submission{Observable: entry.Observable, IdempotencyKey: entry.IdempotencyKey}
+	if entry.File == nil {
+		return submission, nil, nil
+	}
+	sample, err := os.Open(queue.path(name + queueSampleExtension))
+	if err != nil {
+		return Submission{}, nil, err
+	}
+	submission.File = &FileAnalysisRequest{
+		// an *os.File is an io.Seeker, so the Submitter retries it
+		File:                 sample,
+		FileName:             entry.File.FileName,
+		Analyzers:            entry.File.Analyzers,
+		Connectors:           entry.File.Connectors,
+		TLP:                  entry.File.TLP,
+		Tags:                 entry.File.Tags,
+		RuntimeConfiguration: entry.File.RuntimeConfiguration,
+	}
+	return submission, sample, nil
+}
+
+// remove deletes an entry and its sample from the journal.
+func (queue *Queue) remove(name string) error {
+	if err := os.Remove(queue.path(name + queueEntryExtension)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.Remove(queue.path(name + queueSampleExtension)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return syncDirectory(queue.directory)
+}
+
+// reject moves an entry and its sample to the rejected directory of the journal, along with the error.
+func (queue *Queue) reject(name string, rejection error) error {
+	rejectedName := filepath.Join(queueRejectedDirectory, name)
+	if err := writeFileAtomically(queue.path(rejectedName+queueErrorExtension), func(writer io.Writer) error {
+		_, err := io.WriteString(writer, rejection.Error()+"\n")
+		return err
+	}); err != nil {
+		return err
+	}
+	if err := os.Rename(queue.path(name+queueSampleExtension), queue.path(rejectedName+queueSampleExtension)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.Rename(queue.path(name+queueEntryExtension), queue.path(rejectedName+queueEntryExtension)); err != nil {
+		return err
+	}
+	// the entry is only gone from the journal once both directories are synced
+	if err := syncDirectory(queue.path(queueRejectedDirectory)); err != nil {
+		return err
+	}
+	return syncDirectory(queue.directory)
+}
+
+// isQueueRejection checks if err means ThreatMatrix definitively rejected the submission, which then can't be accepted
+// by sending it again, as opposed to a failure such as an outage, an open circuit breaker or a refused token.
+func isQueueRejection(err error) bool {
+	var threatMatrixError *ThreatMatrixError
+	if errors.As(err, &threatMatrixError) {
+		switch threatMatrixError.StatusCode {
+		case http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity:
+			return true
+		}
+		return false
+	}
+	// the submission failed the checks of the client before being sent
+	return errors.Is(err, ErrValidation)
+}
+
+// entryNames returns the names of the journaled entries, without their extension, in the order they were enqueued.
+func (queue *Queue) entryNames() ([]string, error) {
+	files, err := os.ReadDir(queue.directory)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), queueEntryExtension) {
+			names = append(names, strings.TrimSuffix(file.Name(), queueEntryExtension))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// cleanUp removes the temporary files and the samples without an entry a crash left behind.
+func (queue *Queue) cleanUp() error {
+	files, err := os.ReadDir(queue.directory)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		name := file.Name()
+		orphanSample := strings.HasSuffix(name, queueSampleExtension)
+		if orphanSample {
+			_, err := os.Stat(queue.path(strings.TrimSuffix(name, queueSampleExtension) + queueEntryExtension))
+			orphanSample = errors.Is(err, os.ErrNotExist)
+		}
+		if strings.HasSuffix(name, queueTempExtension) || orphanSample {
+			if err := os.Remove(queue.path(name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// path returns the path of a file of the journal.
+func (queue *Queue) path(name string) string {
+	return filepath.Join(queue.directory, name)
+}
+
+// writeFileAtomically writes a file through write, to a temporary file synced to disk then renamed, the directory
+// being synced as well, so the file is either missing or whole and durable even when the process or the host crashes.
+func writeFileAtomically(path string, write func(writer io.Writer) error) error {
+	temp, err := os.OpenFile(path+queueTempExtension, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := write(temp); err != nil {
+		temp.Close()
+		os.Remove(temp.Name())
+		return err
+	}
+	if err := temp.Sync(); err != nil {
+		temp.Close()
+		os.Remove(temp.Name())
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		os.Remove(temp.Name())
+		return err
+	}
+	if err := os.Rename(temp.Name(), path); err != nil {
+		return err
+	}
+	return syncDirectory(filepath.Dir(path))
+}
+
+// syncDirectory syncs directory to disk, making the files created, renamed or removed in it durable.
+// Windows can't sync a directory, its renames being durable once they return.
+func syncDirectory(directory string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	handle, err := os.Open(directory)
+	if err != nil {
+		return err
+	}
+	if err := handle.Sync(); err != nil {
+		handle.Close()
+		return err
+	}
+	return handle.Close()
+}
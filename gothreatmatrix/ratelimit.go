@@ -0,0 +1,91 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxRateLimitedAttempts is how many times a request answered with 429 Too Many Requests is sent again.
+const maxRateLimitedAttempts = 5
+
+// defaultRetryAfter is how long to wait on a 429 response that has no Retry-After header.
+const defaultRetryAfter = time.Second
+
+// rateLimiter is a token bucket: it holds up to burst tokens and refills at rate tokens per second.
+// Every request takes one token, waiting for the bucket to refill when it is empty.
+type rateLimiter struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// WithRateLimit limits the client to requestsPerSecond requests on average,
+// allowing bursts of up to burst requests at once.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(config *clientConfig) {
+		if requestsPerSecond <= 0 {
+			config.limiter = nil
+			return
+		}
+		if burst < 1 {
+			burst = 1
+		}
+		config.limiter = &rateLimiter{
+			rate:   requestsPerSecond,
+			burst:  float64(burst),
+			tokens: float64(burst),
+			last:   time.Now(),
+		}
+	}
+}
+
+// wait blocks until a token is available or the context is done.
+func (limiter *rateLimiter) wait(ctx context.Context) error {
+	for {
+		limiter.mutex.Lock()
+		now := time.Now()
+		limiter.tokens += now.Sub(limiter.last).Seconds() * limiter.rate
+		if limiter.tokens > limiter.burst {
+			limiter.tokens = limiter.burst
+		}
+		limiter.last = now
+		if limiter.tokens >= 1 {
+			limiter.tokens--
+			limiter.mutex.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - limiter.tokens) / limiter.rate * float64(time.Second))
+		limiter.mutex.Unlock()
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// retryAfter works out how long the server asked us to wait through the Retry-After header.
+// The header can either be a number of seconds or an HTTP date.
+// It returns false when waiting would go past the context's deadline.
+func retryAfter(ctx context.Context, response *http.Response) (time.Duration, bool) {
+	delay := defaultRetryAfter
+	if header := strings.TrimSpace(response.Header.Get("Retry-After")); header != "" {
+		if seconds, err := strconv.Atoi(header); err == nil {
+			delay = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(header); err == nil {
+			delay = time.Until(date)
+		}
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+		return 0, false
+	}
+	return delay, true
+}
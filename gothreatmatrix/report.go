@@ -0,0 +1,198 @@
+package gothreatmatrix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrReportNotRegistered is returned by Report.Decode when no report type was registered for the analyzer.
+var ErrReportNotRegistered = errors.New("threatmatrix: no report type registered")
+
+// reportTypes holds the report types registered through RegisterReportType keyed by analyzer name.
+var reportTypes = struct {
+	sync.RWMutex
+	byName map[string]reflect.Type
+}{byName: map[string]reflect.Type{}}
+
+// RegisterReportType registers the Go struct the report of an analyzer decodes into through Report.Decode.
+// report is a value or a pointer of that type, for example RegisterReportType("AbuseIPDB", AbuseIPDBReport{}).
+// Registering an analyzer again replaces its previous type.
+func RegisterReportType(analyzerName string, report interface{}) {
+	reportType := reflect.TypeOf(report)
+	for reportType.Kind() == reflect.Pointer {
+		reportType = reportType.Elem()
+	}
+	reportTypes.Lock()
+	defer reportTypes.Unlock()
+	reportTypes.byName[analyzerName] = reportType
+}
+
+// DecodeInto decodes the raw report into v, which has to be a pointer.
+func (report *Report) DecodeInto(v interface{}) error {
+	reportJson, err := json.Marshal(report.Report)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(reportJson, v)
+}
+
+// Decode decodes the raw report into the type registered for its analyzer and returns a pointer to it.
+// It returns ErrReportNotRegistered when there's no type registered for the analyzer.
+func (report *Report) Decode() (interface{}, error) {
+	reportTypes.RLock()
+	reportType, ok := reportTypes.byName[report.Name]
+	reportTypes.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w for %s", ErrReportNotRegistered, report.Name)
+	}
+	decoded := reflect.New(reportType).Interface()
+	if err := report.DecodeInto(decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// AnalyzerReport returns the report of the given analyzer, if it ran in the job.
+func (job *Job) AnalyzerReport(analyzerName string) (*Report, bool) {
+	for index := range job.AnalyzerReports {
+		if job.AnalyzerReports[index].Name == analyzerName {
+			return &job.AnalyzerReports[index], true
+		}
+	}
+	return nil, false
+}
+
+// Values of the Status of a Report.
+const (
+	ReportStatusPending = "PENDING"
+	ReportStatusRunning = "RUNNING"
+	ReportStatusSuccess = "SUCCESS"
+	ReportStatusFailed  = "FAILED"
+	ReportStatusKilled  = "KILLED"
+)
+
+// Succeeded checks if the plugin ran successfully.
+func (report *Report) Succeeded() bool {
+	return strings.EqualFold(report.Status, ReportStatusSuccess)
+}
+
+// Failed checks if the plugin failed.
+func (report *Report) Failed() bool {
+	return strings.EqualFold(report.Status, ReportStatusFailed)
+}
+
+// ReportByName returns the report of the given analyzer or connector, if it ran in the job.
+// Analyzers are looked up first, should a connector share the name of an analyzer.
+func (job *Job) ReportByName(name string) (*Report, bool) {
+	if report, ok := job.AnalyzerReport(name); ok {
+		return report, true
+	}
+	for index := range job.ConnectorReports {
+		if job.ConnectorReports[index].Name == name {
+			return &job.ConnectorReports[index], true
+		}
+	}
+	return nil, false
+}
+
+// FailedAnalyzers returns the names of the analyzers that failed in the job.
+func (job *Job) FailedAnalyzers() []string {
+	return failedPlugins(job.AnalyzerReports)
+}
+
+// FailedConnectors returns the names of the connectors that failed in the job.
+func (job *Job) FailedConnectors() []string {
+	return failedPlugins(job.ConnectorReports)
+}
+
+// failedPlugins returns the names of the plugins whose report failed.
+func failedPlugins(reports []Report) []string {
+	names := []string{}
+	for index := range reports {
+		if reports[index].Failed() {
+			names = append(names, reports[index].Name)
+		}
+	}
+	return names
+}
+
+// SuccessfulReports returns the reports of the analyzers that ran successfully in the job.
+func (job *Job) SuccessfulReports() []Report {
+	reports := []Report{}
+	for index := range job.AnalyzerReports {
+		if job.AnalyzerReports[index].Succeeded() {
+			reports = append(reports, job.AnalyzerReports[index])
+		}
+	}
+	return reports
+}
+
+// ErrorsSummary returns the errors of the analyzers and connectors of the job, one plugin per line
+// such as "Classic_DNS: timeout; no answer". It's empty when no plugin reported an error.
+func (job *Job) ErrorsSummary() string {
+	summary := strings.Builder{}
+	for _, reports := range [][]Report{job.AnalyzerReports, job.ConnectorReports} {
+		for _, report := range reports {
+			if len(report.Errors) == 0 {
+				continue
+			}
+			if summary.Len() > 0 {
+				summary.WriteString("\n")
+			}
+			fmt.Fprintf(&summary, "%s: %s", report.Name, strings.Join(report.Errors, "; "))
+		}
+	}
+	return summary.String()
+}
+
+// AbuseIPDBReport represents the report of the AbuseIPDB analyzer.
+type AbuseIPDBReport struct {
+	Data struct {
+		IPAddress            string     `json:"ipAddress"`
+		IsPublic             bool       `json:"isPublic"`
+		IsWhitelisted        bool       `json:"isWhitelisted"`
+		AbuseConfidenceScore int        `json:"abuseConfidenceScore"`
+		CountryCode          string     `json:"countryCode"`
+		UsageType            string     `json:"usageType"`
+		Isp                  string     `json:"isp"`
+		Domain               string     `json:"domain"`
+		TotalReports         int        `json:"totalReports"`
+		NumDistinctUsers     int        `json:"numDistinctUsers"`
+		LastReportedAt       *time.Time `json:"lastReportedAt"`
+	} `json:"data"`
+	Permalink string `json:"permalink"`
+}
+
+// VirusTotalAnalysisStats represents how many VirusTotal engines gave each verdict.
+type VirusTotalAnalysisStats struct {
+	Harmless   int `json:"harmless"`
+	Malicious  int `json:"malicious"`
+	Suspicious int `json:"suspicious"`
+	Undetected int `json:"undetected"`
+	Timeout    int `json:"timeout"`
+}
+
+// VirusTotalReport represents the report of the VirusTotal_v3_Get_Observable and VirusTotal_v3_Get_File analyzers.
+type VirusTotalReport struct {
+	Data struct {
+		ID         string `json:"id"`
+		Type       string `json:"type"`
+		Attributes struct {
+			LastAnalysisStats VirusTotalAnalysisStats `json:"last_analysis_stats"`
+			Reputation        int                     `json:"reputation"`
+			Tags              []string                `json:"tags"`
+		} `json:"attributes"`
+	} `json:"data"`
+	Link string `json:"link"`
+}
+
+func init() {
+	RegisterReportType("AbuseIPDB", AbuseIPDBReport{})
+	RegisterReportType("VirusTotal_v3_Get_Observable", VirusTotalReport{})
+	RegisterReportType("VirusTotal_v3_Get_File", VirusTotalReport{})
+}
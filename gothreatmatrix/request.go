@@ -0,0 +1,152 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RequestOption customizes a single call of a service method, overriding the client-level defaults for it.
+//
+//	sample := bytes.Buffer{}
+//	_, err := client.JobService.DownloadSampleTo(ctx, jobId, &sample, gothreatmatrix.WithRequestTimeout(5*time.Minute))
+type RequestOption func(*requestOptions)
+
+// requestOptions collects what the RequestOptions of a call customize.
+type requestOptions struct {
+	// timeout is 0 to keep the timeout of the client
+	timeout time.Duration
+	headers http.Header
+	query   map[string][]string
+	// pageConcurrency is 0 to let JobService.ListAll pick its default
+	pageConcurrency int
+	// progress is nil unless it was set through WithProgress
+	progress func(sent int64, total int64)
+	// verifyChecksum is set through WithChecksumVerification
+	verifyChecksum bool
+	// downloadResumes is nil to resume the downloads DefaultDownloadResumes times
+	downloadResumes *int
+}
+
+// requestOptionsKey is the context key holding the requestOptions of a call.
+type requestOptionsKey struct{}
+
+// WithRequestTimeout overrides the timeout of the client for every request sent by the call, such as a huge sample download.
+// It can go past the client timeout unless the client was given its own http.Client through WithHTTPClient,
+// in which case the shortest of both applies.
+func WithRequestTimeout(timeout time.Duration) RequestOption {
+	return func(options *requestOptions) {
+		options.timeout = timeout
+	}
+}
+
+// WithHeader sets a header on every request sent by the call, replacing the value the client would have sent.
+func WithHeader(key string, value string) RequestOption {
+	return func(options *requestOptions) {
+		options.headers.Set(key, value)
+	}
+}
+
+// WithQueryParam adds a query parameter to every request sent by the call, on top of the ones the method sets.
+func WithQueryParam(key string, value string) RequestOption {
+	return func(options *requestOptions) {
+		options.query[key] = append(options.query[key], value)
+	}
+}
+
+// WithPageConcurrency sets how many pages JobService.ListAll fetches at the same time, 1 fetching them one after the other.
+// It has no effect on the other methods.
+func WithPageConcurrency(concurrency int) RequestOption {
+	return func(options *requestOptions) {
+		options.pageConcurrency = concurrency
+	}
+}
+
+// withRequestOptions returns a context carrying opts on top of the RequestOptions ctx already carries,
+// so the methods called along the way with that context honor them as well.
+func withRequestOptions(ctx context.Context, opts []RequestOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+	options := &requestOptions{headers: http.Header{}, query: map[string][]string{}}
+	if parent := requestOptionsFrom(ctx); parent != nil {
+		options.timeout = parent.timeout
+		options.pageConcurrency = parent.pageConcurrency
+		options.progress = parent.progress
+		options.verifyChecksum = parent.verifyChecksum
+		options.downloadResumes = parent.downloadResumes
+		options.headers = parent.headers.Clone()
+		for key, values := range parent.query {
+			options.query[key] = append([]string{}, values...)
+		}
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, options)
+}
+
+// requestOptionsFrom returns the requestOptions carried by ctx, if any.
+func requestOptionsFrom(ctx context.Context) *requestOptions {
+	options, _ := ctx.Value(requestOptionsKey{}).(*requestOptions)
+	return options
+}
+
+// applyRequestOptions sets the headers and query parameters of the call's RequestOptions on request.
+func applyRequestOptions(request *http.Request) {
+	options := requestOptionsFrom(request.Context())
+	if options == nil {
+		return
+	}
+	for key, values := range options.headers {
+		request.Header[key] = append([]string{}, values...)
+	}
+	if len(options.query) > 0 {
+		query := request.URL.Query()
+		for key, values := range options.query {
+			for _, value := range values {
+				query.Add(key, value)
+			}
+		}
+		request.URL.RawQuery = query.Encode()
+	}
+}
+
+// attemptTimeout returns how long a single attempt at sending request may take, 0 meaning there's no limit.
+// The timeout of the call wins over the one of the client.
+func (client *ThreatMatrixClient) attemptTimeout(request *http.Request) time.Duration {
+	if options := requestOptionsFrom(request.Context()); options != nil && options.timeout > 0 {
+		return options.timeout
+	}
+	return client.timeout
+}
+
+// cancelOnClose releases the context of an attempt once its response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and cancels the context of the attempt.
+func (body *cancelOnClose) Close() error {
+	err := body.ReadCloser.Close()
+	body.cancel()
+	return err
+}
+
+// sendAttempt sends a single attempt of request, bound to its attemptTimeout.
+func (client *ThreatMatrixClient) sendAttempt(request *http.Request) (*http.Response, error) {
+	timeout := client.attemptTimeout(request)
+	if timeout <= 0 {
+		return client.client.Do(request)
+	}
+	ctx, cancel := context.WithTimeout(request.Context(), timeout)
+	response, err := client.client.Do(request.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	response.Body = &cancelOnClose{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
@@ -0,0 +1,176 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RetentionPolicy represents which jobs JobService.ApplyRetention deletes: the ones received more than OlderThan ago,
+// with one of Statuses and one of TLPs.
+type RetentionPolicy struct {
+	// OlderThan is the age past which a job is deleted, it must be positive.
+	OlderThan time.Duration
+	// Statuses are the statuses of the jobs to delete, empty to delete the jobs done being processed whatever their
+	// status, the running ones being left alone.
+	Statuses []JobStatus
+	// KeepSamples leaves the jobs analyzing a file alone, to only delete the jobs analyzing an observable.
+	KeepSamples bool
+	// TLPs are the TLPs of the jobs to delete, empty to delete them whatever their TLP.
+	TLPs []TLP
+	// Progress is called, when not nil, once every matching job was deleted or, for a dry run, found.
+	// The calls are made one at a time.
+	Progress func(progress RetentionProgress)
+}
+
+// RetentionJob is the outcome of applying a RetentionPolicy to one of the jobs matching it.
+// Err is set when the job could not be deleted, and Deleted is false for a dry run.
+type RetentionJob struct {
+	JobID   int
+	Deleted bool
+	Err     error
+}
+
+// RetentionProgress tells how far JobService.ApplyRetention got: Done out of Total matching jobs were handled,
+// Job being the last one.
+type RetentionProgress struct {
+	Job   RetentionJob
+	Done  int
+	Total int
+}
+
+// RetentionReport is the outcome of JobService.ApplyRetention: how many jobs were scanned, and the outcome for every
+// job matching the policy.
+type RetentionReport struct {
+	DryRun  bool
+	Scanned int
+	Jobs    []RetentionJob
+	// Skipped are the IDs of the jobs matching the policy but whose age is unknown, as ThreatMatrix didn't tell when
+	// they were received, which are left alone.
+	Skipped []int
+}
+
+// Deleted returns how many jobs were deleted.
+func (report *RetentionReport) Deleted() int {
+	deleted := 0
+	for _, job := range report.Jobs {
+		if job.Deleted {
+			deleted++
+		}
+	}
+	return deleted
+}
+
+// Validate checks the policy, returning ValidationErrors when OlderThan isn't positive or a status or TLP is unknown.
+func (policy *RetentionPolicy) Validate() error {
+	validationErrors := ValidationErrors{}
+	if policy.OlderThan <= 0 {
+		validationErrors.add("OlderThan", "the age of the jobs to delete must be positive")
+	}
+	for _, status := range policy.Statuses {
+		if !status.Valid() {
+			validationErrors.add("Statuses", "unknown status %q", string(status))
+		}
+	}
+	for _, tlp := range policy.TLPs {
+		validateTLP(&validationErrors, tlp)
+	}
+	return validationErrors.err()
+}
+
+// matches checks if the job falls under the policy, whatever its age.
+func (policy *RetentionPolicy) matches(job *JobList) bool {
+	if policy.KeepSamples && job.IsSample {
+		return false
+	}
+	if len(policy.Statuses) == 0 && !job.Status.IsTerminal() {
+		return false
+	}
+	if len(policy.Statuses) > 0 && !containsStatus(policy.Statuses, job.Status) {
+		return false
+	}
+	return len(policy.TLPs) == 0 || containsTLP(policy.TLPs, job.Tlp)
+}
+
+// params returns the JobListParams narrowing down the jobs to scan as much as ThreatMatrix can filter them.
+func (policy *RetentionPolicy) params(cutoff time.Time) *JobListParams {
+	params := &JobListParams{
+		ReceivedRequestTimeBefore: cutoff,
+		Ordering:                  "received_request_time",
+	}
+	if len(policy.Statuses) == 1 {
+		params.Status = policy.Statuses[0]
+	}
+	if len(policy.TLPs) == 1 {
+		params.Tlp = policy.TLPs[0]
+	}
+	return params
+}
+
+// ApplyRetention deletes every job matching policy, DefaultBulkConcurrency at a time, and reports the outcome for
+// each of them. The matching jobs are all listed before any is deleted so deleting them doesn't shift the pages
+// being walked through. A job whose received time is unknown is never deleted, it's reported as skipped instead.
+// A dry run only lists them, to see what the policy would delete.
+// The error is only set when the policy is invalid or the jobs could not be listed.
+func (jobService *JobService) ApplyRetention(ctx context.Context, policy RetentionPolicy, dryRun bool, opts ...RequestOption) (*RetentionReport, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.ApplyRetention")
+	defer span.End()
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	cutoff := time.Now().Add(-policy.OlderThan)
+	report := &RetentionReport{DryRun: dryRun}
+	iterator := jobService.Iter(ctx, policy.params(cutoff))
+	for iterator.Next() {
+		report.Scanned++
+		job := iterator.Job()
+		if !policy.matches(&job) {
+			continue
+		}
+		switch {
+		case job.ReceivedRequestTime == nil || job.ReceivedRequestTime.IsZero():
+			report.Skipped = append(report.Skipped, job.ID)
+		case job.ReceivedRequestTime.Before(cutoff):
+			report.Jobs = append(report.Jobs, RetentionJob{JobID: job.ID})
+		}
+	}
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+	progressMutex := sync.Mutex{}
+	done := 0
+	forEachConcurrently(len(report.Jobs), DefaultBulkConcurrency, func(index int) {
+		if !dryRun {
+			deleted, err := jobService.Delete(ctx, uint64(report.Jobs[index].JobID))
+			report.Jobs[index].Deleted, report.Jobs[index].Err = deleted, err
+		}
+		if policy.Progress != nil {
+			progressMutex.Lock()
+			defer progressMutex.Unlock()
+			done++
+			policy.Progress(RetentionProgress{Job: report.Jobs[index], Done: done, Total: len(report.Jobs)})
+		}
+	})
+	return report, nil
+}
+
+// containsStatus tells whether statuses holds status.
+func containsStatus(statuses []JobStatus, status JobStatus) bool {
+	for _, candidate := range statuses {
+		if candidate == status {
+			return true
+		}
+	}
+	return false
+}
+
+// containsTLP tells whether tlps holds tlp.
+func containsTLP(tlps []TLP, tlp TLP) bool {
+	for _, candidate := range tlps {
+		if candidate == tlp {
+			return true
+		}
+	}
+	return false
+}
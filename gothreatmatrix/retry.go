@@ -0,0 +1,190 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxRetryDelay caps the exponential backoff so a high attempt count never sleeps for minutes.
+const maxRetryDelay = 30 * time.Second
+
+// retryPolicy represents how failed requests are retried.
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+}
+
+// WithRetry makes the client retry idempotent requests (GET, HEAD, OPTIONS, PUT and DELETE),
+// and the submissions when WithIdempotentSubmissions is given, that failed due to a 5xx response, a network error, or a timeout.
+// Between attempts the client sleeps with exponential backoff starting from baseDelay plus some jitter.
+// maxAttempts counts the first attempt as well, so WithRetry(1, ...) disables retries.
+func WithRetry(maxAttempts int, baseDelay time.Duration) Option {
+	return func(config *clientConfig) {
+		if maxAttempts < 1 {
+			maxAttempts = 1
+		}
+		config.retry = retryPolicy{
+			maxAttempts: maxAttempts,
+			baseDelay:   baseDelay,
+		}
+	}
+}
+
+// retryBudget caps the retries of the client to a share of its requests, so an instance that's down isn't sent
+// maxAttempts times the requests it already can't answer.
+// Every request deposits ratio retries into the budget, up to burst, and every retry withdraws one.
+type retryBudget struct {
+	mutex   sync.Mutex
+	ratio   float64
+	burst   float64
+	balance float64
+}
+
+// WithRetryBudget bounds the retries WithRetry makes across every request of the client: each request earns ratio
+// retries, up to burst retries saved, so WithRetryBudget(0.1, 10) allows a retry for one request out of ten on top
+// of a burst of 10. Once the budget is spent failed requests are returned without being retried, the 429 answers
+// still being retried once their Retry-After has passed.
+func WithRetryBudget(ratio float64, burst int) Option {
+	return func(config *clientConfig) {
+		if ratio < 0 {
+			ratio = 0
+		}
+		if burst < 0 {
+			burst = 0
+		}
+		config.retryBudget = &retryBudget{
+			ratio:   ratio,
+			burst:   float64(burst),
+			balance: float64(burst),
+		}
+	}
+}
+
+// deposit earns the budget the retries of a request.
+func (budget *retryBudget) deposit() {
+	if budget == nil {
+		return
+	}
+	budget.mutex.Lock()
+	defer budget.mutex.Unlock()
+	budget.balance += budget.ratio
+	if budget.balance > budget.burst {
+		budget.balance = budget.burst
+	}
+}
+
+// withdraw spends a retry, returning false when the budget has none left. A nil budget is unlimited.
+func (budget *retryBudget) withdraw() bool {
+	if budget == nil {
+		return true
+	}
+	budget.mutex.Lock()
+	defer budget.mutex.Unlock()
+	if budget.balance < 1 {
+		return false
+	}
+	budget.balance--
+	return true
+}
+
+// WithOverallDeadline bounds the whole of a request, its attempts, the backoff between them and the waits of the rate
+// limiter included, while WithTimeout and WithRequestTimeout bound each attempt on its own. Once the deadline is
+// reached the request fails with context.DeadlineExceeded, even in the middle of a backoff. Every request gets its own
+// budget, so each poll of JobService.WaitForCompletion is bounded while the ctx given to it bounds the whole wait.
+func WithOverallDeadline(timeout time.Duration) Option {
+	return func(config *clientConfig) {
+		config.overallTimeout = timeout
+	}
+}
+
+// backoff returns how long to wait before the given attempt (attempts start at 1).
+// Half of the delay is fixed and the other half is random so concurrent clients don't retry in lockstep.
+func (policy retryPolicy) backoff(attempt int) time.Duration {
+	delay := policy.baseDelay
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// isIdempotent checks if a request can safely be sent more than once, as its method is idempotent or it carries
+// an idempotency key the server is trusted to honor, see WithIdempotentSubmissions.
+func isIdempotent(request *http.Request, trustIdempotencyKeys bool) bool {
+	if trustIdempotencyKeys && request.Header.Get(IdempotencyKeyHeader) != "" {
+		return true
+	}
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isRetryableError checks if a transport error is worth another attempt i.e network errors and timeouts.
+func isRetryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	var netError net.Error
+	if errors.As(err, &netError) {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// canRewindBody checks if the request body can be recreated for another attempt.
+func canRewindBody(request *http.Request) bool {
+	return request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+}
+
+// rewindBody returns a copy of the request with a fresh body so it can be sent again.
+func rewindBody(request *http.Request) (*http.Request, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return request, nil
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retryRequest := request.Clone(request.Context())
+	retryRequest.Body = body
+	return retryRequest, nil
+}
+
+// sleepContext waits for the given delay unless the context is done first.
+// It gives up right away when the delay would go past the context's deadline, as ctx would be done before it's over.
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+		return context.DeadlineExceeded
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// drainAndClose discards what's left of a response body so the connection can be reused.
+func drainAndClose(response *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+	response.Body.Close()
+}
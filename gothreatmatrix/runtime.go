@@ -0,0 +1,146 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// RuntimeConfig builds the runtime configuration of an analysis, overriding the parameters of its analyzers and
+// connectors for that analysis only.
+//
+//	runtimeConfig := gothreatmatrix.NewRuntimeConfig().
+//		ForAnalyzer("VirusTotal_v3_Get_Observable", map[string]interface{}{"max_tries": 5}).
+//		ForConnector("MISP", map[string]interface{}{"ssl_check": false})
+//	if err := runtimeConfig.Validate(ctx, client); err != nil {
+//		return err
+//	}
+//	analysisRequest := gothreatmatrix.ObservableAnalysisRequest{Value: "8.8.8.8", RuntimeConfiguration: runtimeConfig.Map()}
+type RuntimeConfig struct {
+	analyzers  map[string]map[string]interface{}
+	connectors map[string]map[string]interface{}
+}
+
+// NewRuntimeConfig returns an empty RuntimeConfig.
+func NewRuntimeConfig() *RuntimeConfig {
+	return &RuntimeConfig{
+		analyzers:  map[string]map[string]interface{}{},
+		connectors: map[string]map[string]interface{}{},
+	}
+}
+
+// ForAnalyzer sets parameters of an analyzer, on top of the ones already set for it.
+func (runtimeConfig *RuntimeConfig) ForAnalyzer(analyzerName string, params map[string]interface{}) *RuntimeConfig {
+	setPluginParams(runtimeConfig.analyzers, analyzerName, params)
+	return runtimeConfig
+}
+
+// ForConnector sets parameters of a connector, on top of the ones already set for it.
+func (runtimeConfig *RuntimeConfig) ForConnector(connectorName string, params map[string]interface{}) *RuntimeConfig {
+	setPluginParams(runtimeConfig.connectors, connectorName, params)
+	return runtimeConfig
+}
+
+// setPluginParams merges params into the parameters of the plugin.
+func setPluginParams(plugins map[string]map[string]interface{}, pluginName string, params map[string]interface{}) {
+	pluginParams, ok := plugins[pluginName]
+	if !ok {
+		pluginParams = map[string]interface{}{}
+		plugins[pluginName] = pluginParams
+	}
+	for key, value := range params {
+		pluginParams[key] = value
+	}
+}
+
+// Map returns the runtime configuration in the shape ThreatMatrix expects, to be used as the RuntimeConfiguration
+// of an analysis request. Changing it doesn't change the RuntimeConfig.
+func (runtimeConfig *RuntimeConfig) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"analyzers":  copyPluginParams(runtimeConfig.analyzers),
+		"connectors": copyPluginParams(runtimeConfig.connectors),
+	}
+}
+
+// copyPluginParams copies the parameters of every plugin.
+func copyPluginParams(plugins map[string]map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(plugins))
+	for pluginName, params := range plugins {
+		paramsCopy := make(map[string]interface{}, len(params))
+		for key, value := range params {
+			paramsCopy[key] = value
+		}
+		copied[pluginName] = paramsCopy
+	}
+	return copied
+}
+
+// MarshalJSON lets you implement the json.Marshaler interface, marshalling the RuntimeConfig as its Map.
+func (runtimeConfig *RuntimeConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(runtimeConfig.Map())
+}
+
+// Validate checks the runtime configuration against the analyzers and connectors of your ThreatMatrix instance,
+// giving an ErrValidation for every unknown plugin and every parameter a plugin doesn't have.
+// Wrap the services of the client through NewCachedAnalyzerService and NewCachedConnectorService to avoid
+// fetching the catalogs every time.
+func (runtimeConfig *RuntimeConfig) Validate(ctx context.Context, client *ThreatMatrixClient) error {
+	errs := []error{}
+	if len(runtimeConfig.analyzers) > 0 {
+		analyzers, err := client.AnalyzerService.List(ctx)
+		if err != nil {
+			return err
+		}
+		catalog := make(map[string]map[string]Parameter, len(analyzers))
+		for _, analyzer := range analyzers {
+			catalog[analyzer.Name] = analyzer.Params
+		}
+		errs = append(errs, validatePluginParams("analyzer", runtimeConfig.analyzers, catalog)...)
+	}
+	if len(runtimeConfig.connectors) > 0 {
+		connectors, err := client.ConnectorService.List(ctx)
+		if err != nil {
+			return err
+		}
+		catalog := make(map[string]map[string]Parameter, len(connectors))
+		for _, connector := range connectors {
+			catalog[connector.Name] = connector.Params
+		}
+		errs = append(errs, validatePluginParams("connector", runtimeConfig.connectors, catalog)...)
+	}
+	return errors.Join(errs...)
+}
+
+// validatePluginParams checks the plugins and their parameters against the catalog of the given kind of plugin.
+// The parameters are only checked when the catalog lists the ones of the plugin.
+func validatePluginParams(kind string, plugins map[string]map[string]interface{}, catalog map[string]map[string]Parameter) []error {
+	errs := []error{}
+	for _, pluginName := range sortedKeys(plugins) {
+		knownParams, ok := catalog[pluginName]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: unknown %s %q", ErrValidation, kind, pluginName))
+			continue
+		}
+		if len(knownParams) == 0 {
+			continue
+		}
+		for _, param := range sortedKeys(plugins[pluginName]) {
+			if _, ok := knownParams[param]; !ok {
+				errs = append(errs, fmt.Errorf("%w: %s %q has no parameter %q", ErrValidation, kind, pluginName, param))
+			}
+		}
+	}
+	return errs
+}
+
+// sortedKeys returns the keys of m in order, so the errors are reported in a stable order.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
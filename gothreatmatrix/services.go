@@ -0,0 +1,214 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+//go:generate mockgen -source=services.go -destination=mocks/services.go -package=mocks
+
+// TagServiceInterface is the set of tag related methods, implemented by TagService.
+type TagServiceInterface interface {
+	List(ctx context.Context, opts ...RequestOption) (*[]Tag, error)
+	Get(ctx context.Context, tagId uint64, opts ...RequestOption) (*Tag, error)
+	Create(ctx context.Context, tagParams *TagParams, opts ...RequestOption) (*Tag, error)
+	Update(ctx context.Context, tagId uint64, tagParams *TagParams, opts ...RequestOption) (*Tag, error)
+	Delete(ctx context.Context, tagId uint64, opts ...RequestOption) (bool, error)
+	Pager(ctx context.Context, pageSize int, opts ...RequestOption) *Pager[Tag]
+}
+
+// JobServiceInterface is the set of job related methods, implemented by JobService.
+type JobServiceInterface interface {
+	List(ctx context.Context, params *JobListParams, opts ...RequestOption) (*JobListResponse, error)
+	Iter(ctx context.Context, params *JobListParams, opts ...RequestOption) *JobIterator
+	Pager(ctx context.Context, params *JobListParams, opts ...RequestOption) *Pager[JobList]
+	ListAll(ctx context.Context, params *JobListParams, opts ...RequestOption) ([]JobList, error)
+	Stream(ctx context.Context, params *JobListParams, fn func(job *JobList) error, opts ...RequestOption) error
+	Search(ctx context.Context, query *JobSearchQuery, opts ...RequestOption) (*JobListResponse, error)
+	Get(ctx context.Context, jobId uint64, opts ...RequestOption) (*Job, error)
+	RecentScans(ctx context.Context, value string, opts ...RequestOption) ([]RecentScan, error)
+	DownloadSample(ctx context.Context, jobId uint64, opts ...RequestOption) ([]byte, error)
+	DownloadSampleTo(ctx context.Context, jobId uint64, writer io.Writer, opts ...RequestOption) (int64, error)
+	DownloadSampleZipped(ctx context.Context, jobId uint64, password string, writer io.Writer, opts ...RequestOption) error
+	Delete(ctx context.Context, jobId uint64, opts ...RequestOption) (bool, error)
+	Kill(ctx context.Context, jobId uint64, opts ...RequestOption) (bool, error)
+	KillAll(ctx context.Context, filter *JobListParams, opts ...RequestOption) ([]KillResult, error)
+	ApplyRetention(ctx context.Context, policy RetentionPolicy, dryRun bool, opts ...RequestOption) (*RetentionReport, error)
+	KillAnalyzer(ctx context.Context, jobId uint64, analyzerName string, opts ...RequestOption) (bool, error)
+	RetryAnalyzer(ctx context.Context, jobId uint64, analyzerName string, opts ...RequestOption) (bool, error)
+	RetryFailedAnalyzers(ctx context.Context, jobId uint64, opts ...RequestOption) (map[string]RetryResult, error)
+	KillConnector(ctx context.Context, jobId uint64, connectorName string, opts ...RequestOption) (bool, error)
+	RetryConnector(ctx context.Context, jobId uint64, connectorName string, opts ...RequestOption) (bool, error)
+	Watch(ctx context.Context, jobId uint64, opts ...RequestOption) <-chan JobUpdate
+	WaitForCompletion(ctx context.Context, jobId uint64, opts ...RequestOption) (*Job, error)
+	AggregateStatus(ctx context.Context, params *AggregateParams, opts ...RequestOption) (*AggregateResult, error)
+	AggregateType(ctx context.Context, params *AggregateParams, opts ...RequestOption) (*AggregateResult, error)
+	AggregateObservableClassification(ctx context.Context, params *AggregateParams, opts ...RequestOption) (*AggregateResult, error)
+	AggregateFileMimetype(ctx context.Context, params *AggregateParams, opts ...RequestOption) (*AggregateResult, error)
+}
+
+// AnalyzerServiceInterface is the set of analyzer related methods, implemented by AnalyzerService.
+type AnalyzerServiceInterface interface {
+	GetConfigs(ctx context.Context, opts ...RequestOption) (*[]AnalyzerConfig, error)
+	List(ctx context.Context, opts ...RequestOption) ([]AnalyzerConfig, error)
+	Get(ctx context.Context, analyzerName string, opts ...RequestOption) (*AnalyzerConfig, error)
+	HealthCheck(ctx context.Context, analyzerName string, opts ...RequestOption) (HealthStatus, error)
+	Pager(ctx context.Context, pageSize int, opts ...RequestOption) *Pager[AnalyzerConfig]
+}
+
+// ConnectorServiceInterface is the set of connector related methods, implemented by ConnectorService.
+type ConnectorServiceInterface interface {
+	GetConfigs(ctx context.Context, opts ...RequestOption) (*[]ConnectorConfig, error)
+	List(ctx context.Context, opts ...RequestOption) ([]ConnectorConfig, error)
+	Get(ctx context.Context, connectorName string, opts ...RequestOption) (*ConnectorConfig, error)
+	HealthCheck(ctx context.Context, connectorName string, opts ...RequestOption) (HealthStatus, error)
+	HealthCheckAll(ctx context.Context, connectorNames []string, concurrency int, opts ...RequestOption) []HealthCheckResult
+	Pager(ctx context.Context, pageSize int, opts ...RequestOption) *Pager[ConnectorConfig]
+}
+
+// VisualizerServiceInterface is the set of visualizer related methods, implemented by VisualizerService.
+type VisualizerServiceInterface interface {
+	List(ctx context.Context, opts ...RequestOption) ([]VisualizerConfig, error)
+	Get(ctx context.Context, visualizerName string, opts ...RequestOption) (*VisualizerConfig, error)
+	JobReports(ctx context.Context, jobId uint64, opts ...RequestOption) ([]VisualizerReport, error)
+	JobReport(ctx context.Context, jobId uint64, visualizerName string, opts ...RequestOption) (*VisualizerReport, error)
+}
+
+// PivotServiceInterface is the set of pivot related methods, implemented by PivotService.
+type PivotServiceInterface interface {
+	List(ctx context.Context, opts ...RequestOption) ([]PivotConfig, error)
+	Get(ctx context.Context, pivotName string, opts ...RequestOption) (*PivotConfig, error)
+	Children(ctx context.Context, jobId uint64, opts ...RequestOption) ([]Job, error)
+	Tree(ctx context.Context, jobId uint64, maxDepth int, opts ...RequestOption) (*PivotNode, error)
+}
+
+// PluginConfigServiceInterface is the set of plugin config related methods, implemented by PluginConfigService.
+type PluginConfigServiceInterface interface {
+	List(ctx context.Context, opts ...RequestOption) ([]PluginConfig, error)
+	ListForPlugin(ctx context.Context, pluginType PluginType, pluginName string, opts ...RequestOption) ([]PluginConfig, error)
+	Create(ctx context.Context, params []PluginConfigParams, opts ...RequestOption) ([]PluginConfig, error)
+	Update(ctx context.Context, pluginConfigId uint64, value interface{}, opts ...RequestOption) (*PluginConfig, error)
+	Delete(ctx context.Context, pluginConfigId uint64, opts ...RequestOption) (bool, error)
+}
+
+// IngestorServiceInterface is the set of ingestor related methods, implemented by IngestorService.
+type IngestorServiceInterface interface {
+	List(ctx context.Context, opts ...RequestOption) ([]IngestorConfig, error)
+	Get(ctx context.Context, ingestorName string, opts ...RequestOption) (*IngestorConfig, error)
+	Enable(ctx context.Context, ingestorName string, opts ...RequestOption) (bool, error)
+	Disable(ctx context.Context, ingestorName string, opts ...RequestOption) (bool, error)
+	HealthCheck(ctx context.Context, ingestorName string, opts ...RequestOption) (HealthStatus, error)
+}
+
+// InvestigationServiceInterface is the set of investigation related methods, implemented by InvestigationService.
+type InvestigationServiceInterface interface {
+	List(ctx context.Context, opts ...RequestOption) ([]Investigation, error)
+	Get(ctx context.Context, investigationId uint64, opts ...RequestOption) (*Investigation, error)
+	Create(ctx context.Context, investigationParams *InvestigationParams, opts ...RequestOption) (*Investigation, error)
+	AddJob(ctx context.Context, investigationId uint64, jobId uint64, opts ...RequestOption) (bool, error)
+	RemoveJob(ctx context.Context, investigationId uint64, jobId uint64, opts ...RequestOption) (bool, error)
+	Tree(ctx context.Context, investigationId uint64, opts ...RequestOption) (*InvestigationTree, error)
+}
+
+// UserServiceInterface is the set of user related methods, implemented by UserService.
+type UserServiceInterface interface {
+	Access(ctx context.Context, opts ...RequestOption) (*User, error)
+	Organization(ctx context.Context, opts ...RequestOption) (*Organization, error)
+	CreateOrganization(ctx context.Context, organizationParams *OrganizationParams, opts ...RequestOption) (*Organization, error)
+	InviteToOrganization(ctx context.Context, memberParams *MemberParams, opts ...RequestOption) (*Invite, error)
+	RemoveMemberFromOrganization(ctx context.Context, memberParams *MemberParams, opts ...RequestOption) (bool, error)
+	APITokenGet(ctx context.Context, opts ...RequestOption) (*APIToken, error)
+	APITokenCreate(ctx context.Context, opts ...RequestOption) (*APIToken, error)
+	APITokenDelete(ctx context.Context, opts ...RequestOption) (bool, error)
+	Login(ctx context.Context, username string, password string, opts ...RequestOption) (*Session, error)
+	RefreshToken(ctx context.Context, opts ...RequestOption) (*Session, error)
+	Logout(ctx context.Context, opts ...RequestOption) (bool, error)
+}
+
+// AnalyzeServiceInterface is the set of analysis related methods, implemented by AnalyzeService.
+type AnalyzeServiceInterface interface {
+	AnalyzeObservable(ctx context.Context, analysisRequest ObservableAnalysisRequest, opts ...RequestOption) (*AnalysisResponse, error)
+	AnalyzeObservables(ctx context.Context, analysisRequests []ObservableAnalysisRequest, opts ...RequestOption) []ObservableAnalysisResult
+	AnalyzeObservableAndWait(ctx context.Context, analysisRequest ObservableAnalysisRequest, timeout time.Duration, opts ...RequestOption) (*Job, error)
+	AnalyzeFile(ctx context.Context, analysisRequest FileAnalysisRequest, opts ...RequestOption) (*AnalysisResponse, error)
+	AnalyzeFiles(ctx context.Context, analysisRequests []FileAnalysisRequest, opts ...RequestOption) []FileAnalysisResult
+	AnalyzeFileAndWait(ctx context.Context, analysisRequest FileAnalysisRequest, timeout time.Duration, opts ...RequestOption) (*Job, error)
+	CheckAvailability(ctx context.Context, params *AnalysisAvailabilityParams, opts ...RequestOption) (*AnalysisAvailability, error)
+	AnalyzeFileIfNew(ctx context.Context, analysisRequest FileAnalysisRequest, opts ...RequestOption) (*AnalysisResponse, bool, error)
+}
+
+// PlaybookServiceInterface is the set of playbook related methods, implemented by PlaybookService.
+type PlaybookServiceInterface interface {
+	List(ctx context.Context, opts ...RequestOption) ([]PlaybookConfig, error)
+	Get(ctx context.Context, playbookName string, opts ...RequestOption) (*PlaybookConfig, error)
+	Create(ctx context.Context, playbookParams *PlaybookParams, opts ...RequestOption) (*PlaybookConfig, error)
+	Update(ctx context.Context, playbookName string, playbookParams *PlaybookParams, opts ...RequestOption) (*PlaybookConfig, error)
+	Delete(ctx context.Context, playbookName string, opts ...RequestOption) (bool, error)
+	AnalyzeObservable(ctx context.Context, analysisRequest PlaybookObservableAnalysisRequest, opts ...RequestOption) (*AnalysisResponse, error)
+	AnalyzeFile(ctx context.Context, analysisRequest PlaybookFileAnalysisRequest, opts ...RequestOption) (*AnalysisResponse, error)
+}
+
+// OrganizationServiceInterface is the set of organization related methods, implemented by OrganizationService.
+type OrganizationServiceInterface interface {
+	Get(ctx context.Context, opts ...RequestOption) (*Organization, error)
+	Create(ctx context.Context, organizationParams *OrganizationParams, opts ...RequestOption) (*Organization, error)
+	Delete(ctx context.Context, opts ...RequestOption) (bool, error)
+	ListMembers(ctx context.Context, opts ...RequestOption) ([]Member, error)
+	RemoveMember(ctx context.Context, username string, opts ...RequestOption) (bool, error)
+	Leave(ctx context.Context, opts ...RequestOption) (bool, error)
+	DisablePlugin(ctx context.Context, pluginType PluginType, pluginName string, opts ...RequestOption) (bool, error)
+	EnablePlugin(ctx context.Context, pluginType PluginType, pluginName string, opts ...RequestOption) (bool, error)
+}
+
+// InvitationServiceInterface is the set of invitation related methods, implemented by InvitationService.
+type InvitationServiceInterface interface {
+	Invite(ctx context.Context, username string, opts ...RequestOption) (*Invite, error)
+	List(ctx context.Context, opts ...RequestOption) ([]Invitation, error)
+	Accept(ctx context.Context, invitationId uint64, opts ...RequestOption) (bool, error)
+	Decline(ctx context.Context, invitationId uint64, opts ...RequestOption) (bool, error)
+}
+
+// CommentServiceInterface is the set of comment related methods, implemented by CommentService.
+type CommentServiceInterface interface {
+	List(ctx context.Context, jobId uint64, opts ...RequestOption) ([]Comment, error)
+	Create(ctx context.Context, commentParams *CommentParams, opts ...RequestOption) (*Comment, error)
+	Delete(ctx context.Context, commentId uint64, opts ...RequestOption) (bool, error)
+}
+
+// StatsServiceInterface is the set of statistics related methods, implemented by StatsService.
+type StatsServiceInterface interface {
+	Submissions(ctx context.Context, months int, opts ...RequestOption) ([]MonthlySubmissions, error)
+	TopAnalyzers(ctx context.Context, params *StatsParams, opts ...RequestOption) ([]AnalyzerUsage, error)
+	Users(ctx context.Context, params *StatsParams, opts ...RequestOption) ([]UserUsage, error)
+	PluginFailureRates(ctx context.Context, params *StatsParams, opts ...RequestOption) ([]PluginFailureRate, error)
+}
+
+// NotificationServiceInterface is the set of notification related methods, implemented by NotificationService.
+type NotificationServiceInterface interface {
+	List(ctx context.Context, params *NotificationListParams, opts ...RequestOption) ([]Notification, error)
+	MarkRead(ctx context.Context, notificationId uint64, opts ...RequestOption) (bool, error)
+}
+
+// Making sure every service implements its interface.
+var (
+	_ TagServiceInterface           = (*TagService)(nil)
+	_ JobServiceInterface           = (*JobService)(nil)
+	_ AnalyzerServiceInterface      = (*AnalyzerService)(nil)
+	_ ConnectorServiceInterface     = (*ConnectorService)(nil)
+	_ VisualizerServiceInterface    = (*VisualizerService)(nil)
+	_ PivotServiceInterface         = (*PivotService)(nil)
+	_ PluginConfigServiceInterface  = (*PluginConfigService)(nil)
+	_ IngestorServiceInterface      = (*IngestorService)(nil)
+	_ InvestigationServiceInterface = (*InvestigationService)(nil)
+	_ UserServiceInterface          = (*UserService)(nil)
+	_ AnalyzeServiceInterface       = (*AnalyzeService)(nil)
+	_ PlaybookServiceInterface      = (*PlaybookService)(nil)
+	_ OrganizationServiceInterface  = (*OrganizationService)(nil)
+	_ InvitationServiceInterface    = (*InvitationService)(nil)
+	_ CommentServiceInterface       = (*CommentService)(nil)
+	_ StatsServiceInterface         = (*StatsService)(nil)
+	_ NotificationServiceInterface  = (*NotificationService)(nil)
+	_ AnalyzerServiceInterface      = (*CachedAnalyzerService)(nil)
+	_ ConnectorServiceInterface     = (*CachedConnectorService)(nil)
+)
@@ -0,0 +1,245 @@
+package gothreatmatrix
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// tokenRefreshMargin is how long before it expires the access token of a session is refreshed.
+const tokenRefreshMargin = 30 * time.Second
+
+// Session represents the JWTs ThreatMatrix hands out when logging in with a username and a password.
+// While the client has a session its requests are authenticated with AccessToken rather than the API token.
+type Session struct {
+	AccessToken  string `json:"access"`
+	RefreshToken string `json:"refresh"`
+	// ExpiresAt is when AccessToken expires, read from its exp claim, the zero time when it has none.
+	ExpiresAt time.Time `json:"-"`
+}
+
+// LoginParams represents the credentials used to log in to ThreatMatrix.
+type LoginParams struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// sessionAuth holds the session of the client, refreshing it before it expires.
+type sessionAuth struct {
+	mutex   sync.Mutex
+	current *Session
+	// credentials is nil unless they were given through WithCredentials, letting the client log in by itself
+	credentials *LoginParams
+}
+
+// WithCredentials makes the client log in with a username and a password on its first request and authenticate its
+// requests with the session it gets, for deployments where API tokens are disabled. The session is refreshed before
+// it expires, and the client logs in again once it can't be refreshed anymore.
+func WithCredentials(username string, password string) Option {
+	return func(config *clientConfig) {
+		config.credentials = &LoginParams{Username: username, Password: password}
+	}
+}
+
+// Login logs in with a username and a password, the requests that follow being authenticated with the session
+// ThreatMatrix answers with rather than the API token. The session is refreshed before it expires, see RefreshToken.
+//
+//	Endpoint: POST /api/auth/login
+func (userService *UserService) Login(ctx context.Context, username string, password string, opts ...RequestOption) (*Session, error) {
+	ctx = withRedactedBodies(withRequestOptions(ctx, opts))
+	ctx, span := userService.client.startSpan(ctx, "UserService.Login")
+	defer span.End()
+	if strings.TrimSpace(username) == "" || password == "" {
+		return nil, fmt.Errorf("%w: username and password cannot be empty", ErrValidation)
+	}
+	auth := userService.client.session
+	auth.mutex.Lock()
+	defer auth.mutex.Unlock()
+	if err := userService.client.login(ctx, &LoginParams{Username: username, Password: password}); err != nil {
+		return nil, err
+	}
+	session := *auth.current
+	return &session, nil
+}
+
+// RefreshToken exchanges the refresh token of the session for a new access token right away, the client already
+// doing so by itself before the access token expires.
+//
+//	Endpoint: POST /api/auth/refresh
+func (userService *UserService) RefreshToken(ctx context.Context, opts ...RequestOption) (*Session, error) {
+	ctx = withRedactedBodies(withRequestOptions(ctx, opts))
+	ctx, span := userService.client.startSpan(ctx, "UserService.RefreshToken")
+	defer span.End()
+	auth := userService.client.session
+	auth.mutex.Lock()
+	defer auth.mutex.Unlock()
+	if auth.current == nil {
+		return nil, fmt.Errorf("%w: there is no session to refresh, log in first", ErrValidation)
+	}
+	if err := userService.client.refresh(ctx); err != nil {
+		return nil, err
+	}
+	session := *auth.current
+	return &session, nil
+}
+
+// Logout ends the session, blacklisting its refresh token, the requests that follow being authenticated with the
+// API token again. The session is dropped even when ThreatMatrix fails to end it.
+//
+//	Endpoint: POST /api/auth/logout
+func (userService *UserService) Logout(ctx context.Context, opts ...RequestOption) (bool, error) {
+	ctx = withRedactedBodies(withRequestOptions(ctx, opts))
+	ctx, span := userService.client.startSpan(ctx, "UserService.Logout")
+	defer span.End()
+	auth := userService.client.session
+	auth.mutex.Lock()
+	defer auth.mutex.Unlock()
+	if auth.current == nil {
+		return false, fmt.Errorf("%w: there is no session to end", ErrValidation)
+	}
+	session := auth.current
+	auth.current = nil
+	// the client doesn't log in again by itself once logged out
+	auth.credentials = nil
+	params := map[string]string{"refresh": session.RefreshToken}
+	successResp, err := userService.client.sendSession(ctx, constants.LOGOUT_URL, params, session.AccessToken, nil)
+	if err != nil {
+		return false, err
+	}
+	return successResp.StatusCode == http.StatusOK || successResp.StatusCode == http.StatusNoContent || successResp.StatusCode == http.StatusResetContent, nil
+}
+
+// authorization returns the value of the Authorization header of the requests: the access token of the session,
+// logged in or refreshed first when needed, or the API token when there's no session.
+func (client *ThreatMatrixClient) authorization(ctx context.Context) (string, error) {
+	auth := client.session
+	auth.mutex.Lock()
+	if auth.current == nil && auth.credentials == nil {
+		// the lock isn't held while the token provider runs, it may be slow
+		auth.mutex.Unlock()
+		return client.tokenAuthorization(ctx)
+	}
+	defer auth.mutex.Unlock()
+	switch {
+	case auth.current == nil:
+		if err := client.login(withRedactedBodies(ctx), auth.credentials); err != nil {
+			return "", err
+		}
+	case !auth.current.ExpiresAt.IsZero() && time.Until(auth.current.ExpiresAt) < tokenRefreshMargin:
+		err := client.refresh(withRedactedBodies(ctx))
+		if errors.Is(err, ErrUnauthorized) && auth.credentials != nil {
+			// the refresh token expired as well
+			err = client.login(withRedactedBodies(ctx), auth.credentials)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "Bearer " + auth.current.AccessToken, nil
+}
+
+// tokenAuthorization returns the value of the Authorization header of the requests authenticated with the API token,
+// resolved through the TokenProvider of the client when it has one.
+func (client *ThreatMatrixClient) tokenAuthorization(ctx context.Context) (string, error) {
+	if client.tokenProvider == nil {
+		return "token " + client.options.Token, nil
+	}
+	token, err := client.tokenProvider(ctx)
+	if err != nil {
+		return "", fmt.Errorf("threatmatrix: resolving the API token: %w", err)
+	}
+	return "token " + token, nil
+}
+
+// login logs in with the credentials and keeps the session, the caller holding the lock of the session.
+func (client *ThreatMatrixClient) login(ctx context.Context, credentials *LoginParams) error {
+	session := Session{}
+	if _, err := client.sendSession(ctx, constants.LOGIN_URL, credentials, "", &session); err != nil {
+		return err
+	}
+	if session.AccessToken == "" {
+		return errors.New("threatmatrix: the login answer holds no access token")
+	}
+	session.ExpiresAt = tokenExpiry(session.AccessToken)
+	client.session.current = &session
+	return nil
+}
+
+// refresh exchanges the refresh token of the session for a new access token, the caller holding the lock of the session.
+func (client *ThreatMatrixClient) refresh(ctx context.Context) error {
+	current := client.session.current
+	refreshed := Session{}
+	params := map[string]string{"refresh": current.RefreshToken}
+	if _, err := client.sendSession(ctx, constants.REFRESH_TOKEN_URL, params, "", &refreshed); err != nil {
+		return err
+	}
+	if refreshed.AccessToken == "" {
+		return errors.New("threatmatrix: the refresh answer holds no access token")
+	}
+	if refreshed.RefreshToken == "" {
+		// the refresh token is only sent back when ThreatMatrix rotates it
+		refreshed.RefreshToken = current.RefreshToken
+	}
+	refreshed.ExpiresAt = tokenExpiry(refreshed.AccessToken)
+	client.session.current = &refreshed
+	return nil
+}
+
+// sendSession sends params to one of the session endpoints, authenticated with accessToken unless it's empty,
+// decoding the answer into result unless it's nil. Unlike buildRequest it never asks for the Authorization header,
+// which would wait on the lock of the session the caller holds.
+func (client *ThreatMatrixClient) sendSession(ctx context.Context, route string, params interface{}, accessToken string, result interface{}) (*successResponse, error) {
+	jsonData, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequestWithContext(ctx, "POST", client.options.Url+route, bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", client.userAgent)
+	if accessToken != "" {
+		request.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	applyRequestOptions(request)
+	successResp, err := client.newRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if result != nil && len(successResp.Data) > 0 {
+		if unmarshalError := json.Unmarshal(successResp.Data, result); unmarshalError != nil {
+			return nil, unmarshalError
+		}
+	}
+	return successResp, nil
+}
+
+// tokenExpiry reads the exp claim of a JWT without checking its signature, which is up to ThreatMatrix.
+// It returns the zero time when the token isn't a JWT or has no exp claim.
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+	claims := struct {
+		Expiry *Timestamp `json:"exp"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Expiry == nil {
+		return time.Time{}
+	}
+	return claims.Expiry.Time
+}
@@ -0,0 +1,163 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// StatsParams represents the time range and the number of entries of the statistics of your organization.
+// Range takes the same values as AggregateParams.Range, left empty to let ThreatMatrix use its default of a month,
+// and Limit left to 0 returns every entry.
+type StatsParams struct {
+	Range string
+	Limit int
+}
+
+// values encodes the StatsParams as URL query parameters.
+func (params *StatsParams) values() url.Values {
+	query := url.Values{}
+	if params == nil {
+		return query
+	}
+	if params.Range != "" {
+		query.Set("range", params.Range)
+	}
+	if params.Limit > 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	return query
+}
+
+// MonthlySubmissions represents how many analyses your organization submitted during a month.
+type MonthlySubmissions struct {
+	// Month is the first day of the month.
+	Month       Timestamp `json:"month"`
+	Observables int       `json:"observables"`
+	Files       int       `json:"files"`
+	Total       int       `json:"total"`
+}
+
+// AnalyzerUsage represents how many times an analyzer ran for your organization, and how many of these runs failed.
+type AnalyzerUsage struct {
+	Name     string `json:"name"`
+	Runs     int    `json:"runs"`
+	Failures int    `json:"failures"`
+}
+
+// UserUsage represents how many analyses a member of your organization submitted, against their monthly quota.
+type UserUsage struct {
+	Username              string `json:"username"`
+	Submissions           int    `json:"submissions"`
+	ObservableSubmissions int    `json:"observable_submissions"`
+	FileSubmissions       int    `json:"file_submissions"`
+	MonthSubmissions      int    `json:"month_submissions"`
+	// MonthQuota is how many analyses the user can submit in a month, nil when it's unlimited.
+	MonthQuota *int `json:"month_quota"`
+}
+
+// QuotaLeft returns how many analyses the user can still submit this month, and false when their quota is unlimited.
+func (usage *UserUsage) QuotaLeft() (int, bool) {
+	if usage.MonthQuota == nil {
+		return 0, false
+	}
+	if left := *usage.MonthQuota - usage.MonthSubmissions; left > 0 {
+		return left, true
+	}
+	return 0, true
+}
+
+// PluginFailureRate represents how often a plugin failed for your organization.
+type PluginFailureRate struct {
+	Name     string     `json:"name"`
+	Type     PluginType `json:"type"`
+	Runs     int        `json:"runs"`
+	Failures int        `json:"failures"`
+}
+
+// Rate returns the share of the runs of the plugin that failed, from 0 to 1, 0 when it never ran.
+func (failureRate *PluginFailureRate) Rate() float64 {
+	if failureRate.Runs == 0 {
+		return 0
+	}
+	return float64(failureRate.Failures) / float64(failureRate.Runs)
+}
+
+// StatsService handles communication with the statistics of the usage of ThreatMatrix by your organization.
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/stats
+type StatsService struct {
+	client *ThreatMatrixClient
+}
+
+// Submissions fetches how many analyses your organization submitted during each of the last months, the current
+// one included, from the oldest to the newest. months left to 0 lets ThreatMatrix use its default of a year.
+//
+//	Endpoint: GET /api/stats/submissions
+func (statsService *StatsService) Submissions(ctx context.Context, months int, opts ...RequestOption) ([]MonthlySubmissions, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := statsService.client.startSpan(ctx, "StatsService.Submissions")
+	defer span.End()
+	route := constants.SUBMISSIONS_STATS_URL
+	if months > 0 {
+		route += "?" + url.Values{"months": {strconv.Itoa(months)}}.Encode()
+	}
+	submissions := []MonthlySubmissions{}
+	if _, err := statsService.client.sendJSON(ctx, "GET", route, nil, &submissions); err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
+
+// TopAnalyzers fetches the analyzers that ran the most for your organization, the most used first.
+//
+//	Endpoint: GET /api/stats/top_analyzers
+func (statsService *StatsService) TopAnalyzers(ctx context.Context, params *StatsParams, opts ...RequestOption) ([]AnalyzerUsage, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := statsService.client.startSpan(ctx, "StatsService.TopAnalyzers")
+	defer span.End()
+	analyzers := []AnalyzerUsage{}
+	if err := statsService.fetch(ctx, constants.TOP_ANALYZERS_STATS_URL, params, &analyzers); err != nil {
+		return nil, err
+	}
+	return analyzers, nil
+}
+
+// Users fetches how many analyses every member of your organization submitted, the most active first.
+//
+//	Endpoint: GET /api/stats/users
+func (statsService *StatsService) Users(ctx context.Context, params *StatsParams, opts ...RequestOption) ([]UserUsage, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := statsService.client.startSpan(ctx, "StatsService.Users")
+	defer span.End()
+	users := []UserUsage{}
+	if err := statsService.fetch(ctx, constants.USERS_STATS_URL, params, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// PluginFailureRates fetches how often every plugin that ran for your organization failed, the most failing first.
+//
+//	Endpoint: GET /api/stats/plugin_failures
+func (statsService *StatsService) PluginFailureRates(ctx context.Context, params *StatsParams, opts ...RequestOption) ([]PluginFailureRate, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := statsService.client.startSpan(ctx, "StatsService.PluginFailureRates")
+	defer span.End()
+	failureRates := []PluginFailureRate{}
+	if err := statsService.fetch(ctx, constants.PLUGIN_FAILURES_STATS_URL, params, &failureRates); err != nil {
+		return nil, err
+	}
+	return failureRates, nil
+}
+
+// fetch decodes the statistics of the given route, filtered by params, into result.
+func (statsService *StatsService) fetch(ctx context.Context, route string, params *StatsParams, result interface{}) error {
+	if query := params.values(); len(query) > 0 {
+		route += "?" + query.Encode()
+	}
+	_, err := statsService.client.sendJSON(ctx, "GET", route, nil, result)
+	return err
+}
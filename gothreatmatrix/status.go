@@ -0,0 +1,47 @@
+package gothreatmatrix
+
+// JobStatus represents the processing status of a job in ThreatMatrix.
+type JobStatus string
+
+// Values of the JobStatus enum.
+const (
+	StatusPending              JobStatus = "pending"
+	StatusRunning              JobStatus = "running"
+	StatusAnalyzersRunning     JobStatus = "analyzers_running"
+	StatusConnectorsRunning    JobStatus = "connectors_running"
+	StatusVisualizersRunning   JobStatus = "visualizers_running"
+	StatusAnalyzersCompleted   JobStatus = "analyzers_completed"
+	StatusConnectorsCompleted  JobStatus = "connectors_completed"
+	StatusVisualizersCompleted JobStatus = "visualizers_completed"
+	StatusReportedWithoutFails JobStatus = "reported_without_fails"
+	StatusReportedWithFails    JobStatus = "reported_with_fails"
+	StatusKilled               JobStatus = "killed"
+	StatusFailed               JobStatus = "failed"
+)
+
+// jobStatuses tells for every known job status whether a job with that status doesn't change anymore.
+var jobStatuses = map[JobStatus]bool{
+	StatusPending:              false,
+	StatusRunning:              false,
+	StatusAnalyzersRunning:     false,
+	StatusConnectorsRunning:    false,
+	StatusVisualizersRunning:   false,
+	StatusAnalyzersCompleted:   false,
+	StatusConnectorsCompleted:  false,
+	StatusVisualizersCompleted: false,
+	StatusReportedWithoutFails: true,
+	StatusReportedWithFails:    true,
+	StatusKilled:               true,
+	StatusFailed:               true,
+}
+
+// IsTerminal checks if a job with this status is done being processed.
+func (status JobStatus) IsTerminal() bool {
+	return jobStatuses[status]
+}
+
+// Valid checks if the status is one of the values known by ThreatMatrix.
+func (status JobStatus) Valid() bool {
+	_, ok := jobStatuses[status]
+	return ok
+}
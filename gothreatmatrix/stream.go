@@ -0,0 +1,192 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// headBuffer keeps the first bytes written to it, so the head of a streamed body can still be logged.
+type headBuffer struct {
+	data  []byte
+	limit int
+}
+
+// Write keeps what fits below the limit and discards the rest, never failing.
+func (buffer *headBuffer) Write(p []byte) (int, error) {
+	if room := buffer.limit - len(buffer.data); room > 0 {
+		if len(p) < room {
+			room = len(p)
+		}
+		buffer.data = append(buffer.data, p[:room]...)
+	}
+	return len(p), nil
+}
+
+// decodeRequest sends the request and hands the body of a successful response to decode as it's read, without buffering it.
+// Error responses are read and returned as a ThreatMatrixError, the same way newRequest does.
+func (client *ThreatMatrixClient) decodeRequest(ctx context.Context, request *http.Request, decode func(body io.Reader) error) error {
+	start := time.Now()
+	response, err := client.do(ctx, request)
+	if err != nil {
+		client.logRequest(ctx, request, 0, nil, time.Since(start), err)
+		traceError(ctx, err)
+		return err
+	}
+
+	defer response.Body.Close()
+
+	statusCode := response.StatusCode
+	if statusCode < http.StatusOK || statusCode >= http.StatusBadRequest {
+		msgBytes, readError := io.ReadAll(response.Body)
+		client.logRequest(ctx, request, statusCode, msgBytes, time.Since(start), readError)
+		threatMatrixError := newThreatMatrixError(statusCode, string(msgBytes), response)
+		traceError(ctx, threatMatrixError)
+		return threatMatrixError
+	}
+
+	// one byte more than what's logged so the logged body is marked as truncated
+	head := &headBuffer{limit: maxLoggedBodySize + 1}
+	err = decode(io.TeeReader(response.Body, head))
+	client.logRequest(ctx, request, statusCode, head.data, time.Since(start), err)
+	if err != nil {
+		traceError(ctx, err)
+		return err
+	}
+	return nil
+}
+
+// decodeJSON sends the request and decodes the JSON body of a successful response into result while it's read.
+func (client *ThreatMatrixClient) decodeJSON(ctx context.Context, request *http.Request, result interface{}) error {
+	return client.decodeRequest(ctx, request, func(body io.Reader) error {
+		return json.NewDecoder(body).Decode(result)
+	})
+}
+
+// Stream walks through every page of jobs matching params, starting from params.Page (or the first page), and calls fn
+// with each job as soon as it's decoded, so the jobs of a page are never held in memory together.
+// It stops at the first error fn returns, which is returned as is.
+//
+//	err := client.JobService.Stream(ctx, &gothreatmatrix.JobListParams{PageSize: 1000}, func(job *gothreatmatrix.JobList) error {
+//		return csvWriter.Write([]string{strconv.Itoa(job.ID), job.ObservableName})
+//	})
+//
+//	Endpoint: GET /api/jobs
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/jobs/operation/jobs_list
+func (jobService *JobService) Stream(ctx context.Context, params *JobListParams, fn func(job *JobList) error, opts ...RequestOption) error {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.Stream")
+	defer span.End()
+	pageParams := JobListParams{}
+	if params != nil {
+		pageParams = *params
+	}
+	if pageParams.Page < 1 {
+		pageParams.Page = 1
+	}
+	seen := 0
+	for {
+		requestUrl := jobService.client.options.Url + constants.BASE_JOB_URL + "?" + pageParams.values().Encode()
+		request, err := jobService.client.buildRequest(ctx, "GET", "application/json", nil, requestUrl)
+		if err != nil {
+			return err
+		}
+		page := JobListResponse{}
+		pageJobs := 0
+		var fnError error
+		err = jobService.client.decodeRequest(ctx, request, func(body io.Reader) error {
+			return decodeJobPage(body, &page, func(job *JobList) error {
+				pageJobs++
+				fnError = fn(job)
+				return fnError
+			})
+		})
+		if fnError != nil {
+			return fnError
+		}
+		if err != nil {
+			return err
+		}
+		seen += pageJobs
+		// without a number of pages, the count of results tells when the last one was reached
+		lastPage := pageParams.Page >= page.TotalPages
+		if page.TotalPages == 0 {
+			lastPage = seen >= page.Count
+		}
+		if pageJobs == 0 || lastPage {
+			return nil
+		}
+		pageParams.Page++
+	}
+}
+
+// decodeJobPage decodes a page of jobs token by token, filling the count and number of pages of page
+// and handing each job to yield instead of keeping it in page.Results.
+func decodeJobPage(body io.Reader, page *JobListResponse, yield func(job *JobList) error) error {
+	decoder := json.NewDecoder(body)
+	if err := expectDelimiter(decoder, '{'); err != nil {
+		return err
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case "count":
+			err = decoder.Decode(&page.Count)
+		case "total_pages":
+			err = decoder.Decode(&page.TotalPages)
+		case "results":
+			err = decodeJobs(decoder, yield)
+		default:
+			err = decoder.Decode(&json.RawMessage{})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return expectDelimiter(decoder, '}')
+}
+
+// decodeJobs decodes the array of jobs the decoder is at, handing each job to yield.
+func decodeJobs(decoder *json.Decoder, yield func(job *JobList) error) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if token != json.Delim('[') {
+		return fmt.Errorf("threatmatrix: expected an array of jobs, got %v", token)
+	}
+	for decoder.More() {
+		job := JobList{}
+		if err := decoder.Decode(&job); err != nil {
+			return err
+		}
+		if err := yield(&job); err != nil {
+			return err
+		}
+	}
+	return expectDelimiter(decoder, ']')
+}
+
+// expectDelimiter reads the next token, failing unless it's the given delimiter.
+func expectDelimiter(decoder *json.Decoder, delimiter json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delimiter {
+		return fmt.Errorf("threatmatrix: expected %v in the JSON response, got %v", delimiter, token)
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// DefaultSubmitterAttempts is how many times a Submitter tries to submit an analysis by default, the first attempt included.
+const DefaultSubmitterAttempts = 3
+
+// DefaultSubmitterRetryDelay is the base delay of the exponential backoff between the attempts of a Submitter.
+const DefaultSubmitterRetryDelay = time.Second
+
+// Submission is an analysis to submit through a Submitter, either Observable or File is set.
+// A File is only retried when its reader is an io.Seeker, it's then rewound before every new attempt.
+type Submission struct {
+	Observable *ObservableAnalysisRequest
+	File       *FileAnalysisRequest
+	// IdempotencyKey is the key every attempt carries, see WithIdempotencyKey, a random one being used when it's empty.
+	IdempotencyKey string
+}
+
+// SubmissionResult is the outcome of one of the analyses submitted through a Submitter.
+// Either Response or Err is set, Attempts is how many times the analysis was sent.
+// Deduplicated is true when the analysis wasn't sent because the same one was submitted during the dedupe window,
+// Response then being the analysis created back then.
+type SubmissionResult struct {
+	Submission   Submission
+	Response     *AnalysisResponse
+	Err          error
+	Attempts     int
+	Deduplicated bool
+}
+
+// SubmitterOptions represents how a Submitter sends the analyses.
+//
+// Concurrency defaults to DefaultBulkConcurrency, MaxAttempts to DefaultSubmitterAttempts and
+// RetryDelay to DefaultSubmitterRetryDelay. RequestsPerSecond of 0 doesn't limit the submission rate,
+// on top of any limit set on the client itself through WithRateLimit.
+//
+// DedupeWindow of 0 submits every analysis. Otherwise an observable, or a file with the same SHA-256, submitted again
+// within DedupeWindow of its last successful submission isn't sent, its result holding the analysis already created,
+// which keeps noisy feeds from burning the quota. Files are only deduplicated when their reader is an io.Seeker.
+type SubmitterOptions struct {
+	Concurrency       int
+	RequestsPerSecond float64
+	Burst             int
+	MaxAttempts       int
+	RetryDelay        time.Duration
+	DedupeWindow      time.Duration
+}
+
+// Submitter submits many analyses concurrently, such as the observables of a feed, and emits the outcome of each of them.
+// Analyses failing because of rate limiting, a server error or a network error are retried with exponential backoff.
+//
+//	submitter := gothreatmatrix.NewSubmitter(client, &gothreatmatrix.SubmitterOptions{Concurrency: 8, RequestsPerSecond: 5})
+//	for result := range submitter.SubmitObservables(ctx, requests) {
+//		...
+//	}
+type Submitter struct {
+	client      *ThreatMatrixClient
+	concurrency int
+	limiter     *rateLimiter
+	retry       retryPolicy
+	// dedupe is nil unless SubmitterOptions.DedupeWindow is set
+	dedupe *dedupeCache
+}
+
+// NewSubmitter returns a Submitter sending analyses through client, options can be nil to use the defaults.
+func NewSubmitter(client *ThreatMatrixClient, options *SubmitterOptions) *Submitter {
+	if options == nil {
+		options = &SubmitterOptions{}
+	}
+	submitter := &Submitter{
+		client:      client,
+		concurrency: options.Concurrency,
+		retry:       retryPolicy{maxAttempts: options.MaxAttempts, baseDelay: options.RetryDelay},
+	}
+	if submitter.concurrency < 1 {
+		submitter.concurrency = DefaultBulkConcurrency
+	}
+	if submitter.retry.maxAttempts < 1 {
+		submitter.retry.maxAttempts = DefaultSubmitterAttempts
+	}
+	if submitter.retry.baseDelay <= 0 {
+		submitter.retry.baseDelay = DefaultSubmitterRetryDelay
+	}
+	if options.RequestsPerSecond > 0 {
+		config := clientConfig{}
+		WithRateLimit(options.RequestsPerSecond, options.Burst)(&config)
+		submitter.limiter = config.limiter
+	}
+	if options.DedupeWindow > 0 {
+		submitter.dedupe = newDedupeCache(options.DedupeWindow)
+	}
+	return submitter
+}
+
+// Submit submits every analysis received on submissions until it's closed, and emits their outcome on the returned channel.
+// Results are emitted as soon as they're known, so not in the order of the submissions, and the channel is closed once
+// submissions is closed and every analysis is done. When ctx is done the remaining submissions are left unread.
+// The returned channel must be drained.
+func (submitter *Submitter) Submit(ctx context.Context, submissions <-chan Submission) <-chan SubmissionResult {
+	results := make(chan SubmissionResult, submitter.concurrency)
+	waitGroup := sync.WaitGroup{}
+	for worker := 0; worker < submitter.concurrency; worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case submission, ok := <-submissions:
+					if !ok {
+						return
+					}
+					results <- submitter.submit(ctx, submission)
+				}
+			}
+		}()
+	}
+	go func() {
+		waitGroup.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// SubmitObservables submits every observable through Submit.
+func (submitter *Submitter) SubmitObservables(ctx context.Context, analysisRequests []ObservableAnalysisRequest) <-chan SubmissionResult {
+	submissions := make([]Submission, len(analysisRequests))
+	for index := range analysisRequests {
+		submissions[index] = Submission{Observable: &analysisRequests[index]}
+	}
+	return submitter.Submit(ctx, sliceChannel(ctx, submissions))
+}
+
+// SubmitFiles submits every file through Submit.
+func (submitter *Submitter) SubmitFiles(ctx context.Context, analysisRequests []FileAnalysisRequest) <-chan SubmissionResult {
+	submissions := make([]Submission, len(analysisRequests))
+	for index := range analysisRequests {
+		submissions[index] = Submission{File: &analysisRequests[index]}
+	}
+	return submitter.Submit(ctx, sliceChannel(ctx, submissions))
+}
+
+// submit sends a single analysis unless it's deduplicated.
+func (submitter *Submitter) submit(ctx context.Context, submission Submission) SubmissionResult {
+	if (submission.Observable == nil) == (submission.File == nil) {
+		return SubmissionResult{Submission: submission, Err: fmt.Errorf("%w: a submission needs either an observable or a file", ErrValidation)}
+	}
+	if submitter.dedupe == nil {
+		return submitter.send(ctx, submission)
+	}
+	return submitter.deduplicate(ctx, submission)
+}
+
+// send sends a single analysis, retrying it for as long as it fails with a retryable error.
+func (submitter *Submitter) send(ctx context.Context, submission Submission) SubmissionResult {
+	result := SubmissionResult{Submission: submission}
+	fileStart := int64(-1)
+	if submission.File != nil {
+		if seeker, ok := submission.File.File.(io.Seeker); ok {
+			if offset, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+				fileStart = offset
+			}
+		}
+	}
+	// every attempt carries the same key so a submission that timed out after being created isn't created again
+	key := submission.IdempotencyKey
+	if key == "" {
+		key = NewIdempotencyKey()
+	}
+	idempotencyKey := WithIdempotencyKey(key)
+	for {
+		if result.Attempts > 0 {
+			if err := sleepContext(ctx, submitter.retry.backoff(result.Attempts)); err != nil {
+				return result
+			}
+			if submission.File != nil {
+				if _, err := submission.File.File.(io.Seeker).Seek(fileStart, io.SeekStart); err != nil {
+					return result
+				}
+			}
+		}
+		if submitter.limiter != nil {
+			if err := submitter.limiter.wait(ctx); err != nil {
+				if result.Err == nil {
+					result.Err = err
+				}
+				return result
+			}
+		}
+		result.Attempts++
+		if submission.Observable != nil {
+			result.Response, result.Err = submitter.client.AnalyzeService.AnalyzeObservable(ctx, *submission.Observable, idempotencyKey)
+		} else {
+			result.Response, result.Err = submitter.client.AnalyzeService.AnalyzeFile(ctx, *submission.File, idempotencyKey)
+		}
+		retryable := result.Err != nil && (submission.File == nil || fileStart >= 0) && isRetryableSubmissionError(result.Err)
+		if !retryable || result.Attempts >= submitter.retry.maxAttempts {
+			return result
+		}
+	}
+}
+
+// isRetryableSubmissionError checks if an analysis that failed with err is worth another attempt.
+func isRetryableSubmissionError(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServer) || isRetryableError(err)
+}
+
+// sliceChannel sends every item of items on the returned channel, closing it once they're all sent or ctx is done.
+func sliceChannel[T any](ctx context.Context, items []T) <-chan T {
+	channel := make(chan T)
+	go func() {
+		defer close(channel)
+		for _, item := range items {
+			select {
+			case <-ctx.Done():
+				return
+			case channel <- item:
+			}
+		}
+	}()
+	return channel
+}
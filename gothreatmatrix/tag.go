@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/khulnasoft/go-threatmatrix/constants"
 )
@@ -36,7 +38,30 @@ func checkTagID(id uint64) error {
 	if id > 0 {
 		return nil
 	}
-	return errors.New("Tag ID cannot be 0")
+	return fmt.Errorf("%w: Tag ID cannot be 0", ErrValidation)
+}
+
+// maxTagLabelLength is the longest label ThreatMatrix accepts for a tag.
+const maxTagLabelLength = 50
+
+// tagColorPattern matches the hex colors ThreatMatrix accepts for a tag, with 6 digits like "#ffb703" or 3 like "#fb0".
+var tagColorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}){1,2}$`)
+
+// checkTagParams is used to check that a tag has a label and a hex color ThreatMatrix will accept.
+func checkTagParams(tagParams *TagParams) error {
+	if tagParams == nil {
+		return fmt.Errorf("%w: tag params cannot be nil", ErrValidation)
+	}
+	if strings.TrimSpace(tagParams.Label) == "" {
+		return fmt.Errorf("%w: tag label cannot be empty", ErrValidation)
+	}
+	if utf8.RuneCountInString(tagParams.Label) > maxTagLabelLength {
+		return fmt.Errorf("%w: tag label cannot be longer than %d characters", ErrValidation, maxTagLabelLength)
+	}
+	if !tagColorPattern.MatchString(tagParams.Color) {
+		return fmt.Errorf("%w: tag color %q is not a hex color like #ffb703", ErrValidation, tagParams.Color)
+	}
+	return nil
 }
 
 // List fetches all the working tags in ThreatMatrix.
@@ -44,7 +69,10 @@ func checkTagID(id uint64) error {
 //	Endpoint: GET "/api/tags"
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/tags/operation/tags_list
-func (tagService *TagService) List(ctx context.Context) (*[]Tag, error) {
+func (tagService *TagService) List(ctx context.Context, opts ...RequestOption) (*[]Tag, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := tagService.client.startSpan(ctx, "TagService.List")
+	defer span.End()
 	requestUrl := tagService.client.options.Url + constants.BASE_TAG_URL
 	contentType := "application/json"
 	method := "GET"
@@ -70,7 +98,10 @@ func (tagService *TagService) List(ctx context.Context) (*[]Tag, error) {
 //	Endpoint: GET "/api/tags/{id}"
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/tags/operation/tags_retrieve
-func (tagService *TagService) Get(ctx context.Context, tagId uint64) (*Tag, error) {
+func (tagService *TagService) Get(ctx context.Context, tagId uint64, opts ...RequestOption) (*Tag, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := tagService.client.startSpan(ctx, "TagService.Get")
+	defer span.End()
 	if err := checkTagID(tagId); err != nil {
 		return nil, err
 	}
@@ -99,7 +130,13 @@ func (tagService *TagService) Get(ctx context.Context, tagId uint64) (*Tag, erro
 //	Endpoint: POST "/api/tags/"
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/tags/operation/tags_create
-func (tagService *TagService) Create(ctx context.Context, tagParams *TagParams) (*Tag, error) {
+func (tagService *TagService) Create(ctx context.Context, tagParams *TagParams, opts ...RequestOption) (*Tag, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := tagService.client.startSpan(ctx, "TagService.Create")
+	defer span.End()
+	if err := checkTagParams(tagParams); err != nil {
+		return nil, err
+	}
 	requestUrl := tagService.client.options.Url + constants.BASE_TAG_URL
 	tagJson, err := json.Marshal(tagParams)
 	if err != nil {
@@ -129,7 +166,16 @@ func (tagService *TagService) Create(ctx context.Context, tagParams *TagParams)
 //	Endpoint: PUT "/api/tags/{id}"
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/tags/operation/tags_update
-func (tagService *TagService) Update(ctx context.Context, tagId uint64, tagParams *TagParams) (*Tag, error) {
+func (tagService *TagService) Update(ctx context.Context, tagId uint64, tagParams *TagParams, opts ...RequestOption) (*Tag, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := tagService.client.startSpan(ctx, "TagService.Update")
+	defer span.End()
+	if err := checkTagID(tagId); err != nil {
+		return nil, err
+	}
+	if err := checkTagParams(tagParams); err != nil {
+		return nil, err
+	}
 	route := tagService.client.options.Url + constants.SPECIFIC_TAG_URL
 	requestUrl := fmt.Sprintf(route, tagId)
 	// Getting the relevant JSON data
@@ -162,7 +208,10 @@ func (tagService *TagService) Update(ctx context.Context, tagId uint64, tagParam
 //	Endpoint: DELETE "/api/tags/{id}"
 //
 // ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/tags/operation/tags_destroy
-func (tagService *TagService) Delete(ctx context.Context, tagId uint64) (bool, error) {
+func (tagService *TagService) Delete(ctx context.Context, tagId uint64, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := tagService.client.startSpan(ctx, "TagService.Delete")
+	defer span.End()
 	if err := checkTagID(tagId); err != nil {
 		return false, err
 	}
@@ -0,0 +1,79 @@
+package gothreatmatrix
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a time ThreatMatrix sends in whatever format the analyzer or the version of ThreatMatrix that
+// wrote it picked: RFC 3339, a date and time without a timezone, which is taken as UTC, or a Unix epoch in
+// seconds, possibly with a fraction, sent as a number or a string.
+//
+// Decoding a Timestamp never fails: a value in none of these formats leaves it as the zero time, so a change
+// of format doesn't fail decoding the whole job. It encodes to JSON as RFC 3339, like a time.Time.
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp returns a pointer to a Timestamp of t, handy to fill the optional times of a job.
+func NewTimestamp(t time.Time) *Timestamp {
+	return &Timestamp{Time: t}
+}
+
+// timestampLayouts are the layouts a Timestamp is parsed with, in order.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// epochMillisecondsThreshold is the epoch above which a Timestamp is taken as milliseconds rather than seconds,
+// seconds this large being past the year 5000.
+const epochMillisecondsThreshold = 1e11
+
+// UnmarshalJSON lets you decode a Timestamp from any of the formats ThreatMatrix sends.
+func (timestamp *Timestamp) UnmarshalJSON(data []byte) error {
+	timestamp.Time = time.Time{}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if data[0] != '"' {
+		if epoch, err := strconv.ParseFloat(string(data), 64); err == nil {
+			timestamp.Time = parseEpoch(epoch)
+		}
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil || value == "" {
+		return nil
+	}
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			timestamp.Time = parsed
+			return nil
+		}
+	}
+	if epoch, err := strconv.ParseFloat(value, 64); err == nil {
+		timestamp.Time = parseEpoch(epoch)
+	}
+	return nil
+}
+
+// parseEpoch converts a Unix epoch in seconds, or in milliseconds when it's too large to be seconds, to UTC.
+func parseEpoch(epoch float64) time.Time {
+	if math.IsNaN(epoch) || math.IsInf(epoch, 0) {
+		return time.Time{}
+	}
+	if math.Abs(epoch) >= epochMillisecondsThreshold {
+		epoch /= 1000
+	}
+	seconds, fraction := math.Modf(epoch)
+	return time.Unix(int64(seconds), int64(math.Round(fraction*1e9))).UTC()
+}
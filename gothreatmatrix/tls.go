@@ -0,0 +1,93 @@
+package gothreatmatrix
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// WithTLSConfig sets the TLS configuration of the default http.Client, a copy of tlsConfig being kept.
+// It replaces whatever TLS setting was given before it, so give it before WithClientCertificate and WithRootCAs.
+// Like every TLS option, it's ignored when WithHTTPClient is given: configure the transport of your http.Client instead.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(config *clientConfig) {
+		if tlsConfig == nil {
+			config.tlsConfig = nil
+			return
+		}
+		config.tlsConfig = tlsConfig.Clone()
+	}
+}
+
+// WithClientCertificate makes the client present certificate to ThreatMatrix, as required by instances behind mTLS enforcing proxies.
+// Use tls.LoadX509KeyPair to load it from PEM files.
+func WithClientCertificate(certificate tls.Certificate) Option {
+	return func(config *clientConfig) {
+		config.tls().Certificates = append(config.tls().Certificates, certificate)
+	}
+}
+
+// WithRootCAs makes the client trust the certificate authorities of pool instead of the system ones,
+// such as the private CA of an on-prem ThreatMatrix instance. Use LoadCertPool to load it from PEM files.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(config *clientConfig) {
+		config.tls().RootCAs = pool
+	}
+}
+
+// LoadCertPool returns a pool holding the system certificate authorities along with the ones of the given PEM files.
+func LoadCertPool(caFiles ...string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	for _, caFile := range caFiles {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("%w: no PEM certificate found in %s", ErrValidation, caFile)
+		}
+	}
+	return pool, nil
+}
+
+// tls returns the TLS configuration being built, creating it the first time.
+func (config *clientConfig) tls() *tls.Config {
+	if config.tlsConfig == nil {
+		config.tlsConfig = &tls.Config{}
+	}
+	return config.tlsConfig
+}
+
+// withInsecureTLS disables the verification of the certificate of ThreatMatrix.
+func withInsecureTLS() Option {
+	return func(config *clientConfig) {
+		config.tls().InsecureSkipVerify = true
+	}
+}
+
+// tlsFileOptions turns PEM files into the Options trusting caFile and presenting the certFile and keyFile pair, empty paths being skipped.
+func tlsFileOptions(caFile string, certFile string, keyFile string) ([]Option, error) {
+	opts := []Option{}
+	if caFile != "" {
+		pool, err := LoadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithRootCAs(pool))
+	}
+	if (certFile == "") != (keyFile == "") {
+		return nil, fmt.Errorf("%w: a client certificate needs both a certificate and a key file", ErrValidation)
+	}
+	if certFile != "" {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithClientCertificate(certificate))
+	}
+	return opts, nil
+}
@@ -0,0 +1,94 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation name reported on every span made by the client.
+const tracerName = "github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+
+// These are the attribute keys set on the spans made by the client.
+const (
+	endpointAttributeKey   = attribute.Key("threatmatrix.endpoint")
+	jobIDAttributeKey      = attribute.Key("threatmatrix.job_id")
+	pluginAttributeKey     = attribute.Key("threatmatrix.plugin")
+	statusCodeAttributeKey = attribute.Key("http.response.status_code")
+	retryCountAttributeKey = attribute.Key("threatmatrix.retry_count")
+)
+
+// WithTracerProvider enables OpenTelemetry tracing: every service call gets its own span
+// (for example "JobService.Get") carrying the endpoint, job ID, status code and retry count.
+// Tracing is disabled unless this option is given.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(config *clientConfig) {
+		config.tracerProvider = provider
+	}
+}
+
+// newTracer returns the tracer of the given provider or a no-op one when there's no provider.
+func newTracer(provider trace.TracerProvider) trace.Tracer {
+	if provider == nil {
+		provider = noop.NewTracerProvider()
+	}
+	return provider.Tracer(tracerName)
+}
+
+// operationContextKey is the context key under which the name of the current service call is kept.
+type operationContextKey struct{}
+
+// startSpan starts the span of a service call, the caller has to end it.
+// The name of the service call is also kept in the returned context so metrics can be labeled with it.
+func (client *ThreatMatrixClient) startSpan(ctx context.Context, operation string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx = context.WithValue(ctx, operationContextKey{}, operation)
+	return client.tracer.Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+}
+
+// operationFromContext returns the name of the service call the context belongs to.
+func operationFromContext(ctx context.Context) string {
+	if operation, ok := ctx.Value(operationContextKey{}).(string); ok {
+		return operation
+	}
+	return "unknown"
+}
+
+// jobIDAttribute tags a span with the job it is about.
+func jobIDAttribute(jobId uint64) attribute.KeyValue {
+	return jobIDAttributeKey.Int64(int64(jobId))
+}
+
+// pluginAttribute tags a span with the analyzer, connector or playbook it is about.
+func pluginAttribute(pluginName string) attribute.KeyValue {
+	return pluginAttributeKey.String(pluginName)
+}
+
+// traceAttempts records how the request went on the span of the service call, if there's one.
+func traceAttempts(ctx context.Context, request *http.Request, response *http.Response, attempts int) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	retries := attempts - 1
+	if retries < 0 {
+		retries = 0
+	}
+	span.SetAttributes(endpointAttributeKey.String(request.URL.Path), retryCountAttributeKey.Int(retries))
+	if response != nil {
+		span.SetAttributes(statusCodeAttributeKey.Int(response.StatusCode))
+	}
+}
+
+// traceError marks the span of the service call as failed.
+func traceError(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
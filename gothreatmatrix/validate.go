@@ -0,0 +1,220 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// FieldError is a problem with a field of a request, found by its Validate method before it's sent.
+type FieldError struct {
+	// Field is the name of the field of the request, such as "Analyzers".
+	Field   string
+	Message string
+}
+
+// Error lets you implement the error interface.
+func (fieldError *FieldError) Error() string {
+	return fieldError.Field + ": " + fieldError.Message
+}
+
+// ValidationErrors gathers every FieldError a Validate method found, so they can all be fixed at once.
+// It matches ErrValidation through errors.Is, like the validation errors ThreatMatrix answers with.
+type ValidationErrors []*FieldError
+
+// Error lets you implement the error interface.
+func (validationErrors ValidationErrors) Error() string {
+	messages := make([]string, len(validationErrors))
+	for index, fieldError := range validationErrors {
+		messages[index] = fieldError.Error()
+	}
+	return fmt.Sprintf("%v: %s", ErrValidation, strings.Join(messages, "; "))
+}
+
+// Is lets errors.Is match ValidationErrors against ErrValidation.
+func (validationErrors ValidationErrors) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// FieldErrors returns the messages by field, the way ThreatMatrixError.FieldErrors holds the ones of ThreatMatrix.
+func (validationErrors ValidationErrors) FieldErrors() map[string][]string {
+	fieldErrors := map[string][]string{}
+	for _, fieldError := range validationErrors {
+		fieldErrors[fieldError.Field] = append(fieldErrors[fieldError.Field], fieldError.Message)
+	}
+	return fieldErrors
+}
+
+// add records a FieldError with a message formatted like fmt.Sprintf.
+func (validationErrors *ValidationErrors) add(field string, format string, args ...interface{}) {
+	*validationErrors = append(*validationErrors, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// err returns the ValidationErrors as an error, nil when there are none.
+func (validationErrors ValidationErrors) err() error {
+	if len(validationErrors) == 0 {
+		return nil
+	}
+	return validationErrors
+}
+
+// knownClassifications are the observable classifications ThreatMatrix accepts.
+var knownClassifications = []string{ClassificationIP, ClassificationURL, ClassificationDomain, ClassificationHash, ClassificationGeneric}
+
+// Validate checks the request before it's submitted, returning ValidationErrors listing every problem found:
+// an empty value, a classification the value doesn't match, an unknown TLP, and analyzers and connectors
+// that don't exist, are disabled, or, for analyzers, don't support the classification of the observable.
+//
+// The analyzers and connectors are checked against the catalogs of client, which is skipped when client is nil.
+// The client keeps the catalogs for DefaultCatalogTTL, fetching them again when a name isn't found or is disabled
+// in case it was just added or enabled, unless its services were wrapped through NewCachedAnalyzerService and
+// NewCachedConnectorService, whose caches are used as they are. An error fetching them is returned as is.
+func (analysisRequest *ObservableAnalysisRequest) Validate(ctx context.Context, client *ThreatMatrixClient) error {
+	validationErrors := ValidationErrors{}
+	value := strings.TrimSpace(analysisRequest.Value)
+	if client != nil {
+		// the value is checked the way it's sent, refanged when the client refangs the observables
+		value = client.observableValue(value)
+	}
+	classification := classificationOf(analysisRequest.Classification, value)
+	switch {
+	case value == "":
+		validationErrors.add("Value", "observable value cannot be empty")
+	case !containsString(knownClassifications, classification):
+		validationErrors.add("Classification", "unknown classification %q, expected one of %s", classification, strings.Join(knownClassifications, ", "))
+	case classification != ClassificationGeneric && Classify(value) != classification:
+		validationErrors.add("Classification", "%q is not a valid %s", value, classification)
+	}
+	validateTLP(&validationErrors, analysisRequest.TLP)
+	if client != nil {
+		err := validateAnalyzers(ctx, client, &validationErrors, analysisRequest.Analyzers, func(analyzer *AnalyzerConfig) string {
+			if analyzer.Type == "file" || (len(analyzer.ObservableSupported) > 0 && !containsString(analyzer.ObservableSupported, classification)) {
+				return fmt.Sprintf("analyzer %q doesn't support %s observables", analyzer.Name, classification)
+			}
+			return ""
+		})
+		if err != nil {
+			return err
+		}
+		if err := validateConnectors(ctx, client, &validationErrors, analysisRequest.Connectors); err != nil {
+			return err
+		}
+	}
+	return validationErrors.err()
+}
+
+// Validate checks the request before it's submitted, returning ValidationErrors listing every problem found:
+// a missing file or file name, an unknown TLP, and analyzers and connectors that don't exist, are disabled,
+// or, for analyzers, only analyze observables. It checks the catalogs of client the way
+// ObservableAnalysisRequest.Validate does.
+func (analysisRequest *FileAnalysisRequest) Validate(ctx context.Context, client *ThreatMatrixClient) error {
+	validationErrors := ValidationErrors{}
+	if analysisRequest.File == nil {
+		validationErrors.add("File", "file cannot be nil")
+	}
+	if strings.TrimSpace(analysisRequest.FileName) == "" {
+		validationErrors.add("FileName", "file name cannot be empty")
+	}
+	validateTLP(&validationErrors, analysisRequest.TLP)
+	if client != nil {
+		err := validateAnalyzers(ctx, client, &validationErrors, analysisRequest.Analyzers, func(analyzer *AnalyzerConfig) string {
+			if analyzer.Type == "observable" {
+				return fmt.Sprintf("analyzer %q doesn't support files", analyzer.Name)
+			}
+			return ""
+		})
+		if err != nil {
+			return err
+		}
+		if err := validateConnectors(ctx, client, &validationErrors, analysisRequest.Connectors); err != nil {
+			return err
+		}
+	}
+	return validationErrors.err()
+}
+
+// validateTLP records a FieldError when the TLP is set to a value ThreatMatrix doesn't know.
+func validateTLP(validationErrors *ValidationErrors, tlp TLP) {
+	if tlp != "" && !tlp.Valid() {
+		validationErrors.add("TLP", "unknown TLP %q", string(tlp))
+	}
+}
+
+// validateAnalyzers records a FieldError for every analyzer missing from the catalog of client, disabled,
+// or for which unsupported returns a message.
+func validateAnalyzers(ctx context.Context, client *ThreatMatrixClient, validationErrors *ValidationErrors, analyzerNames []string, unsupported func(analyzer *AnalyzerConfig) string) error {
+	if len(analyzerNames) == 0 {
+		return nil
+	}
+	for refresh := false; ; refresh = true {
+		analyzers, cached, err := client.analyzerCatalog(ctx, refresh)
+		if err != nil {
+			return err
+		}
+		catalog := make(map[string]*AnalyzerConfig, len(analyzers))
+		for index := range analyzers {
+			catalog[analyzers[index].Name] = &analyzers[index]
+		}
+		problems := ValidationErrors{}
+		for _, analyzerName := range analyzerNames {
+			analyzer, ok := catalog[analyzerName]
+			switch {
+			case !ok:
+				problems.add("Analyzers", "unknown analyzer %q", analyzerName)
+			case analyzer.Disabled:
+				problems.add("Analyzers", "analyzer %q is disabled", analyzerName)
+			default:
+				if message := unsupported(analyzer); message != "" {
+					problems.add("Analyzers", "%s", message)
+				}
+			}
+		}
+		// the cached catalog may predate the analyzers being added or enabled
+		if len(problems) == 0 || !cached || refresh {
+			*validationErrors = append(*validationErrors, problems...)
+			return nil
+		}
+	}
+}
+
+// validateConnectors records a FieldError for every connector missing from the catalog of client or disabled.
+func validateConnectors(ctx context.Context, client *ThreatMatrixClient, validationErrors *ValidationErrors, connectorNames []string) error {
+	if len(connectorNames) == 0 {
+		return nil
+	}
+	for refresh := false; ; refresh = true {
+		connectors, cached, err := client.connectorCatalog(ctx, refresh)
+		if err != nil {
+			return err
+		}
+		catalog := make(map[string]*ConnectorConfig, len(connectors))
+		for index := range connectors {
+			catalog[connectors[index].Name] = &connectors[index]
+		}
+		problems := ValidationErrors{}
+		for _, connectorName := range connectorNames {
+			connector, ok := catalog[connectorName]
+			switch {
+			case !ok:
+				problems.add("Connectors", "unknown connector %q", connectorName)
+			case connector.Disabled:
+				problems.add("Connectors", "connector %q is disabled", connectorName)
+			}
+		}
+		// the cached catalog may predate the connectors being added or enabled
+		if len(problems) == 0 || !cached || refresh {
+			*validationErrors = append(*validationErrors, problems...)
+			return nil
+		}
+	}
+}
+
+// containsString tells whether values holds value.
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
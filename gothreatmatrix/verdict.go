@@ -0,0 +1,168 @@
+package gothreatmatrix
+
+import (
+	"fmt"
+	"sync"
+)
+
+// VerdictLabel tells how harmful an observable or a file is.
+type VerdictLabel string
+
+// Values of the VerdictLabel enum, from the least to the most severe.
+const (
+	VerdictUnknown    VerdictLabel = "unknown"
+	VerdictBenign     VerdictLabel = "benign"
+	VerdictSuspicious VerdictLabel = "suspicious"
+	VerdictMalicious  VerdictLabel = "malicious"
+)
+
+// verdictRanks orders the verdict labels by severity.
+var verdictRanks = map[VerdictLabel]int{
+	VerdictUnknown:    0,
+	VerdictBenign:     1,
+	VerdictSuspicious: 2,
+	VerdictMalicious:  3,
+}
+
+// Rank returns the severity of the label, from 0 for unknown to 3 for malicious.
+func (label VerdictLabel) Rank() int {
+	return verdictRanks[label]
+}
+
+// These are the lowest scores of the suspicious and malicious verdicts, the benign ones scoring below SuspiciousScore.
+const (
+	SuspiciousScore = 25
+	MaliciousScore  = 75
+)
+
+// Evidence is what an analyzer report says about the analyzed observable or file.
+type Evidence struct {
+	// Plugin is the name of the analyzer whose report gave the evidence.
+	Plugin string
+	Label  VerdictLabel
+	// Score goes from 0 to 100, within the range of Label: below SuspiciousScore for benign, from MaliciousScore for malicious.
+	Score int
+	// Reason explains the evidence, such as "5/70 engines detected it as malicious".
+	Reason string
+}
+
+// Verdict is the normalized verdict of a job, given by the most severe evidence of its analyzer reports.
+type Verdict struct {
+	Score    int
+	Label    VerdictLabel
+	Evidence []Evidence
+}
+
+// VerdictEvaluator reads the verdict out of the report of an analyzer.
+// It returns false when the report doesn't tell anything about the observable or file.
+type VerdictEvaluator interface {
+	Evaluate(report *Report) (Evidence, bool)
+}
+
+// VerdictEvaluatorFunc is a function satisfying VerdictEvaluator.
+type VerdictEvaluatorFunc func(report *Report) (Evidence, bool)
+
+// Evaluate calls the function.
+func (evaluatorFunc VerdictEvaluatorFunc) Evaluate(report *Report) (Evidence, bool) {
+	return evaluatorFunc(report)
+}
+
+// verdictEvaluators holds the evaluators registered through RegisterVerdictEvaluator keyed by analyzer name.
+var verdictEvaluators = struct {
+	sync.RWMutex
+	byName map[string]VerdictEvaluator
+}{byName: map[string]VerdictEvaluator{}}
+
+// RegisterVerdictEvaluator registers the evaluator reading the verdict out of the reports of an analyzer.
+// Registering an analyzer again replaces its previous evaluator.
+func RegisterVerdictEvaluator(analyzerName string, evaluator VerdictEvaluator) {
+	verdictEvaluators.Lock()
+	defer verdictEvaluators.Unlock()
+	verdictEvaluators.byName[analyzerName] = evaluator
+}
+
+// Evidence returns the evidence of a successful report whose analyzer has a registered evaluator.
+// It returns false for the other reports.
+func (report *Report) Evidence() (Evidence, bool) {
+	if !report.Succeeded() {
+		return Evidence{}, false
+	}
+	verdictEvaluators.RLock()
+	evaluator, ok := verdictEvaluators.byName[report.Name]
+	verdictEvaluators.RUnlock()
+	if !ok {
+		return Evidence{}, false
+	}
+	evidence, ok := evaluator.Evaluate(report)
+	if !ok {
+		return Evidence{}, false
+	}
+	evidence.Plugin = report.Name
+	return evidence, true
+}
+
+// Verdict returns the verdict of the job: the label and score of its most severe evidence, alongside the evidence of
+// every analyzer report that could be evaluated. A job without evidence is unknown with a score of 0.
+func (job *Job) Verdict() Verdict {
+	verdict := Verdict{Label: VerdictUnknown, Evidence: []Evidence{}}
+	for index := range job.AnalyzerReports {
+		evidence, ok := job.AnalyzerReports[index].Evidence()
+		if !ok {
+			continue
+		}
+		verdict.Evidence = append(verdict.Evidence, evidence)
+		if evidence.Label.Rank() > verdict.Label.Rank() || (evidence.Label == verdict.Label && evidence.Score > verdict.Score) {
+			verdict.Label = evidence.Label
+			verdict.Score = evidence.Score
+		}
+	}
+	return verdict
+}
+
+// evaluateAbuseIPDB uses the abuse confidence score as the score of the evidence.
+func evaluateAbuseIPDB(report *Report) (Evidence, bool) {
+	abuseIPDBReport := AbuseIPDBReport{}
+	if err := report.DecodeInto(&abuseIPDBReport); err != nil {
+		return Evidence{}, false
+	}
+	score := abuseIPDBReport.Data.AbuseConfidenceScore
+	evidence := Evidence{
+		Label:  VerdictBenign,
+		Score:  score,
+		Reason: fmt.Sprintf("abuse confidence score of %d%% over %d reports", score, abuseIPDBReport.Data.TotalReports),
+	}
+	switch {
+	case score >= MaliciousScore:
+		evidence.Label = VerdictMalicious
+	case score >= SuspiciousScore:
+		evidence.Label = VerdictSuspicious
+	}
+	return evidence, true
+}
+
+// evaluateVirusTotal considers an observable malicious once 3 engines detected it as malicious, and suspicious once a
+// single engine flagged it. The score grows with the number of engines detecting it within the range of the label.
+func evaluateVirusTotal(report *Report) (Evidence, bool) {
+	virusTotalReport := VirusTotalReport{}
+	if err := report.DecodeInto(&virusTotalReport); err != nil {
+		return Evidence{}, false
+	}
+	stats := virusTotalReport.Data.Attributes.LastAnalysisStats
+	engines := stats.Harmless + stats.Malicious + stats.Suspicious + stats.Undetected
+	reason := fmt.Sprintf("%d/%d engines detected it as malicious, %d as suspicious", stats.Malicious, engines, stats.Suspicious)
+	switch {
+	case stats.Malicious >= 3:
+		return Evidence{Label: VerdictMalicious, Score: min(100, MaliciousScore+stats.Malicious-3), Reason: reason}, true
+	case stats.Malicious > 0 || stats.Suspicious > 0:
+		return Evidence{Label: VerdictSuspicious, Score: min(MaliciousScore-1, SuspiciousScore+10*stats.Malicious+5*stats.Suspicious), Reason: reason}, true
+	case engines > 0:
+		return Evidence{Label: VerdictBenign, Score: 0, Reason: reason}, true
+	}
+	return Evidence{}, false
+}
+
+func init() {
+	RegisterVerdictEvaluator("AbuseIPDB", VerdictEvaluatorFunc(evaluateAbuseIPDB))
+	RegisterVerdictEvaluator("VirusTotal_v3_Get_Observable", VerdictEvaluatorFunc(evaluateVirusTotal))
+	RegisterVerdictEvaluator("VirusTotal_v3_Get_File", VerdictEvaluatorFunc(evaluateVirusTotal))
+}
@@ -0,0 +1,145 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// VisualizerConfig represents how a visualizer is configured in ThreatMatrix.
+//
+// ThreatMatrix docs: https://threatmatrix.readthedocs.io/en/latest/Usage.html#visualizers
+type VisualizerConfig struct {
+	BaseConfigurationType
+	// Playbooks are the playbooks whose jobs the visualizer renders.
+	Playbooks []string `json:"playbooks"`
+	// Extra holds the fields ThreatMatrix sent that the SDK doesn't model yet, by name.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the visualizer configuration, keeping the fields the SDK doesn't model in Extra.
+func (visualizerConfig *VisualizerConfig) UnmarshalJSON(data []byte) error {
+	type plainVisualizerConfig VisualizerConfig
+	extra, err := decodeWithExtra(data, (*plainVisualizerConfig)(visualizerConfig))
+	visualizerConfig.Extra = extra
+	return err
+}
+
+// VisualizerReport represents the output of a visualizer for a job: the pages the ThreatMatrix GUI renders.
+type VisualizerReport struct {
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Errors      []string  `json:"errors"`
+	ProcessTime float64   `json:"process_time"`
+	StartTime   Timestamp `json:"start_time"`
+	EndTime     Timestamp `json:"end_time"`
+	// Report holds the pages of the visualizer, each of them being a level of elements to render.
+	Report []VisualizerPage `json:"report"`
+	Type   string           `json:"type"`
+}
+
+// VisualizerPage represents a page rendered by a visualizer.
+type VisualizerPage struct {
+	// LevelPosition is the position of the page among the pages of the visualizer.
+	LevelPosition int    `json:"level_position"`
+	LevelSize     string `json:"level_size"`
+	// Elements are the raw elements of the page, such as titles, lists and tables, as the GUI receives them.
+	Elements map[string]interface{} `json:"elements"`
+}
+
+// VisualizerService handles communication with visualizer related methods of the ThreatMatrix API.
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/visualizer
+type VisualizerService struct {
+	client *ThreatMatrixClient
+}
+
+// List fetches every visualizer configured in your ThreatMatrix instance.
+//
+//	Endpoint: GET /api/visualizer
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/visualizer/operation/visualizer_list
+func (visualizerService *VisualizerService) List(ctx context.Context, opts ...RequestOption) ([]VisualizerConfig, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := visualizerService.client.startSpan(ctx, "VisualizerService.List")
+	defer span.End()
+	requestUrl := visualizerService.client.options.Url + constants.BASE_VISUALIZER_URL
+	contentType := "application/json"
+	method := "GET"
+	request, err := visualizerService.client.buildRequest(ctx, method, contentType, nil, requestUrl)
+	if err != nil {
+		return nil, err
+	}
+	successResp, err := visualizerService.client.newRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return decodeConfigList[VisualizerConfig](successResp.Data)
+}
+
+// Get fetches the configuration of a specific visualizer through its name.
+//
+//	Endpoint: GET /api/visualizer/{NameOfVisualizer}
+//
+// ThreatMatrix REST API docs: https://threatmatrix.readthedocs.io/en/latest/Redoc.html#tag/visualizer/operation/visualizer_retrieve
+func (visualizerService *VisualizerService) Get(ctx context.Context, visualizerName string, opts ...RequestOption) (*VisualizerConfig, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := visualizerService.client.startSpan(ctx, "VisualizerService.Get", pluginAttribute(visualizerName))
+	defer span.End()
+	route := visualizerService.client.options.Url + constants.SPECIFIC_VISUALIZER_URL
+	requestUrl := fmt.Sprintf(route, url.PathEscape(visualizerName))
+	contentType := "application/json"
+	method := "GET"
+	request, err := visualizerService.client.buildRequest(ctx, method, contentType, nil, requestUrl)
+	if err != nil {
+		return nil, err
+	}
+	successResp, err := visualizerService.client.newRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	visualizerConfig := VisualizerConfig{}
+	if unmarshalError := json.Unmarshal(successResp.Data, &visualizerConfig); unmarshalError != nil {
+		return nil, unmarshalError
+	}
+	return &visualizerConfig, nil
+}
+
+// JobReports fetches the output of every visualizer that ran for a job, in the order the GUI shows them.
+//
+//	Endpoint: GET /api/jobs/{jobID}
+func (visualizerService *VisualizerService) JobReports(ctx context.Context, jobId uint64, opts ...RequestOption) ([]VisualizerReport, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := visualizerService.client.startSpan(ctx, "VisualizerService.JobReports", jobIDAttribute(jobId))
+	defer span.End()
+	job, err := visualizerService.client.JobService.Get(ctx, jobId)
+	if err != nil {
+		return nil, err
+	}
+	if job.VisualizerReports == nil {
+		return []VisualizerReport{}, nil
+	}
+	return job.VisualizerReports, nil
+}
+
+// JobReport fetches the output of a specific visualizer for a job, an ErrNotFound meaning it didn't run for the job.
+//
+//	Endpoint: GET /api/jobs/{jobID}
+func (visualizerService *VisualizerService) JobReport(ctx context.Context, jobId uint64, visualizerName string, opts ...RequestOption) (*VisualizerReport, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := visualizerService.client.startSpan(ctx, "VisualizerService.JobReport", jobIDAttribute(jobId), pluginAttribute(visualizerName))
+	defer span.End()
+	visualizerReports, err := visualizerService.JobReports(ctx, jobId)
+	if err != nil {
+		return nil, err
+	}
+	for index := range visualizerReports {
+		if visualizerReports[index].Name == visualizerName {
+			return &visualizerReports[index], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: visualizer %q didn't run for job %d", ErrNotFound, visualizerName, jobId)
+}
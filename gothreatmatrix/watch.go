@@ -0,0 +1,160 @@
+package gothreatmatrix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/khulnasoft/go-threatmatrix/constants"
+)
+
+// DefaultPollInterval is how often a job is fetched again when the client has to poll for its updates.
+const DefaultPollInterval = 5 * time.Second
+
+// MinPollInterval is the shortest poll interval, a shorter one given to WithPollInterval being raised to it.
+const MinPollInterval = time.Second
+
+// maxWebsocketMessageSize is the biggest job update accepted through the websocket, reports can be quite big.
+const maxWebsocketMessageSize = 32 << 20
+
+// WithPollInterval sets how often jobs are fetched again when the client has to poll for their updates.
+// It can't be shorter than MinPollInterval so a zero or negative interval doesn't flood ThreatMatrix with requests.
+func WithPollInterval(interval time.Duration) Option {
+	return func(config *clientConfig) {
+		if interval < MinPollInterval {
+			interval = MinPollInterval
+		}
+		config.pollInterval = interval
+	}
+}
+
+// JobUpdate represents a new state of a job received through JobService.Watch.
+// Err is set when the job could not be watched anymore, it is always the last update.
+type JobUpdate struct {
+	Job *Job
+	Err error
+}
+
+// Watch streams the updates of a job through ThreatMatrix's websocket job channel.
+// When websockets are unavailable it falls back to polling the job every poll interval (see WithPollInterval).
+// The channel is closed once the job is done being processed, an error occurred, or ctx is canceled.
+//
+//	Endpoint: GET /ws/jobs/{jobID}
+func (jobService *JobService) Watch(ctx context.Context, jobId uint64, opts ...RequestOption) <-chan JobUpdate {
+	ctx = withRequestOptions(ctx, opts)
+	updates := make(chan JobUpdate)
+	go func() {
+		defer close(updates)
+		if jobService.watchWebsocket(ctx, jobId, updates) {
+			return
+		}
+		jobService.watchPolling(ctx, jobId, updates)
+	}()
+	return updates
+}
+
+// watchWebsocket streams the job updates sent through the websocket.
+// It returns false when the websocket could not be used so the caller can fall back to polling.
+func (jobService *JobService) watchWebsocket(ctx context.Context, jobId uint64, updates chan<- JobUpdate) bool {
+	requestUrl := jobService.client.options.Url + fmt.Sprintf(constants.JOB_WEBSOCKET_URL, jobId)
+	requestUrl = strings.Replace(requestUrl, "http", "ws", 1)
+	authorization, err := jobService.client.authorization(ctx)
+	if err != nil {
+		// polling reports the error
+		return false
+	}
+	header := http.Header{}
+	header.Set("Authorization", authorization)
+	header.Set("User-Agent", jobService.client.userAgent)
+	connection, _, err := websocket.Dial(ctx, requestUrl, &websocket.DialOptions{
+		HTTPClient: jobService.client.client,
+		HTTPHeader: header,
+	})
+	if err != nil {
+		return ctx.Err() != nil
+	}
+	defer connection.CloseNow()
+	connection.SetReadLimit(maxWebsocketMessageSize)
+
+	for {
+		_, message, err := connection.Read(ctx)
+		if err != nil {
+			// the connection dropped before the job was done, polling takes it from here
+			return ctx.Err() != nil
+		}
+		job := Job{}
+		if unmarshalError := json.Unmarshal(message, &job); unmarshalError != nil {
+			sendJobUpdate(ctx, updates, JobUpdate{Err: unmarshalError})
+			return true
+		}
+		if !sendJobUpdate(ctx, updates, JobUpdate{Job: &job}) {
+			return true
+		}
+		if job.Status.IsTerminal() {
+			connection.Close(websocket.StatusNormalClosure, "")
+			return true
+		}
+	}
+}
+
+// watchPolling fetches the job every poll interval and sends it whenever its status changed.
+func (jobService *JobService) watchPolling(ctx context.Context, jobId uint64, updates chan<- JobUpdate) {
+	lastStatus := JobStatus("")
+	for {
+		job, err := jobService.Get(ctx, jobId)
+		if err != nil {
+			if ctx.Err() == nil {
+				sendJobUpdate(ctx, updates, JobUpdate{Err: err})
+			}
+			return
+		}
+		if job.Status != lastStatus {
+			if !sendJobUpdate(ctx, updates, JobUpdate{Job: job}) {
+				return
+			}
+			lastStatus = job.Status
+		}
+		if job.Status.IsTerminal() {
+			return
+		}
+		if sleepContext(ctx, jobService.client.pollInterval) != nil {
+			return
+		}
+	}
+}
+
+// WaitForCompletion polls a job every poll interval (see WithPollInterval) until it is done being processed and returns it.
+// Use a ctx with a deadline to bound how long to wait.
+//
+//	Endpoint: GET /api/jobs/{jobID}
+func (jobService *JobService) WaitForCompletion(ctx context.Context, jobId uint64, opts ...RequestOption) (*Job, error) {
+	ctx = withRequestOptions(ctx, opts)
+	ctx, span := jobService.client.startSpan(ctx, "JobService.WaitForCompletion", jobIDAttribute(jobId))
+	defer span.End()
+	for {
+		job, err := jobService.Get(ctx, jobId)
+		if err != nil {
+			return nil, err
+		}
+		if job.Status.IsTerminal() {
+			return job, nil
+		}
+		if err := sleepContext(ctx, jobService.client.pollInterval); err != nil {
+			return nil, fmt.Errorf("threatmatrix: job %d is still %s: %w", jobId, job.Status, err)
+		}
+	}
+}
+
+// sendJobUpdate sends the update unless ctx is canceled first, it returns false if it wasn't sent.
+func sendJobUpdate(ctx context.Context, updates chan<- JobUpdate, update JobUpdate) bool {
+	select {
+	case updates <- update:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
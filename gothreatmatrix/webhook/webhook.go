@@ -0,0 +1,236 @@
+// Package webhook receives the callbacks ThreatMatrix sends once a job is done being processed,
+// so jobs don't have to be polled.
+//
+//	receiver, err := webhook.NewReceiver(os.Getenv("THREATMATRIX_WEBHOOK_SECRET"))
+//	receiver.Handle(func(ctx context.Context, job *gothreatmatrix.Job) error {
+//		log.Printf("job %d is %s", job.ID, job.Status)
+//		return nil
+//	})
+//	http.Handle("/threatmatrix/callback", receiver)
+//
+// Jobs are told where to send their callback when they're submitted, through CallbackURL:
+//
+//	client.AnalyzeService.AnalyzeObservable(ctx, analysisRequest, webhook.CallbackURL("https://soar.local/threatmatrix/callback"))
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// These are the headers of a callback.
+const (
+	// SignatureHeader holds the HMAC-SHA256, keyed with the shared secret, of the TimestampHeader, a dot and the
+	// callback body, as "sha256=<hex>".
+	SignatureHeader = "X-ThreatMatrix-Signature"
+	// TimestampHeader holds when the callback was sent, in seconds since the Unix epoch.
+	TimestampHeader = "X-ThreatMatrix-Timestamp"
+	// CallbackURLHeader tells ThreatMatrix where to send the callback of the submitted job.
+	CallbackURLHeader = "X-ThreatMatrix-Callback-URL"
+)
+
+// signaturePrefix prefixes the hex encoded signature in the SignatureHeader.
+const signaturePrefix = "sha256="
+
+// DefaultMaxBodySize is the largest callback body a Receiver reads when WithMaxBodySize wasn't given.
+const DefaultMaxBodySize = 10 << 20
+
+// DefaultTolerance is how far from the current time the timestamp of a callback can be when WithTolerance wasn't given.
+const DefaultTolerance = 5 * time.Minute
+
+// These are the errors Verify and NewReceiver return.
+var (
+	// ErrInvalidSignature is returned when the signature doesn't match the timestamp and the body.
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	// ErrInvalidTimestamp is returned when the timestamp is missing or too far from the current time, as a callback
+	// captured earlier and replayed would be.
+	ErrInvalidTimestamp = errors.New("webhook: invalid timestamp")
+	// ErrReplayed is returned by the Receiver for a callback it already handled.
+	ErrReplayed = errors.New("webhook: callback already received")
+	// ErrEmptySecret is returned when the secret is empty, as anyone could sign callbacks with it.
+	ErrEmptySecret = errors.New("webhook: the secret cannot be empty")
+)
+
+// HandlerFunc is called with the job of every valid callback, an error answers ThreatMatrix with a 500
+// so the callback is sent again.
+type HandlerFunc func(ctx context.Context, job *gothreatmatrix.Job) error
+
+// Receiver is an http.Handler receiving the job-completion callbacks of ThreatMatrix.
+// Callbacks that aren't signed with its secret, or whose timestamp is outside its tolerance, are rejected before
+// their body is decoded. The signatures of the callbacks it handled are remembered for as long as their timestamp is
+// within the tolerance, so a callback replayed in the meantime is rejected as well.
+type Receiver struct {
+	secret       []byte
+	maxBodySize  int64
+	tolerance    time.Duration
+	mutex        sync.RWMutex
+	handlers     []HandlerFunc
+	handledMutex sync.Mutex
+	// handled maps the signatures of the handled callbacks to when they can be forgotten
+	handled map[string]time.Time
+}
+
+// ReceiverOption configures a Receiver made through NewReceiver.
+type ReceiverOption func(*Receiver)
+
+// WithMaxBodySize sets the largest callback body the Receiver reads, larger ones being rejected.
+func WithMaxBodySize(size int64) ReceiverOption {
+	return func(receiver *Receiver) {
+		receiver.maxBodySize = size
+	}
+}
+
+// WithTolerance sets how far from the current time the timestamp of a callback can be, DefaultTolerance otherwise.
+func WithTolerance(tolerance time.Duration) ReceiverOption {
+	return func(receiver *Receiver) {
+		receiver.tolerance = tolerance
+	}
+}
+
+// NewReceiver returns a Receiver checking the callbacks against the secret shared with ThreatMatrix.
+// It fails with ErrEmptySecret when secret is empty.
+func NewReceiver(secret string, opts ...ReceiverOption) (*Receiver, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+	receiver := &Receiver{secret: []byte(secret), maxBodySize: DefaultMaxBodySize, tolerance: DefaultTolerance, handled: map[string]time.Time{}}
+	for _, opt := range opts {
+		opt(receiver)
+	}
+	if receiver.tolerance <= 0 {
+		receiver.tolerance = DefaultTolerance
+	}
+	return receiver, nil
+}
+
+// Handle adds a handler called with the job of every valid callback, the handlers running in the order they were added.
+func (receiver *Receiver) Handle(handler HandlerFunc) {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+	receiver.handlers = append(receiver.handlers, handler)
+}
+
+// ServeHTTP verifies the callback, decodes its job and hands it to the handlers.
+func (receiver *Receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, receiver.maxBodySize+1))
+	if err != nil {
+		http.Error(w, "could not read the body", http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > receiver.maxBodySize {
+		http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	signature := r.Header.Get(SignatureHeader)
+	if err := Verify(receiver.secret, body, signature, r.Header.Get(TimestampHeader), receiver.tolerance); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if !receiver.claim(signature) {
+		http.Error(w, ErrReplayed.Error(), http.StatusConflict)
+		return
+	}
+	job := &gothreatmatrix.Job{}
+	if err := json.Unmarshal(body, job); err != nil {
+		http.Error(w, "could not decode the job", http.StatusBadRequest)
+		return
+	}
+	receiver.mutex.RLock()
+	handlers := append([]HandlerFunc{}, receiver.handlers...)
+	receiver.mutex.RUnlock()
+	for _, handler := range handlers {
+		if err := handler(r.Context(), job); err != nil {
+			// the callback is sent again, it has to be accepted then
+			receiver.release(signature)
+			http.Error(w, fmt.Sprintf("could not handle job %d", job.ID), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// claim records the signature of a callback about to be handled, returning false when it already was.
+func (receiver *Receiver) claim(signature string) bool {
+	receiver.handledMutex.Lock()
+	defer receiver.handledMutex.Unlock()
+	now := time.Now()
+	for handled, expiry := range receiver.handled {
+		if now.After(expiry) {
+			delete(receiver.handled, handled)
+		}
+	}
+	if _, ok := receiver.handled[signature]; ok {
+		return false
+	}
+	// a callback older than twice the tolerance is rejected by its timestamp anyway
+	receiver.handled[signature] = now.Add(2 * receiver.tolerance)
+	return true
+}
+
+// release forgets the signature of a callback that couldn't be handled.
+func (receiver *Receiver) release(signature string) {
+	receiver.handledMutex.Lock()
+	defer receiver.handledMutex.Unlock()
+	delete(receiver.handled, signature)
+}
+
+// Sign returns the values of the SignatureHeader and the TimestampHeader of a callback with body sent at timestamp,
+// to sign callbacks such as in tests.
+func Sign(secret []byte, timestamp time.Time, body []byte) (signature string, timestampHeader string) {
+	timestampHeader = strconv.FormatInt(timestamp.Unix(), 10)
+	return sign(secret, timestampHeader, body), timestampHeader
+}
+
+// sign returns the value of the SignatureHeader of the timestamp and the body.
+func sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature and the timestamp taken from the SignatureHeader and the TimestampHeader against body,
+// the signature in constant time. The timestamp can't be further than tolerance from the current time, which bounds
+// how long a captured callback can be replayed. It fails with ErrEmptySecret when secret is empty.
+func Verify(secret []byte, body []byte, signature string, timestamp string, tolerance time.Duration) error {
+	if len(secret) == 0 {
+		return ErrEmptySecret
+	}
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(sign(secret, timestamp, body)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	if age := time.Since(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return ErrInvalidTimestamp
+	}
+	return nil
+}
+
+// CallbackURL asks ThreatMatrix to send the callback of the jobs submitted by the call to url.
+func CallbackURL(url string) gothreatmatrix.RequestOption {
+	return gothreatmatrix.WithHeader(CallbackURLHeader, url)
+}
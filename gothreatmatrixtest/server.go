@@ -0,0 +1,534 @@
+package gothreatmatrixtest
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// Token is the API token the fake server accepts, the clients made through Server.Client already use it.
+const Token = "test-token"
+
+// defaultPageSize is how many jobs a page holds when the request doesn't ask for a page size.
+const defaultPageSize = 10
+
+// Request records a request the fake server received.
+type Request struct {
+	Method string
+	Path   string
+}
+
+// Server is a fake ThreatMatrix instance serving the jobs, tags and analyze endpoints from memory.
+// Analyzing an observable or a file creates a job that's immediately reported without fails,
+// use OnAnalyze to script the jobs differently, AddJob and AddTag to seed canned data
+// and Handle to replace the response of any endpoint.
+//
+//	server := gothreatmatrixtest.NewServer()
+//	defer server.Close()
+//	client := server.Client()
+type Server struct {
+	// URL is the base URL of the fake server.
+	URL string
+
+	server      *httptest.Server
+	mutex       sync.Mutex
+	jobs        map[int]*gothreatmatrix.Job
+	samples     map[int][]byte
+	tags        map[uint64]*gothreatmatrix.Tag
+	nextJobId   int
+	nextTagId   uint64
+	handlers    map[string]http.HandlerFunc
+	analyzeHook func(job *gothreatmatrix.Job)
+	requests    []Request
+}
+
+// NewServer starts a fake ThreatMatrix server without any job or tag, close it once you're done.
+func NewServer() *Server {
+	server := &Server{
+		jobs:      map[int]*gothreatmatrix.Job{},
+		samples:   map[int][]byte{},
+		tags:      map[uint64]*gothreatmatrix.Tag{},
+		nextJobId: 1,
+		nextTagId: 1,
+		handlers:  map[string]http.HandlerFunc{},
+	}
+	server.server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	server.URL = server.server.URL
+	return server
+}
+
+// Close shuts the fake server down.
+func (server *Server) Close() {
+	server.server.Close()
+}
+
+// Client returns a ThreatMatrixClient talking to the fake server, opts being applied after its URL and token.
+func (server *Server) Client(opts ...gothreatmatrix.Option) *gothreatmatrix.ThreatMatrixClient {
+	clientOptions := append([]gothreatmatrix.Option{
+		gothreatmatrix.WithURL(server.URL),
+		gothreatmatrix.WithToken(Token),
+	}, opts...)
+	return gothreatmatrix.NewClient(clientOptions...)
+}
+
+// AddJob stores a canned job and returns its ID, a job without an ID gets the next free one.
+func (server *Server) AddJob(job gothreatmatrix.Job) int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.storeJob(&job)
+}
+
+// Job returns a copy of a stored job.
+func (server *Server) Job(jobId int) (gothreatmatrix.Job, bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	job, ok := server.jobs[jobId]
+	if !ok {
+		return gothreatmatrix.Job{}, false
+	}
+	return *job, true
+}
+
+// SetSample stores the file sample downloaded through the download_sample endpoint of a job.
+func (server *Server) SetSample(jobId int, sample []byte) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.samples[jobId] = sample
+}
+
+// AddTag stores a canned tag and returns its ID, a tag without an ID gets the next free one.
+func (server *Server) AddTag(tag gothreatmatrix.Tag) uint64 {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.storeTag(&tag)
+}
+
+// OnAnalyze registers a hook called with every job created by an analysis before it's stored,
+// letting you change its status, reports or anything else. The hook must not call the Server's methods.
+func (server *Server) OnAnalyze(hook func(job *gothreatmatrix.Job)) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.analyzeHook = hook
+}
+
+// Handle replaces the response of the fake server to the given method and path, such as "GET" and "/api/jobs/1".
+func (server *Server) Handle(method string, path string, handler http.HandlerFunc) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.handlers[method+" "+path] = handler
+}
+
+// Requests returns the requests the fake server received so far, in order.
+func (server *Server) Requests() []Request {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return append([]Request{}, server.requests...)
+}
+
+// serveHTTP checks the token, records the request and routes it.
+func (server *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mutex.Lock()
+	server.requests = append(server.requests, Request{Method: r.Method, Path: r.URL.Path})
+	handler, ok := server.handlers[r.Method+" "+r.URL.Path]
+	server.mutex.Unlock()
+	if ok {
+		handler(w, r)
+		return
+	}
+	if r.Header.Get("Authorization") != "token "+Token {
+		writeDetail(w, http.StatusUnauthorized, "Invalid token.")
+		return
+	}
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case path == constants.ANALYZE_OBSERVABLE_URL && r.Method == http.MethodPost:
+		server.analyzeObservable(w, r)
+	case path == constants.ANALYZE_MULTIPLE_OBSERVABLES_URL && r.Method == http.MethodPost:
+		server.analyzeMultipleObservables(w, r)
+	case path == constants.ANALYZE_FILE_URL && r.Method == http.MethodPost:
+		server.analyzeFiles(w, r, "file")
+	case path == constants.ANALYZE_MULTIPLE_FILES_URL && r.Method == http.MethodPost:
+		server.analyzeFiles(w, r, "files")
+	case path == constants.BASE_JOB_URL:
+		server.serveJobs(w, r)
+	case strings.HasPrefix(path, constants.BASE_JOB_URL+"/"):
+		server.serveJob(w, r, strings.Split(strings.TrimPrefix(path, constants.BASE_JOB_URL+"/"), "/"))
+	case path == constants.BASE_TAG_URL:
+		server.serveTags(w, r)
+	case strings.HasPrefix(path, constants.BASE_TAG_URL+"/"):
+		server.serveTag(w, r, strings.TrimPrefix(path, constants.BASE_TAG_URL+"/"))
+	default:
+		writeDetail(w, http.StatusNotFound, "Not found.")
+	}
+}
+
+// analyzeObservable serves POST /api/analyze_observable.
+func (server *Server) analyzeObservable(w http.ResponseWriter, r *http.Request) {
+	params := gothreatmatrix.ObservableAnalysisParams{}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		writeDetail(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if params.ObservableName == "" {
+		writeDetail(w, http.StatusBadRequest, "observable_name is required")
+		return
+	}
+	job := server.analyze(params.BasicAnalysisParams, func(job *gothreatmatrix.Job) {
+		job.ObservableName = params.ObservableName
+		job.ObservableClassification = params.ObservableClassification
+		job.Md5 = fmt.Sprintf("%x", md5.Sum([]byte(params.ObservableName)))
+	})
+	writeJSON(w, http.StatusOK, analysisResponse(job))
+}
+
+// analyzeMultipleObservables serves POST /api/analyze_multiple_observables.
+func (server *Server) analyzeMultipleObservables(w http.ResponseWriter, r *http.Request) {
+	params := gothreatmatrix.MultipleObservableAnalysisParams{}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		writeDetail(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	response := gothreatmatrix.MultipleAnalysisResponse{Results: []gothreatmatrix.AnalysisResponse{}}
+	for _, observable := range params.Observables {
+		if len(observable) != 2 {
+			writeDetail(w, http.StatusBadRequest, "observables must be [classification, name] pairs")
+			return
+		}
+		job := server.analyze(params.BasicAnalysisParams, func(job *gothreatmatrix.Job) {
+			job.ObservableClassification = observable[0]
+			job.ObservableName = observable[1]
+			job.Md5 = fmt.Sprintf("%x", md5.Sum([]byte(observable[1])))
+		})
+		response.Results = append(response.Results, analysisResponse(job))
+	}
+	response.Count = len(response.Results)
+	writeJSON(w, http.StatusOK, response)
+}
+
+// analyzeFiles serves the file analysis endpoints, reading the files from the given form field.
+func (server *Server) analyzeFiles(w http.ResponseWriter, r *http.Request, fileField string) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		writeDetail(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	files := r.MultipartForm.File[fileField]
+	if len(files) == 0 {
+		writeDetail(w, http.StatusBadRequest, fileField+" is required")
+		return
+	}
+	params := gothreatmatrix.BasicAnalysisParams{
+		Tlp:                 gothreatmatrix.TLP(r.FormValue("tlp")),
+		AnalyzersRequested:  r.MultipartForm.Value["analyzers_requested"],
+		ConnectorsRequested: r.MultipartForm.Value["connectors_requested"],
+		TagsLabels:          r.MultipartForm.Value["tags_labels"],
+	}
+	responses := []gothreatmatrix.AnalysisResponse{}
+	for _, fileHeader := range files {
+		sample, err := readFormFile(fileHeader)
+		if err != nil {
+			writeDetail(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		job := server.analyze(params, func(job *gothreatmatrix.Job) {
+			job.IsSample = true
+			job.FileName = fileHeader.Filename
+			job.FileMimetype = http.DetectContentType(sample)
+			job.Md5 = fmt.Sprintf("%x", md5.Sum(sample))
+		})
+		server.SetSample(job.ID, sample)
+		responses = append(responses, analysisResponse(job))
+	}
+	if fileField == "file" {
+		writeJSON(w, http.StatusOK, responses[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, gothreatmatrix.MultipleAnalysisResponse{Count: len(responses), Results: responses})
+}
+
+// analyze creates the job of an analysis, runs the OnAnalyze hook on it and stores it.
+func (server *Server) analyze(params gothreatmatrix.BasicAnalysisParams, describe func(job *gothreatmatrix.Job)) *gothreatmatrix.Job {
+	now := time.Now().UTC()
+	job := &gothreatmatrix.Job{
+		BaseJob: gothreatmatrix.BaseJob{
+			User:                 gothreatmatrix.UserDetails{Username: "test"},
+			Tags:                 []gothreatmatrix.Tag{},
+			Status:               gothreatmatrix.StatusReportedWithoutFails,
+			AnalyzersRequested:   params.AnalyzersRequested,
+			ConnectorsRequested:  params.ConnectorsRequested,
+			AnalyzersToExecute:   params.AnalyzersRequested,
+			ConnectorsToExecute:  params.ConnectorsRequested,
+			ReceivedRequestTime:  gothreatmatrix.NewTimestamp(now),
+			FinishedAnalysisTime: gothreatmatrix.NewTimestamp(now),
+			Tlp:                  params.Tlp,
+			Errors:               []string{},
+		},
+		AnalyzerReports:  []gothreatmatrix.Report{},
+		ConnectorReports: []gothreatmatrix.Report{},
+	}
+	if job.Tlp == "" {
+		job.Tlp = gothreatmatrix.TLPWhite
+	}
+	describe(job)
+	if job.ObservableName != "" && job.ObservableClassification == "" {
+		job.ObservableClassification = gothreatmatrix.Classify(job.ObservableName)
+	}
+
+	server.mutex.Lock()
+	for _, label := range params.TagsLabels {
+		job.Tags = append(job.Tags, *server.tagByLabel(label))
+	}
+	hook := server.analyzeHook
+	server.mutex.Unlock()
+
+	if hook != nil {
+		hook(job)
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.storeJob(job)
+	return job
+}
+
+// serveJobs serves GET /api/jobs, newest jobs first.
+func (server *Server) serveJobs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeDetail(w, http.StatusMethodNotAllowed, "Method not allowed.")
+		return
+	}
+	query := r.URL.Query()
+	page, pageSize := queryInt(query.Get("page"), 1), queryInt(query.Get("page_size"), defaultPageSize)
+	server.mutex.Lock()
+	jobs := []gothreatmatrix.JobList{}
+	for _, job := range server.jobs {
+		if status := query.Get("status"); status != "" && string(job.Status) != status {
+			continue
+		}
+		if md5 := query.Get("md5"); md5 != "" && job.Md5 != md5 {
+			continue
+		}
+		if observableName := query.Get("observable_name"); observableName != "" && job.ObservableName != observableName {
+			continue
+		}
+		if fileName := query.Get("file_name"); fileName != "" && job.FileName != fileName {
+			continue
+		}
+		jobs = append(jobs, gothreatmatrix.JobList{BaseJob: job.BaseJob})
+	}
+	server.mutex.Unlock()
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].ID > jobs[j].ID
+	})
+	response := gothreatmatrix.JobListResponse{
+		Count:      len(jobs),
+		TotalPages: (len(jobs) + pageSize - 1) / pageSize,
+		Results:    []gothreatmatrix.JobList{},
+	}
+	if start := (page - 1) * pageSize; start < len(jobs) {
+		end := start + pageSize
+		if end > len(jobs) {
+			end = len(jobs)
+		}
+		response.Results = jobs[start:end]
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// serveJob serves the endpoints of a specific job: /api/jobs/{id}, /api/jobs/{id}/kill and /api/jobs/{id}/download_sample.
+func (server *Server) serveJob(w http.ResponseWriter, r *http.Request, segments []string) {
+	jobId, err := strconv.Atoi(segments[0])
+	if err != nil {
+		writeDetail(w, http.StatusNotFound, "Not found.")
+		return
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	job, ok := server.jobs[jobId]
+	if !ok {
+		writeDetail(w, http.StatusNotFound, "Not found.")
+		return
+	}
+	switch action := strings.Join(segments[1:], "/"); {
+	case action == "" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, job)
+	case action == "" && r.Method == http.MethodDelete:
+		delete(server.jobs, jobId)
+		delete(server.samples, jobId)
+		w.WriteHeader(http.StatusNoContent)
+	case action == "kill" && r.Method == http.MethodPatch:
+		if job.Status.IsTerminal() {
+			writeDetail(w, http.StatusBadRequest, "Job is not running")
+			return
+		}
+		job.Status = gothreatmatrix.StatusKilled
+		w.WriteHeader(http.StatusNoContent)
+	case action == "download_sample" && r.Method == http.MethodGet:
+		sample, ok := server.samples[jobId]
+		if !ok {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+				"errors": map[string]string{"detail": "Requested job does not have a sample associated with it."},
+			})
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(sample)
+	default:
+		writeDetail(w, http.StatusNotFound, "Not found.")
+	}
+}
+
+// serveTags serves GET and POST /api/tags.
+func (server *Server) serveTags(w http.ResponseWriter, r *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	switch r.Method {
+	case http.MethodGet:
+		tags := []gothreatmatrix.Tag{}
+		for _, tag := range server.tags {
+			tags = append(tags, *tag)
+		}
+		sort.Slice(tags, func(i, j int) bool {
+			return tags[i].ID < tags[j].ID
+		})
+		writeJSON(w, http.StatusOK, tags)
+	case http.MethodPost:
+		tag := gothreatmatrix.Tag{}
+		if err := json.NewDecoder(r.Body).Decode(&tag); err != nil || tag.Label == "" {
+			writeDetail(w, http.StatusBadRequest, "label is required")
+			return
+		}
+		tag.ID = 0
+		server.storeTag(&tag)
+		writeJSON(w, http.StatusCreated, tag)
+	default:
+		writeDetail(w, http.StatusMethodNotAllowed, "Method not allowed.")
+	}
+}
+
+// serveTag serves GET, PUT and DELETE /api/tags/{id}.
+func (server *Server) serveTag(w http.ResponseWriter, r *http.Request, segment string) {
+	tagId, err := strconv.ParseUint(segment, 10, 64)
+	if err != nil {
+		writeDetail(w, http.StatusNotFound, "Not found.")
+		return
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	tag, ok := server.tags[tagId]
+	if !ok {
+		writeDetail(w, http.StatusNotFound, "Not found.")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, tag)
+	case http.MethodPut, http.MethodPatch:
+		update := gothreatmatrix.TagParams{}
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			writeDetail(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if update.Label != "" {
+			tag.Label = update.Label
+		}
+		if update.Color != "" {
+			tag.Color = update.Color
+		}
+		writeJSON(w, http.StatusOK, tag)
+	case http.MethodDelete:
+		delete(server.tags, tagId)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeDetail(w, http.StatusMethodNotAllowed, "Method not allowed.")
+	}
+}
+
+// storeJob stores a job, giving it the next free ID when it has none. The caller must hold the mutex.
+func (server *Server) storeJob(job *gothreatmatrix.Job) int {
+	if job.ID == 0 {
+		job.ID = server.nextJobId
+	}
+	if job.ID >= server.nextJobId {
+		server.nextJobId = job.ID + 1
+	}
+	server.jobs[job.ID] = job
+	return job.ID
+}
+
+// storeTag stores a tag, giving it the next free ID when it has none. The caller must hold the mutex.
+func (server *Server) storeTag(tag *gothreatmatrix.Tag) uint64 {
+	if tag.ID == 0 {
+		tag.ID = server.nextTagId
+	}
+	if tag.ID >= server.nextTagId {
+		server.nextTagId = tag.ID + 1
+	}
+	server.tags[tag.ID] = tag
+	return tag.ID
+}
+
+// tagByLabel returns the tag with the given label, creating it like ThreatMatrix does when it doesn't exist yet.
+// The caller must hold the mutex.
+func (server *Server) tagByLabel(label string) *gothreatmatrix.Tag {
+	for _, tag := range server.tags {
+		if tag.Label == label {
+			return tag
+		}
+	}
+	tag := &gothreatmatrix.Tag{Label: label, Color: "#1655D3"}
+	server.storeTag(tag)
+	return tag
+}
+
+// analysisResponse returns what ThreatMatrix answers once it accepted the analysis creating job.
+func analysisResponse(job *gothreatmatrix.Job) gothreatmatrix.AnalysisResponse {
+	return gothreatmatrix.AnalysisResponse{
+		JobID:             job.ID,
+		Status:            "accepted",
+		Warnings:          []string{},
+		AnalyzersRunning:  job.AnalyzersToExecute,
+		ConnectorsRunning: job.ConnectorsToExecute,
+	}
+}
+
+// readFormFile reads an uploaded file.
+func readFormFile(fileHeader *multipart.FileHeader) ([]byte, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// queryInt parses a positive integer query parameter, falling back to defaultValue.
+func queryInt(value string, defaultValue int) int {
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 1 {
+		return defaultValue
+	}
+	return parsed
+}
+
+// writeJSON writes v as the JSON body of a response with the given status code.
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeDetail writes an error response the way ThreatMatrix does.
+func writeDetail(w http.ResponseWriter, statusCode int, detail string) {
+	writeJSON(w, statusCode, map[string]string{"detail": detail})
+}
@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// analyzeCommand returns the "analyze" command and its subcommands.
+func analyzeCommand() *command {
+	return &command{
+		name:    "analyze",
+		summary: "submit observables and files for analysis",
+		usage:   "analyze <command> [flags] [arguments]",
+		subcommands: []*command{
+			{name: "observable", summary: "analyze an observable, such as an IP, a domain or a hash", usage: "analyze observable [flags] <value>", run: runAnalyzeObservable},
+			{name: "file", summary: `analyze a file, "-" reading it from stdin`, usage: "analyze file [flags] <path|->", run: runAnalyzeFile},
+		},
+	}
+}
+
+// submissionFlags are the flags picking how observables and files are analyzed.
+type submissionFlags struct {
+	playbook   string
+	tlp        string
+	tags       stringsFlag
+	analyzers  stringsFlag
+	connectors stringsFlag
+}
+
+// addSubmissionFlags adds the submissionFlags to flags.
+func addSubmissionFlags(flags *flag.FlagSet) *submissionFlags {
+	submission := &submissionFlags{}
+	flags.StringVar(&submission.playbook, "playbook", "", "playbook to run, instead of picking the analyzers and connectors")
+	flags.StringVar(&submission.tlp, "tlp", "", "TLP of the analysis, such as AMBER")
+	flags.Var(&submission.tags, "tag", "label of a tag to add to the job, can be repeated")
+	flags.Var(&submission.analyzers, "analyzer", "analyzer to run, can be repeated, every supported one running when none is given")
+	flags.Var(&submission.connectors, "connector", "connector to run, can be repeated")
+	return submission
+}
+
+// validate checks the flags go together and returns the TLP given through --tlp, empty when it wasn't given.
+func (submission *submissionFlags) validate() (gothreatmatrix.TLP, error) {
+	if submission.playbook != "" && (len(submission.analyzers) > 0 || len(submission.connectors) > 0) {
+		return "", newUsageError("--playbook can't be given along with --analyzer or --connector")
+	}
+	if submission.tlp == "" {
+		return "", nil
+	}
+	tlp := gothreatmatrix.ParseTLP(submission.tlp)
+	if tlp == "" {
+		return "", newUsageError("unknown TLP %q", submission.tlp)
+	}
+	return tlp, nil
+}
+
+// submitObservable submits the observable through the playbook, or to the analyzers and connectors.
+func (submission *submissionFlags) submitObservable(ctx context.Context, client *gothreatmatrix.ThreatMatrixClient, tlp gothreatmatrix.TLP, value string, classification string) (*gothreatmatrix.AnalysisResponse, error) {
+	if submission.playbook != "" {
+		return client.PlaybookService.AnalyzeObservable(ctx, gothreatmatrix.PlaybookObservableAnalysisRequest{
+			Playbook:       submission.playbook,
+			Value:          value,
+			Classification: classification,
+			TLP:            tlp,
+			Tags:           submission.tags,
+		})
+	}
+	return client.AnalyzeService.AnalyzeObservable(ctx, gothreatmatrix.ObservableAnalysisRequest{
+		Value:          value,
+		Classification: classification,
+		Analyzers:      submission.analyzers,
+		Connectors:     submission.connectors,
+		TLP:            tlp,
+		Tags:           submission.tags,
+	})
+}
+
+// submitFile submits the file through the playbook, or to the analyzers and connectors.
+func (submission *submissionFlags) submitFile(ctx context.Context, client *gothreatmatrix.ThreatMatrixClient, tlp gothreatmatrix.TLP, file io.Reader, fileName string) (*gothreatmatrix.AnalysisResponse, error) {
+	if submission.playbook != "" {
+		return client.PlaybookService.AnalyzeFile(ctx, gothreatmatrix.PlaybookFileAnalysisRequest{
+			Playbook: submission.playbook,
+			File:     file,
+			FileName: fileName,
+			TLP:      tlp,
+			Tags:     submission.tags,
+		})
+	}
+	return client.AnalyzeService.AnalyzeFile(ctx, gothreatmatrix.FileAnalysisRequest{
+		File:       file,
+		FileName:   fileName,
+		Analyzers:  submission.analyzers,
+		Connectors: submission.connectors,
+		TLP:        tlp,
+		Tags:       submission.tags,
+	})
+}
+
+// analysisFlags are the flags of the analyze commands.
+type analysisFlags struct {
+	*submissionFlags
+	wait        bool
+	waitTimeout time.Duration
+}
+
+// addAnalysisFlags adds the analysisFlags to flags.
+func addAnalysisFlags(flags *flag.FlagSet) *analysisFlags {
+	analysis := &analysisFlags{submissionFlags: addSubmissionFlags(flags)}
+	flags.BoolVar(&analysis.wait, "wait", false, "wait for the job to be done and print its verdict")
+	flags.DurationVar(&analysis.waitTimeout, "wait-timeout", 0, "how long --wait waits at most, such as 5m, waiting until interrupted when it's 0")
+	return analysis
+}
+
+// runAnalyzeObservable submits the observable given as argument.
+func runAnalyzeObservable(app *app, cmd *command, args []string) error {
+	flags := app.newFlagSet(cmd)
+	classification := flags.String("type", "", "classification of the observable, guessed when it's not given")
+	analysis := addAnalysisFlags(flags)
+	addOutputFlag(flags, &app.output)
+	arguments, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(arguments) != 1 {
+		return newUsageError("analyze observable takes a single observable")
+	}
+	tlp, err := analysis.validate()
+	if err != nil {
+		return err
+	}
+	client, err := app.threatMatrix()
+	if err != nil {
+		return err
+	}
+	response, err := analysis.submitObservable(app.ctx, client, tlp, arguments[0], *classification)
+	if err != nil {
+		return err
+	}
+	return app.printAnalysis(client, analysis, response)
+}
+
+// runAnalyzeFile submits the file given as argument, or stdin when it's "-".
+func runAnalyzeFile(app *app, cmd *command, args []string) error {
+	flags := app.newFlagSet(cmd)
+	name := flags.String("name", "", `name of the file sent to ThreatMatrix (default the base name of the path, or "stdin")`)
+	analysis := addAnalysisFlags(flags)
+	addOutputFlag(flags, &app.output)
+	arguments, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(arguments) != 1 {
+		return newUsageError("analyze file takes a single path, or - to read from stdin")
+	}
+	tlp, err := analysis.validate()
+	if err != nil {
+		return err
+	}
+	var file io.Reader = app.stdin
+	fileName := "stdin"
+	if path := arguments[0]; path != "-" {
+		openedFile, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer openedFile.Close()
+		file, fileName = openedFile, filepath.Base(path)
+	}
+	if *name != "" {
+		fileName = *name
+	}
+	client, err := app.threatMatrix()
+	if err != nil {
+		return err
+	}
+	response, err := analysis.submitFile(app.ctx, client, tlp, file, fileName)
+	if err != nil {
+		return err
+	}
+	return app.printAnalysis(client, analysis, response)
+}
+
+// analysisOutput is what the analyze commands print, the verdict being null unless they waited for the job.
+type analysisOutput struct {
+	JobID    int            `json:"job_id"`
+	Status   string         `json:"status"`
+	Warnings []string       `json:"warnings"`
+	Verdict  *verdictOutput `json:"verdict"`
+}
+
+func (output analysisOutput) header() []string {
+	return []string{"job_id", "status", "verdict", "score"}
+}
+
+func (output analysisOutput) rows() [][]string {
+	row := []string{strconv.Itoa(output.JobID), output.Status, "", ""}
+	if output.Verdict != nil {
+		row[2], row[3] = string(output.Verdict.Label), strconv.Itoa(output.Verdict.Score)
+	}
+	return [][]string{row}
+}
+
+// verdictOutput is the verdict of a job the analyze commands waited for.
+type verdictOutput struct {
+	Label    gothreatmatrix.VerdictLabel `json:"label"`
+	Score    int                         `json:"score"`
+	Evidence []evidenceOutput            `json:"evidence"`
+}
+
+// evidenceOutput is an evidence of a verdictOutput.
+type evidenceOutput struct {
+	Plugin string                      `json:"plugin"`
+	Label  gothreatmatrix.VerdictLabel `json:"label"`
+	Score  int                         `json:"score"`
+	Reason string                      `json:"reason"`
+}
+
+// printAnalysis prints the job created by an analysis, waiting for it to be done first with --wait.
+func (app *app) printAnalysis(client *gothreatmatrix.ThreatMatrixClient, analysis *analysisFlags, response *gothreatmatrix.AnalysisResponse) error {
+	output := analysisOutput{JobID: response.JobID, Status: response.Status, Warnings: append([]string{}, response.Warnings...)}
+	if analysis.wait {
+		ctx := app.ctx
+		if analysis.waitTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, analysis.waitTimeout)
+			defer cancel()
+		}
+		job, err := client.JobService.WaitForCompletion(ctx, uint64(response.JobID))
+		if err != nil {
+			return err
+		}
+		verdict := job.Verdict()
+		output.Status = string(job.Status)
+		output.Verdict = &verdictOutput{Label: verdict.Label, Score: verdict.Score, Evidence: []evidenceOutput{}}
+		for _, evidence := range verdict.Evidence {
+			output.Verdict.Evidence = append(output.Verdict.Evidence, evidenceOutput(evidence))
+		}
+	}
+	return writeOutput(app.stdout, app.output, output, func(table *tabwriter.Writer) {
+		writeRow(table, "Job:", strconv.Itoa(output.JobID))
+		writeRow(table, "Status:", output.Status)
+		for _, warning := range output.Warnings {
+			writeRow(table, "Warning:", warning)
+		}
+		if output.Verdict == nil {
+			return
+		}
+		writeRow(table, "Verdict:", fmt.Sprintf("%s (score %d)", output.Verdict.Label, output.Verdict.Score))
+		if len(output.Verdict.Evidence) == 0 {
+			return
+		}
+		writeRow(table)
+		writeRow(table, "PLUGIN", "VERDICT", "SCORE", "REASON")
+		for _, evidence := range output.Verdict.Evidence {
+			writeRow(table, evidence.Plugin, string(evidence.Label), strconv.Itoa(evidence.Score), orDash(evidence.Reason))
+		}
+	})
+}
@@ -0,0 +1,269 @@
+// Package cli implements intelx, the command line interface to ThreatMatrix built on the SDK.
+// It lives apart from cmd/intelx so it can be run against a test server.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// These are the exit codes of intelx.
+const (
+	ExitOK    = 0
+	ExitError = 1
+	ExitUsage = 2
+)
+
+// usageError is an error in the way intelx was called, such as an unknown subcommand or a missing argument.
+type usageError struct {
+	message string
+	// reported is true when the flag package already reported the error along with the usage
+	reported bool
+}
+
+func (err *usageError) Error() string {
+	return err.message
+}
+
+// newUsageError returns a usageError with a message formatted like fmt.Sprintf.
+func newUsageError(format string, args ...interface{}) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// command is a node of the intelx command tree, it either runs or dispatches to its subcommands.
+type command struct {
+	name    string
+	summary string
+	// usage is the synopsis of the command, without the "intelx" prefix
+	usage       string
+	subcommands []*command
+	// arguments are the values the command takes as arguments, when it only takes some, for the completion scripts
+	arguments []string
+	run       func(app *app, cmd *command, args []string) error
+}
+
+// subcommand returns the subcommand called name, if any.
+func (cmd *command) subcommand(name string) *command {
+	for _, subcommand := range cmd.subcommands {
+		if subcommand.name == name {
+			return subcommand
+		}
+	}
+	return nil
+}
+
+// app holds what the commands share: the streams, the global flags and the client they configure.
+type app struct {
+	ctx     context.Context
+	stdin   io.Reader
+	stdout  io.Writer
+	stderr  io.Writer
+	url     string
+	token   string
+	profile string
+	timeout time.Duration
+	debug   bool
+	output  outputFlag
+	client  *gothreatmatrix.ThreatMatrixClient
+	// flags is the flag set created last, which is how the completion scripts learn the flags of the commands
+	flags *flag.FlagSet
+}
+
+// rootCommand returns the command tree of intelx.
+func rootCommand() *command {
+	return &command{
+		name:    "intelx",
+		summary: "interact with ThreatMatrix from the terminal",
+		usage:   "[global flags] <command> [flags] [arguments]",
+		subcommands: []*command{
+			jobsCommand(),
+			analyzeCommand(),
+			watchCommand(),
+			completionCommand(),
+		},
+	}
+}
+
+// Run runs intelx with args, the command line without the program name, and returns its exit code.
+//
+// The client is configured through the --url and --token flags, the THREATMATRIX_ environment variables
+// (see gothreatmatrix.NewClientFromEnv), or a profile of the config file (see gothreatmatrix.NewClientFromConfig),
+// in that order.
+//
+// The output of the commands is picked through --output, either the global flag or the one of the command.
+func Run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	app := &app{ctx: ctx, stdin: stdin, stdout: stdout, stderr: stderr, output: formatTable}
+	root := rootCommand()
+	flags := app.globalFlags(root)
+	if err := flags.Parse(args); err != nil {
+		return exitCode(stderr, flagError(err))
+	}
+	return exitCode(stderr, app.dispatch(root, flags.Args()))
+}
+
+// globalFlags returns the flags intelx takes before the command.
+func (app *app) globalFlags(root *command) *flag.FlagSet {
+	flags := app.newFlagSet(root)
+	flags.StringVar(&app.url, "url", "", "URL of the ThreatMatrix instance")
+	flags.StringVar(&app.token, "token", "", "API token of the ThreatMatrix instance")
+	flags.StringVar(&app.profile, "profile", "", "profile of the config file to use")
+	flags.DurationVar(&app.timeout, "timeout", 0, "timeout of each request, such as 30s")
+	flags.BoolVar(&app.debug, "debug", false, "dump the requests and responses to stderr")
+	addOutputFlag(flags, &app.output)
+	return flags
+}
+
+// exitCode reports err on stderr and returns the exit code matching it.
+func exitCode(stderr io.Writer, err error) int {
+	var usageErr *usageError
+	switch {
+	case err == nil || errors.Is(err, flag.ErrHelp):
+		return ExitOK
+	case errors.As(err, &usageErr):
+		if !usageErr.reported {
+			fmt.Fprintf(stderr, "intelx: %v\nRun 'intelx help' for usage.\n", err)
+		}
+		return ExitUsage
+	}
+	fmt.Fprintf(stderr, "intelx: %v\n", err)
+	return ExitError
+}
+
+// dispatch runs cmd, or the subcommand named by the first argument when cmd has subcommands.
+func (app *app) dispatch(cmd *command, args []string) error {
+	if cmd.run != nil {
+		return cmd.run(app, cmd, args)
+	}
+	if len(args) == 0 {
+		app.printHelp(cmd)
+		return newUsageError("%s needs a command", cmd.name)
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		if len(args) > 1 {
+			if subcommand := cmd.subcommand(args[1]); subcommand != nil {
+				return app.dispatch(subcommand, []string{"--help"})
+			}
+		}
+		app.printHelp(cmd)
+		return nil
+	}
+	subcommand := cmd.subcommand(args[0])
+	if subcommand == nil {
+		return newUsageError("unknown command %q for %s", args[0], cmd.name)
+	}
+	return app.dispatch(subcommand, args[1:])
+}
+
+// printHelp lists the subcommands of cmd.
+func (app *app) printHelp(cmd *command) {
+	fmt.Fprintf(app.stderr, "Usage: intelx %s\n\n%s\n\nCommands:\n", cmd.usage, capitalize(cmd.summary))
+	for _, subcommand := range cmd.subcommands {
+		fmt.Fprintf(app.stderr, "  %-10s %s\n", subcommand.name, subcommand.summary)
+	}
+}
+
+// newFlagSet returns a flag set reporting its errors and usage on stderr instead of exiting.
+func (app *app) newFlagSet(cmd *command) *flag.FlagSet {
+	flags := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	flags.SetOutput(app.stderr)
+	app.flags = flags
+	flags.Usage = func() {
+		fmt.Fprintf(app.stderr, "Usage: intelx %s\n\n%s\n", cmd.usage, capitalize(cmd.summary))
+		hasFlags := false
+		flags.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			fmt.Fprintf(app.stderr, "\nFlags:\n")
+			flags.PrintDefaults()
+		}
+	}
+	return flags
+}
+
+// parseFlags parses args, letting the flags come after the arguments as well, and returns the arguments.
+// Everything after "--" is an argument.
+func parseFlags(flags *flag.FlagSet, args []string) ([]string, error) {
+	arguments := []string{}
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, flagError(err)
+		}
+		rest := flags.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(arguments, rest...), nil
+		}
+		if len(rest) == 0 {
+			return arguments, nil
+		}
+		arguments = append(arguments, rest[0])
+		args = rest[1:]
+	}
+}
+
+// stringsFlag is a flag that can be repeated, collecting every value it's given.
+type stringsFlag []string
+
+func (values *stringsFlag) String() string {
+	return strings.Join(*values, ",")
+}
+
+func (values *stringsFlag) Set(value string) error {
+	*values = append(*values, value)
+	return nil
+}
+
+// flagError turns an error of the flag package into a usageError, flag.ErrHelp being kept as is.
+func flagError(err error) error {
+	if errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return &usageError{message: err.Error(), reported: true}
+}
+
+// capitalize upper cases the first letter of s.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// threatMatrix returns the client configured through the global flags, building it the first time.
+func (app *app) threatMatrix() (*gothreatmatrix.ThreatMatrixClient, error) {
+	if app.client != nil {
+		return app.client, nil
+	}
+	opts := []gothreatmatrix.Option{
+		gothreatmatrix.WithUserAgent(gothreatmatrix.DefaultUserAgent + " intelx"),
+		gothreatmatrix.WithLoggerParams(&gothreatmatrix.LoggerParams{File: app.stderr}),
+	}
+	if app.url != "" {
+		opts = append(opts, gothreatmatrix.WithURL(app.url))
+	}
+	if app.token != "" {
+		opts = append(opts, gothreatmatrix.WithToken(app.token))
+	}
+	if app.timeout > 0 {
+		opts = append(opts, gothreatmatrix.WithTimeout(app.timeout))
+	}
+	if app.debug {
+		opts = append(opts, gothreatmatrix.WithDebug(app.stderr))
+	}
+	var err error
+	switch {
+	case app.profile == "" && app.url != "":
+		app.client = gothreatmatrix.NewClient(opts...)
+	case app.profile == "" && os.Getenv(gothreatmatrix.EnvURL) != "":
+		app.client, err = gothreatmatrix.NewClientFromEnv(opts...)
+	default:
+		app.client, err = gothreatmatrix.NewClientFromConfig(app.profile, opts...)
+	}
+	return app.client, err
+}
@@ -0,0 +1,236 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// completionShells are the shells "intelx completion" prints a script for.
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// completionCommand returns the "completion" command.
+func completionCommand() *command {
+	return &command{
+		name:      "completion",
+		summary:   "print the script completing the commands and flags of intelx in bash, zsh or fish",
+		usage:     "completion <bash|zsh|fish>",
+		arguments: completionShells,
+		run:       runCompletion,
+	}
+}
+
+// completionNode is a command as the completion scripts see it.
+type completionNode struct {
+	// path is the names of the command and its parents joined by slashes, such as "intelx/jobs/list"
+	path    string
+	command *command
+	flags   []*flag.Flag
+}
+
+// choicesValue is a flag value the completion scripts can offer the values of.
+type choicesValue interface {
+	choices() []string
+}
+
+// runCompletion prints the completion script of the shell given as argument.
+func runCompletion(app *app, cmd *command, args []string) error {
+	flags := app.newFlagSet(cmd)
+	flags.Usage = func() {
+		fmt.Fprintf(app.stderr, "Usage: intelx %s\n\n%s\n\n", cmd.usage, capitalize(cmd.summary))
+		fmt.Fprintf(app.stderr, "Load it in the current shell with:\n")
+		fmt.Fprintf(app.stderr, "  bash: source <(intelx completion bash)\n")
+		fmt.Fprintf(app.stderr, "  zsh:  source <(intelx completion zsh)\n")
+		fmt.Fprintf(app.stderr, "  fish: intelx completion fish | source\n")
+	}
+	arguments, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(arguments) != 1 {
+		return newUsageError("completion takes a single shell, one of %s", strings.Join(completionShells, ", "))
+	}
+	nodes := completionNodes()
+	switch arguments[0] {
+	case "bash":
+		return writeBashCompletion(app.stdout, nodes)
+	case "zsh":
+		// zsh runs the bash script through its emulation of the bash completion
+		fmt.Fprintf(app.stdout, "# zsh completion for intelx, generated by \"intelx completion zsh\".\n")
+		fmt.Fprintf(app.stdout, "autoload -U +X bashcompinit && bashcompinit\n")
+		return writeBashCompletion(app.stdout, nodes)
+	case "fish":
+		return writeFishCompletion(app.stdout, nodes)
+	}
+	return newUsageError("unknown shell %q, pick one of %s", arguments[0], strings.Join(completionShells, ", "))
+}
+
+// completionNodes returns the commands of intelx along with their flags, the root command first.
+// Every command defines its flags before doing anything else, so running it with --help collects them
+// without side effects.
+func completionNodes() []completionNode {
+	scratch := &app{ctx: context.Background(), stdin: strings.NewReader(""), stdout: io.Discard, stderr: io.Discard}
+	root := rootCommand()
+	nodes := []completionNode{{path: root.name, command: root, flags: visitFlags(scratch.globalFlags(root))}}
+	var walk func(path string, cmd *command)
+	walk = func(path string, cmd *command) {
+		for _, subcommand := range cmd.subcommands {
+			node := completionNode{path: path + "/" + subcommand.name, command: subcommand}
+			if subcommand.run != nil {
+				scratch.flags = nil
+				_ = subcommand.run(scratch, subcommand, []string{"--help"})
+				if scratch.flags != nil {
+					node.flags = visitFlags(scratch.flags)
+				}
+			}
+			nodes = append(nodes, node)
+			walk(node.path, subcommand)
+		}
+	}
+	walk(root.name, root)
+	return nodes
+}
+
+// visitFlags returns the flags of the flag set, sorted by name.
+func visitFlags(flags *flag.FlagSet) []*flag.Flag {
+	visited := []*flag.Flag{}
+	flags.VisitAll(func(f *flag.Flag) { visited = append(visited, f) })
+	return visited
+}
+
+// words returns what the completion scripts offer after the command: its subcommands, or its arguments and flags.
+func (node *completionNode) words() []string {
+	words := append([]string{}, node.command.arguments...)
+	for _, subcommand := range node.command.subcommands {
+		words = append(words, subcommand.name)
+	}
+	if len(node.command.subcommands) > 0 {
+		words = append(words, "help")
+	}
+	for _, f := range node.flags {
+		words = append(words, flagSpelling(f))
+	}
+	return words
+}
+
+// flagSpelling returns how the flag is typed: "-o" for a single letter, "--output" otherwise.
+func flagSpelling(f *flag.Flag) string {
+	if len(f.Name) == 1 {
+		return "-" + f.Name
+	}
+	return "--" + f.Name
+}
+
+// isBoolFlag tells whether the flag takes no value.
+func isBoolFlag(f *flag.Flag) bool {
+	boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && boolFlag.IsBoolFlag()
+}
+
+// flagChoices returns the values offered for every flag with choices, by spelling.
+func flagChoices(nodes []completionNode) map[string][]string {
+	choices := map[string][]string{}
+	for _, node := range nodes {
+		for _, f := range node.flags {
+			if value, ok := f.Value.(choicesValue); ok {
+				choices[flagSpelling(f)] = value.choices()
+			}
+		}
+	}
+	return choices
+}
+
+// writeBashCompletion writes the bash completion script. It finds the command being completed by
+// following the known command names among the words typed so far, then offers its subcommands or flags,
+// falling back to the file names.
+func writeBashCompletion(w io.Writer, nodes []completionNode) error {
+	paths := []string{}
+	for _, node := range nodes[1:] {
+		paths = append(paths, node.path)
+	}
+	choices := flagChoices(nodes)
+	spellings := []string{}
+	for spelling := range choices {
+		spellings = append(spellings, spelling)
+	}
+	sort.Strings(spellings)
+
+	script := &strings.Builder{}
+	script.WriteString("# bash completion for intelx, generated by \"intelx completion bash\".\n")
+	script.WriteString("_intelx() {\n")
+	script.WriteString("    local cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	script.WriteString("    local path=intelx words=\"\" index\n")
+	script.WriteString("    for ((index = 1; index < COMP_CWORD; index++)); do\n")
+	script.WriteString("        case \"$path/${COMP_WORDS[index]}\" in\n")
+	fmt.Fprintf(script, "        %s) path=\"$path/${COMP_WORDS[index]}\" ;;\n", strings.Join(paths, "|"))
+	script.WriteString("        esac\n")
+	script.WriteString("    done\n")
+	script.WriteString("    case \"$prev\" in\n")
+	for _, spelling := range spellings {
+		fmt.Fprintf(script, "    %s) COMPREPLY=($(compgen -W \"%s\" -- \"$cur\")); return ;;\n", spelling, strings.Join(choices[spelling], " "))
+	}
+	script.WriteString("    esac\n")
+	script.WriteString("    case \"$path\" in\n")
+	for _, node := range nodes {
+		fmt.Fprintf(script, "    %s) words=\"%s\" ;;\n", node.path, strings.Join(node.words(), " "))
+	}
+	script.WriteString("    esac\n")
+	script.WriteString("    COMPREPLY=($(compgen -W \"$words\" -- \"$cur\"))\n")
+	script.WriteString("}\n")
+	script.WriteString("complete -o default -F _intelx intelx\n")
+	_, err := io.WriteString(w, script.String())
+	return err
+}
+
+// writeFishCompletion writes the fish completion script, a complete command per subcommand and flag
+// conditioned on the command being completed.
+func writeFishCompletion(w io.Writer, nodes []completionNode) error {
+	paths := []string{}
+	for _, node := range nodes[1:] {
+		paths = append(paths, node.path)
+	}
+
+	script := &strings.Builder{}
+	script.WriteString("# fish completion for intelx, generated by \"intelx completion fish\".\n")
+	script.WriteString("function __intelx_command_is\n")
+	script.WriteString("    set -l path intelx\n")
+	script.WriteString("    for word in (commandline -opc)[2..-1]\n")
+	fmt.Fprintf(script, "        if contains -- $path/$word %s\n", strings.Join(paths, " "))
+	script.WriteString("            set path $path/$word\n")
+	script.WriteString("        end\n")
+	script.WriteString("    end\n")
+	script.WriteString("    test $path = $argv[1]\n")
+	script.WriteString("end\n")
+	for _, node := range nodes {
+		condition := fishQuote("__intelx_command_is " + node.path)
+		for _, subcommand := range node.command.subcommands {
+			fmt.Fprintf(script, "complete -c intelx -n %s -f -a %s -d %s\n", condition, subcommand.name, fishQuote(subcommand.summary))
+		}
+		if len(node.command.arguments) > 0 {
+			fmt.Fprintf(script, "complete -c intelx -n %s -f -a %s\n", condition, fishQuote(strings.Join(node.command.arguments, " ")))
+		}
+		for _, f := range node.flags {
+			option := "-l " + f.Name
+			if len(f.Name) == 1 {
+				option = "-s " + f.Name
+			}
+			switch value, ok := f.Value.(choicesValue); {
+			case ok:
+				option += " -x -a " + fishQuote(strings.Join(value.choices(), " "))
+			case !isBoolFlag(f):
+				option += " -r"
+			}
+			fmt.Fprintf(script, "complete -c intelx -n %s %s -d %s\n", condition, option, fishQuote(f.Usage))
+		}
+	}
+	_, err := io.WriteString(w, script.String())
+	return err
+}
+
+// fishQuote quotes s between single quotes for fish.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,421 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// DefaultListLimit is how many jobs "intelx jobs list" prints when --limit isn't given.
+const DefaultListLimit = 50
+
+// maxListPageSize is the largest page fetched by "intelx jobs list".
+const maxListPageSize = 100
+
+// errLimitReached stops the stream of jobs once enough of them were listed.
+var errLimitReached = errors.New("limit reached")
+
+// jobsCommand returns the "jobs" command and its subcommands.
+func jobsCommand() *command {
+	return &command{
+		name:    "jobs",
+		summary: "list, inspect and manage jobs",
+		usage:   "jobs <command> [flags] [arguments]",
+		subcommands: []*command{
+			{name: "list", summary: "list the jobs matching the filters", usage: "jobs list [flags]", run: runJobsList},
+			{name: "get", summary: "print a job and the status of its reports", usage: "jobs get [flags] <job ID>", run: runJobsGet},
+			{name: "kill", summary: "stop running jobs", usage: "jobs kill [flags] <job ID>...", run: runJobsKill},
+			{name: "delete", summary: "delete jobs", usage: "jobs delete [flags] <job ID>...", run: runJobsDelete},
+			{name: "download", summary: "download the sample of a file job", usage: "jobs download [flags] <job ID>", run: runJobsDownload},
+			{name: "tail", summary: "print the jobs as they show up and change state", usage: "jobs tail [flags]", run: runJobsTail},
+		},
+	}
+}
+
+// jobOutput is how the jobs commands print a job.
+type jobOutput struct {
+	ID       int                       `json:"id"`
+	Name     string                    `json:"name"`
+	Type     string                    `json:"type"`
+	IsSample bool                      `json:"is_sample"`
+	MD5      string                    `json:"md5"`
+	Status   string                    `json:"status"`
+	TLP      string                    `json:"tlp"`
+	Tags     []string                  `json:"tags"`
+	User     string                    `json:"user"`
+	Received *gothreatmatrix.Timestamp `json:"received"`
+	Finished *gothreatmatrix.Timestamp `json:"finished"`
+	Errors   []string                  `json:"errors"`
+	Reports  []reportOutput            `json:"reports,omitempty"`
+}
+
+// reportOutput is the status of a report of a jobOutput, only printed by "intelx jobs get".
+type reportOutput struct {
+	Plugin string   `json:"plugin"`
+	Type   string   `json:"type"`
+	Status string   `json:"status"`
+	Errors []string `json:"errors"`
+}
+
+// newJobOutput returns the jobOutput of the job, without its reports.
+func newJobOutput(job *gothreatmatrix.BaseJob) jobOutput {
+	output := jobOutput{
+		ID:       job.ID,
+		Name:     job.ObservableName,
+		Type:     job.ObservableClassification,
+		IsSample: job.IsSample,
+		MD5:      job.Md5,
+		Status:   string(job.Status),
+		TLP:      job.Tlp.String(),
+		Tags:     []string{},
+		User:     job.User.Username,
+		Received: job.ReceivedRequestTime,
+		Finished: job.FinishedAnalysisTime,
+		Errors:   []string{},
+	}
+	if job.IsSample {
+		output.Name, output.Type = job.FileName, job.FileMimetype
+	}
+	for _, tag := range job.Tags {
+		output.Tags = append(output.Tags, tag.Label)
+	}
+	output.Errors = append(output.Errors, job.Errors...)
+	return output
+}
+
+func (job jobOutput) header() []string {
+	return []string{"id", "status", "tlp", "type", "name", "received"}
+}
+
+func (job jobOutput) rows() [][]string {
+	return [][]string{{strconv.Itoa(job.ID), job.Status, job.TLP, job.Type, job.Name, formatRecordTime(job.Received)}}
+}
+
+// jobsOutput is how "intelx jobs list" prints the jobs.
+type jobsOutput []jobOutput
+
+func (jobs jobsOutput) header() []string {
+	return jobOutput{}.header()
+}
+
+func (jobs jobsOutput) rows() [][]string {
+	rows := [][]string{}
+	for _, job := range jobs {
+		rows = append(rows, job.rows()...)
+	}
+	return rows
+}
+
+// runJobsList lists the jobs matching the filters given through the flags.
+func runJobsList(app *app, cmd *command, args []string) error {
+	flags := app.newFlagSet(cmd)
+	params := &gothreatmatrix.JobListParams{}
+	status := flags.String("status", "", "only list the jobs with this status, such as reported_with_fails")
+	tlp := flags.String("tlp", "", "only list the jobs with this TLP")
+	flags.StringVar(&params.ObservableClassification, "type", "", "only list the observables of this classification, such as ip or domain")
+	flags.StringVar(&params.ObservableName, "name", "", "only list the jobs of this observable")
+	flags.StringVar(&params.FileName, "file-name", "", "only list the jobs of this file name")
+	flags.StringVar(&params.Md5, "md5", "", "only list the jobs of the observable or file with this MD5")
+	flags.StringVar(&params.Analyzer, "analyzer", "", "only list the jobs that ran this analyzer")
+	flags.StringVar(&params.Tag, "tag", "", "only list the jobs tagged with this label")
+	flags.StringVar(&params.User, "user", "", "only list the jobs submitted by this username")
+	after := flags.String("after", "", "only list the jobs received after this time, a date, an RFC 3339 time or a duration ago such as 24h")
+	before := flags.String("before", "", "only list the jobs received before this time, in the same formats as --after")
+	flags.StringVar(&params.Ordering, "ordering", "-received_request_time", "field to sort the jobs by, prefixed with - for a descending order")
+	limit := flags.Int("limit", DefaultListLimit, "how many jobs to list at most, 0 listing all of them")
+	addOutputFlag(flags, &app.output)
+	arguments, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(arguments) > 0 {
+		return newUsageError("jobs list takes no arguments, got %q", arguments[0])
+	}
+	params.Status = gothreatmatrix.JobStatus(*status)
+	if *tlp != "" {
+		if params.Tlp = gothreatmatrix.ParseTLP(*tlp); params.Tlp == "" {
+			return newUsageError("unknown TLP %q", *tlp)
+		}
+	}
+	if params.ReceivedRequestTimeAfter, err = parseTimeFlag("after", *after); err != nil {
+		return err
+	}
+	if params.ReceivedRequestTimeBefore, err = parseTimeFlag("before", *before); err != nil {
+		return err
+	}
+	params.PageSize = maxListPageSize
+	if *limit > 0 && *limit < maxListPageSize {
+		params.PageSize = *limit
+	}
+
+	client, err := app.threatMatrix()
+	if err != nil {
+		return err
+	}
+	jobs := jobsOutput{}
+	err = client.JobService.Stream(app.ctx, params, func(job *gothreatmatrix.JobList) error {
+		jobs = append(jobs, newJobOutput(&job.BaseJob))
+		if *limit > 0 && len(jobs) >= *limit {
+			return errLimitReached
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errLimitReached) {
+		return err
+	}
+	return writeOutput(app.stdout, app.output, jobs, func(table *tabwriter.Writer) {
+		writeRow(table, "ID", "STATUS", "TLP", "TYPE", "NAME", "RECEIVED")
+		for _, job := range jobs {
+			writeRow(table, strconv.Itoa(job.ID), job.Status, job.TLP, orDash(job.Type), orDash(job.Name), formatTime(job.Received))
+		}
+	})
+}
+
+// runJobsGet prints a job along with the status of its reports.
+func runJobsGet(app *app, cmd *command, args []string) error {
+	flags := app.newFlagSet(cmd)
+	addOutputFlag(flags, &app.output)
+	arguments, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(arguments) != 1 {
+		return newUsageError("jobs get takes a single job ID")
+	}
+	jobId, err := parseJobID(arguments[0])
+	if err != nil {
+		return err
+	}
+	client, err := app.threatMatrix()
+	if err != nil {
+		return err
+	}
+	job, err := client.JobService.Get(app.ctx, jobId)
+	if err != nil {
+		return err
+	}
+	output := newJobOutput(&job.BaseJob)
+	output.Reports = []reportOutput{}
+	for pluginIndex, reports := range [][]gothreatmatrix.Report{job.AnalyzerReports, job.ConnectorReports} {
+		pluginType := [...]string{"analyzer", "connector"}[pluginIndex]
+		for _, report := range reports {
+			output.Reports = append(output.Reports, reportOutput{Plugin: report.Name, Type: pluginType, Status: report.Status, Errors: append([]string{}, report.Errors...)})
+		}
+	}
+	return writeOutput(app.stdout, app.output, output, func(table *tabwriter.Writer) {
+		writeRow(table, "ID:", strconv.Itoa(output.ID))
+		writeRow(table, "Name:", orDash(output.Name))
+		writeRow(table, "Type:", orDash(output.Type))
+		writeRow(table, "Status:", output.Status)
+		writeRow(table, "TLP:", output.TLP)
+		writeRow(table, "Tags:", orDash(strings.Join(output.Tags, ", ")))
+		writeRow(table, "User:", orDash(output.User))
+		writeRow(table, "Received:", formatTime(output.Received))
+		writeRow(table, "Finished:", formatTime(output.Finished))
+		for _, jobError := range output.Errors {
+			writeRow(table, "Error:", jobError)
+		}
+		if len(output.Reports) == 0 {
+			return
+		}
+		writeRow(table)
+		writeRow(table, "PLUGIN", "TYPE", "STATUS")
+		for _, report := range output.Reports {
+			writeRow(table, report.Plugin, report.Type, report.Status)
+		}
+	})
+}
+
+// jobActionResult is the outcome of killing or deleting a job.
+type jobActionResult struct {
+	ID    uint64 `json:"id"`
+	Done  bool   `json:"done"`
+	Error string `json:"error"`
+}
+
+// jobActionResults is how "intelx jobs kill" and "intelx jobs delete" print the outcome of their actions.
+type jobActionResults []jobActionResult
+
+func (results jobActionResults) header() []string {
+	return []string{"id", "done", "error"}
+}
+
+func (results jobActionResults) rows() [][]string {
+	rows := [][]string{}
+	for _, result := range results {
+		rows = append(rows, []string{strconv.FormatUint(result.ID, 10), strconv.FormatBool(result.Done), result.Error})
+	}
+	return rows
+}
+
+// runJobsKill stops the given jobs.
+func runJobsKill(app *app, cmd *command, args []string) error {
+	return runJobsAction(app, cmd, "killed", args, func(client *gothreatmatrix.ThreatMatrixClient, jobId uint64) (bool, error) {
+		return client.JobService.Kill(app.ctx, jobId)
+	})
+}
+
+// runJobsDelete deletes the given jobs.
+func runJobsDelete(app *app, cmd *command, args []string) error {
+	return runJobsAction(app, cmd, "deleted", args, func(client *gothreatmatrix.ThreatMatrixClient, jobId uint64) (bool, error) {
+		return client.JobService.Delete(app.ctx, jobId)
+	})
+}
+
+// runJobsAction runs action on every job given as argument, going on when it fails for one of them.
+// It fails once done when the action failed for any of the jobs.
+func runJobsAction(app *app, cmd *command, done string, args []string, action func(client *gothreatmatrix.ThreatMatrixClient, jobId uint64) (bool, error)) error {
+	flags := app.newFlagSet(cmd)
+	addOutputFlag(flags, &app.output)
+	arguments, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(arguments) == 0 {
+		return newUsageError("jobs %s takes at least one job ID", cmd.name)
+	}
+	jobIds := make([]uint64, len(arguments))
+	for index, argument := range arguments {
+		if jobIds[index], err = parseJobID(argument); err != nil {
+			return err
+		}
+	}
+	client, err := app.threatMatrix()
+	if err != nil {
+		return err
+	}
+	results := make(jobActionResults, len(jobIds))
+	failed := 0
+	for index, jobId := range jobIds {
+		results[index].ID = jobId
+		results[index].Done, err = action(client, jobId)
+		if err != nil {
+			results[index].Error = err.Error()
+		}
+		if !results[index].Done {
+			failed++
+		}
+	}
+	err = writeOutput(app.stdout, app.output, results, func(table *tabwriter.Writer) {
+		for _, result := range results {
+			switch {
+			case result.Error != "":
+				writeRow(table, fmt.Sprintf("job %d", result.ID), "failed: "+strings.ReplaceAll(result.Error, "\n", " "))
+			case result.Done:
+				writeRow(table, fmt.Sprintf("job %d", result.ID), done)
+			default:
+				writeRow(table, fmt.Sprintf("job %d", result.ID), "not "+done)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d jobs could not be %s", failed, len(jobIds), done)
+	}
+	return nil
+}
+
+// runJobsDownload saves the sample of a file job.
+func runJobsDownload(app *app, cmd *command, args []string) error {
+	flags := app.newFlagSet(cmd)
+	path := flags.String("file", "", `file to save the sample to, "-" writing it to stdout (default "job_<job ID>_sample", or ".zip" with --zip)`)
+	zipped := flags.Bool("zip", false, "save the sample in an encrypted zip archive")
+	password := flags.String("password", gothreatmatrix.DefaultSamplePassword, "password of the zip archive")
+	arguments, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(arguments) != 1 {
+		return newUsageError("jobs download takes a single job ID")
+	}
+	jobId, err := parseJobID(arguments[0])
+	if err != nil {
+		return err
+	}
+	client, err := app.threatMatrix()
+	if err != nil {
+		return err
+	}
+	if *path == "" {
+		*path = fmt.Sprintf("job_%d_sample", jobId)
+		if *zipped {
+			*path += ".zip"
+		}
+	}
+	download := func(writer io.Writer) error {
+		if *zipped {
+			return client.JobService.DownloadSampleZipped(app.ctx, jobId, *password, writer)
+		}
+		_, err := client.JobService.DownloadSampleTo(app.ctx, jobId, writer)
+		return err
+	}
+	if *path == "-" {
+		return download(app.stdout)
+	}
+	file, err := os.Create(*path)
+	if err != nil {
+		return err
+	}
+	err = download(file)
+	if closeError := file.Close(); err == nil {
+		err = closeError
+	}
+	if err != nil {
+		// a partial sample is worse than none
+		os.Remove(*path)
+		return err
+	}
+	fmt.Fprintf(app.stdout, "job %d sample saved to %s\n", jobId, *path)
+	return nil
+}
+
+// parseJobID parses a job ID given as argument.
+func parseJobID(argument string) (uint64, error) {
+	jobId, err := strconv.ParseUint(argument, 10, 64)
+	if err != nil || jobId == 0 {
+		return 0, newUsageError("invalid job ID %q", argument)
+	}
+	return jobId, nil
+}
+
+// parseTimeFlag parses the value of a time flag: a date, an RFC 3339 time, or a duration before now.
+// An empty value is the zero time.
+func parseTimeFlag(name string, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if duration, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-duration), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if parsed, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, newUsageError("invalid --%s %q, expected a date, an RFC 3339 time or a duration", name, value)
+}
+
+// jobName returns the observable or the file name of the job.
+func jobName(job *gothreatmatrix.BaseJob) string {
+	if job.IsSample {
+		return orDash(job.FileName)
+	}
+	return orDash(job.ObservableName)
+}
+
+// jobType returns the classification of the observable or the MIME type of the file of the job.
+func jobType(job *gothreatmatrix.BaseJob) string {
+	if job.IsSample {
+		return orDash(job.FileMimetype)
+	}
+	return orDash(job.ObservableClassification)
+}
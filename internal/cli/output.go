@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+	"gopkg.in/yaml.v3"
+)
+
+// These are the formats the commands can print their output in.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+	formatTSV   = "tsv"
+)
+
+// outputFormats are the values --output accepts.
+var outputFormats = []string{formatTable, formatJSON, formatYAML, formatTSV}
+
+// outputFlag is the value of the --output flag, it only accepts the outputFormats.
+type outputFlag string
+
+func (output *outputFlag) String() string {
+	return string(*output)
+}
+
+func (output *outputFlag) Set(value string) error {
+	for _, format := range outputFormats {
+		if value == format {
+			*output = outputFlag(value)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown output format %q, pick one of %s", value, strings.Join(outputFormats, ", "))
+}
+
+// choices returns the values the completion scripts offer for the flag.
+func (output *outputFlag) choices() []string {
+	return outputFormats
+}
+
+// addOutputFlag adds the --output flag, and its -o shorthand, to flags. Every command sets the same output,
+// so the global --output is the default of the one of the command.
+func addOutputFlag(flags *flag.FlagSet, output *outputFlag) {
+	usage := "output format: " + strings.Join(outputFormats, ", ")
+	flags.Var(output, "output", usage)
+	flags.Var(output, "o", "shorthand for --output")
+}
+
+// records is an output that can be written as tab separated values: a header naming the columns after the
+// JSON fields, then a row per record.
+type records interface {
+	header() []string
+	rows() [][]string
+}
+
+// writeOutput writes value as JSON, YAML or tab separated values, or calls table to write it as a table.
+// YAML is converted from the JSON encoding so both formats share the same field names, and the value must
+// implement records to be written as tab separated values.
+//
+// The values are the output schemas of the commands rather than the types of the SDK, so the output stays
+// the same for the scripts parsing it whatever ThreatMatrix adds to its API.
+func writeOutput(w io.Writer, format outputFlag, value interface{}, table func(table *tabwriter.Writer)) error {
+	switch format {
+	case formatTSV:
+		tabular, ok := value.(records)
+		if !ok {
+			return fmt.Errorf("this command has no %s output", formatTSV)
+		}
+		return writeTSV(w, tabular, true)
+	case formatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case formatYAML:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		var generic interface{}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(generic); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+	tableWriter := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	table(tableWriter)
+	return tableWriter.Flush()
+}
+
+// cellReplacer replaces the tabs and line breaks of the cells of the tab separated values, which would shift their columns.
+var cellReplacer = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+
+// writeTSV writes the rows of the records as tab separated values, after their header when withHeader is true.
+func writeTSV(w io.Writer, tabular records, withHeader bool) error {
+	rows := tabular.rows()
+	if withHeader {
+		rows = append([][]string{tabular.header()}, rows...)
+	}
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for index, cell := range row {
+			cells[index] = cellReplacer.Replace(cell)
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(cells, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatRecordTime formats a time of the tab separated values, an unset time being empty.
+func formatRecordTime(value *gothreatmatrix.Timestamp) string {
+	if value == nil || value.IsZero() {
+		return ""
+	}
+	return value.UTC().Format(time.RFC3339)
+}
+
+// writeRow writes the cells as a row of the table.
+func writeRow(table io.Writer, cells ...string) {
+	fmt.Fprintln(table, strings.Join(cells, "\t"))
+}
+
+// formatTime formats a time of the table output, an unset time being "-".
+func formatTime(value *gothreatmatrix.Timestamp) string {
+	if value == nil || value.IsZero() {
+		return "-"
+	}
+	return value.Local().Format("2006-01-02 15:04:05")
+}
+
+// orDash returns value, or "-" when it's empty so the columns of the table stay aligned.
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
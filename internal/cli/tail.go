@@ -0,0 +1,261 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+	"gopkg.in/yaml.v3"
+)
+
+// These are the defaults of "intelx jobs tail".
+const (
+	DefaultTailInterval = 5 * time.Second
+	DefaultTailWindow   = 100
+	// MaxTailBackoff caps how long "intelx jobs tail" waits before listing the jobs again after failing to,
+	// the wait doubling from --interval on every consecutive failure.
+	MaxTailBackoff = 2 * time.Minute
+)
+
+// tailRowFormat lays out the rows "intelx jobs tail" prints as a table, as they can't be aligned once printed.
+const tailRowFormat = "%-8s  %-22s  %-5s  %-12s  %s\n"
+
+// jobFilter is a --filter expression of "intelx jobs tail", such as "status=failed" or "name~^10\.".
+type jobFilter struct {
+	field    string
+	operator string
+	value    string
+	pattern  *regexp.Regexp
+}
+
+// jobFilterFields gives the values of every field a jobFilter can match.
+var jobFilterFields = map[string]func(job *gothreatmatrix.BaseJob) []string{
+	"id":     func(job *gothreatmatrix.BaseJob) []string { return []string{strconv.Itoa(job.ID)} },
+	"status": func(job *gothreatmatrix.BaseJob) []string { return []string{string(job.Status)} },
+	"tlp":    func(job *gothreatmatrix.BaseJob) []string { return []string{job.Tlp.String()} },
+	"type":   func(job *gothreatmatrix.BaseJob) []string { return []string{jobType(job)} },
+	"name":   func(job *gothreatmatrix.BaseJob) []string { return []string{jobName(job)} },
+	"user":   func(job *gothreatmatrix.BaseJob) []string { return []string{job.User.Username} },
+	"tag": func(job *gothreatmatrix.BaseJob) []string {
+		labels := []string{}
+		for _, tag := range job.Tags {
+			labels = append(labels, tag.Label)
+		}
+		return labels
+	},
+}
+
+// filterExpression splits a --filter expression into its field, operator and value.
+var filterExpression = regexp.MustCompile(`^\s*([a-z]+)\s*(!=|!~|=|~)(.*)$`)
+
+// parseJobFilter parses a --filter expression: a field among id, status, tlp, type, name, user and tag,
+// an operator among = (equals), != (differs), ~ (matches the regular expression) and !~ (doesn't match it) and a value.
+func parseJobFilter(expression string) (*jobFilter, error) {
+	match := filterExpression.FindStringSubmatch(expression)
+	if match == nil {
+		return nil, newUsageError("invalid --filter %q, expected <field><=|!=|~|!~><value>", expression)
+	}
+	filter := &jobFilter{field: match[1], operator: match[2], value: match[3]}
+	if _, ok := jobFilterFields[filter.field]; !ok {
+		return nil, newUsageError("invalid --filter %q, unknown field %q", expression, filter.field)
+	}
+	if strings.HasSuffix(filter.operator, "~") {
+		pattern, err := regexp.Compile(filter.value)
+		if err != nil {
+			return nil, newUsageError("invalid --filter %q: %v", expression, err)
+		}
+		filter.pattern = pattern
+	}
+	return filter, nil
+}
+
+// matches tells whether the job passes the filter. A field with several values, such as the tags, passes
+// = and ~ when any of its values does, and != and !~ when none of them matches.
+func (filter *jobFilter) matches(job *gothreatmatrix.BaseJob) bool {
+	found := false
+	for _, value := range jobFilterFields[filter.field](job) {
+		if filter.pattern != nil {
+			found = filter.pattern.MatchString(value)
+		} else {
+			found = strings.EqualFold(value, filter.value)
+		}
+		if found {
+			break
+		}
+	}
+	return found == !strings.HasPrefix(filter.operator, "!")
+}
+
+// runJobsTail prints the most recent jobs, then every job that shows up or changes state until intelx is interrupted.
+// A failure to list the jobs, such as ThreatMatrix being restarted, is reported on stderr and the jobs are listed again
+// after a backoff, only the failures that won't go away by themselves, such as a revoked token, stopping it.
+func runJobsTail(app *app, cmd *command, args []string) error {
+	flags := app.newFlagSet(cmd)
+	filterExpressions := stringsFlag{}
+	flags.Var(&filterExpressions, "filter", `only print the jobs matching the expression, such as "status=failed", "tlp!=RED" or "name~\.exe$", can be repeated`)
+	interval := flags.Duration("interval", DefaultTailInterval, "how often the jobs are fetched again")
+	window := flags.Int("window", DefaultTailWindow, "how many of the most recent jobs are followed")
+	addOutputFlag(flags, &app.output)
+	arguments, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(arguments) > 0 {
+		return newUsageError("jobs tail takes no arguments, got %q", arguments[0])
+	}
+	if *interval <= 0 || *window < 1 {
+		return newUsageError("--interval and --window must be positive")
+	}
+	filters := []*jobFilter{}
+	for _, expression := range filterExpressions {
+		filter, err := parseJobFilter(expression)
+		if err != nil {
+			return err
+		}
+		filters = append(filters, filter)
+	}
+	client, err := app.threatMatrix()
+	if err != nil {
+		return err
+	}
+
+	printer := &tailPrinter{writer: app.stdout, format: app.output}
+	params := &gothreatmatrix.JobListParams{Ordering: "-received_request_time", PageSize: *window}
+	statuses := map[int]gothreatmatrix.JobStatus{}
+	failures := 0
+	for {
+		jobs, err := client.JobService.List(app.ctx, params)
+		if app.ctx.Err() != nil {
+			// being interrupted is the way to stop tailing
+			return nil
+		}
+		if err != nil {
+			if isPermanentTailError(err) {
+				return err
+			}
+			failures++
+			delay := tailBackoff(*interval, failures)
+			fmt.Fprintf(app.stderr, "intelx: listing the jobs: %v, retrying in %s\n", err, delay)
+			select {
+			case <-app.ctx.Done():
+				return nil
+			case <-time.After(delay):
+			}
+			continue
+		}
+		failures = 0
+		// the oldest jobs first, so the changes are printed in the order they're likely to have happened
+		for index := len(jobs.Results) - 1; index >= 0; index-- {
+			job := &jobs.Results[index].BaseJob
+			if status, ok := statuses[job.ID]; ok && status == job.Status {
+				continue
+			}
+			statuses[job.ID] = job.Status
+			if matchesFilters(filters, job) {
+				if err := printer.print(newJobOutput(job)); err != nil {
+					return err
+				}
+			}
+		}
+		if len(statuses) > 4*(*window) {
+			// forget the jobs that fell out of the window
+			followed := map[int]gothreatmatrix.JobStatus{}
+			for _, job := range jobs.Results {
+				followed[job.ID] = job.Status
+			}
+			statuses = followed
+		}
+		select {
+		case <-app.ctx.Done():
+			return nil
+		case <-time.After(*interval):
+		}
+	}
+}
+
+// isPermanentTailError tells whether listing the jobs failed in a way that listing them again won't fix: the
+// credentials being rejected or the request being invalid. The others, such as ThreatMatrix being unreachable,
+// answering 5xx or its circuit being open, are transient.
+func isPermanentTailError(err error) bool {
+	return errors.Is(err, gothreatmatrix.ErrUnauthorized) || errors.Is(err, gothreatmatrix.ErrForbidden) || errors.Is(err, gothreatmatrix.ErrValidation)
+}
+
+// tailBackoff returns how long to wait before listing the jobs again after failures consecutive failures: interval
+// doubled on every failure, up to MaxTailBackoff or interval when it's longer.
+func tailBackoff(interval time.Duration, failures int) time.Duration {
+	limit := MaxTailBackoff
+	if interval > limit {
+		limit = interval
+	}
+	delay := interval
+	for attempt := 1; attempt < failures && delay < limit; attempt++ {
+		delay *= 2
+	}
+	if delay > limit {
+		delay = limit
+	}
+	return delay
+}
+
+// matchesFilters tells whether the job passes every filter.
+func matchesFilters(filters []*jobFilter, job *gothreatmatrix.BaseJob) bool {
+	for _, filter := range filters {
+		if !filter.matches(job) {
+			return false
+		}
+	}
+	return true
+}
+
+// tailPrinter prints the jobs as "intelx jobs tail" follows them: as table rows, a JSON object per line,
+// a YAML document per job, or as tab separated values.
+type tailPrinter struct {
+	writer        io.Writer
+	format        outputFlag
+	printedHeader bool
+}
+
+// print prints a job that showed up or changed state.
+func (printer *tailPrinter) print(job jobOutput) error {
+	switch printer.format {
+	case formatTSV:
+		err := writeTSV(printer.writer, job, !printer.printedHeader)
+		printer.printedHeader = true
+		return err
+	case formatJSON:
+		data, err := json.Marshal(job)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(printer.writer, "%s\n", data)
+		return err
+	case formatYAML:
+		var generic interface{}
+		data, err := json.Marshal(job)
+		if err == nil {
+			err = json.Unmarshal(data, &generic)
+		}
+		if err != nil {
+			return err
+		}
+		if data, err = yaml.Marshal(generic); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(printer.writer, "---\n%s", data)
+		return err
+	}
+	if !printer.printedHeader {
+		printer.printedHeader = true
+		if _, err := fmt.Fprintf(printer.writer, tailRowFormat, "ID", "STATUS", "TLP", "TYPE", "NAME"); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(printer.writer, tailRowFormat, strconv.Itoa(job.ID), job.Status, job.TLP, orDash(job.Type), orDash(job.Name))
+	return err
+}
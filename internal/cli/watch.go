@@ -0,0 +1,288 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// DefaultWatchInterval is how often "intelx watch" scans the directory when --interval isn't given.
+const DefaultWatchInterval = 2 * time.Second
+
+// watchCommand returns the "watch" command.
+func watchCommand() *command {
+	return &command{
+		name:    "watch",
+		summary: "submit the files dropped in a directory for analysis",
+		usage:   "watch [flags] <directory>",
+		run:     runWatch,
+	}
+}
+
+// directoryWatcher submits the files dropped in a directory and moves them out of the way once they're processed.
+// A file is only submitted once its size and modification time didn't change between two scans, so files still
+// being written are left be. The directory is scanned on an interval rather than through file system notifications
+// such as fsnotify: a file has to be seen twice to know it's complete anyway, and it keeps the CLI free of a
+// dependency and working on network file systems, which don't notify.
+type directoryWatcher struct {
+	app        *app
+	client     *gothreatmatrix.ThreatMatrixClient
+	submission *submissionFlags
+	tlp        gothreatmatrix.TLP
+	directory  string
+	doneDir    string
+	failedDir  string
+	// pending holds the files seen during the last scan, which are submitted if they're unchanged in the next one
+	pending map[string]os.FileInfo
+	health  watchHealth
+}
+
+// watchHealth is what the health endpoint of "intelx watch" reports. Status is "degraded" while the directory can't
+// be scanned, "ok" otherwise.
+type watchHealth struct {
+	mutex     sync.Mutex
+	Status    string `json:"status"`
+	Directory string `json:"directory"`
+	Submitted int    `json:"submitted"`
+	// Failed counts the files that could not be submitted
+	Failed       int        `json:"failed"`
+	ScanFailures int        `json:"scan_failures"`
+	MoveFailures int        `json:"move_failures"`
+	LastScan     *time.Time `json:"last_scan"`
+	LastError    string     `json:"last_error,omitempty"`
+	LastErrorAt  *time.Time `json:"last_error_at,omitempty"`
+}
+
+// runWatch scans the directory given as argument until intelx is interrupted.
+func runWatch(app *app, cmd *command, args []string) error {
+	flags := app.newFlagSet(cmd)
+	submission := addSubmissionFlags(flags)
+	interval := flags.Duration("interval", DefaultWatchInterval, "how often the directory is scanned")
+	doneDir := flags.String("done-dir", "", `directory the submitted files are moved to (default "<directory>/done")`)
+	failedDir := flags.String("failed-dir", "", `directory the files that could not be submitted are moved to (default "<directory>/failed")`)
+	healthAddr := flags.String("health-addr", "", "address to serve the health of the watcher on, such as 127.0.0.1:8080, under /healthz")
+	once := flags.Bool("once", false, "scan the directory twice, a scan apart, and exit instead of watching it")
+	arguments, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(arguments) != 1 {
+		return newUsageError("watch takes a single directory")
+	}
+	if *interval <= 0 {
+		return newUsageError("invalid --interval %s", *interval)
+	}
+	tlp, err := submission.validate()
+	if err != nil {
+		return err
+	}
+	watcher := &directoryWatcher{
+		app:        app,
+		submission: submission,
+		tlp:        tlp,
+		directory:  arguments[0],
+		doneDir:    *doneDir,
+		failedDir:  *failedDir,
+		pending:    map[string]os.FileInfo{},
+		health:     watchHealth{Status: "ok", Directory: arguments[0]},
+	}
+	if watcher.doneDir == "" {
+		watcher.doneDir = filepath.Join(watcher.directory, "done")
+	}
+	if watcher.failedDir == "" {
+		watcher.failedDir = filepath.Join(watcher.directory, "failed")
+	}
+	for _, directory := range []string{watcher.doneDir, watcher.failedDir} {
+		if err := os.MkdirAll(directory, 0o750); err != nil {
+			return err
+		}
+	}
+	if watcher.client, err = app.threatMatrix(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(app.ctx)
+	defer cancel()
+	serverErrors := make(chan error, 1)
+	if *healthAddr != "" {
+		listener, err := net.Listen("tcp", *healthAddr)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(app.stderr, "serving the health of the watcher on http://%s/healthz\n", listener.Addr())
+		server := &http.Server{Handler: watcher.healthHandler(), ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+				serverErrors <- err
+				cancel()
+			}
+		}()
+		defer server.Close()
+	}
+
+	scans := 0
+	for {
+		if err := watcher.scan(ctx); err != nil {
+			watcher.health.mutex.Lock()
+			watcher.health.Status = "degraded"
+			watcher.health.ScanFailures++
+			watcher.health.setError(err.Error())
+			watcher.health.mutex.Unlock()
+			fmt.Fprintf(app.stderr, "intelx: scanning %s: %v\n", watcher.directory, err)
+		}
+		scans++
+		if *once && scans == 2 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			select {
+			case err := <-serverErrors:
+				return err
+			default:
+				// being interrupted is the way to stop watching
+				return nil
+			}
+		case <-time.After(*interval):
+		}
+	}
+}
+
+// scan submits the files that didn't change since the previous scan.
+func (watcher *directoryWatcher) scan(ctx context.Context) error {
+	entries, err := os.ReadDir(watcher.directory)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	watcher.health.mutex.Lock()
+	watcher.health.Status = "ok"
+	watcher.health.LastScan = &now
+	watcher.health.mutex.Unlock()
+
+	seen := map[string]os.FileInfo{}
+	names := []string{}
+	for _, entry := range entries {
+		// hidden files are usually temporary files of the program dropping the samples
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		seen[entry.Name()] = info
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		info := seen[name]
+		previous, ok := watcher.pending[name]
+		if !ok || previous.Size() != info.Size() || !previous.ModTime().Equal(info.ModTime()) {
+			continue
+		}
+		delete(seen, name)
+		if err := ctx.Err(); err != nil {
+			return nil
+		}
+		watcher.process(ctx, name)
+	}
+	watcher.pending = seen
+	return nil
+}
+
+// process submits a file and moves it to the done or failed directory.
+func (watcher *directoryWatcher) process(ctx context.Context, name string) {
+	path := filepath.Join(watcher.directory, name)
+	response, err := watcher.submit(ctx, path, name)
+	if err != nil && ctx.Err() != nil {
+		// the file is submitted again the next time intelx watches the directory
+		return
+	}
+	target := watcher.doneDir
+	watcher.health.mutex.Lock()
+	if err != nil {
+		target = watcher.failedDir
+		watcher.health.Failed++
+		watcher.health.setError(fmt.Sprintf("%s: %v", name, err))
+		fmt.Fprintf(watcher.app.stdout, "%s %s failed: %s\n", time.Now().Format(time.RFC3339), name, strings.ReplaceAll(err.Error(), "\n", " "))
+	} else {
+		watcher.health.Submitted++
+		fmt.Fprintf(watcher.app.stdout, "%s %s submitted as job %d\n", time.Now().Format(time.RFC3339), name, response.JobID)
+	}
+	watcher.health.mutex.Unlock()
+	if err := moveFile(path, target, name); err != nil {
+		watcher.health.mutex.Lock()
+		watcher.health.MoveFailures++
+		watcher.health.setError(err.Error())
+		watcher.health.mutex.Unlock()
+		fmt.Fprintf(watcher.app.stderr, "intelx: moving %s: %v\n", name, err)
+	}
+}
+
+// moveFile moves the file at path into directory under name, or under name suffixed with the current time when a
+// file of the directory already has that name, such as a sample dropped twice, so none is ever overwritten.
+func moveFile(path string, directory string, name string) error {
+	target := filepath.Join(directory, name)
+	extension := filepath.Ext(name)
+	for attempt := 0; ; attempt++ {
+		if _, err := os.Lstat(target); errors.Is(err, os.ErrNotExist) {
+			return os.Rename(path, target)
+		} else if err != nil {
+			return err
+		}
+		if attempt == 100 {
+			return fmt.Errorf("%s already exists in %s", name, directory)
+		}
+		target = filepath.Join(directory, fmt.Sprintf("%s-%d%s", strings.TrimSuffix(name, extension), time.Now().UnixNano(), extension))
+	}
+}
+
+// submit sends the file at path for analysis.
+func (watcher *directoryWatcher) submit(ctx context.Context, path string, name string) (*gothreatmatrix.AnalysisResponse, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return watcher.submission.submitFile(ctx, watcher.client, watcher.tlp, file, name)
+}
+
+// setError records the last error the watcher ran into, the mutex being held.
+func (health *watchHealth) setError(message string) {
+	now := time.Now()
+	health.LastError = message
+	health.LastErrorAt = &now
+}
+
+// healthHandler serves the watchHealth as JSON under /healthz, answering 503 Service Unavailable while it's degraded.
+func (watcher *directoryWatcher) healthHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		watcher.health.mutex.Lock()
+		data, err := json.Marshal(&watcher.health)
+		degraded := watcher.health.Status != "ok"
+		watcher.health.mutex.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if degraded {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_, _ = w.Write(data)
+	})
+	return mux
+}
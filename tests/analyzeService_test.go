@@ -0,0 +1,358 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestAnalyzeServiceAnalyzeObservable(t *testing.T) {
+	analysisJsonString := `{"job_id":260,"status":"accepted","warnings":[],"analyzers_running":["Classic_DNS"],"connectors_running":["YETI"]}`
+	analysisResponse := gothreatmatrix.AnalysisResponse{}
+	if unmarshalError := json.Unmarshal([]byte(analysisJsonString), &analysisResponse); unmarshalError != nil {
+		t.Fatalf("Error: %s", unmarshalError)
+	}
+	testCases := make(map[string]TestData)
+	testCases["simple"] = TestData{
+		Input: gothreatmatrix.ObservableAnalysisRequest{
+			Value: "8.8.8.8",
+		},
+		Data:       analysisJsonString,
+		StatusCode: http.StatusOK,
+		Want: map[string]interface{}{
+			"user":                  float64(0),
+			"observable_name":       "8.8.8.8",
+			"classification":        "ip",
+			"tlp":                   "WHITE",
+			"analyzers_requested":   []interface{}{},
+			"connectors_requested":  []interface{}{},
+			"tags_labels":           []interface{}{},
+			"runtime_configuration": map[string]interface{}{},
+		},
+	}
+	testCases["full"] = TestData{
+		Input: gothreatmatrix.ObservableAnalysisRequest{
+			Value:                "google.com",
+			Classification:       "domain",
+			Analyzers:            []string{"Classic_DNS"},
+			Connectors:           []string{"YETI"},
+			TLP:                  gothreatmatrix.AMBER,
+			Tags:                 []string{"phishing"},
+			RuntimeConfiguration: map[string]interface{}{"Classic_DNS": map[string]interface{}{"query_type": "A"}},
+		},
+		Data:       analysisJsonString,
+		StatusCode: http.StatusOK,
+		Want: map[string]interface{}{
+			"user":                  float64(0),
+			"observable_name":       "google.com",
+			"classification":        "domain",
+			"tlp":                   "AMBER",
+			"analyzers_requested":   []interface{}{"Classic_DNS"},
+			"connectors_requested":  []interface{}{"YETI"},
+			"tags_labels":           []interface{}{"phishing"},
+			"runtime_configuration": map[string]interface{}{"Classic_DNS": map[string]interface{}{"query_type": "A"}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			ctx := context.Background()
+			var gottenBody map[string]interface{}
+			apiHandler.HandleFunc(constants.ANALYZE_OBSERVABLE_URL, func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&gottenBody); err != nil {
+					t.Errorf("Could not decode the request body: %v", err)
+				}
+				serverHandler(t, testCase, "POST").ServeHTTP(w, r)
+			})
+			gottenAnalysisResponse, err := client.AnalyzeService.AnalyzeObservable(ctx, testCase.Input.(gothreatmatrix.ObservableAnalysisRequest))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, &analysisResponse, gottenAnalysisResponse)
+			testWantData(t, testCase.Want, gottenBody)
+		})
+	}
+}
+
+func TestAnalyzeServiceAnalyzeObservableEmptyValue(t *testing.T) {
+	client, _, closeServer := setup()
+	defer closeServer()
+	_, err := client.AnalyzeService.AnalyzeObservable(context.Background(), gothreatmatrix.ObservableAnalysisRequest{Value: " "})
+	if !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected a validation error got: %v", err)
+	}
+}
+
+func TestAnalyzeServiceAnalyzeFile(t *testing.T) {
+	analysisJsonString := `{"job_id":261,"status":"accepted","warnings":[],"analyzers_running":["File_Info"],"connectors_running":[]}`
+	analysisResponse := gothreatmatrix.AnalysisResponse{}
+	if unmarshalError := json.Unmarshal([]byte(analysisJsonString), &analysisResponse); unmarshalError != nil {
+		t.Fatalf("Error: %s", unmarshalError)
+	}
+	// big enough to make sure the sample goes through in chunks
+	sample := bytes.Repeat([]byte("MZ"), 4<<20)
+	testData := TestData{
+		Input: gothreatmatrix.FileAnalysisRequest{
+			File:                 bytes.NewReader(sample),
+			FileName:             "sample.exe",
+			Analyzers:            []string{"File_Info", "Strings_Info"},
+			TLP:                  gothreatmatrix.GREEN,
+			Tags:                 []string{"malware"},
+			RuntimeConfiguration: map[string]interface{}{"File_Info": map[string]interface{}{"deep": true}},
+		},
+		Data:       analysisJsonString,
+		StatusCode: http.StatusOK,
+		Want: map[string][]string{
+			"tlp":                   {"GREEN"},
+			"runtime_configuration": {`{"File_Info":{"deep":true}}`},
+			"analyzers_requested":   {"File_Info", "Strings_Info"},
+			"tags_labels":           {"malware"},
+		},
+	}
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.ANALYZE_FILE_URL, func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != -1 {
+			t.Errorf("Expected a streamed request body got a content length of %d", r.ContentLength)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("Could not parse the multipart form: %v", err)
+			return
+		}
+		testWantData(t, testData.Want, r.MultipartForm.Value)
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("Could not get the file: %v", err)
+			return
+		}
+		defer file.Close()
+		testWantData(t, "sample.exe", header.Filename)
+		content, _ := io.ReadAll(file)
+		if !bytes.Equal(sample, content) {
+			t.Errorf("The uploaded file does not match the sample")
+		}
+		serverHandler(t, testData, "POST").ServeHTTP(w, r)
+	})
+	gottenAnalysisResponse, err := client.AnalyzeService.AnalyzeFile(context.Background(), testData.Input.(gothreatmatrix.FileAnalysisRequest))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, &analysisResponse, gottenAnalysisResponse)
+}
+
+func TestAnalyzeServiceAnalyzeFileError(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["noFile"] = TestData{
+		Input: gothreatmatrix.FileAnalysisRequest{FileName: "sample.exe"},
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	testCases["noFileName"] = TestData{
+		Input: gothreatmatrix.FileAnalysisRequest{File: strings.NewReader("MZ")},
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	testCases["serverError"] = TestData{
+		Input:      gothreatmatrix.FileAnalysisRequest{File: strings.NewReader("MZ"), FileName: "sample.exe"},
+		Data:       `{"detail":"You do not have permission to perform this action."}`,
+		StatusCode: http.StatusForbidden,
+		Want:       gothreatmatrix.ErrForbidden,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.Handle(constants.ANALYZE_FILE_URL, serverHandler(t, testCase, "POST"))
+			_, err := client.AnalyzeService.AnalyzeFile(context.Background(), testCase.Input.(gothreatmatrix.FileAnalysisRequest))
+			if !errors.Is(err, testCase.Want.(error)) {
+				t.Fatalf("Expected %v got: %v", testCase.Want, err)
+			}
+		})
+	}
+}
+
+func TestAnalyzeServiceAnalyzeObservables(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	gottenBodies := []map[string]interface{}{}
+	jobId := 300
+	apiHandler.HandleFunc(constants.ANALYZE_MULTIPLE_OBSERVABLES_URL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		body := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Could not decode the request body: %v", err)
+		}
+		gottenBodies = append(gottenBodies, body)
+		if body["tlp"] == "RED" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"detail":"TLP RED is not allowed."}`))
+			return
+		}
+		response := gothreatmatrix.MultipleAnalysisResponse{}
+		for range body["observables"].([]interface{}) {
+			jobId++
+			response.Results = append(response.Results, gothreatmatrix.AnalysisResponse{JobID: jobId, Status: "accepted"})
+		}
+		response.Count = len(response.Results)
+		_ = json.NewEncoder(w).Encode(response)
+	})
+	analysisRequests := []gothreatmatrix.ObservableAnalysisRequest{
+		{Value: "8.8.8.8", Classification: "ip"},
+		{Value: "google.com", Classification: "domain", TLP: gothreatmatrix.AMBER},
+		{Value: ""},
+		{Value: "1.1.1.1", Classification: "ip", TLP: gothreatmatrix.WHITE},
+		{Value: "evil.com", TLP: gothreatmatrix.RED},
+	}
+	results := client.AnalyzeService.AnalyzeObservables(context.Background(), analysisRequests)
+
+	testWantData(t, 3, len(gottenBodies))
+	testWantData(t, []interface{}{
+		[]interface{}{"ip", "8.8.8.8"},
+		[]interface{}{"ip", "1.1.1.1"},
+	}, gottenBodies[0]["observables"])
+	testWantData(t, len(analysisRequests), len(results))
+	for index, result := range results {
+		testWantData(t, analysisRequests[index], result.Request)
+	}
+	testWantData(t, 301, results[0].Response.JobID)
+	testWantData(t, 303, results[1].Response.JobID)
+	testWantData(t, 302, results[3].Response.JobID)
+	if !errors.Is(results[2].Err, gothreatmatrix.ErrValidation) {
+		t.Errorf("Expected a validation error got: %v", results[2].Err)
+	}
+	if !errors.Is(results[4].Err, gothreatmatrix.ErrValidation) || results[4].Response != nil {
+		t.Errorf("Expected the server error got: %v", results[4].Err)
+	}
+}
+
+func TestAnalyzeServiceAnalyzeFiles(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	gottenFiles := [][]string{}
+	jobId := 400
+	apiHandler.HandleFunc(constants.ANALYZE_MULTIPLE_FILES_URL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("Could not parse the multipart form: %v", err)
+			return
+		}
+		if r.FormValue("tlp") == "RED" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"detail":"TLP RED is not allowed."}`))
+			return
+		}
+		files := []string{}
+		response := gothreatmatrix.MultipleAnalysisResponse{}
+		for _, header := range r.MultipartForm.File["files"] {
+			file, _ := header.Open()
+			content, _ := io.ReadAll(file)
+			file.Close()
+			files = append(files, header.Filename+":"+string(content))
+			jobId++
+			response.Results = append(response.Results, gothreatmatrix.AnalysisResponse{JobID: jobId, Status: "accepted"})
+		}
+		gottenFiles = append(gottenFiles, files)
+		response.Count = len(response.Results)
+		_ = json.NewEncoder(w).Encode(response)
+	})
+	analysisRequests := []gothreatmatrix.FileAnalysisRequest{
+		{File: strings.NewReader("first"), FileName: "first.exe"},
+		{File: strings.NewReader("second"), FileName: "second.exe", TLP: gothreatmatrix.RED},
+		{FileName: "missing.exe"},
+		{File: strings.NewReader("third"), FileName: "third.exe"},
+	}
+	results := client.AnalyzeService.AnalyzeFiles(context.Background(), analysisRequests)
+
+	testWantData(t, [][]string{{"first.exe:first", "third.exe:third"}}, gottenFiles)
+	testWantData(t, len(analysisRequests), len(results))
+	testWantData(t, 401, results[0].Response.JobID)
+	testWantData(t, 402, results[3].Response.JobID)
+	if !errors.Is(results[1].Err, gothreatmatrix.ErrValidation) || results[1].Response != nil {
+		t.Errorf("Expected the server error got: %v", results[1].Err)
+	}
+	if !errors.Is(results[2].Err, gothreatmatrix.ErrValidation) {
+		t.Errorf("Expected a validation error got: %v", results[2].Err)
+	}
+}
+
+func TestAnalyzeServiceAndWait(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["observable"] = TestData{
+		Input: []string{"running", "running", "reported_without_fails"},
+		Want:  gothreatmatrix.StatusReportedWithoutFails,
+	}
+	testCases["file"] = TestData{
+		Input: []string{"pending", "reported_with_fails"},
+		Want:  gothreatmatrix.StatusReportedWithFails,
+	}
+	testCases["timeout"] = TestData{
+		Input: []string{"running"},
+		Want:  context.DeadlineExceeded,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := http.NewServeMux()
+			testServer := httptest.NewServer(apiHandler)
+			defer testServer.Close()
+			analysisJsonString := `{"job_id":260,"status":"accepted","warnings":[],"analyzers_running":["Classic_DNS"],"connectors_running":[]}`
+			apiHandler.HandleFunc(constants.ANALYZE_OBSERVABLE_URL, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(analysisJsonString))
+			})
+			apiHandler.HandleFunc(constants.ANALYZE_FILE_URL, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(io.Discard, r.Body)
+				_, _ = w.Write([]byte(analysisJsonString))
+			})
+			statuses := testCase.Input.([]string)
+			polls := 0
+			apiHandler.HandleFunc(fmt.Sprintf(constants.SPECIFIC_JOB_URL, 260), func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				status := statuses[len(statuses)-1]
+				if polls < len(statuses) {
+					status = statuses[polls]
+				}
+				polls++
+				fmt.Fprintf(w, `{"id":260,"status":%q,"observable_name":"8.8.8.8"}`, status)
+			})
+			client := gothreatmatrix.NewClient(
+				gothreatmatrix.WithURL(testServer.URL),
+				gothreatmatrix.WithToken("test-token"),
+				gothreatmatrix.WithPollInterval(time.Millisecond),
+			)
+			ctx := context.Background()
+			var job *gothreatmatrix.Job
+			var err error
+			// every poll waits for gothreatmatrix.MinPollInterval
+			timeout := time.Duration(len(statuses)+1) * gothreatmatrix.MinPollInterval
+			if name == "timeout" {
+				timeout = 20 * time.Millisecond
+			}
+			if name == "file" {
+				job, err = client.AnalyzeService.AnalyzeFileAndWait(ctx, gothreatmatrix.FileAnalysisRequest{File: strings.NewReader("MZ"), FileName: "sample.exe"}, timeout)
+			} else {
+				job, err = client.AnalyzeService.AnalyzeObservableAndWait(ctx, gothreatmatrix.ObservableAnalysisRequest{Value: "8.8.8.8"}, timeout)
+			}
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, job.Status)
+			testWantData(t, "8.8.8.8", job.ObservableName)
+			testWantData(t, len(statuses), polls)
+		})
+	}
+}
@@ -104,7 +104,19 @@ func TestAnalyzerServiceHealthCheck(t *testing.T) {
 		Input:      "Floss",
 		Data:       `{"status": true}`,
 		StatusCode: http.StatusOK,
-		Want:       true,
+		Want:       gothreatmatrix.HealthStatusUp,
+	}
+	testCases["down"] = TestData{
+		Input:      "Floss",
+		Data:       `{"status": false}`,
+		StatusCode: http.StatusOK,
+		Want:       gothreatmatrix.HealthStatusDown,
+	}
+	testCases["unsupported"] = TestData{
+		Input:      "Classic_DNS",
+		Data:       `{"errors": {"detail": "No healthcheck implemented"}}`,
+		StatusCode: http.StatusBadRequest,
+		Want:       gothreatmatrix.HealthStatusUnsupported,
 	}
 	testCases["analyzerDoesntExist"] = TestData{
 		Input:      "notAnAnalyzer",
@@ -113,6 +125,7 @@ func TestAnalyzerServiceHealthCheck(t *testing.T) {
 		Want: &gothreatmatrix.ThreatMatrixError{
 			StatusCode: http.StatusBadRequest,
 			Message:    `{"errors": {"detail": "Analyzer doesn't exist"}}`,
+			Detail:     "Analyzer doesn't exist",
 		},
 	}
 	for name, testCase := range testCases {
@@ -127,6 +140,9 @@ func TestAnalyzerServiceHealthCheck(t *testing.T) {
 				apiHandler.Handle(testUrl, serverHandler(t, testCase, "GET"))
 				status, err := client.AnalyzerService.HealthCheck(ctx, input)
 				if err != nil {
+					if _, ok := testCase.Want.(error); !ok {
+						t.Fatalf("Unexpected error: %v", err)
+					}
 					testError(t, testCase, err)
 				} else {
 					testWantData(t, testCase.Want, status)
@@ -135,3 +151,75 @@ func TestAnalyzerServiceHealthCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestAnalyzerServiceList(t *testing.T) {
+	analyzerJsonString := `{"name":"Classic_DNS","python_module":"dns.dns_resolvers.classic_dns_resolver.ClassicDNSResolver","disabled":false,"description":"Retrieve current domain resolution with default DNS","type":"observable","docker_based":false,"maximum_tlp":"AMBER","observable_supported":["ip","domain","url"],"params":{"query_type":{"value":"A","type":"str","description":"Query type against the chosen DNS resolver."}}}`
+	analyzerConfig := gothreatmatrix.AnalyzerConfig{}
+	if unmarshalError := json.Unmarshal([]byte(analyzerJsonString), &analyzerConfig); unmarshalError != nil {
+		t.Fatalf("Error: %s", unmarshalError)
+	}
+	testCases := make(map[string]TestData)
+	testCases["list"] = TestData{
+		Data:       "[" + analyzerJsonString + "]",
+		StatusCode: http.StatusOK,
+		Want:       []gothreatmatrix.AnalyzerConfig{analyzerConfig},
+	}
+	testCases["paginated"] = TestData{
+		Data:       `{"count":1,"total_pages":1,"results":[` + analyzerJsonString + "]}",
+		StatusCode: http.StatusOK,
+		Want:       []gothreatmatrix.AnalyzerConfig{analyzerConfig},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.Handle(constants.BASE_ANALYZER_URL, serverHandler(t, testCase, "GET"))
+			gottenAnalyzerConfigs, err := client.AnalyzerService.List(context.Background())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenAnalyzerConfigs)
+			testWantData(t, gothreatmatrix.AMBER, gottenAnalyzerConfigs[0].MaximumTlp)
+			testWantData(t, []string{"ip", "domain", "url"}, gottenAnalyzerConfigs[0].ObservableSupported)
+		})
+	}
+}
+
+func TestAnalyzerServiceGet(t *testing.T) {
+	analyzerJsonString := `{"name":"Classic_DNS","type":"observable","docker_based":false,"maximum_tlp":"RED","observable_supported":["ip","domain"]}`
+	analyzerConfig := gothreatmatrix.AnalyzerConfig{}
+	if unmarshalError := json.Unmarshal([]byte(analyzerJsonString), &analyzerConfig); unmarshalError != nil {
+		t.Fatalf("Error: %s", unmarshalError)
+	}
+	testCases := make(map[string]TestData)
+	testCases["simple"] = TestData{
+		Input:      "Classic_DNS",
+		Data:       analyzerJsonString,
+		StatusCode: http.StatusOK,
+		Want:       &analyzerConfig,
+	}
+	testCases["notFound"] = TestData{
+		Input:      "Missing_Analyzer",
+		Data:       `{"detail":"Not found."}`,
+		StatusCode: http.StatusNotFound,
+		Want: &gothreatmatrix.ThreatMatrixError{
+			StatusCode: http.StatusNotFound,
+			Message:    `{"detail":"Not found."}`,
+			Detail:     "Not found.",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			analyzerName := testCase.Input.(string)
+			apiHandler.Handle(fmt.Sprintf(constants.SPECIFIC_ANALYZER_URL, analyzerName), serverHandler(t, testCase, "GET"))
+			gottenAnalyzerConfig, err := client.AnalyzerService.Get(context.Background(), analyzerName)
+			if err != nil {
+				testError(t, testCase, err)
+			} else {
+				testWantData(t, testCase.Want, gottenAnalyzerConfig)
+			}
+		})
+	}
+}
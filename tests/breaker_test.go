@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	listCalls, getCalls := 0, 0
+	healthy := false
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+		listCalls++
+		if !healthy {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	})
+	apiHandler.HandleFunc(constants.BASE_TAG_URL+"/", func(w http.ResponseWriter, r *http.Request) {
+		getCalls++
+		_, _ = w.Write([]byte(`{"id":1,"label":"breaker","color":"#ffffff"}`))
+	})
+	cooldown := 50 * time.Millisecond
+	client := gothreatmatrix.NewClient(
+		gothreatmatrix.WithURL(testServer.URL),
+		gothreatmatrix.WithToken("test-token"),
+		gothreatmatrix.WithCircuitBreaker(3, cooldown),
+	)
+	ctx := context.Background()
+
+	for attempt := 0; attempt < 3; attempt++ {
+		if _, err := client.TagService.List(ctx); !errors.Is(err, gothreatmatrix.ErrServer) {
+			t.Fatalf("attempt %d: got %v, want ErrServer", attempt, err)
+		}
+	}
+	if _, err := client.TagService.List(ctx); !errors.Is(err, gothreatmatrix.ErrCircuitOpen) {
+		t.Fatalf("got %v, want ErrCircuitOpen", err)
+	}
+	if listCalls != 3 {
+		t.Errorf("the server got %d calls, want 3 as the open circuit fails fast", listCalls)
+	}
+
+	// the circuits are per endpoint
+	if _, err := client.TagService.Get(ctx, 1); err != nil {
+		t.Fatalf("TagService.Get: %v", err)
+	}
+	if getCalls != 1 {
+		t.Errorf("TagService.Get sent %d calls, want 1", getCalls)
+	}
+
+	// once the cooldown passed a successful trial closes the circuit
+	healthy = true
+	time.Sleep(cooldown)
+	for attempt := 0; attempt < 2; attempt++ {
+		if _, err := client.TagService.List(ctx); err != nil {
+			t.Fatalf("attempt %d after the cooldown: %v", attempt, err)
+		}
+	}
+	if listCalls != 5 {
+		t.Errorf("the server got %d calls, want 5", listCalls)
+	}
+}
+
+func TestCircuitBreakerStopsRetries(t *testing.T) {
+	calls := 0
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client := gothreatmatrix.NewClient(
+		gothreatmatrix.WithURL(testServer.URL),
+		gothreatmatrix.WithToken("test-token"),
+		gothreatmatrix.WithRetry(5, time.Millisecond),
+		gothreatmatrix.WithCircuitBreaker(2, time.Minute),
+	)
+	_, err := client.TagService.List(context.Background())
+	if !errors.Is(err, gothreatmatrix.ErrCircuitOpen) {
+		t.Fatalf("got %v, want ErrCircuitOpen", err)
+	}
+	if calls != 2 {
+		t.Errorf("the server got %d calls, want 2", calls)
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	calls := 0
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client := gothreatmatrix.NewClient(
+		gothreatmatrix.WithURL(testServer.URL),
+		gothreatmatrix.WithToken("test-token"),
+		gothreatmatrix.WithRetry(5, time.Millisecond),
+		gothreatmatrix.WithRetryBudget(0, 2),
+	)
+	ctx := context.Background()
+	// the burst of 2 retries is spent by the first request
+	if _, err := client.TagService.List(ctx); !errors.Is(err, gothreatmatrix.ErrServer) {
+		t.Fatalf("got %v, want ErrServer", err)
+	}
+	if calls != 3 {
+		t.Errorf("the first request sent %d calls, want 3", calls)
+	}
+	calls = 0
+	if _, err := client.TagService.List(ctx); !errors.Is(err, gothreatmatrix.ErrServer) {
+		t.Fatalf("got %v, want ErrServer", err)
+	}
+	if calls != 1 {
+		t.Errorf("the second request sent %d calls, want 1 once the budget is spent", calls)
+	}
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// benchmarkJobList returns a page of jobs as ThreatMatrix sends it.
+func benchmarkJobList(jobs int) []byte {
+	page := &bytes.Buffer{}
+	fmt.Fprintf(page, `{"count":%d,"total_pages":1,"results":[`, jobs)
+	for id := 1; id <= jobs; id++ {
+		if id > 1 {
+			page.WriteByte(',')
+		}
+		fmt.Fprintf(page, `{"id":%d,"observable_name":"198.51.100.%d","observable_classification":"ip","status":"reported_without_fails","tlp":"AMBER","tags":[{"id":1,"label":"scanner","color":"#ff0000"}]}`, id, id%256)
+	}
+	page.WriteString("]}")
+	return page.Bytes()
+}
+
+func TestDoRequestBody(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc("/api/echo", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	})
+	for index := 0; index < 3; index++ {
+		echoed := []byte{}
+		params := map[string]interface{}{"observable_name": fmt.Sprintf("<%d>", index)}
+		if err := client.Do(context.Background(), http.MethodPost, "/api/echo", params, &echoed); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		want, _ := json.Marshal(params)
+		testWantData(t, string(want), string(echoed))
+	}
+}
+
+func BenchmarkJobServiceList(b *testing.B) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	jobList := benchmarkJobList(500)
+	apiHandler.HandleFunc(constants.BASE_JOB_URL, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(jobList)
+	})
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.SetBytes(int64(len(jobList)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.JobService.List(ctx, &gothreatmatrix.JobListParams{PageSize: 500}); err != nil {
+			b.Fatalf("Unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkDo(b *testing.B) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	response := bytes.Repeat([]byte(`{"job_id":1,"status":"accepted"},`), 1000)
+	apiHandler.HandleFunc("/api/analyze_multiple_observables", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		_, _ = w.Write([]byte("["))
+		_, _ = w.Write(response[:len(response)-1])
+		_, _ = w.Write([]byte("]"))
+	})
+	observables := make([][]string, 1000)
+	for index := range observables {
+		observables[index] = []string{"ip", fmt.Sprintf("198.51.100.%d", index%256)}
+	}
+	params := map[string]interface{}{"observables": observables, "tlp": "AMBER"}
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		raw := []byte{}
+		if err := client.Do(ctx, http.MethodPost, "/api/analyze_multiple_observables", params, &raw); err != nil {
+			b.Fatalf("Unexpected error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCachedAnalyzerService(t *testing.T) {
+	controller := gomock.NewController(t)
+	analyzerService := mocks.NewMockAnalyzerServiceInterface(controller)
+	analyzers := []gothreatmatrix.AnalyzerConfig{{}, {}}
+	analyzers[0].Name = "Classic_DNS"
+	analyzers[1].Name = "AbuseIPDB"
+	ctx := context.Background()
+	cachedService := gothreatmatrix.NewCachedAnalyzerService(analyzerService, time.Hour)
+
+	analyzerService.EXPECT().List(gomock.Any()).Return(analyzers, nil).Times(1)
+	for i := 0; i < 3; i++ {
+		gottenAnalyzers, err := cachedService.List(ctx)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		testWantData(t, analyzers, gottenAnalyzers)
+	}
+	gottenConfigs, err := cachedService.GetConfigs(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, analyzers, *gottenConfigs)
+
+	analyzerService.EXPECT().Get(gomock.Any(), "Classic_DNS").Return(&analyzers[0], nil).Times(1)
+	for i := 0; i < 2; i++ {
+		gottenAnalyzer, err := cachedService.Get(ctx, "Classic_DNS")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		testWantData(t, "Classic_DNS", gottenAnalyzer.Name)
+	}
+
+	// health checks are never cached
+	analyzerService.EXPECT().HealthCheck(gomock.Any(), "Classic_DNS").Return(gothreatmatrix.HealthStatusUp, nil).Times(2)
+	for i := 0; i < 2; i++ {
+		if _, err := cachedService.HealthCheck(ctx, "Classic_DNS"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	cachedService.Invalidate()
+	analyzerService.EXPECT().List(gomock.Any()).Return(analyzers[:1], nil).Times(1)
+	gottenAnalyzers, err := cachedService.List(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 1, len(gottenAnalyzers))
+}
+
+func TestCachedConnectorServiceExpiry(t *testing.T) {
+	controller := gomock.NewController(t)
+	connectorService := mocks.NewMockConnectorServiceInterface(controller)
+	connectors := []gothreatmatrix.ConnectorConfig{{}}
+	connectors[0].Name = "MISP"
+	ctx := context.Background()
+	cachedService := gothreatmatrix.NewCachedConnectorService(connectorService, 20*time.Millisecond)
+
+	// errors are never cached
+	connectorService.EXPECT().List(gomock.Any()).Return(nil, gothreatmatrix.ErrServer).Times(1)
+	if _, err := cachedService.List(ctx); !errors.Is(err, gothreatmatrix.ErrServer) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrServer, err)
+	}
+	connectorService.EXPECT().List(gomock.Any()).Return(connectors, nil).Times(2)
+	for i := 0; i < 2; i++ {
+		if _, err := cachedService.List(ctx); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	time.Sleep(30 * time.Millisecond)
+	gottenConnectors, err := cachedService.List(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, connectors, gottenConnectors)
+}
+
+func TestCachedAnalyzerServiceConcurrentFetches(t *testing.T) {
+	controller := gomock.NewController(t)
+	analyzerService := mocks.NewMockAnalyzerServiceInterface(controller)
+	ctx := context.Background()
+	cachedService := gothreatmatrix.NewCachedAnalyzerService(analyzerService, time.Hour)
+	release := make(chan struct{})
+	slowAnalyzer := &gothreatmatrix.AnalyzerConfig{}
+	slowAnalyzer.Name = "Slow"
+	fastAnalyzer := &gothreatmatrix.AnalyzerConfig{}
+	fastAnalyzer.Name = "Fast"
+	analyzerService.EXPECT().Get(gomock.Any(), "Slow").DoAndReturn(func(ctx context.Context, name string, opts ...gothreatmatrix.RequestOption) (*gothreatmatrix.AnalyzerConfig, error) {
+		<-release
+		return slowAnalyzer, nil
+	}).Times(1)
+	analyzerService.EXPECT().Get(gomock.Any(), "Fast").Return(fastAnalyzer, nil).Times(1)
+
+	results := make(chan string, 3)
+	for i := 0; i < 3; i++ {
+		go func() {
+			analyzer, err := cachedService.Get(ctx, "Slow")
+			if err != nil {
+				results <- err.Error()
+				return
+			}
+			results <- analyzer.Name
+		}()
+	}
+	// another analyzer is fetched while the slow one is in flight
+	gottenAnalyzer, err := cachedService.Get(ctx, "Fast")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, "Fast", gottenAnalyzer.Name)
+	close(release)
+	for i := 0; i < 3; i++ {
+		testWantData(t, "Slow", <-results)
+	}
+}
@@ -0,0 +1,30 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestClassify(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["ipv4"] = TestData{Input: "8.8.8.8", Want: gothreatmatrix.ClassificationIP}
+	testCases["ipv6"] = TestData{Input: "2001:4860:4860::8888", Want: gothreatmatrix.ClassificationIP}
+	testCases["url"] = TestData{Input: "https://evil.com/payload", Want: gothreatmatrix.ClassificationURL}
+	testCases["urlOtherScheme"] = TestData{Input: "hxxp://evil.com", Want: gothreatmatrix.ClassificationURL}
+	testCases["domain"] = TestData{Input: "google.com", Want: gothreatmatrix.ClassificationDomain}
+	testCases["subdomain"] = TestData{Input: "Mail.Google.com", Want: gothreatmatrix.ClassificationDomain}
+	testCases["leadingDotDomain"] = TestData{Input: ".google.com", Want: gothreatmatrix.ClassificationDomain}
+	testCases["md5"] = TestData{Input: "d41d8cd98f00b204e9800998ecf8427e", Want: gothreatmatrix.ClassificationHash}
+	testCases["sha1"] = TestData{Input: "DA39A3EE5E6B4B0D3255BFEF95601890AFD80709", Want: gothreatmatrix.ClassificationHash}
+	testCases["sha256"] = TestData{Input: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", Want: gothreatmatrix.ClassificationHash}
+	testCases["badLengthHash"] = TestData{Input: "d41d8cd98f00b204e9800998ecf8427", Want: gothreatmatrix.ClassificationGeneric}
+	testCases["email"] = TestData{Input: "admin@google.com", Want: gothreatmatrix.ClassificationGeneric}
+	testCases["generic"] = TestData{Input: "some random text", Want: gothreatmatrix.ClassificationGeneric}
+	testCases["spaces"] = TestData{Input: " 1.1.1.1 ", Want: gothreatmatrix.ClassificationIP}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			testWantData(t, testCase.Want, gothreatmatrix.Classify(testCase.Input.(string)))
+		})
+	}
+}
@@ -0,0 +1,415 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/internal/cli"
+)
+
+// cliJobList is a page of jobs as ThreatMatrix sends it to intelx.
+const cliJobList = `{"count":2,"total_pages":1,"results":[
+	{"id":2,"observable_name":"8.8.8.8","observable_classification":"ip","status":"reported_without_fails","tlp":"AMBER","received_request_time":"2023-03-01T12:00:00Z"},
+	{"id":1,"is_sample":true,"file_name":"invoice.pdf","file_mimetype":"application/pdf","status":"running","tlp":"RED"}
+]}`
+
+// setupCLI starts a test server for intelx to talk to.
+func setupCLI() (serverUrl string, apiHandler *http.ServeMux, closeServer func()) {
+	apiHandler = http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	return testServer.URL, apiHandler, testServer.Close
+}
+
+// runCLI runs intelx against serverUrl and returns what it wrote to stdout and stderr along with its exit code.
+func runCLI(serverUrl string, args ...string) (string, string, int) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	args = append([]string{"--url", serverUrl, "--token", "test-token"}, args...)
+	exitCode := cli.Run(context.Background(), args, strings.NewReader(""), stdout, stderr)
+	return stdout.String(), stderr.String(), exitCode
+}
+
+func TestCLIJobsList(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["table"] = TestData{
+		Input: []string{"jobs", "list", "--tlp", "amber"},
+		Want:  []string{"ID  STATUS", "2   reported_without_fails  AMBER  ip", "8.8.8.8", "invoice.pdf"},
+	}
+	testCases["json"] = TestData{
+		Input: []string{"jobs", "list", "--tlp", "amber", "-o", "json"},
+		Want:  []string{`"name": "8.8.8.8"`, `"name": "invoice.pdf"`, `"received": "2023-03-01T12:00:00Z"`},
+	}
+	testCases["yaml"] = TestData{
+		Input: []string{"jobs", "list", "--tlp", "amber", "--output", "yaml"},
+		Want:  []string{"  id: 2\n", "  name: 8.8.8.8\n", "  type: application/pdf\n"},
+	}
+	testCases["tsv"] = TestData{
+		Input: []string{"--output", "tsv", "jobs", "list", "--tlp", "amber"},
+		Want:  []string{"id\tstatus\ttlp\ttype\tname\treceived\n2\treported_without_fails\tAMBER\tip\t8.8.8.8\t2023-03-01T12:00:00Z\n1\trunning\tRED\tapplication/pdf\tinvoice.pdf\t\n"},
+	}
+	testCases["limit"] = TestData{
+		Input: []string{"jobs", "list", "--tlp", "amber", "--limit", "1"},
+		Want:  []string{"8.8.8.8"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			serverUrl, apiHandler, closeServer := setupCLI()
+			defer closeServer()
+			apiHandler.HandleFunc(constants.BASE_JOB_URL, func(w http.ResponseWriter, r *http.Request) {
+				testWantData(t, "AMBER", r.URL.Query().Get("tlp"))
+				testWantData(t, "-received_request_time", r.URL.Query().Get("ordering"))
+				_, _ = w.Write([]byte(cliJobList))
+			})
+			stdout, stderr, exitCode := runCLI(serverUrl, testCase.Input.([]string)...)
+			testWantData(t, cli.ExitOK, exitCode)
+			testWantData(t, "", stderr)
+			for _, want := range testCase.Want.([]string) {
+				if !strings.Contains(stdout, want) {
+					t.Fatalf("Expected the output to contain %q got:\n%s", want, stdout)
+				}
+			}
+			if name == "limit" && strings.Contains(stdout, "invoice.pdf") {
+				t.Fatalf("Expected a single job got:\n%s", stdout)
+			}
+		})
+	}
+}
+
+func TestCLIJobsGet(t *testing.T) {
+	serverUrl, apiHandler, closeServer := setupCLI()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.BASE_JOB_URL+"/2", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":2,"observable_name":"8.8.8.8","observable_classification":"ip","status":"reported_with_fails","tlp":"AMBER",
+			"tags":[{"id":1,"label":"phishing","color":"#ff0000"}],
+			"analyzer_reports":[{"name":"Classic_DNS","status":"SUCCESS"},{"name":"Shodan","status":"FAILED"}],
+			"connector_reports":[{"name":"MISP","status":"SUCCESS"}]}`))
+	})
+	stdout, _, exitCode := runCLI(serverUrl, "jobs", "get", "2")
+	testWantData(t, cli.ExitOK, exitCode)
+	for _, want := range []string{"Status:    reported_with_fails", "Tags:      phishing", "Shodan       analyzer   FAILED", "MISP         connector  SUCCESS"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("Expected the output to contain %q got:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestCLIJobsKill(t *testing.T) {
+	serverUrl, apiHandler, closeServer := setupCLI()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.BASE_JOB_URL+"/1/kill", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	apiHandler.HandleFunc(constants.BASE_JOB_URL+"/2/kill", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"detail":"Job is not running"}`))
+	})
+	stdout, stderr, exitCode := runCLI(serverUrl, "jobs", "kill", "1", "2", "-o", "json")
+	testWantData(t, cli.ExitError, exitCode)
+	if !strings.Contains(stdout, `"id": 1,`+"\n"+`    "done": true`) || !strings.Contains(stdout, "Job is not running") {
+		t.Fatalf("Unexpected output:\n%s", stdout)
+	}
+	testWantData(t, "intelx: 1 of 2 jobs could not be killed\n", stderr)
+}
+
+func TestCLIJobsDownload(t *testing.T) {
+	serverUrl, apiHandler, closeServer := setupCLI()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.BASE_JOB_URL+"/3/download_sample", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("MZ sample"))
+	})
+	path := filepath.Join(t.TempDir(), "sample.bin")
+	stdout, _, exitCode := runCLI(serverUrl, "jobs", "download", "3", "--file", path)
+	testWantData(t, cli.ExitOK, exitCode)
+	testWantData(t, "job 3 sample saved to "+path+"\n", stdout)
+	sample, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, "MZ sample", string(sample))
+}
+
+func TestCLIAnalyzeObservableWait(t *testing.T) {
+	serverUrl, apiHandler, closeServer := setupCLI()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.ANALYZE_OBSERVABLE_URL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		body, _ := io.ReadAll(r.Body)
+		for _, want := range []string{`"observable_name":"8.8.8.8"`, `"tlp":"AMBER"`, `"tags_labels":["triage","cli"]`} {
+			if !strings.Contains(string(body), want) {
+				t.Errorf("Expected the body to contain %s got: %s", want, body)
+			}
+		}
+		_, _ = w.Write([]byte(`{"job_id":7,"status":"accepted"}`))
+	})
+	apiHandler.HandleFunc(constants.BASE_JOB_URL+"/7", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":7,"status":"reported_without_fails","analyzer_reports":[
+			{"name":"AbuseIPDB","status":"SUCCESS","report":{"data":{"abuseConfidenceScore":90,"totalReports":12}}}]}`))
+	})
+	stdout, _, exitCode := runCLI(serverUrl, "analyze", "observable", "8.8.8.8", "--tlp", "amber", "--tag", "triage", "--tag", "cli", "--wait", "-o", "json")
+	testWantData(t, cli.ExitOK, exitCode)
+	for _, want := range []string{`"job_id": 7`, `"status": "reported_without_fails"`, `"label": "malicious"`, `"plugin": "AbuseIPDB"`} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("Expected the output to contain %s got:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestCLIAnalyzeFileStdin(t *testing.T) {
+	serverUrl, apiHandler, closeServer := setupCLI()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.PLAYBOOK_ANALYZE_MULTIPLE_FILES_URL, func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("files")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		sample, _ := io.ReadAll(file)
+		testWantData(t, "dropper.exe", header.Filename)
+		testWantData(t, "MZ from stdin", string(sample))
+		testWantData(t, "Sample_Static_Analysis", r.FormValue("playbook_requested"))
+		_, _ = w.Write([]byte(`{"count":1,"results":[{"job_id":8,"status":"accepted"}]}`))
+	})
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	args := []string{"--url", serverUrl, "analyze", "file", "-", "--name", "dropper.exe", "--playbook", "Sample_Static_Analysis"}
+	exitCode := cli.Run(context.Background(), args, strings.NewReader("MZ from stdin"), stdout, stderr)
+	testWantData(t, cli.ExitOK, exitCode)
+	testWantData(t, "", stderr.String())
+	if !strings.Contains(stdout.String(), "Job:     8") {
+		t.Fatalf("Unexpected output:\n%s", stdout)
+	}
+}
+
+func TestCLIWatchOnce(t *testing.T) {
+	serverUrl, apiHandler, closeServer := setupCLI()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.ANALYZE_FILE_URL, func(w http.ResponseWriter, r *http.Request) {
+		_, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if header.Filename == "broken.bin" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"file":["The submitted file is empty."]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"job_id":9,"status":"accepted"}`))
+	})
+	directory := t.TempDir()
+	// a sample of the same name was already submitted, it must not be overwritten
+	if err := os.Mkdir(filepath.Join(directory, "done"), 0o750); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for name, content := range map[string]string{"dropper.exe": "MZ", "broken.bin": "", ".partial": "MZ", "done/dropper.exe": "MZ earlier"} {
+		if err := os.WriteFile(filepath.Join(directory, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	stdout, _, exitCode := runCLI(serverUrl, "watch", directory, "--once", "--interval", "10ms")
+	testWantData(t, cli.ExitOK, exitCode)
+	if !strings.Contains(stdout, "dropper.exe submitted as job 9") || !strings.Contains(stdout, "broken.bin failed") {
+		t.Fatalf("Unexpected output:\n%s", stdout)
+	}
+	for _, path := range []string{"done/dropper.exe", "failed/broken.bin", ".partial"} {
+		if _, err := os.Stat(filepath.Join(directory, path)); err != nil {
+			t.Fatalf("Expected %s to exist: %v", path, err)
+		}
+	}
+	earlier, err := os.ReadFile(filepath.Join(directory, "done", "dropper.exe"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, "MZ earlier", string(earlier))
+	moved, err := filepath.Glob(filepath.Join(directory, "done", "dropper-*.exe"))
+	if err != nil || len(moved) != 1 {
+		t.Fatalf("Expected the submitted dropper.exe to be moved under another name got: %v %v", moved, err)
+	}
+}
+
+func TestCLIWatchHealth(t *testing.T) {
+	serverUrl, _, closeServer := setupCLI()
+	defer closeServer()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	healthAddr := listener.Addr().String()
+	listener.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	exitCodes := make(chan int)
+	go func() {
+		args := []string{"--url", serverUrl, "watch", t.TempDir(), "--health-addr", healthAddr, "--interval", "10ms"}
+		exitCodes <- cli.Run(ctx, args, strings.NewReader(""), io.Discard, io.Discard)
+	}()
+	health := map[string]interface{}{}
+	for attempt := 0; ; attempt++ {
+		response, err := http.Get("http://" + healthAddr + "/healthz")
+		if err == nil {
+			err = json.NewDecoder(response.Body).Decode(&health)
+			response.Body.Close()
+		}
+		if err == nil && health["last_scan"] != nil {
+			break
+		}
+		if attempt == 100 {
+			t.Fatalf("The health endpoint never answered: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	testWantData(t, "ok", health["status"])
+	testWantData(t, float64(0), health["submitted"])
+	testWantData(t, float64(0), health["scan_failures"])
+	testWantData(t, float64(0), health["move_failures"])
+	cancel()
+	testWantData(t, cli.ExitOK, <-exitCodes)
+}
+
+func TestCLIJobsTail(t *testing.T) {
+	serverUrl, apiHandler, closeServer := setupCLI()
+	defer closeServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pages := []string{
+		cliJobList,
+		`{"count":3,"total_pages":1,"results":[
+			{"id":3,"is_sample":true,"file_name":"dropper.exe","status":"pending","tlp":"CLEAR"},
+			{"id":2,"observable_name":"8.8.8.8","observable_classification":"ip","status":"reported_without_fails","tlp":"AMBER"},
+			{"id":1,"is_sample":true,"file_name":"invoice.pdf","status":"reported_with_fails","tlp":"RED"}
+		]}`,
+	}
+	requests := 0
+	apiHandler.HandleFunc(constants.BASE_JOB_URL, func(w http.ResponseWriter, r *http.Request) {
+		testWantData(t, "10", r.URL.Query().Get("page_size"))
+		requests++
+		if requests > len(pages) {
+			// tailing stops once intelx is interrupted
+			cancel()
+			return
+		}
+		_, _ = w.Write([]byte(pages[requests-1]))
+	})
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	args := []string{"--url", serverUrl, "jobs", "tail", "--interval", "10ms", "--window", "10", "--filter", `name!~^8\.`}
+	exitCode := cli.Run(ctx, args, strings.NewReader(""), stdout, stderr)
+	testWantData(t, cli.ExitOK, exitCode)
+	testWantData(t, "", stderr.String())
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	testWantData(t, 4, len(lines))
+	for index, want := range []string{"ID ", "1         running ", "1         reported_with_fails ", "3         pending "} {
+		if !strings.HasPrefix(lines[index], want) {
+			t.Fatalf("Expected line %d to start with %q got:\n%s", index, want, stdout)
+		}
+	}
+}
+
+func TestCLIJobsTailErrors(t *testing.T) {
+	serverUrl, apiHandler, closeServer := setupCLI()
+	defer closeServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	statusCodes := []int{http.StatusBadGateway, http.StatusOK, http.StatusUnauthorized}
+	requests := 0
+	apiHandler.HandleFunc(constants.BASE_JOB_URL, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > len(statusCodes) {
+			cancel()
+			return
+		}
+		switch statusCodes[requests-1] {
+		case http.StatusBadGateway:
+			w.WriteHeader(http.StatusBadGateway)
+		case http.StatusUnauthorized:
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"detail":"Invalid token."}`))
+		default:
+			_, _ = w.Write([]byte(cliJobList))
+		}
+	})
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	args := []string{"--url", serverUrl, "jobs", "tail", "--interval", "10ms"}
+	exitCode := cli.Run(ctx, args, strings.NewReader(""), stdout, stderr)
+	// the bad gateway is retried, the rejected token stops tailing
+	testWantData(t, cli.ExitError, exitCode)
+	testWantData(t, 3, requests)
+	if !strings.Contains(stderr.String(), "retrying in 10ms") || !strings.Contains(stderr.String(), "Invalid token.") {
+		t.Fatalf("Expected the failures to be reported got:\n%s", stderr)
+	}
+	if !strings.HasPrefix(stdout.String(), "ID ") {
+		t.Fatalf("Expected the jobs to be printed got:\n%s", stdout)
+	}
+}
+
+func TestCLICompletion(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["bash"] = TestData{
+		Input: "bash",
+		Want:  []string{"complete -o default -F _intelx intelx", `intelx/jobs) words="list get kill delete download tail help" ;;`, `--output) COMPREPLY=($(compgen -W "table json yaml tsv" -- "$cur")); return ;;`},
+	}
+	testCases["zsh"] = TestData{
+		Input: "zsh",
+		Want:  []string{"bashcompinit", `intelx/completion) words="bash zsh fish" ;;`},
+	}
+	testCases["fish"] = TestData{
+		Input: "fish",
+		Want:  []string{"complete -c intelx -n '__intelx_command_is intelx/jobs/list' -l status -r", "complete -c intelx -n '__intelx_command_is intelx/analyze/observable' -l wait -d"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			stdout, stderr, exitCode := runCLI("http://localhost:0", "completion", testCase.Input.(string))
+			testWantData(t, cli.ExitOK, exitCode)
+			testWantData(t, "", stderr)
+			for _, want := range testCase.Want.([]string) {
+				if !strings.Contains(stdout, want) {
+					t.Fatalf("Expected the script to contain %q got:\n%s", want, stdout)
+				}
+			}
+		})
+	}
+}
+
+func TestCLIUsageErrors(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["unknownCommand"] = TestData{
+		Input: []string{"jobs", "explode"},
+		Want:  `unknown command "explode" for jobs`,
+	}
+	testCases["invalidJobID"] = TestData{
+		Input: []string{"jobs", "get", "forty-two"},
+		Want:  `invalid job ID "forty-two"`,
+	}
+	testCases["playbookWithAnalyzers"] = TestData{
+		Input: []string{"analyze", "observable", "8.8.8.8", "--playbook", "Dns", "--analyzer", "Classic_DNS"},
+		Want:  "--playbook can't be given along with --analyzer",
+	}
+	testCases["unknownOutput"] = TestData{
+		Input: []string{"jobs", "list", "-o", "xml"},
+		Want:  `unknown output format "xml"`,
+	}
+	testCases["unknownShell"] = TestData{
+		Input: []string{"completion", "powershell"},
+		Want:  `unknown shell "powershell"`,
+	}
+	testCases["invalidFilter"] = TestData{
+		Input: []string{"jobs", "tail", "--filter", "size>10"},
+		Want:  `invalid --filter "size>10"`,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, stderr, exitCode := runCLI("http://localhost:0", testCase.Input.([]string)...)
+			testWantData(t, cli.ExitUsage, exitCode)
+			if !strings.Contains(stderr, testCase.Want.(string)) {
+				t.Fatalf("Expected stderr to contain %q got:\n%s", testCase.Want, stderr)
+			}
+		})
+	}
+}
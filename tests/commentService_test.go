@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestCommentServiceList(t *testing.T) {
+	testData := TestData{
+		Input:      uint64(42),
+		Data:       `[{"id":1,"user":{"username":"hussain"},"content":"looks like a false positive","created_at":"2023-03-01T12:00:00Z"}]`,
+		StatusCode: http.StatusOK,
+		Want: []gothreatmatrix.Comment{{
+			ID:        1,
+			User:      gothreatmatrix.UserDetails{Username: "hussain"},
+			Content:   "looks like a false positive",
+			CreatedAt: time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC),
+		}},
+	}
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.BASE_COMMENT_URL, func(w http.ResponseWriter, r *http.Request) {
+		testWantData(t, "42", r.URL.Query().Get("job_id"))
+		serverHandler(t, testData, "GET").ServeHTTP(w, r)
+	})
+	gottenComments, err := client.CommentService.List(context.Background(), testData.Input.(uint64))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, testData.Want, gottenComments)
+}
+
+func TestCommentServiceCreate(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["simple"] = TestData{
+		Input:      &gothreatmatrix.CommentParams{JobID: 42, Content: "escalated to IR"},
+		Data:       `{"id":2,"user":{"username":"hussain"},"content":"escalated to IR","created_at":"2023-03-01T12:00:00Z"}`,
+		StatusCode: http.StatusCreated,
+		Want: &gothreatmatrix.Comment{
+			ID:        2,
+			User:      gothreatmatrix.UserDetails{Username: "hussain"},
+			Content:   "escalated to IR",
+			CreatedAt: time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+	testCases["noJob"] = TestData{
+		Input: &gothreatmatrix.CommentParams{Content: "escalated to IR"},
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	testCases["noContent"] = TestData{
+		Input: &gothreatmatrix.CommentParams{JobID: 42, Content: " "},
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.HandleFunc(constants.BASE_COMMENT_URL, func(w http.ResponseWriter, r *http.Request) {
+				gottenParams := gothreatmatrix.CommentParams{}
+				if err := json.NewDecoder(r.Body).Decode(&gottenParams); err != nil {
+					t.Errorf("Could not decode the request body: %v", err)
+				}
+				testWantData(t, *testCase.Input.(*gothreatmatrix.CommentParams), gottenParams)
+				serverHandler(t, testCase, "POST").ServeHTTP(w, r)
+			})
+			gottenComment, err := client.CommentService.Create(context.Background(), testCase.Input.(*gothreatmatrix.CommentParams))
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenComment)
+		})
+	}
+}
+
+func TestCommentServiceDelete(t *testing.T) {
+	testData := TestData{
+		Input:      uint64(2),
+		StatusCode: http.StatusNoContent,
+		Want:       true,
+	}
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.Handle(fmt.Sprintf(constants.SPECIFIC_COMMENT_URL, 2), serverHandler(t, testData, "DELETE"))
+	ctx := context.Background()
+	deleted, err := client.CommentService.Delete(ctx, testData.Input.(uint64))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, testData.Want, deleted)
+	if _, err := client.CommentService.Delete(ctx, 0); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected a validation error got: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestWithCompression(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["largeBody"] = TestData{
+		Input: 500,
+		Want:  "gzip",
+	}
+	testCases["smallBody"] = TestData{
+		Input: 1,
+		Want:  "",
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := http.NewServeMux()
+			testServer := httptest.NewServer(apiHandler)
+			defer testServer.Close()
+			observables := make([][]string, testCase.Input.(int))
+			for index := range observables {
+				observables[index] = []string{"ip", fmt.Sprintf("198.51.100.%d", index)}
+			}
+			apiHandler.HandleFunc(constants.ANALYZE_MULTIPLE_OBSERVABLES_URL, func(w http.ResponseWriter, r *http.Request) {
+				testWantData(t, "gzip", r.Header.Get("Accept-Encoding"))
+				testWantData(t, testCase.Want, r.Header.Get("Content-Encoding"))
+				body := io.Reader(r.Body)
+				if r.Header.Get("Content-Encoding") == "gzip" {
+					reader, err := gzip.NewReader(r.Body)
+					if err != nil {
+						t.Fatalf("Unexpected error: %v", err)
+					}
+					body = reader
+				}
+				data, _ := io.ReadAll(body)
+				if !strings.Contains(string(data), fmt.Sprintf(`["ip","198.51.100.%d"]`, len(observables)-1)) {
+					t.Errorf("Unexpected body: %s", data)
+				}
+				w.Header().Set("Content-Encoding", "gzip")
+				writer := gzip.NewWriter(w)
+				_, _ = writer.Write([]byte(`{"count":1,"results":[{"job_id":1,"status":"accepted"}]}`))
+				_ = writer.Close()
+			})
+			client := gothreatmatrix.NewClient(
+				gothreatmatrix.WithURL(testServer.URL),
+				gothreatmatrix.WithCompression(-1),
+			)
+			params := map[string]interface{}{"observables": observables, "tlp": "AMBER"}
+			result := map[string]interface{}{}
+			if err := client.Do(context.Background(), http.MethodPost, constants.ANALYZE_MULTIPLE_OBSERVABLES_URL, params, &result); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, float64(1), result["count"])
+		})
+	}
+}
+
+func TestWithCompressionPlainResponse(t *testing.T) {
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id":1,"label":"apt","color":"#ff0000"}]`))
+	})
+	client := gothreatmatrix.NewClient(gothreatmatrix.WithURL(testServer.URL), gothreatmatrix.WithCompression(0))
+	tags, err := client.TagService.List(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, "apt", (*tags)[0].Label)
+}
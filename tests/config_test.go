@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// configServer runs a test server checking the token of the tags list requests it gets.
+func configServer(t *testing.T, wantedToken string) *httptest.Server {
+	apiHandler := http.NewServeMux()
+	apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+		testWantData(t, "token "+wantedToken, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`[]`))
+	})
+	return httptest.NewServer(apiHandler)
+}
+
+func writeConfigFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestNewClientFromConfig(t *testing.T) {
+	prodServer := configServer(t, "prod-token")
+	defer prodServer.Close()
+	stagingServer := configServer(t, "staging-token")
+	defer stagingServer.Close()
+	yamlConfig := `default_profile: prod
+profiles:
+  prod:
+    url: ` + prodServer.URL + `
+    api_key: prod-token
+    timeout: 30s
+  staging:
+    url: ` + stagingServer.URL + `
+    api_key: staging-token
+    timeout: 5
+`
+	tomlConfig := `default_profile = "prod"
+
+[profiles.prod]
+url = "` + prodServer.URL + `"
+api_key = "prod-token"
+timeout = "30s"
+
+[profiles.staging]
+url = "` + stagingServer.URL + `"
+api_key = "staging-token"
+timeout = 5
+`
+	testCases := make(map[string]TestData)
+	testCases["yamlDefault"] = TestData{Input: []string{"config.yaml", yamlConfig, ""}, Want: "prod"}
+	testCases["yamlStaging"] = TestData{Input: []string{"config.yaml", yamlConfig, "staging"}, Want: "staging"}
+	testCases["tomlDefault"] = TestData{Input: []string{"config.toml", tomlConfig, ""}, Want: "prod"}
+	testCases["tomlStaging"] = TestData{Input: []string{"config.toml", tomlConfig, "staging"}, Want: "staging"}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			input := testCase.Input.([]string)
+			// the config file is looked for in ~/.threatmatrix
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			t.Setenv(gothreatmatrix.EnvConfig, "")
+			writeConfigFile(t, filepath.Join(home, ".threatmatrix", input[0]), input[1])
+			client, err := gothreatmatrix.NewClientFromConfig(input[2])
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if _, err := client.TagService.List(context.Background()); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewClientFromConfigEnvPath(t *testing.T) {
+	testServer := configServer(t, "override-token")
+	defer testServer.Close()
+	path := filepath.Join(t.TempDir(), "threatmatrix.yml")
+	writeConfigFile(t, path, "profiles:\n  default:\n    url: "+testServer.URL+"\n    api_key: file-token\n")
+	t.Setenv(gothreatmatrix.EnvConfig, path)
+	gottenPath, err := gothreatmatrix.DefaultConfigPath()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, path, gottenPath)
+	// options given to NewClientFromConfig take precedence over the profile
+	client, err := gothreatmatrix.NewClientFromConfig("", gothreatmatrix.WithToken("override-token"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.TagService.List(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestNewClientFromConfigErrors(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["unknownProfile"] = TestData{
+		Input: []string{"config.yaml", "profiles:\n  prod:\n    url: https://a\n    api_key: a\n  dev:\n    url: https://b\n    api_key: b\n", "staging"},
+		Want:  `profile "staging" not found, available profiles: dev, prod`,
+	}
+	testCases["incompleteProfile"] = TestData{
+		Input: []string{"config.toml", "[profiles.default]\ntimeout = \"later\"\n", ""},
+		Want:  `profile "default" has no url`,
+	}
+	testCases["invalidTimeout"] = TestData{
+		Input: []string{"config.toml", "[profiles.default]\nurl = \"https://a\"\napi_key = \"a\"\ntimeout = \"later\"\n", ""},
+		Want:  `timeout of profile "default" must be a duration`,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			input := testCase.Input.([]string)
+			path := filepath.Join(t.TempDir(), input[0])
+			writeConfigFile(t, path, input[1])
+			client, err := gothreatmatrix.NewClientFromConfigFile(path, input[2])
+			if client != nil || !errors.Is(err, gothreatmatrix.ErrValidation) {
+				t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+			}
+			if !strings.Contains(err.Error(), testCase.Want.(string)) {
+				t.Fatalf("Expected %q in %q", testCase.Want, err.Error())
+			}
+		})
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(gothreatmatrix.EnvConfig, "")
+	if _, err := gothreatmatrix.NewClientFromConfig("prod"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Expected %v got: %v", os.ErrNotExist, err)
+	}
+}
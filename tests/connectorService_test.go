@@ -3,10 +3,13 @@ package tests
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"sort"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/khulnasoft/go-threatmatrix/constants"
 	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
@@ -62,7 +65,7 @@ func TestConnectorServiceHealthCheck(t *testing.T) {
 		Input:      "OpenCTI",
 		Data:       `{"status": false}`,
 		StatusCode: http.StatusOK,
-		Want:       false,
+		Want:       gothreatmatrix.HealthStatusDown,
 	}
 	testCases["connectorDoesntExist"] = TestData{
 		Input:      "notAConnector",
@@ -71,6 +74,7 @@ func TestConnectorServiceHealthCheck(t *testing.T) {
 		Want: &gothreatmatrix.ThreatMatrixError{
 			StatusCode: http.StatusBadRequest,
 			Message:    `{"errors": {"detail": "Connector doesn't exist"}}`,
+			Detail:     "Connector doesn't exist",
 		},
 	}
 	for name, testCase := range testCases {
@@ -93,3 +97,119 @@ func TestConnectorServiceHealthCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestConnectorServiceList(t *testing.T) {
+	connectorJsonString := `{"name":"MISP","python_module":"misp.MISP","disabled":false,"description":"Automatically creates an event on your MISP instance","maximum_tlp":"AMBER","run_on_failure":true}`
+	connectorConfig := gothreatmatrix.ConnectorConfig{}
+	if unmarshalError := json.Unmarshal([]byte(connectorJsonString), &connectorConfig); unmarshalError != nil {
+		t.Fatalf("Error: %s", unmarshalError)
+	}
+	testCases := make(map[string]TestData)
+	testCases["list"] = TestData{
+		Data:       "[" + connectorJsonString + "]",
+		StatusCode: http.StatusOK,
+		Want:       []gothreatmatrix.ConnectorConfig{connectorConfig},
+	}
+	testCases["paginated"] = TestData{
+		Data:       `{"count":1,"total_pages":1,"results":[` + connectorJsonString + "]}",
+		StatusCode: http.StatusOK,
+		Want:       []gothreatmatrix.ConnectorConfig{connectorConfig},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.Handle(constants.BASE_CONNECTOR_URL, serverHandler(t, testCase, "GET"))
+			gottenConnectorConfigs, err := client.ConnectorService.List(context.Background())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenConnectorConfigs)
+			testWantData(t, gothreatmatrix.AMBER, gottenConnectorConfigs[0].MaximumTlp)
+			testWantData(t, true, gottenConnectorConfigs[0].RunOnFailure)
+		})
+	}
+}
+
+func TestConnectorServiceGet(t *testing.T) {
+	connectorJsonString := `{"name":"YETI","maximum_tlp":"WHITE","run_on_failure":false}`
+	connectorConfig := gothreatmatrix.ConnectorConfig{}
+	if unmarshalError := json.Unmarshal([]byte(connectorJsonString), &connectorConfig); unmarshalError != nil {
+		t.Fatalf("Error: %s", unmarshalError)
+	}
+	testCases := make(map[string]TestData)
+	testCases["simple"] = TestData{
+		Input:      "YETI",
+		Data:       connectorJsonString,
+		StatusCode: http.StatusOK,
+		Want:       &connectorConfig,
+	}
+	testCases["notFound"] = TestData{
+		Input:      "Missing_Connector",
+		Data:       `{"detail":"Not found."}`,
+		StatusCode: http.StatusNotFound,
+		Want: &gothreatmatrix.ThreatMatrixError{
+			StatusCode: http.StatusNotFound,
+			Message:    `{"detail":"Not found."}`,
+			Detail:     "Not found.",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			connectorName := testCase.Input.(string)
+			apiHandler.Handle(fmt.Sprintf(constants.SPECIFIC_CONNECTOR_URL, connectorName), serverHandler(t, testCase, "GET"))
+			gottenConnectorConfig, err := client.ConnectorService.Get(context.Background(), connectorName)
+			if err != nil {
+				testError(t, testCase, err)
+			} else {
+				testWantData(t, testCase.Want, gottenConnectorConfig)
+			}
+		})
+	}
+}
+
+func TestConnectorServiceHealthCheckAll(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	connectorStatuses := map[string]string{
+		"MISP":        `{"status": true}`,
+		"OpenCTI":     `{"status": false}`,
+		"YETI":        `{"status": true}`,
+		"Slack":       `{"status": true}`,
+		"EmailSender": `{"status": true}`,
+	}
+	running, maxRunning := int32(0), int32(0)
+	for connectorName, statusJson := range connectorStatuses {
+		statusJson := statusJson
+		apiHandler.HandleFunc(fmt.Sprintf(constants.CONNECTOR_HEALTHCHECK_URL, connectorName), func(w http.ResponseWriter, r *http.Request) {
+			current := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				seen := atomic.LoadInt32(&maxRunning)
+				if current <= seen || atomic.CompareAndSwapInt32(&maxRunning, seen, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			_, _ = w.Write([]byte(statusJson))
+		})
+	}
+	connectorNames := []string{"MISP", "OpenCTI", "missing", "YETI", "Slack", "EmailSender"}
+	results := client.ConnectorService.HealthCheckAll(context.Background(), connectorNames, 2)
+
+	if maxRunning > 2 {
+		t.Errorf("Expected at most 2 concurrent health checks got %d", maxRunning)
+	}
+	testWantData(t, len(connectorNames), len(results))
+	for index, result := range results {
+		testWantData(t, connectorNames[index], result.Name)
+	}
+	testWantData(t, gothreatmatrix.HealthStatusUp, results[0].Status)
+	testWantData(t, gothreatmatrix.HealthStatusDown, results[1].Status)
+	if !errors.Is(results[2].Err, gothreatmatrix.ErrNotFound) {
+		t.Errorf("Expected a not found error got: %v", results[2].Err)
+	}
+	testWantData(t, gothreatmatrix.HealthStatusUp, results[5].Status)
+}
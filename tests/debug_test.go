@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestWithDebug(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["defaultLimit"] = TestData{
+		Input: []gothreatmatrix.Option{},
+		Want: []string{
+			"--> POST ",
+			"Authorization: [SCRUBBED]\n",
+			`{"label":"apt","color":"#ff0000"}`,
+			"<-- 400 Bad Request",
+			`{"label":["This field must be unique."]}`,
+		},
+	}
+	testCases["truncated"] = TestData{
+		Input: []gothreatmatrix.Option{gothreatmatrix.WithDebugBodyLimit(8)},
+		Want: []string{
+			`{"label"...(truncated)`,
+			"<-- 400 Bad Request",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := http.NewServeMux()
+			testServer := httptest.NewServer(apiHandler)
+			defer testServer.Close()
+			apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"label":["This field must be unique."]}`))
+			})
+			dump := &bytes.Buffer{}
+			opts := append([]gothreatmatrix.Option{
+				gothreatmatrix.WithURL(testServer.URL),
+				gothreatmatrix.WithToken("super-secret-token"),
+				gothreatmatrix.WithDebug(dump),
+			}, testCase.Input.([]gothreatmatrix.Option)...)
+			client := gothreatmatrix.NewClient(opts...)
+			_, err := client.TagService.Create(context.Background(), &gothreatmatrix.TagParams{Label: "apt", Color: "#ff0000"})
+			// the dumped body is still handed to the client
+			var threatMatrixError *gothreatmatrix.ThreatMatrixError
+			if !errors.As(err, &threatMatrixError) {
+				t.Fatalf("Expected a ThreatMatrixError got: %v", err)
+			}
+			testWantData(t, []string{"This field must be unique."}, threatMatrixError.FieldErrors["label"])
+
+			output := dump.String()
+			for _, want := range testCase.Want.([]string) {
+				if !strings.Contains(output, want) {
+					t.Fatalf("Expected the dump to contain %q got:\n%s", want, output)
+				}
+			}
+			if strings.Contains(output, "super-secret-token") {
+				t.Fatalf("Expected the token to be scrubbed got:\n%s", output)
+			}
+		})
+	}
+}
+
+func TestWithDebugStreamedBody(t *testing.T) {
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	apiHandler.HandleFunc(constants.ANALYZE_FILE_URL, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"job_id":1,"status":"accepted"}`))
+	})
+	dump := &bytes.Buffer{}
+	client := gothreatmatrix.NewClient(gothreatmatrix.WithURL(testServer.URL), gothreatmatrix.WithDebug(dump))
+	analysisRequest := gothreatmatrix.FileAnalysisRequest{File: strings.NewReader("MZ-secret-sample"), FileName: "sample.exe"}
+	if _, err := client.AnalyzeService.AnalyzeFile(context.Background(), analysisRequest); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output := dump.String()
+	if strings.Contains(output, "MZ-secret-sample") || !strings.Contains(output, "(streamed body left out)") {
+		t.Fatalf("Expected the file to be left out got:\n%s", output)
+	}
+	if !strings.Contains(output, `{"job_id":1,"status":"accepted"}`) {
+		t.Fatalf("Expected the response body got:\n%s", output)
+	}
+}
+
+func TestWithDebugScrubsBodies(t *testing.T) {
+	body := `{"id":1,"label":"apt","color":"#ff0000","secret":"body-secret-value"}`
+	testCases := make(map[string]TestData)
+	testCases["whole"] = TestData{
+		Input: []gothreatmatrix.Option{},
+		Want:  `"secret":"[SCRUBBED]"`,
+	}
+	// the body is cut off in the middle of the secret, so it isn't valid JSON anymore
+	testCases["truncated"] = TestData{
+		Input: []gothreatmatrix.Option{gothreatmatrix.WithDebugBodyLimit(len(body) - 6)},
+		Want:  `"secret":"[SCRUBBED]"...(truncated)`,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := http.NewServeMux()
+			testServer := httptest.NewServer(apiHandler)
+			defer testServer.Close()
+			apiHandler.HandleFunc(fmt.Sprintf(constants.SPECIFIC_TAG_URL, 1), func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(body))
+			})
+			dump := &bytes.Buffer{}
+			opts := append([]gothreatmatrix.Option{gothreatmatrix.WithURL(testServer.URL), gothreatmatrix.WithDebug(dump)}, testCase.Input.([]gothreatmatrix.Option)...)
+			client := gothreatmatrix.NewClient(opts...)
+			tag, err := client.TagService.Get(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, "apt", tag.Label)
+			output := dump.String()
+			if strings.Contains(output, "body-secret") {
+				t.Fatalf("Expected the secret to be scrubbed got:\n%s", output)
+			}
+			if !strings.Contains(output, testCase.Want.(string)) {
+				t.Fatalf("Expected the dump to contain %q got:\n%s", testCase.Want, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/export"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestRefang(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["url"] = TestData{Input: "hxxp://evil[.]com", Want: "http://evil.com"}
+	testCases["https"] = TestData{Input: "hXXps[://]evil(.)com/a[.]php", Want: "https://evil.com/a.php"}
+	testCases["ftp"] = TestData{Input: "fxp://files{dot}evil[dot]com", Want: "ftp://files.evil.com"}
+	testCases["ip"] = TestData{Input: "1.2.3[.]4", Want: "1.2.3.4"}
+	testCases["escapedDots"] = TestData{Input: `evil\.com`, Want: "evil.com"}
+	testCases["port"] = TestData{Input: "evil[.]com[:]8080", Want: "evil.com:8080"}
+	testCases["email"] = TestData{Input: "phish[at]evil[.]com", Want: "phish@evil.com"}
+	testCases["notDefanged"] = TestData{Input: "d41d8cd98f00b204e9800998ecf8427e", Want: "d41d8cd98f00b204e9800998ecf8427e"}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			testWantData(t, testCase.Want, gothreatmatrix.Refang(testCase.Input.(string)))
+		})
+	}
+}
+
+func TestDefang(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["url"] = TestData{Input: "http://evil.com/a.php", Want: "hxxp://evil[.]com/a.php"}
+	testCases["https"] = TestData{Input: "https://sub.evil.com", Want: "hxxps://sub[.]evil[.]com"}
+	testCases["domain"] = TestData{Input: "evil.com", Want: "evil[.]com"}
+	testCases["ip"] = TestData{Input: "1.2.3.4", Want: "1[.]2[.]3[.]4"}
+	testCases["alreadyDefanged"] = TestData{Input: "hxxp://evil[.]com", Want: "hxxp://evil[.]com"}
+	testCases["hash"] = TestData{Input: "d41d8cd98f00b204e9800998ecf8427e", Want: "d41d8cd98f00b204e9800998ecf8427e"}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			defanged := gothreatmatrix.Defang(testCase.Input.(string))
+			testWantData(t, testCase.Want, defanged)
+			testWantData(t, gothreatmatrix.Refang(testCase.Input.(string)), gothreatmatrix.Refang(defanged))
+		})
+	}
+}
+
+func TestWithRefang(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["refanged"] = TestData{
+		Input: []gothreatmatrix.Option{gothreatmatrix.WithRefang()},
+		Want:  []string{"evil.com", "domain"},
+	}
+	testCases["asIs"] = TestData{
+		Input: []gothreatmatrix.Option{},
+		Want:  []string{"evil[.]com", "generic"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := http.NewServeMux()
+			testServer := httptest.NewServer(apiHandler)
+			defer testServer.Close()
+			submitted := gothreatmatrix.ObservableAnalysisParams{}
+			apiHandler.HandleFunc(constants.ANALYZE_OBSERVABLE_URL, func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&submitted); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				_, _ = w.Write([]byte(`{"job_id":1,"status":"accepted"}`))
+			})
+			client := gothreatmatrix.NewClient(append(testCase.Input.([]gothreatmatrix.Option), gothreatmatrix.WithURL(testServer.URL))...)
+			if _, err := client.AnalyzeService.AnalyzeObservable(context.Background(), gothreatmatrix.ObservableAnalysisRequest{Value: "evil[.]com"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, []string{submitted.ObservableName, submitted.ObservableClassification})
+		})
+	}
+}
+
+func TestDefangJob(t *testing.T) {
+	job := &gothreatmatrix.Job{}
+	job.ObservableName = "evil.com"
+	defanged := export.DefangJob(job)
+	testWantData(t, "evil[.]com", defanged.ObservableName)
+	testWantData(t, "evil.com", job.ObservableName)
+}
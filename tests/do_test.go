@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+type analyzable struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestClientDo(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["decoded"] = TestData{
+		Input:      "/api/analyzable",
+		Data:       `[{"id":1,"name":"8.8.8.8"}]`,
+		StatusCode: http.StatusOK,
+		Want:       []analyzable{{ID: 1, Name: "8.8.8.8"}},
+	}
+	testCases["withoutLeadingSlash"] = TestData{
+		Input:      "api/analyzable",
+		Data:       `[]`,
+		StatusCode: http.StatusOK,
+		Want:       []analyzable{},
+	}
+	testCases["notFound"] = TestData{
+		Input:      "/api/analyzable",
+		Data:       `{"detail":"Not found."}`,
+		StatusCode: http.StatusNotFound,
+		Want:       gothreatmatrix.ErrNotFound,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.Handle("/api/analyzable", serverHandler(t, testCase, "GET"))
+			analyzables := []analyzable{}
+			err := client.Do(context.Background(), http.MethodGet, testCase.Input.(string), nil, &analyzables)
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, analyzables)
+		})
+	}
+}
+
+func TestClientDoBody(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["marshalled"] = TestData{
+		Input: map[string]string{"name": "8.8.8.8"},
+		Want:  `{"name":"8.8.8.8"}`,
+	}
+	testCases["raw"] = TestData{
+		Input: []byte(`{"name": "8.8.8.8"}`),
+		Want:  `{"name": "8.8.8.8"}`,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.HandleFunc("/api/analyzable", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "POST")
+				testWantData(t, "token test-token", r.Header.Get("Authorization"))
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				testWantData(t, testCase.Want, string(body))
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"id":1,"name":"8.8.8.8"}`))
+			})
+			// a *[]byte gets the raw response
+			var response []byte
+			if err := client.Do(context.Background(), http.MethodPost, "/api/analyzable", testCase.Input, &response); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			created := analyzable{}
+			if err := json.Unmarshal(response, &created); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, analyzable{ID: 1, Name: "8.8.8.8"}, created)
+		})
+	}
+}
+
+func TestDo(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["decoded"] = TestData{
+		Input:      gothreatmatrix.Request{Path: "/api/analyzable", Query: url.Values{"name": {"8.8.8.8"}}},
+		Data:       `[{"id":1,"name":"8.8.8.8"}]`,
+		StatusCode: http.StatusOK,
+		Want:       []analyzable{{ID: 1, Name: "8.8.8.8"}},
+	}
+	testCases["queryAppended"] = TestData{
+		Input:      gothreatmatrix.Request{Method: http.MethodGet, Path: "/api/analyzable?page=1", Query: url.Values{"name": {"8.8.8.8"}}},
+		Data:       `[]`,
+		StatusCode: http.StatusOK,
+		Want:       []analyzable{},
+	}
+	testCases["forbidden"] = TestData{
+		Input:      gothreatmatrix.Request{Path: "/api/analyzable", Query: url.Values{"name": {"8.8.8.8"}}},
+		Data:       `{"detail":"You do not have permission to perform this action."}`,
+		StatusCode: http.StatusForbidden,
+		Want:       gothreatmatrix.ErrForbidden,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.HandleFunc("/api/analyzable", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				testWantData(t, "8.8.8.8", r.URL.Query().Get("name"))
+				w.WriteHeader(testCase.StatusCode)
+				_, _ = w.Write([]byte(testCase.Data))
+			})
+			analyzables, err := gothreatmatrix.Do[[]analyzable](context.Background(), &client, testCase.Input.(gothreatmatrix.Request))
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, *analyzables)
+		})
+	}
+}
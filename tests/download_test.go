@@ -0,0 +1,138 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// flakySampleServer serves a sample, dropping the connection halfway through the first download.
+type flakySampleServer struct {
+	mutex        sync.Mutex
+	sample       []byte
+	ignoreRanges bool
+	// etags is the ETag of each download, the last one being kept for the ones that follow
+	etags     []string
+	ranges    []string
+	encodings []string
+	served    int
+}
+
+func (server *flakySampleServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mutex.Lock()
+	served := server.served
+	server.served++
+	server.ranges = append(server.ranges, r.Header.Get("Range")+"|"+r.Header.Get("If-Range"))
+	server.encodings = append(server.encodings, r.Header.Get("Accept-Encoding"))
+	etag := server.etags[len(server.etags)-1]
+	if served < len(server.etags) {
+		etag = server.etags[served]
+	}
+	server.mutex.Unlock()
+	w.Header().Set("ETag", etag)
+	content := server.sample
+	if rangeHeader := r.Header.Get("Range"); rangeHeader != "" && !server.ignoreRanges && r.Header.Get("If-Range") == etag {
+		start := 0
+		_, _ = fmt.Sscanf(rangeHeader, "bytes=%d-", &start)
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+		w.Header().Set("Content-Length", fmt.Sprint(len(content)-start))
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write(content[start:])
+		return
+	}
+	w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+	if served == 0 {
+		// the connection is closed once the handler returns without having written the whole body
+		_, _ = w.Write(content[:len(content)/2])
+		return
+	}
+	_, _ = w.Write(content)
+}
+
+func newFlakySampleClient(t *testing.T, server *flakySampleServer, opts ...gothreatmatrix.Option) (*gothreatmatrix.ThreatMatrixClient, func()) {
+	apiHandler := http.NewServeMux()
+	apiHandler.Handle(fmt.Sprintf(constants.DOWNLOAD_SAMPLE_JOB_URL, 1), server)
+	testServer := httptest.NewServer(apiHandler)
+	client := gothreatmatrix.NewClient(append([]gothreatmatrix.Option{
+		gothreatmatrix.WithURL(testServer.URL),
+		gothreatmatrix.WithToken("test-token"),
+		gothreatmatrix.WithRetry(1, time.Millisecond),
+	}, opts...)...)
+	return client, testServer.Close
+}
+
+func TestDownloadSampleToResumes(t *testing.T) {
+	sample := []byte(strings.Repeat("0123456789", 10000))
+	testCases := map[string]struct {
+		ignoreRanges bool
+		compression  bool
+		wantRanges   []string
+	}{
+		"range":        {wantRanges: []string{"|", fmt.Sprintf("bytes=%d-|\"v1\"", len(sample)/2)}},
+		"rangeIgnored": {ignoreRanges: true, wantRanges: []string{"|", fmt.Sprintf("bytes=%d-|\"v1\"", len(sample)/2)}},
+		"compression":  {compression: true, wantRanges: []string{"|", fmt.Sprintf("bytes=%d-|\"v1\"", len(sample)/2)}},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := &flakySampleServer{sample: sample, ignoreRanges: testCase.ignoreRanges, etags: []string{`"v1"`}}
+			opts := []gothreatmatrix.Option{}
+			if testCase.compression {
+				opts = append(opts, gothreatmatrix.WithCompression(-1))
+			}
+			client, closeServer := newFlakySampleClient(t, server, opts...)
+			defer closeServer()
+			downloaded := bytes.Buffer{}
+			sent := int64(0)
+			written, err := client.JobService.DownloadSampleTo(context.Background(), 1, &downloaded, gothreatmatrix.WithProgress(func(progress int64, total int64) {
+				sent = progress
+				testWantData(t, int64(len(sample)), total)
+			}))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, int64(len(sample)), written)
+			testWantData(t, int64(len(sample)), sent)
+			if !bytes.Equal(sample, downloaded.Bytes()) {
+				t.Fatalf("the resumed sample differs from the original")
+			}
+			testWantData(t, testCase.wantRanges, server.ranges)
+			// the offsets of the resume are the ones of the sample, never of a compressed body
+			testWantData(t, []string{"identity", "identity"}, server.encodings)
+		})
+	}
+}
+
+func TestDownloadSampleToResumeErrors(t *testing.T) {
+	sample := []byte(strings.Repeat("0123456789", 10000))
+
+	// the sample changed between both requests
+	server := &flakySampleServer{sample: sample, etags: []string{`"v1"`, `"v2"`}}
+	client, closeServer := newFlakySampleClient(t, server)
+	defer closeServer()
+	_, err := client.JobService.DownloadSampleTo(context.Background(), 1, io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "changed") {
+		t.Fatalf("Expected the download to fail as the sample changed got: %v", err)
+	}
+
+	// resuming is disabled
+	server = &flakySampleServer{sample: sample, etags: []string{`"v1"`}}
+	client, closeServer = newFlakySampleClient(t, server)
+	defer closeServer()
+	written, err := client.JobService.DownloadSampleTo(context.Background(), 1, io.Discard, gothreatmatrix.WithDownloadResumes(0))
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected %v got: %v", io.ErrUnexpectedEOF, err)
+	}
+	testWantData(t, int64(len(sample)/2), written)
+	testWantData(t, 1, server.served)
+}
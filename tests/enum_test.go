@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestJobStatus(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["pending"] = TestData{
+		Input: gothreatmatrix.StatusPending,
+		Want:  []bool{true, false},
+	}
+	testCases["analyzersCompleted"] = TestData{
+		Input: gothreatmatrix.StatusAnalyzersCompleted,
+		Want:  []bool{true, false},
+	}
+	testCases["reportedWithFails"] = TestData{
+		Input: gothreatmatrix.StatusReportedWithFails,
+		Want:  []bool{true, true},
+	}
+	testCases["killed"] = TestData{
+		Input: gothreatmatrix.StatusKilled,
+		Want:  []bool{true, true},
+	}
+	testCases["unknown"] = TestData{
+		Input: gothreatmatrix.JobStatus("exploded"),
+		Want:  []bool{false, false},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			status := testCase.Input.(gothreatmatrix.JobStatus)
+			testWantData(t, testCase.Want, []bool{status.Valid(), status.IsTerminal()})
+		})
+	}
+}
+
+func TestParseTLP(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["clear"] = TestData{
+		Input: "CLEAR",
+		Want:  gothreatmatrix.TLPClear,
+	}
+	testCases["lowercase"] = TestData{
+		Input: " amber ",
+		Want:  gothreatmatrix.TLPAmber,
+	}
+	testCases["legacy"] = TestData{
+		Input: "WHITE",
+		Want:  gothreatmatrix.WHITE,
+	}
+	testCases["unknown"] = TestData{
+		Input: "PURPLE",
+		Want:  gothreatmatrix.TLP(""),
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tlp := gothreatmatrix.ParseTLP(testCase.Input.(string))
+			testWantData(t, testCase.Want, tlp)
+			testWantData(t, testCase.Want != gothreatmatrix.TLP(""), tlp.Valid())
+		})
+	}
+}
+
+func TestTLPMarshalJSON(t *testing.T) {
+	params := struct {
+		Tlp   gothreatmatrix.TLP `json:"tlp"`
+		Empty gothreatmatrix.TLP `json:"empty"`
+	}{Tlp: gothreatmatrix.TLPRed}
+	gottenJson, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, `{"tlp":"RED","empty":"WHITE"}`, string(gottenJson))
+}
@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestNewClientFromEnv(t *testing.T) {
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewTLSServer(apiHandler)
+	defer testServer.Close()
+	apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testWantData(t, "token env-token", r.Header.Get("Authorization"))
+		testWantData(t, "soc-container/2.0", r.Header.Get("User-Agent"))
+		_, _ = w.Write([]byte(`[]`))
+	})
+	t.Setenv(gothreatmatrix.EnvURL, testServer.URL)
+	t.Setenv(gothreatmatrix.EnvAPIKey, "env-token")
+	t.Setenv(gothreatmatrix.EnvTimeout, "5")
+	t.Setenv(gothreatmatrix.EnvInsecureTLS, "true")
+	t.Setenv(gothreatmatrix.EnvUserAgent, "soc-container/2.0")
+	client, err := gothreatmatrix.NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.TagService.List(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// without THREATMATRIX_INSECURE_TLS the self-signed certificate of the test server is refused
+	t.Setenv(gothreatmatrix.EnvInsecureTLS, "")
+	client, err = gothreatmatrix.NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.TagService.List(context.Background()); err == nil {
+		t.Fatalf("Expected a certificate error")
+	}
+}
+
+func TestNewClientFromEnvErrors(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["missing"] = TestData{
+		Input: map[string]string{},
+		Want:  []string{"THREATMATRIX_URL is not set", "THREATMATRIX_API_KEY is not set"},
+	}
+	testCases["missingAPIKey"] = TestData{
+		Input: map[string]string{gothreatmatrix.EnvURL: "https://threatmatrix.example.com"},
+		Want:  []string{"THREATMATRIX_API_KEY is not set"},
+	}
+	testCases["invalid"] = TestData{
+		Input: map[string]string{
+			gothreatmatrix.EnvURL:         "https://threatmatrix.example.com",
+			gothreatmatrix.EnvAPIKey:      "env-token",
+			gothreatmatrix.EnvTimeout:     "soon",
+			gothreatmatrix.EnvInsecureTLS: "maybe",
+		},
+		Want: []string{"THREATMATRIX_TIMEOUT must be a duration", "THREATMATRIX_INSECURE_TLS must be a boolean"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for _, variable := range []string{gothreatmatrix.EnvURL, gothreatmatrix.EnvAPIKey, gothreatmatrix.EnvTimeout, gothreatmatrix.EnvInsecureTLS, gothreatmatrix.EnvUserAgent} {
+				t.Setenv(variable, testCase.Input.(map[string]string)[variable])
+			}
+			client, err := gothreatmatrix.NewClientFromEnv()
+			if client != nil || !errors.Is(err, gothreatmatrix.ErrValidation) {
+				t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+			}
+			for _, message := range testCase.Want.([]string) {
+				if !strings.Contains(err.Error(), message) {
+					t.Errorf("Expected %q in %q", message, err.Error())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestThreatMatrixErrorIs(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["notFound"] = TestData{
+		Input:      gothreatmatrix.ErrNotFound,
+		Data:       `{"detail":"Not found."}`,
+		StatusCode: http.StatusNotFound,
+	}
+	testCases["unauthorized"] = TestData{
+		Input:      gothreatmatrix.ErrUnauthorized,
+		Data:       `{"detail":"Invalid token."}`,
+		StatusCode: http.StatusUnauthorized,
+	}
+	testCases["forbidden"] = TestData{
+		Input:      gothreatmatrix.ErrForbidden,
+		Data:       `{"detail":"You do not have permission to perform this action."}`,
+		StatusCode: http.StatusForbidden,
+	}
+	testCases["validation"] = TestData{
+		Input:      gothreatmatrix.ErrValidation,
+		Data:       `{"label":["This field may not be blank."]}`,
+		StatusCode: http.StatusBadRequest,
+	}
+	testCases["server"] = TestData{
+		Input:      gothreatmatrix.ErrServer,
+		Data:       `<h1>Server Error (500)</h1>`,
+		StatusCode: http.StatusInternalServerError,
+	}
+	sentinels := []error{
+		gothreatmatrix.ErrNotFound,
+		gothreatmatrix.ErrUnauthorized,
+		gothreatmatrix.ErrForbidden,
+		gothreatmatrix.ErrValidation,
+		gothreatmatrix.ErrServer,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			ctx := context.Background()
+			apiHandler.HandleFunc(fmt.Sprintf(constants.SPECIFIC_TAG_URL, 1), func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Request-ID", "req-"+name)
+				w.WriteHeader(testCase.StatusCode)
+				_, _ = w.Write([]byte(testCase.Data))
+			})
+			_, err := client.TagService.Get(ctx, 1)
+			for _, sentinel := range sentinels {
+				testWantData(t, sentinel == testCase.Input, errors.Is(err, sentinel))
+			}
+			var threatMatrixError *gothreatmatrix.ThreatMatrixError
+			if !errors.As(err, &threatMatrixError) {
+				t.Fatalf("Expected a ThreatMatrixError got: %v", err)
+			}
+			testWantData(t, "req-"+name, threatMatrixError.RequestID)
+		})
+	}
+}
+
+func TestTagIDValidationError(t *testing.T) {
+	client, _, closeServer := setup()
+	defer closeServer()
+	_, err := client.TagService.Get(context.Background(), 0)
+	testWantData(t, true, errors.Is(err, gothreatmatrix.ErrValidation))
+}
@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/export"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestToECSDocument(t *testing.T) {
+	document := export.ToECSDocument(exportedJob())
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	decoded := struct {
+		Timestamp string `json:"@timestamp"`
+		Event     struct {
+			Kind     string `json:"kind"`
+			Dataset  string `json:"dataset"`
+			Outcome  string `json:"outcome"`
+			Duration int64  `json:"duration"`
+		} `json:"event"`
+		Threat struct {
+			Indicator struct {
+				Type    string            `json:"type"`
+				IP      string            `json:"ip"`
+				Marking map[string]string `json:"marking"`
+			} `json:"indicator"`
+		} `json:"threat"`
+		Tags         []string `json:"tags"`
+		ThreatMatrix struct {
+			Verdict string `json:"verdict"`
+		} `json:"threatmatrix"`
+	}{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, "2023-03-01T12:01:30Z", decoded.Timestamp)
+	testWantData(t, "enrichment", decoded.Event.Kind)
+	testWantData(t, "threatmatrix.job", decoded.Event.Dataset)
+	testWantData(t, "success", decoded.Event.Outcome)
+	testWantData(t, int64(90_000_000_000), decoded.Event.Duration)
+	testWantData(t, "ipv4-addr", decoded.Threat.Indicator.Type)
+	testWantData(t, "8.8.8.8", decoded.Threat.Indicator.IP)
+	testWantData(t, "AMBER", decoded.Threat.Indicator.Marking["tlp"])
+	testWantData(t, []string{"phishing"}, decoded.Tags)
+	testWantData(t, "malicious", decoded.ThreatMatrix.Verdict)
+
+	sample := exportedJob()
+	sample.IsSample = true
+	sample.FileName = "invoice.docm"
+	indicator := export.ToECSDocument(sample)["threat"].(map[string]interface{})["indicator"].(map[string]interface{})
+	testWantData(t, "file", indicator["type"])
+	testWantData(t, "invoice.docm", indicator["file"].(map[string]interface{})["name"])
+}
+
+func TestElasticsearchExporter(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["indexed"] = TestData{
+		Input:      `{"took":3,"errors":false,"items":[]}`,
+		StatusCode: http.StatusOK,
+	}
+	testCases["rejected"] = TestData{
+		Input:      `{"took":3,"errors":true,"items":[{"index":{"_id":"threatmatrix-job-42","status":400,"error":{"type":"mapper_parsing_exception"}}}]}`,
+		StatusCode: http.StatusOK,
+		Want:       export.ErrBulkFailed,
+	}
+	testCases["unauthorized"] = TestData{
+		Input:      `{"error":"security_exception"}`,
+		StatusCode: http.StatusUnauthorized,
+		Want:       export.ErrBulkFailed,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ids := []string{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				testWantData(t, "/_bulk", r.URL.Path)
+				testWantData(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+				testWantData(t, "ApiKey secret", r.Header.Get("Authorization"))
+				scanner := bufio.NewScanner(r.Body)
+				for line := 0; scanner.Scan(); line++ {
+					if line%2 == 1 {
+						continue
+					}
+					action := map[string]map[string]string{}
+					if err := json.Unmarshal(scanner.Bytes(), &action); err != nil {
+						t.Fatalf("Unexpected error: %v", err)
+					}
+					testWantData(t, "threatmatrix", action["index"]["_index"])
+					ids = append(ids, action["index"]["_id"])
+				}
+				w.WriteHeader(testCase.StatusCode)
+				_, _ = w.Write([]byte(testCase.Input.(string)))
+			}))
+			defer server.Close()
+			exporter := &export.ElasticsearchExporter{
+				URL:            server.URL,
+				Index:          "threatmatrix",
+				APIKey:         "secret",
+				IncludeReports: true,
+			}
+			err := exporter.Export(context.Background(), exportedJob())
+			if testCase.Want != nil {
+				if !errors.Is(err, testCase.Want.(error)) {
+					t.Fatalf("Expected %v got: %v", testCase.Want, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, []string{"threatmatrix-job-42", "threatmatrix-job-42-analyzer-AbuseIPDB", "threatmatrix-job-42-analyzer-Shodan"}, ids)
+		})
+	}
+}
+
+func TestElasticsearchExporterDataStream(t *testing.T) {
+	testCases := make(map[string]TestData)
+	// the job was exported before, its report is new
+	testCases["exportedAgain"] = TestData{
+		Input: `{"errors":true,"items":[{"create":{"_id":"threatmatrix-job-42","status":409,"error":{"type":"version_conflict_engine_exception"}}},` +
+			`{"create":{"_id":"threatmatrix-job-42-analyzer-AbuseIPDB","status":201}}]}`,
+	}
+	testCases["rejected"] = TestData{
+		Input: `{"errors":true,"items":[{"create":{"_id":"threatmatrix-job-42","status":400,"error":{"type":"mapper_parsing_exception"}}}]}`,
+		Want:  export.ErrBulkFailed,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				scanner := bufio.NewScanner(r.Body)
+				for line := 0; scanner.Scan(); line++ {
+					if line%2 == 1 {
+						continue
+					}
+					action := map[string]map[string]string{}
+					if err := json.Unmarshal(scanner.Bytes(), &action); err != nil {
+						t.Fatalf("Unexpected error: %v", err)
+					}
+					// data streams reject the index actions
+					if _, ok := action["create"]; !ok {
+						t.Errorf("Expected a create action got: %s", scanner.Bytes())
+					}
+					testWantData(t, "logs-threatmatrix-default", action["create"]["_index"])
+				}
+				_, _ = w.Write([]byte(testCase.Input.(string)))
+			}))
+			defer server.Close()
+			exporter := &export.ElasticsearchExporter{URL: server.URL, Index: "logs-threatmatrix-default", OpType: export.ElasticsearchOpCreate}
+			err := exporter.Export(context.Background(), exportedJob())
+			if testCase.Want != nil {
+				if !errors.Is(err, testCase.Want.(error)) {
+					t.Fatalf("Expected %v got: %v", testCase.Want, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestElasticsearchExporterBatches(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"errors":false,"items":[]}`))
+	}))
+	defer server.Close()
+	exporter := &export.ElasticsearchExporter{URL: server.URL, Index: "threatmatrix", BatchSize: 2}
+	jobs := []*gothreatmatrix.Job{exportedJob(), exportedJob(), exportedJob()}
+	if err := exporter.Export(context.Background(), jobs...); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 2, requests)
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/export"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestKafkaSink(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["json"] = TestData{
+		Input: export.JSONSerializer{},
+		Want:  "application/json",
+	}
+	testCases["schemaJson"] = TestData{
+		Input: export.SchemaJSONSerializer{},
+		Want:  "application/vnd.kafka.connect.json",
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			produced := []export.KafkaMessage{}
+			sink := &export.KafkaSink{
+				Producer: export.KafkaProducerFunc(func(ctx context.Context, messages ...export.KafkaMessage) error {
+					produced = append(produced, messages...)
+					return nil
+				}),
+				Topic:      "threatmatrix.jobs",
+				Serializer: testCase.Input.(export.KafkaSerializer),
+				MaxTLP:     gothreatmatrix.TLPAmber,
+			}
+			redJob := exportedJob()
+			redJob.Tlp = gothreatmatrix.TLPRed
+			runningJob := exportedJob()
+			runningJob.Status = gothreatmatrix.StatusRunning
+			unknownTlpJob := exportedJob()
+			unknownTlpJob.Tlp = ""
+			if err := sink.PublishBatch(context.Background(), exportedJob(), redJob, runningJob, unknownTlpJob); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, 1, len(produced))
+			message := produced[0]
+			testWantData(t, "threatmatrix.jobs", message.Topic)
+			testWantData(t, "42", string(message.Key))
+			testWantData(t, testCase.Want, message.Headers["content-type"])
+
+			decoded := map[string]interface{}{}
+			if err := json.Unmarshal(message.Value, &decoded); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if payload, ok := decoded["payload"].(map[string]interface{}); ok {
+				testWantData(t, "struct", decoded["schema"].(map[string]interface{})["type"])
+				testWantData(t, float64(1677672090000), payload["finished_analysis_time"])
+				decoded = payload
+			}
+			testWantData(t, float64(42), decoded["job_id"])
+			testWantData(t, "malicious", decoded["verdict"])
+			testWantData(t, "AMBER", decoded["tlp"])
+		})
+	}
+}
+
+func TestKafkaSinkErrors(t *testing.T) {
+	sink := &export.KafkaSink{Topic: "threatmatrix.jobs"}
+	if err := sink.Publish(context.Background(), exportedJob()); !errors.Is(err, export.ErrNoProducer) {
+		t.Fatalf("Expected %v got: %v", export.ErrNoProducer, err)
+	}
+	brokerDown := errors.New("broker down")
+	sink.Producer = export.KafkaProducerFunc(func(ctx context.Context, messages ...export.KafkaMessage) error {
+		return brokerDown
+	})
+	if err := sink.Publish(context.Background(), exportedJob()); !errors.Is(err, brokerDown) {
+		t.Fatalf("Expected %v got: %v", brokerDown, err)
+	}
+}
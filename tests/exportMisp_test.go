@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/export"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestToMISPEvent(t *testing.T) {
+	event, err := export.ToMISPEvent(exportedJob())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	details := event.Event
+	testWantData(t, "ThreatMatrix job 42: 8.8.8.8", details.Info)
+	testWantData(t, "2023-03-01", details.Date)
+	testWantData(t, "1", details.ThreatLevelID)
+	testWantData(t, "2", details.Analysis)
+	testWantData(t, []export.MISPTag{{Name: "tlp:amber"}, {Name: "phishing"}}, details.Tag)
+	testWantData(t, 2, len(details.Attribute))
+
+	observable := details.Attribute[0]
+	testWantData(t, "ip-dst", observable.Type)
+	testWantData(t, "Network activity", observable.Category)
+	testWantData(t, "8.8.8.8", observable.Value)
+	testWantData(t, true, observable.ToIDS)
+
+	abuseIPDB := details.Attribute[1]
+	testWantData(t, "text", abuseIPDB.Type)
+	testWantData(t, "AbuseIPDB: malicious", abuseIPDB.Value)
+	testWantData(t, false, abuseIPDB.ToIDS)
+
+	eventJson, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	decoded := map[string]map[string]interface{}{}
+	if err := json.Unmarshal(eventJson, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, details.UUID, decoded["Event"]["uuid"])
+}
+
+func TestToMISPEventAttributes(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["domain"] = TestData{
+		Input: gothreatmatrix.BaseJob{ObservableName: "evil.com"},
+		Want:  []string{"domain"},
+	}
+	testCases["sha1"] = TestData{
+		Input: gothreatmatrix.BaseJob{ObservableName: "e5fa44f2b31c1fb553b6021e7360d07d5d91ff5e", ObservableClassification: gothreatmatrix.ClassificationHash},
+		Want:  []string{"sha1"},
+	}
+	testCases["generic"] = TestData{
+		Input: gothreatmatrix.BaseJob{ObservableName: "ransomware", ObservableClassification: gothreatmatrix.ClassificationGeneric},
+		Want:  []string{"text"},
+	}
+	testCases["file"] = TestData{
+		Input: gothreatmatrix.BaseJob{IsSample: true, FileName: "invoice.exe", FileMimetype: "application/x-dosexec", Md5: "f1d2d2f924e986ac86fdf7b36c94bcdf"},
+		Want:  []string{"md5", "filename", "mime-type"},
+	}
+	testCases["empty"] = TestData{
+		Input: gothreatmatrix.BaseJob{},
+		Want:  export.ErrNotExportable,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			event, err := export.ToMISPEvent(&gothreatmatrix.Job{BaseJob: testCase.Input.(gothreatmatrix.BaseJob)})
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			gottenTypes := []string{}
+			for _, attribute := range event.Event.Attribute {
+				gottenTypes = append(gottenTypes, attribute.Type)
+			}
+			testWantData(t, testCase.Want, gottenTypes)
+			testWantData(t, "4", event.Event.ThreatLevelID)
+			testWantData(t, "1", event.Event.Analysis)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/export"
+)
+
+func TestToOpenCTI(t *testing.T) {
+	indicator, err := export.ToOpenCTI(exportedJob())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	bundle, err := export.ToSTIXBundle(exportedJob())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, bundle.Objects[1].(export.STIXIndicator).ID, indicator.StixID)
+	testWantData(t, "[ipv4-addr:value = '8.8.8.8']", indicator.Pattern)
+	testWantData(t, "stix", indicator.PatternType)
+	testWantData(t, "IPv4-Addr", indicator.MainObservableType)
+	testWantData(t, time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC), indicator.ValidFrom)
+	testWantData(t, 90, indicator.Score)
+	testWantData(t, true, indicator.Detection)
+	testWantData(t, []string{"phishing"}, indicator.Labels)
+
+	generic := exportedJob()
+	generic.ObservableName = "some text"
+	generic.ObservableClassification = "generic"
+	if _, err := export.ToOpenCTI(generic); !errors.Is(err, export.ErrNotExportable) {
+		t.Fatalf("Expected %v got: %v", export.ErrNotExportable, err)
+	}
+}
+
+func TestOpenCTIPusher(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["created"] = TestData{
+		Input:      `{"data":{"indicatorAdd":{"id":"8a2f4e9c","standard_id":"indicator--1"}}}`,
+		StatusCode: http.StatusOK,
+		Want:       "8a2f4e9c",
+	}
+	testCases["graphqlError"] = TestData{
+		Input:      `{"errors":[{"message":"You are not allowed to do this."}],"data":{"indicatorAdd":null}}`,
+		StatusCode: http.StatusOK,
+		Want:       export.ErrOpenCTIRequest,
+	}
+	testCases["unavailable"] = TestData{
+		Input:      `Bad Gateway`,
+		StatusCode: http.StatusBadGateway,
+		Want:       export.ErrOpenCTIRequest,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				testWantData(t, "/graphql", r.URL.Path)
+				testWantData(t, "Bearer opencti-token", r.Header.Get("Authorization"))
+				request := struct {
+					Variables struct {
+						Input export.OpenCTIIndicator `json:"input"`
+					} `json:"variables"`
+				}{}
+				if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				testWantData(t, "8.8.8.8", request.Variables.Input.Name)
+				w.WriteHeader(testCase.StatusCode)
+				_, _ = w.Write([]byte(testCase.Input.(string)))
+			}))
+			defer server.Close()
+			pusher := &export.OpenCTIPusher{URL: server.URL, Token: "opencti-token"}
+			indicatorId, err := pusher.Push(context.Background(), exportedJob())
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, indicatorId)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/export"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestSplunkHECForwarder(t *testing.T) {
+	amberJob := exportedJob()
+	redJob := exportedJob()
+	redJob.ID = 43
+	redJob.Tlp = gothreatmatrix.TLPRed
+	runningJob := exportedJob()
+	runningJob.ID = 44
+	runningJob.Status = gothreatmatrix.StatusRunning
+
+	ids := []int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testWantData(t, "/services/collector/event", r.URL.Path)
+		testWantData(t, "Splunk hec-token", r.Header.Get("Authorization"))
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			event := struct {
+				Time       float64               `json:"time"`
+				SourceType string                `json:"sourcetype"`
+				Index      string                `json:"index"`
+				Event      export.SplunkJobEvent `json:"event"`
+			}{}
+			if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, float64(time.Date(2023, 3, 1, 12, 1, 30, 0, time.UTC).Unix()), event.Time)
+			testWantData(t, "threatmatrix:job", event.SourceType)
+			testWantData(t, "threat_intel", event.Index)
+			testWantData(t, "malicious", event.Event.Verdict)
+			testWantData(t, 2, len(event.Event.Reports))
+			ids = append(ids, event.Event.ID)
+		}
+		_, _ = w.Write([]byte(`{"text":"Success","code":0}`))
+	}))
+	defer server.Close()
+	forwarder := &export.SplunkHECForwarder{
+		URL:            server.URL,
+		Token:          "hec-token",
+		Index:          "threat_intel",
+		IncludeReports: true,
+		MaxTLP:         gothreatmatrix.TLPAmber,
+	}
+	forwarded, err := forwarder.Forward(context.Background(), amberJob, redJob, runningJob)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 1, forwarded)
+	testWantData(t, []int{42}, ids)
+}
+
+func TestSplunkHECForwarderRetry(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["retried"] = TestData{
+		Input:      []int{http.StatusServiceUnavailable, http.StatusOK},
+		StatusCode: 2,
+	}
+	testCases["exhausted"] = TestData{
+		Input:      []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+		StatusCode: 3,
+		Want:       export.ErrHECFailed,
+	}
+	testCases["invalidToken"] = TestData{
+		Input:      []int{http.StatusForbidden},
+		StatusCode: 1,
+		Want:       export.ErrHECFailed,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			statuses := testCase.Input.([]int)
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(statuses[attempts])
+				attempts++
+				_, _ = w.Write([]byte(`{"text":"Server is busy","code":9}`))
+			}))
+			defer server.Close()
+			forwarder := &export.SplunkHECForwarder{URL: server.URL, Token: "hec-token", RetryDelay: time.Millisecond}
+			_, err := forwarder.Forward(context.Background(), exportedJob())
+			testWantData(t, testCase.StatusCode, attempts)
+			if testCase.Want != nil {
+				if !errors.Is(err, testCase.Want.(error)) {
+					t.Fatalf("Expected %v got: %v", testCase.Want, err)
+				}
+			} else if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/export"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// exportedJob returns a finished job analyzing an IP address that AbuseIPDB considers malicious.
+func exportedJob() *gothreatmatrix.Job {
+	received := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	finished := received.Add(90 * time.Second)
+	return &gothreatmatrix.Job{
+		BaseJob: gothreatmatrix.BaseJob{
+			ID:                       42,
+			Md5:                      "f1d2d2f924e986ac86fdf7b36c94bcdf",
+			ObservableName:           "8.8.8.8",
+			ObservableClassification: gothreatmatrix.ClassificationIP,
+			Status:                   gothreatmatrix.StatusReportedWithFails,
+			Tlp:                      gothreatmatrix.TLPAmber,
+			Tags:                     []gothreatmatrix.Tag{{Label: "phishing"}},
+			ReceivedRequestTime:      gothreatmatrix.NewTimestamp(received),
+			FinishedAnalysisTime:     gothreatmatrix.NewTimestamp(finished),
+		},
+		AnalyzerReports: []gothreatmatrix.Report{
+			{
+				Name:      "AbuseIPDB",
+				Status:    "SUCCESS",
+				Report:    map[string]interface{}{"data": map[string]interface{}{"abuseConfidenceScore": 90}},
+				StartTime: gothreatmatrix.Timestamp{Time: received},
+				EndTime:   gothreatmatrix.Timestamp{Time: finished},
+			},
+			{Name: "Shodan", Status: "FAILED"},
+		},
+	}
+}
+
+func TestToSTIXBundle(t *testing.T) {
+	bundle, err := export.ToSTIXBundle(exportedJob())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, "bundle", bundle.Type)
+	testWantData(t, 5, len(bundle.Objects))
+
+	observable := bundle.Objects[0].(export.STIXCyberObservable)
+	testWantData(t, "ipv4-addr", observable.Type)
+	testWantData(t, "8.8.8.8", observable.Value)
+	if !regexp.MustCompile(`^ipv4-addr--[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(observable.ID) {
+		t.Fatalf("Invalid cyber observable identifier: %s", observable.ID)
+	}
+
+	indicator := bundle.Objects[1].(export.STIXIndicator)
+	testWantData(t, "[ipv4-addr:value = '8.8.8.8']", indicator.Pattern)
+	testWantData(t, []string{"malicious-activity"}, indicator.IndicatorTypes)
+	testWantData(t, []string{"phishing"}, indicator.Labels)
+	testWantData(t, []string{"marking-definition--f88d31f6-486f-44da-b317-01333bde0b82"}, indicator.ObjectMarkingRefs)
+
+	observedData := bundle.Objects[2].(export.STIXObservedData)
+	testWantData(t, []string{observable.ID}, observedData.ObjectRefs)
+
+	abuseIPDB := bundle.Objects[3].(export.STIXMalwareAnalysis)
+	testWantData(t, "AbuseIPDB", abuseIPDB.Product)
+	testWantData(t, "malicious", abuseIPDB.Result)
+	shodan := bundle.Objects[4].(export.STIXMalwareAnalysis)
+	testWantData(t, "unknown", shodan.Result)
+
+	bundleJson, err := json.Marshal(indicator)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !regexp.MustCompile(`"valid_from":"2023-03-01T12:00:00.000Z"`).Match(bundleJson) {
+		t.Fatalf("Invalid STIX timestamp in %s", bundleJson)
+	}
+
+	// exporting the same job again gives the same objects
+	sameBundle, _ := export.ToSTIXBundle(exportedJob())
+	testWantData(t, bundle.ID, sameBundle.ID)
+	testWantData(t, indicator.ID, sameBundle.Objects[1].(export.STIXIndicator).ID)
+}
+
+func TestToSTIXBundleObservables(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["domain"] = TestData{
+		Input: gothreatmatrix.BaseJob{ObservableName: "evil.com"},
+		Want:  "[domain-name:value = 'evil.com']",
+	}
+	testCases["ipv6"] = TestData{
+		Input: gothreatmatrix.BaseJob{ObservableName: "2001:db8::1", ObservableClassification: gothreatmatrix.ClassificationIP},
+		Want:  "[ipv6-addr:value = '2001:db8::1']",
+	}
+	testCases["url"] = TestData{
+		Input: gothreatmatrix.BaseJob{ObservableName: "http://evil.com/it's", ObservableClassification: gothreatmatrix.ClassificationURL},
+		Want:  `[url:value = 'http://evil.com/it\'s']`,
+	}
+	testCases["sha256"] = TestData{
+		Input: gothreatmatrix.BaseJob{ObservableName: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", ObservableClassification: gothreatmatrix.ClassificationHash},
+		Want:  "[file:hashes.'SHA-256' = '9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08']",
+	}
+	testCases["file"] = TestData{
+		Input: gothreatmatrix.BaseJob{IsSample: true, FileName: "invoice.exe", Md5: "f1d2d2f924e986ac86fdf7b36c94bcdf"},
+		Want:  "[file:hashes.'MD5' = 'f1d2d2f924e986ac86fdf7b36c94bcdf']",
+	}
+	testCases["generic"] = TestData{
+		Input: gothreatmatrix.BaseJob{ObservableName: "ransomware", ObservableClassification: gothreatmatrix.ClassificationGeneric},
+		Want:  export.ErrNotExportable,
+	}
+	testCases["empty"] = TestData{
+		Input: gothreatmatrix.BaseJob{},
+		Want:  export.ErrNotExportable,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			bundle, err := export.ToSTIXBundle(&gothreatmatrix.Job{BaseJob: testCase.Input.(gothreatmatrix.BaseJob)})
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, bundle.Objects[1].(export.STIXIndicator).Pattern)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/export"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestToCEF(t *testing.T) {
+	job := exportedJob()
+	job.Tags = []gothreatmatrix.Tag{{Label: "phishing"}, {Label: "a=b"}}
+	want := "CEF:0|ThreatMatrix|go-threatmatrix|1.0|malicious|8.8.8.8 is malicious|10|" +
+		`rt=1677672090000 externalId=42 outcome=reported_with_fails cat=ip dst=8.8.8.8 ` +
+		`cs1Label=verdict cs1=malicious cs2Label=tlp cs2=AMBER cs3Label=tags cs3=phishing,a\=b`
+	testWantData(t, want, export.ToCEF(job))
+
+	sample := exportedJob()
+	sample.IsSample = true
+	sample.ObservableName = ""
+	sample.FileName = "in|voice.docm"
+	sample.AnalyzerReports = nil
+	cef := export.ToCEF(sample)
+	if !strings.HasPrefix(cef, `CEF:0|ThreatMatrix|go-threatmatrix|1.0|unknown|in\|voice.docm is unknown|0|`) {
+		t.Fatalf("Unexpected CEF header: %s", cef)
+	}
+	if !strings.Contains(cef, "cat=file fname=in|voice.docm fileHash=f1d2d2f924e986ac86fdf7b36c94bcdf") {
+		t.Fatalf("Unexpected CEF extension: %s", cef)
+	}
+}
+
+func TestToLEEF(t *testing.T) {
+	job := exportedJob()
+	job.User = gothreatmatrix.UserDetails{Username: "analyst"}
+	want := "LEEF:2.0|ThreatMatrix|go-threatmatrix|1.0|malicious|x09|sev=10\tdevTime=1677672090000\tdevTimeFormat=epoch" +
+		"\texternalId=42\toutcome=reported_with_fails\tcat=ip\tdst=8.8.8.8" +
+		"\tcs1Label=verdict\tcs1=malicious\tcs2Label=tlp\tcs2=AMBER\tcs3Label=tags\tcs3=phishing\tusrName=analyst"
+	testWantData(t, want, export.ToLEEF(job))
+}
+
+func TestSyslogExporter(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["cef"] = TestData{
+		Input: export.SyslogExporter{Facility: 16, Hostname: "collector"},
+		Want:  `^<132>1 \S+ collector go-threatmatrix - CEF - CEF:0\|.*\n$`,
+	}
+	testCases["leefOctetCounting"] = TestData{
+		Input: export.SyslogExporter{Facility: 16, Hostname: "collector", AppName: "intel", Format: export.FormatLEEF, OctetCounting: true},
+		Want:  `^\d+ <132>1 \S+ collector intel - LEEF - LEEF:2\.0\|.*cs3=phishing$`,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			exporter := testCase.Input.(export.SyslogExporter)
+			exporter.Writer = output
+			if err := exporter.Export(exportedJob()); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !regexp.MustCompile(testCase.Want.(string)).Match(output.Bytes()) {
+				t.Fatalf("Unexpected syslog message: %q", output.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/export"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// exportedJobList returns the jobs exported by the CSV and JSON Lines tests.
+func exportedJobList() []gothreatmatrix.JobList {
+	received := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	return []gothreatmatrix.JobList{
+		{BaseJob: gothreatmatrix.BaseJob{
+			ID:                       42,
+			User:                     gothreatmatrix.UserDetails{Username: "hussain"},
+			Tags:                     []gothreatmatrix.Tag{{ID: 1, Label: "phishing"}, {ID: 2, Label: "apt, 28"}},
+			ObservableName:           "8.8.8.8",
+			ObservableClassification: "ip",
+			Status:                   gothreatmatrix.StatusReportedWithoutFails,
+			ReceivedRequestTime:      gothreatmatrix.NewTimestamp(received),
+			ProcessTime:              1.5,
+			Tlp:                      gothreatmatrix.TLPAmber,
+		}},
+		{BaseJob: gothreatmatrix.BaseJob{
+			ID:       43,
+			IsSample: true,
+			FileName: "invoice.docm",
+			Md5:      "f1d2d2f924e986ac86fdf7b36c94bcdf",
+			Status:   gothreatmatrix.StatusRunning,
+		}},
+	}
+}
+
+func TestJobsToCSV(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["default"] = TestData{
+		Input: []string{},
+		Want: "id,status,observable_name,observable_classification,file_name,md5,tlp,user,tags,received_request_time,finished_analysis_time,process_time\n" +
+			`42,reported_without_fails,8.8.8.8,ip,,,AMBER,hussain,"phishing;apt, 28",2023-03-01T12:00:00Z,,1.5` + "\n" +
+			"43,running,,,invoice.docm,f1d2d2f924e986ac86fdf7b36c94bcdf,WHITE,,,,,0\n",
+	}
+	testCases["selected"] = TestData{
+		Input: []string{"is_sample", "id"},
+		Want:  "is_sample,id\nfalse,42\ntrue,43\n",
+	}
+	testCases["unknown"] = TestData{
+		Input: []string{"id", "verdict"},
+		Want:  export.ErrUnknownColumn,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			err := export.JobsToCSV(output, exportedJobList(), testCase.Input.([]string)...)
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, output.String())
+		})
+	}
+}
+
+func TestJobsToCSVNeutralizesFormulas(t *testing.T) {
+	jobs := []gothreatmatrix.JobList{
+		{BaseJob: gothreatmatrix.BaseJob{ID: 1, FileName: `=HYPERLINK("http://evil.local","invoice")`}},
+		{BaseJob: gothreatmatrix.BaseJob{ID: 2, FileName: "@SUM(A1)", Tags: []gothreatmatrix.Tag{{Label: "+cmd"}}}},
+		{BaseJob: gothreatmatrix.BaseJob{ID: 3, FileName: "-2+3", ObservableName: "\tevil.local"}},
+	}
+	output := &bytes.Buffer{}
+	if err := export.JobsToCSV(output, jobs, "id", "file_name", "observable_name", "tags"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, "id,file_name,observable_name,tags\n"+
+		`1,"'=HYPERLINK(""http://evil.local"",""invoice"")",,`+"\n"+
+		"2,'@SUM(A1),,'+cmd\n"+
+		"3,'-2+3,'\tevil.local,\n", output.String())
+}
+
+func TestJobsToJSONL(t *testing.T) {
+	output := &bytes.Buffer{}
+	if err := export.JobsToJSONL(output, exportedJobList(), "status", "id", "tags"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := `{"status":"reported_without_fails","id":42,"tags":[{"id":1,"label":"phishing","color":""},{"id":2,"label":"apt, 28","color":""}]}` + "\n" +
+		`{"status":"running","id":43,"tags":null}` + "\n"
+	testWantData(t, want, output.String())
+
+	output.Reset()
+	if err := export.JobsToJSONL(output, exportedJobList()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 2, bytes.Count(output.Bytes(), []byte("\n")))
+}
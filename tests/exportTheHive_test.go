@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/export"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestToTheHiveAlert(t *testing.T) {
+	alert := export.ToTheHiveAlert(exportedJob())
+	testWantData(t, "job-42", alert.SourceRef)
+	testWantData(t, "8.8.8.8 is malicious", alert.Title)
+	testWantData(t, 3, alert.Severity)
+	testWantData(t, 2, alert.TLP)
+	testWantData(t, []string{"phishing"}, alert.Tags)
+	if !strings.Contains(alert.Description, "| AbuseIPDB | SUCCESS | malicious |") {
+		t.Fatalf("Unexpected description: %s", alert.Description)
+	}
+	testWantData(t, []export.TheHiveObservable{{
+		DataType: "ip",
+		Data:     "8.8.8.8",
+		Message:  "ThreatMatrix job 42",
+		TLP:      2,
+		IOC:      true,
+		Tags:     []string{"threatmatrix:verdict=malicious", "phishing"},
+	}}, alert.Observables)
+
+	sample := exportedJob()
+	sample.IsSample = true
+	sample.ObservableName = ""
+	sample.FileName = "invoice.docm"
+	sample.AnalyzerReports = nil
+	observables := export.ToTheHiveObservables(sample)
+	testWantData(t, 2, len(observables))
+	testWantData(t, "filename", observables[0].DataType)
+	testWantData(t, "hash", observables[1].DataType)
+	testWantData(t, false, observables[1].IOC)
+
+	// a job whose TLP is unknown is shared as RED
+	unknownTlp := exportedJob()
+	unknownTlp.Tlp = ""
+	alert = export.ToTheHiveAlert(unknownTlp)
+	testWantData(t, 3, alert.TLP)
+	testWantData(t, 3, alert.PAP)
+	testWantData(t, 3, alert.Observables[0].TLP)
+}
+
+func TestTheHiveClient(t *testing.T) {
+	routes := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testWantData(t, "Bearer thehive-key", r.Header.Get("Authorization"))
+		testWantData(t, "soc", r.Header.Get("X-Organisation"))
+		routes = append(routes, r.URL.Path)
+		if r.URL.Path == "/api/v1/case/~4104/observable" {
+			observable := export.TheHiveObservable{}
+			if err := json.NewDecoder(r.Body).Decode(&observable); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if observable.DataType == "hash" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"type":"BadRequest","message":"observable already exists"}`))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"_id":"~8192","_type":"Alert"}`))
+	}))
+	defer server.Close()
+	theHive := &export.TheHiveClient{URL: server.URL, APIKey: "thehive-key", Organisation: "soc"}
+
+	alertId, created, err := theHive.AlertIfMalicious(context.Background(), exportedJob())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, true, created)
+	testWantData(t, "~8192", alertId)
+
+	benign := exportedJob()
+	benign.AnalyzerReports = []gothreatmatrix.Report{}
+	if _, created, err = theHive.AlertIfMalicious(context.Background(), benign); created || err != nil {
+		t.Fatalf("Expected no alert, got %v, %v", created, err)
+	}
+	testWantData(t, []string{"/api/v1/alert"}, routes)
+
+	sample := exportedJob()
+	sample.IsSample = true
+	sample.ObservableName = ""
+	sample.FileName = "invoice.docm"
+	err = theHive.AddCaseObservables(context.Background(), "~4104", sample)
+	if !errors.Is(err, export.ErrTheHiveRequest) {
+		t.Fatalf("Expected %v got: %v", export.ErrTheHiveRequest, err)
+	}
+	testWantData(t, 3, len(routes))
+}
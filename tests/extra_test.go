@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestJobExtra(t *testing.T) {
+	data := `{"id":1,"observable_name":"8.8.8.8","Status":"running","data_model":{"evaluation":"malicious"},
+		"analyzer_reports":[{"name":"Classic_DNS","status":"SUCCESS","parameters":{"query_type":"A"}}]}`
+	job := gothreatmatrix.Job{}
+	if err := json.Unmarshal([]byte(data), &job); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 1, job.ID)
+	testWantData(t, gothreatmatrix.StatusRunning, job.Status)
+	// fields matched case insensitively, such as Status, aren't extra
+	testWantData(t, map[string]json.RawMessage{"data_model": json.RawMessage(`{"evaluation":"malicious"}`)}, job.Extra)
+	testWantData(t, map[string]json.RawMessage{"parameters": json.RawMessage(`{"query_type":"A"}`)}, job.AnalyzerReports[0].Extra)
+	encoded, err := json.Marshal(job)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	decoded := gothreatmatrix.Job{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded.Extra != nil {
+		t.Fatalf("Expected the fields modeled by the SDK only to be encoded got %v", decoded.Extra)
+	}
+}
+
+func TestConfigExtra(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["analyzer"] = TestData{
+		Input: &gothreatmatrix.AnalyzerConfig{},
+		Data:  `{"name":"Classic_DNS","python_module":"classic_dns.ClassicDNSResolver","type":"observable","health_check_status":true}`,
+		Want:  map[string]json.RawMessage{"health_check_status": json.RawMessage(`true`)},
+	}
+	testCases["connector"] = TestData{
+		Input: &gothreatmatrix.ConnectorConfig{},
+		Data:  `{"name":"MISP","maximum_tlp":"AMBER","run_on_failure":false}`,
+		Want:  map[string]json.RawMessage(nil),
+	}
+	testCases["playbook"] = TestData{
+		Input: &gothreatmatrix.PlaybookConfig{},
+		Data:  `{"name":"Dns","analyzers":["Classic_DNS"],"starting":true,"scan_mode":2}`,
+		Want:  map[string]json.RawMessage{"starting": json.RawMessage(`true`), "scan_mode": json.RawMessage(`2`)},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(testCase.Data), testCase.Input); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			var extra map[string]json.RawMessage
+			switch config := testCase.Input.(type) {
+			case *gothreatmatrix.AnalyzerConfig:
+				testWantData(t, "classic_dns.ClassicDNSResolver", config.PythonModule)
+				extra = config.Extra
+			case *gothreatmatrix.ConnectorConfig:
+				testWantData(t, gothreatmatrix.TLPAmber, config.MaximumTlp)
+				extra = config.Extra
+			case *gothreatmatrix.PlaybookConfig:
+				testWantData(t, []string{"Classic_DNS"}, config.Analyzers)
+				extra = config.Extra
+			}
+			testWantData(t, testCase.Want, extra)
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// endpointServer is a ThreatMatrix node answering 503 while it's down.
+type endpointServer struct {
+	mutex sync.Mutex
+	down  bool
+	hits  int
+}
+
+func (server *endpointServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if server.down {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if r.URL.Path == constants.BASE_TAG_URL {
+		server.hits++
+	}
+	if r.Method == http.MethodPost {
+		_, _ = w.Write([]byte(`{"id":1,"label":"apt","color":"#ff0000"}`))
+		return
+	}
+	_, _ = w.Write([]byte(`[]`))
+}
+
+func (server *endpointServer) setDown(down bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.down = down
+}
+
+func (server *endpointServer) hitCount() int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.hits
+}
+
+func TestWithEndpointsPrimaryBackup(t *testing.T) {
+	primary, backup := &endpointServer{down: true}, &endpointServer{}
+	primaryServer, backupServer := httptest.NewServer(primary), httptest.NewServer(backup)
+	defer primaryServer.Close()
+	defer backupServer.Close()
+	client := gothreatmatrix.NewClient(
+		gothreatmatrix.WithEndpoints([]string{primaryServer.URL, backupServer.URL}),
+		gothreatmatrix.WithHealthProbe("/", 10*time.Millisecond),
+	)
+	defer client.Close()
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := client.TagService.List(ctx); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	testWantData(t, 0, primary.hitCount())
+	testWantData(t, 3, backup.hitCount())
+
+	// once the probe finds the primary up again it gets the requests back
+	primary.setDown(false)
+	time.Sleep(50 * time.Millisecond)
+	if _, err := client.TagService.List(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 1, primary.hitCount())
+	testWantData(t, 3, backup.hitCount())
+}
+
+func TestWithEndpointsRoundRobin(t *testing.T) {
+	first, second := &endpointServer{}, &endpointServer{}
+	firstServer, secondServer := httptest.NewServer(first), httptest.NewServer(second)
+	defer firstServer.Close()
+	defer secondServer.Close()
+	client := gothreatmatrix.NewClient(
+		gothreatmatrix.WithEndpoints([]string{firstServer.URL, secondServer.URL + "/"}),
+		gothreatmatrix.WithFailoverPolicy(gothreatmatrix.FailoverRoundRobin),
+	)
+	for i := 0; i < 4; i++ {
+		if _, err := client.TagService.List(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	testWantData(t, 2, first.hitCount())
+	testWantData(t, 2, second.hitCount())
+}
+
+func TestWithEndpointsPaths(t *testing.T) {
+	primary, backup := &endpointServer{down: true}, &endpointServer{}
+	primaryServer := httptest.NewServer(http.StripPrefix("/threatmatrix", primary))
+	backupServer := httptest.NewServer(http.StripPrefix("/threatmatrix", backup))
+	defer primaryServer.Close()
+	defer backupServer.Close()
+	other := &endpointServer{}
+	otherServer := httptest.NewServer(other)
+	defer otherServer.Close()
+	// the port of the primary endpoint is a prefix of the port of the other server
+	otherURL, _ := url.Parse(otherServer.URL)
+	unrelatedEndpoint := "http://" + otherURL.Host[:len(otherURL.Host)-1]
+	testCases := make(map[string]TestData)
+	testCases["path"] = TestData{
+		Input: []string{primaryServer.URL + "/threatmatrix", backupServer.URL + "/threatmatrix/"},
+		Want:  backup,
+	}
+	// requests sent elsewhere, here by a middleware, are left alone
+	testCases["otherPort"] = TestData{
+		Input: []string{unrelatedEndpoint, backupServer.URL + "/threatmatrix"},
+		Want:  other,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := gothreatmatrix.NewClient(
+				gothreatmatrix.WithEndpoints(testCase.Input.([]string)),
+				gothreatmatrix.WithFailoverPolicy(gothreatmatrix.FailoverRoundRobin),
+				gothreatmatrix.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+					return gothreatmatrix.RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+						if name == "otherPort" {
+							request.URL.Host = otherURL.Host
+						}
+						return next.RoundTrip(request)
+					})
+				}),
+			)
+			defer client.Close()
+			want := testCase.Want.(*endpointServer)
+			hits := want.hitCount()
+			for i := 0; i < 2; i++ {
+				if _, err := client.TagService.List(context.Background()); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+			testWantData(t, hits+2, want.hitCount())
+		})
+	}
+}
+
+func TestWithURLAfterEndpoints(t *testing.T) {
+	node := &endpointServer{}
+	nodeServer := httptest.NewServer(node)
+	defer nodeServer.Close()
+	client := gothreatmatrix.NewClient(
+		gothreatmatrix.WithEndpoints([]string{"http://primary.invalid", "http://backup.invalid"}),
+		gothreatmatrix.WithURL(nodeServer.URL),
+	)
+	defer client.Close()
+	// the endpoints were replaced, the requests are sent to the URL only
+	if _, err := client.TagService.List(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 1, node.hitCount())
+}
+
+func TestWithEndpointsNonIdempotent(t *testing.T) {
+	backup := &endpointServer{}
+	backupServer := httptest.NewServer(backup)
+	defer backupServer.Close()
+	tagParams := &gothreatmatrix.TagParams{Label: "apt", Color: "#ff0000"}
+
+	// a node that answered may have created the tag, so the request doesn't fail over
+	primary := &endpointServer{down: true}
+	primaryServer := httptest.NewServer(primary)
+	defer primaryServer.Close()
+	client := gothreatmatrix.NewClient(gothreatmatrix.WithEndpoints([]string{primaryServer.URL, backupServer.URL}))
+	_, err := client.TagService.Create(context.Background(), tagParams)
+	if !errors.Is(err, gothreatmatrix.ErrServer) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrServer, err)
+	}
+	testWantData(t, 0, backup.hitCount())
+
+	// a node that couldn't be reached never saw the request
+	unreachableServer := httptest.NewServer(http.NotFoundHandler())
+	unreachableServer.Close()
+	client = gothreatmatrix.NewClient(gothreatmatrix.WithEndpoints([]string{unreachableServer.URL, backupServer.URL}))
+	tag, err := client.TagService.Create(context.Background(), tagParams)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, "apt", tag.Label)
+	testWantData(t, 1, backup.hitCount())
+}
+
+func TestWithEndpointsClose(t *testing.T) {
+	probesMutex := sync.Mutex{}
+	probes := 0
+	primaryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			probesMutex.Lock()
+			probes++
+			probesMutex.Unlock()
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primaryServer.Close()
+	backupServer := httptest.NewServer(&endpointServer{})
+	defer backupServer.Close()
+	client := gothreatmatrix.NewClient(
+		gothreatmatrix.WithEndpoints([]string{primaryServer.URL, backupServer.URL}),
+		gothreatmatrix.WithHealthProbe("/health", 5*time.Millisecond),
+	)
+	if _, err := client.TagService.List(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	// the probes of the primary, which stays down, stop once the client is closed
+	client.Close()
+	// a probe canceled by Close may still reach the server
+	time.Sleep(10 * time.Millisecond)
+	probesMutex.Lock()
+	probed := probes
+	probesMutex.Unlock()
+	if probed == 0 {
+		t.Fatalf("the primary should have been probed while it was down")
+	}
+	time.Sleep(30 * time.Millisecond)
+	probesMutex.Lock()
+	defer probesMutex.Unlock()
+	testWantData(t, probed, probes)
+}
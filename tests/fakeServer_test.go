@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrixtest"
+)
+
+func TestFakeServerAnalyzeObservable(t *testing.T) {
+	server := gothreatmatrixtest.NewServer()
+	defer server.Close()
+	client := server.Client(gothreatmatrix.WithPollInterval(time.Millisecond))
+	ctx := context.Background()
+	job, err := client.AnalyzeService.AnalyzeObservableAndWait(ctx, gothreatmatrix.ObservableAnalysisRequest{
+		Value:     "8.8.8.8",
+		Analyzers: []string{"Classic_DNS"},
+		Tags:      []string{"phishing"},
+		TLP:       gothreatmatrix.TLPAmber,
+	}, time.Second)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, gothreatmatrix.StatusReportedWithoutFails, job.Status)
+	testWantData(t, "ip", job.ObservableClassification)
+	testWantData(t, gothreatmatrix.TLPAmber, job.Tlp)
+	testWantData(t, []string{"Classic_DNS"}, job.AnalyzersToExecute)
+	testWantData(t, "phishing", job.Tags[0].Label)
+
+	jobList, err := client.JobService.List(ctx, &gothreatmatrix.JobListParams{Status: gothreatmatrix.StatusReportedWithoutFails})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 1, jobList.Count)
+	testWantData(t, job.ID, jobList.Results[0].ID)
+}
+
+func TestFakeServerAnalyzeFile(t *testing.T) {
+	server := gothreatmatrixtest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+	response, err := client.AnalyzeService.AnalyzeFile(ctx, gothreatmatrix.FileAnalysisRequest{File: strings.NewReader("MZ sample"), FileName: "sample.exe"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sample := bytes.Buffer{}
+	if _, err := client.JobService.DownloadSampleTo(ctx, uint64(response.JobID), &sample); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, "MZ sample", sample.String())
+	job, _ := server.Job(response.JobID)
+	testWantData(t, "sample.exe", job.FileName)
+	testWantData(t, true, job.IsSample)
+}
+
+func TestFakeServerScripted(t *testing.T) {
+	server := gothreatmatrixtest.NewServer()
+	defer server.Close()
+	server.OnAnalyze(func(job *gothreatmatrix.Job) {
+		job.Status = gothreatmatrix.StatusRunning
+		job.FinishedAnalysisTime = nil
+	})
+	client := server.Client()
+	ctx := context.Background()
+	response, err := client.AnalyzeService.AnalyzeObservable(ctx, gothreatmatrix.ObservableAnalysisRequest{Value: "evil.com"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	killed, err := client.JobService.Kill(ctx, uint64(response.JobID))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, true, killed)
+	job, _ := server.Job(response.JobID)
+	testWantData(t, gothreatmatrix.StatusKilled, job.Status)
+	if _, err := client.JobService.Kill(ctx, uint64(response.JobID)); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+
+	server.Handle("GET", "/api/jobs/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	if _, err := client.JobService.Get(ctx, 1); !errors.Is(err, gothreatmatrix.ErrServer) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrServer, err)
+	}
+	testWantData(t, gothreatmatrixtest.Request{Method: "GET", Path: "/api/jobs/1"}, server.Requests()[len(server.Requests())-1])
+}
+
+func TestFakeServerTags(t *testing.T) {
+	server := gothreatmatrixtest.NewServer()
+	defer server.Close()
+	server.AddTag(gothreatmatrix.Tag{Label: "apt", Color: "#ff0000"})
+	client := server.Client()
+	ctx := context.Background()
+	createdTag, err := client.TagService.Create(ctx, &gothreatmatrix.TagParams{Label: "phishing", Color: "#00ff00"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, uint64(2), createdTag.ID)
+	updatedTag, err := client.TagService.Update(ctx, createdTag.ID, &gothreatmatrix.TagParams{Label: "spam", Color: "#0000ff"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, &gothreatmatrix.Tag{ID: 2, Label: "spam", Color: "#0000ff"}, updatedTag)
+	deleted, err := client.TagService.Delete(ctx, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, true, deleted)
+	tags, err := client.TagService.List(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, []gothreatmatrix.Tag{*updatedTag}, *tags)
+	if _, err := client.TagService.Get(ctx, 1); !errors.Is(err, gothreatmatrix.ErrNotFound) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrNotFound, err)
+	}
+}
+
+func TestFakeServerUnauthorized(t *testing.T) {
+	server := gothreatmatrixtest.NewServer()
+	defer server.Close()
+	client := gothreatmatrix.NewClient(gothreatmatrix.WithURL(server.URL), gothreatmatrix.WithToken("wrong-token"))
+	if _, err := client.JobService.List(context.Background(), nil); !errors.Is(err, gothreatmatrix.ErrUnauthorized) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrUnauthorized, err)
+	}
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestHashFile(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["empty"] = TestData{
+		Input: "",
+		Want: gothreatmatrix.FileHashes{
+			MD5:    "d41d8cd98f00b204e9800998ecf8427e",
+			SHA1:   "da39a3ee5e6b4b0d3255bfef95601890afd80709",
+			SHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+	}
+	testCases["abc"] = TestData{
+		Input: "abc",
+		Want: gothreatmatrix.FileHashes{
+			MD5:    "900150983cd24fb0d6963f7d28e17f72",
+			SHA1:   "a9993e364706816aba3e25717850c26c9cd0d89d",
+			SHA256: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "sample")
+			if err := os.WriteFile(path, []byte(testCase.Input.(string)), 0o600); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			hashes, err := gothreatmatrix.HashFile(path)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, *hashes)
+		})
+	}
+	if _, err := gothreatmatrix.HashFile(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatalf("Expected an error for a missing file")
+	}
+}
+
+func TestAnalyzeFileIfNew(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["alreadyAnalyzed"] = TestData{
+		Data: `{"status":"reported_without_fails","job_id":7,"analyzers_to_execute":["File_Info"]}`,
+		Want: &gothreatmatrix.AnalysisResponse{JobID: 7, Status: "reported_without_fails", AnalyzersRunning: []string{"File_Info"}},
+	}
+	testCases["new"] = TestData{
+		Data: `{"status":"not_available"}`,
+		Want: &gothreatmatrix.AnalysisResponse{JobID: 8, Status: "accepted"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.HandleFunc(constants.ASK_ANALYSIS_AVAILABILITY_URL, func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "POST")
+				params := gothreatmatrix.AnalysisAvailabilityParams{}
+				if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				testWantData(t, gothreatmatrix.AnalysisAvailabilityParams{Md5: "900150983cd24fb0d6963f7d28e17f72", Analyzers: []string{"File_Info"}}, params)
+				_, _ = w.Write([]byte(testCase.Data))
+			})
+			uploads := 0
+			apiHandler.HandleFunc(constants.ANALYZE_FILE_URL, func(w http.ResponseWriter, r *http.Request) {
+				uploads++
+				file, _, err := r.FormFile("file")
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				content, _ := io.ReadAll(file)
+				// the file was rewound after being hashed
+				testWantData(t, "abc", string(content))
+				_, _ = w.Write([]byte(`{"job_id":8,"status":"accepted"}`))
+			})
+			analysisRequest := gothreatmatrix.FileAnalysisRequest{File: bytes.NewReader([]byte("abc")), FileName: "sample", Analyzers: []string{"File_Info"}}
+			analysisResponse, existing, err := client.AnalyzeService.AnalyzeFileIfNew(context.Background(), analysisRequest)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, analysisResponse)
+			testWantData(t, analysisResponse.JobID == 7, existing)
+			testWantData(t, !existing, uploads == 1)
+		})
+	}
+}
+
+func TestAnalyzeFileIfNewNotSeekable(t *testing.T) {
+	client, _, closeServer := setup()
+	defer closeServer()
+	analysisRequest := gothreatmatrix.FileAnalysisRequest{File: io.MultiReader(strings.NewReader("abc")), FileName: "sample"}
+	if _, _, err := client.AnalyzeService.AnalyzeFileIfNew(context.Background(), analysisRequest); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+}
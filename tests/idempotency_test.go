@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestIdempotencyKeyRetries(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["generatedKey"] = TestData{
+		Input: []gothreatmatrix.RequestOption{},
+		Want:  "",
+	}
+	testCases["givenKey"] = TestData{
+		Input: []gothreatmatrix.RequestOption{gothreatmatrix.WithIdempotencyKey("incident-42-8.8.8.8")},
+		Want:  "incident-42-8.8.8.8",
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			keys := []string{}
+			apiHandler := http.NewServeMux()
+			testServer := httptest.NewServer(apiHandler)
+			defer testServer.Close()
+			apiHandler.HandleFunc(constants.ANALYZE_OBSERVABLE_URL, func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "POST")
+				keys = append(keys, r.Header.Get(gothreatmatrix.IdempotencyKeyHeader))
+				if len(keys) < 3 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				_, _ = w.Write([]byte(`{"job_id":1,"status":"accepted"}`))
+			})
+			client := gothreatmatrix.NewClient(gothreatmatrix.WithURL(testServer.URL), gothreatmatrix.WithRetry(3, time.Millisecond), gothreatmatrix.WithIdempotentSubmissions())
+			analysisRequest := gothreatmatrix.ObservableAnalysisRequest{Value: "8.8.8.8"}
+			if _, err := client.AnalyzeService.AnalyzeObservable(context.Background(), analysisRequest, testCase.Input.([]gothreatmatrix.RequestOption)...); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			// the submission is retried as every attempt carries the same key
+			testWantData(t, 3, len(keys))
+			testWantData(t, []string{keys[0], keys[0], keys[0]}, keys)
+			if testCase.Want != "" {
+				testWantData(t, testCase.Want, keys[0])
+			} else if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(keys[0]) {
+				t.Fatalf("Expected a UUID got: %q", keys[0])
+			}
+		})
+	}
+}
+
+func TestIdempotencyKeyNotRetriedByDefault(t *testing.T) {
+	attempts := 0
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	apiHandler.HandleFunc(constants.ANALYZE_OBSERVABLE_URL, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client := gothreatmatrix.NewClient(gothreatmatrix.WithURL(testServer.URL), gothreatmatrix.WithRetry(3, time.Millisecond))
+	analysisRequest := gothreatmatrix.ObservableAnalysisRequest{Value: "8.8.8.8"}
+	if _, err := client.AnalyzeService.AnalyzeObservable(context.Background(), analysisRequest); err == nil {
+		t.Fatalf("Expected an error got none")
+	}
+	// the server isn't known to honor the key, so the submission may already have created a job
+	testWantData(t, 1, attempts)
+}
+
+func TestIdempotencyKeyPerSubmission(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	keys := map[string]bool{}
+	apiHandler.HandleFunc(constants.ANALYZE_FILE_URL, func(w http.ResponseWriter, r *http.Request) {
+		keys[r.Header.Get(gothreatmatrix.IdempotencyKeyHeader)] = true
+		_, _ = w.Write([]byte(`{"job_id":1,"status":"accepted"}`))
+	})
+	for i := 0; i < 2; i++ {
+		analysisRequest := gothreatmatrix.FileAnalysisRequest{File: strings.NewReader("MZ"), FileName: "sample.exe"}
+		if _, err := client.AnalyzeService.AnalyzeFile(context.Background(), analysisRequest); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	testWantData(t, 2, len(keys))
+}
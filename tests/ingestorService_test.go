@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestIngestorServiceList(t *testing.T) {
+	ingestorJsonString := `{"name":"ThreatFox","python_module":"threatfox.ThreatFox","disabled":false,"description":"Ingest the last IOCs of ThreatFox","schedule":{"minute":"0","hour":"*","day_of_week":"*","day_of_month":"*","month_of_year":"*"},"playbook_to_execute":"Popular_IP_Reputation_Services","maximum_jobs":50}`
+	ingestorConfig := gothreatmatrix.IngestorConfig{}
+	if unmarshalError := json.Unmarshal([]byte(ingestorJsonString), &ingestorConfig); unmarshalError != nil {
+		t.Fatalf("Error: %s", unmarshalError)
+	}
+	testCases := make(map[string]TestData)
+	testCases["list"] = TestData{
+		Data:       "[" + ingestorJsonString + "]",
+		StatusCode: http.StatusOK,
+		Want:       []gothreatmatrix.IngestorConfig{ingestorConfig},
+	}
+	testCases["paginated"] = TestData{
+		Data:       `{"count":1,"total_pages":1,"results":[` + ingestorJsonString + "]}",
+		StatusCode: http.StatusOK,
+		Want:       []gothreatmatrix.IngestorConfig{ingestorConfig},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.Handle(constants.BASE_INGESTOR_URL, serverHandler(t, testCase, "GET"))
+			gottenIngestorConfigs, err := client.IngestorService.List(context.Background())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenIngestorConfigs)
+			testWantData(t, "0", gottenIngestorConfigs[0].Schedule.Minute)
+		})
+	}
+}
+
+func TestIngestorServiceGet(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["simple"] = TestData{
+		Input:      "ThreatFox",
+		Data:       `{"name":"ThreatFox","disabled":true,"playbook_to_execute":"Popular_IP_Reputation_Services","maximum_jobs":50}`,
+		StatusCode: http.StatusOK,
+		Want: &gothreatmatrix.IngestorConfig{
+			BaseConfigurationType: gothreatmatrix.BaseConfigurationType{Name: "ThreatFox", Disabled: true},
+			PlaybookToExecute:     "Popular_IP_Reputation_Services",
+			MaximumJobs:           50,
+		},
+	}
+	testCases["notFound"] = TestData{
+		Input:      "Missing",
+		Data:       `{"detail":"Not found."}`,
+		StatusCode: http.StatusNotFound,
+		Want:       gothreatmatrix.ErrNotFound,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			ingestorName := testCase.Input.(string)
+			apiHandler.Handle(fmt.Sprintf(constants.SPECIFIC_INGESTOR_URL, ingestorName), serverHandler(t, testCase, "GET"))
+			gottenIngestorConfig, err := client.IngestorService.Get(context.Background(), ingestorName)
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenIngestorConfig)
+		})
+	}
+}
+
+func TestIngestorServiceEnableDisable(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["enable"] = TestData{Input: false, Want: true}
+	testCases["disable"] = TestData{Input: true, Want: true}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.HandleFunc(fmt.Sprintf(constants.SPECIFIC_INGESTOR_URL, "ThreatFox"), func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "PATCH")
+				gottenParams := map[string]interface{}{}
+				if err := json.NewDecoder(r.Body).Decode(&gottenParams); err != nil {
+					t.Errorf("Could not decode the request body: %v", err)
+				}
+				testWantData(t, map[string]interface{}{"disabled": testCase.Input}, gottenParams)
+				_, _ = w.Write([]byte(`{"name":"ThreatFox"}`))
+			})
+			var gotten bool
+			var err error
+			if testCase.Input.(bool) {
+				gotten, err = client.IngestorService.Disable(context.Background(), "ThreatFox")
+			} else {
+				gotten, err = client.IngestorService.Enable(context.Background(), "ThreatFox")
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gotten)
+		})
+	}
+	client, _, closeServer := setup()
+	defer closeServer()
+	if _, err := client.IngestorService.Disable(context.Background(), " "); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+}
+
+func TestIngestorServiceHealthCheck(t *testing.T) {
+	testData := TestData{
+		Data:       `{"status":true}`,
+		StatusCode: http.StatusOK,
+		Want:       gothreatmatrix.HealthStatusUp,
+	}
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.Handle(fmt.Sprintf(constants.INGESTOR_HEALTHCHECK_URL, "ThreatFox"), serverHandler(t, testData, "GET"))
+	status, err := client.IngestorService.HealthCheck(context.Background(), "ThreatFox")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, testData.Want, status)
+}
@@ -0,0 +1,155 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestInvestigationServiceList(t *testing.T) {
+	investigationJsonString := `{"id":1,"name":"phishing campaign","description":"","owner":{"username":"hussain"},"tags":["phishing"],"tlp":"AMBER","status":"running","total_jobs":3,"jobs":[10,11],"start_time":null,"end_time":null}`
+	investigation := gothreatmatrix.Investigation{}
+	if unmarshalError := json.Unmarshal([]byte(investigationJsonString), &investigation); unmarshalError != nil {
+		t.Fatalf("Error: %s", unmarshalError)
+	}
+	testCases := make(map[string]TestData)
+	testCases["list"] = TestData{
+		Data:       "[" + investigationJsonString + "]",
+		StatusCode: http.StatusOK,
+		Want:       []gothreatmatrix.Investigation{investigation},
+	}
+	testCases["paginated"] = TestData{
+		Data:       `{"count":1,"total_pages":1,"results":[` + investigationJsonString + "]}",
+		StatusCode: http.StatusOK,
+		Want:       []gothreatmatrix.Investigation{investigation},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.Handle(constants.BASE_INVESTIGATION_URL, serverHandler(t, testCase, "GET"))
+			gottenInvestigations, err := client.InvestigationService.List(context.Background())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenInvestigations)
+			testWantData(t, gothreatmatrix.InvestigationRunning, gottenInvestigations[0].Status)
+		})
+	}
+}
+
+func TestInvestigationServiceCreate(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["simple"] = TestData{
+		Input:      &gothreatmatrix.InvestigationParams{Name: "phishing campaign", Tlp: gothreatmatrix.TLPAmber},
+		Data:       `{"id":1,"name":"phishing campaign","tlp":"AMBER","status":"created","total_jobs":0,"jobs":[]}`,
+		StatusCode: http.StatusCreated,
+		Want: &gothreatmatrix.Investigation{
+			ID:     1,
+			Name:   "phishing campaign",
+			Tlp:    gothreatmatrix.TLPAmber,
+			Status: gothreatmatrix.InvestigationCreated,
+			Jobs:   []int{},
+		},
+	}
+	testCases["noName"] = TestData{
+		Input: &gothreatmatrix.InvestigationParams{Name: " "},
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	testCases["badTlp"] = TestData{
+		Input: &gothreatmatrix.InvestigationParams{Name: "phishing campaign", Tlp: "PURPLE"},
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.HandleFunc(constants.BASE_INVESTIGATION_URL, func(w http.ResponseWriter, r *http.Request) {
+				gottenParams := map[string]interface{}{}
+				if err := json.NewDecoder(r.Body).Decode(&gottenParams); err != nil {
+					t.Errorf("Could not decode the request body: %v", err)
+				}
+				testWantData(t, map[string]interface{}{"name": "phishing campaign", "tlp": "AMBER"}, gottenParams)
+				serverHandler(t, testCase, "POST").ServeHTTP(w, r)
+			})
+			gottenInvestigation, err := client.InvestigationService.Create(context.Background(), testCase.Input.(*gothreatmatrix.InvestigationParams))
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenInvestigation)
+		})
+	}
+}
+
+func TestInvestigationServiceJobs(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	for _, route := range []string{constants.ADD_JOB_INVESTIGATION_URL, constants.REMOVE_JOB_INVESTIGATION_URL} {
+		apiHandler.HandleFunc(fmt.Sprintf(route, 1), func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "POST")
+			gottenParams := map[string]interface{}{}
+			if err := json.NewDecoder(r.Body).Decode(&gottenParams); err != nil {
+				t.Errorf("Could not decode the request body: %v", err)
+			}
+			testWantData(t, map[string]interface{}{"job": float64(42)}, gottenParams)
+			w.WriteHeader(http.StatusOK)
+		})
+	}
+	added, err := client.InvestigationService.AddJob(context.Background(), 1, 42)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, true, added)
+	removed, err := client.InvestigationService.RemoveJob(context.Background(), 1, 42)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, true, removed)
+	if _, err := client.InvestigationService.AddJob(context.Background(), 0, 42); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+}
+
+func TestInvestigationServiceTree(t *testing.T) {
+	testData := TestData{
+		Input:      uint64(1),
+		Data:       `{"name":"phishing campaign","owner":3,"description":"","jobs":[{"pk":10,"analyzed_object_name":"evil.com","playbook":"Dns","status":"reported_without_fails","is_sample":false,"children":[{"pk":12,"analyzed_object_name":"1.2.3.4","playbook":"FREE_TO_USE_ANALYZERS","status":"running","is_sample":false}]}]}`,
+		StatusCode: http.StatusOK,
+		Want: &gothreatmatrix.InvestigationTree{
+			Name:  "phishing campaign",
+			Owner: 3,
+			Jobs: []gothreatmatrix.InvestigationTreeJob{{
+				ID:                 10,
+				AnalyzedObjectName: "evil.com",
+				Playbook:           "Dns",
+				Status:             gothreatmatrix.StatusReportedWithoutFails,
+				Children: []gothreatmatrix.InvestigationTreeJob{{
+					ID:                 12,
+					AnalyzedObjectName: "1.2.3.4",
+					Playbook:           "FREE_TO_USE_ANALYZERS",
+					Status:             gothreatmatrix.StatusRunning,
+				}},
+			}},
+		},
+	}
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.Handle(fmt.Sprintf(constants.TREE_INVESTIGATION_URL, 1), serverHandler(t, testData, "GET"))
+	gottenTree, err := client.InvestigationService.Tree(context.Background(), testData.Input.(uint64))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, testData.Want, gottenTree)
+}
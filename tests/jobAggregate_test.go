@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestJobServiceAggregateStatus(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["list"] = TestData{
+		Input:      &gothreatmatrix.AggregateParams{Range: gothreatmatrix.AggregateRangeDay},
+		Data:       `[{"date":"2023-03-01T00:00:00Z","pending":1,"reported_without_fails":3},{"date":"2023-03-01T01:00:00Z","pending":0,"reported_without_fails":2}]`,
+		StatusCode: http.StatusOK,
+		Want: &gothreatmatrix.AggregateResult{
+			Values: []string{"pending", "reported_without_fails"},
+			Buckets: []gothreatmatrix.AggregateBucket{
+				{Date: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), Counts: map[string]int{"pending": 1, "reported_without_fails": 3}},
+				{Date: time.Date(2023, 3, 1, 1, 0, 0, 0, time.UTC), Counts: map[string]int{"pending": 0, "reported_without_fails": 2}},
+			},
+		},
+	}
+	testCases["withValues"] = TestData{
+		Data:       `{"values":["running","failed"],"aggregation":[{"date":"2023-03-01T00:00:00Z","running":4,"failed":1}]}`,
+		StatusCode: http.StatusOK,
+		Want: &gothreatmatrix.AggregateResult{
+			Values: []string{"running", "failed"},
+			Buckets: []gothreatmatrix.AggregateBucket{
+				{Date: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), Counts: map[string]int{"running": 4, "failed": 1}},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			params, _ := testCase.Input.(*gothreatmatrix.AggregateParams)
+			apiHandler.HandleFunc(fmt.Sprintf(constants.AGGREGATE_JOB_URL, "status"), func(w http.ResponseWriter, r *http.Request) {
+				wantRange := ""
+				if params != nil {
+					wantRange = params.Range
+				}
+				testWantData(t, wantRange, r.URL.Query().Get("range"))
+				serverHandler(t, testCase, "GET").ServeHTTP(w, r)
+			})
+			gottenResult, err := client.JobService.AggregateStatus(context.Background(), params)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenResult)
+		})
+	}
+}
+
+func TestJobServiceAggregateFields(t *testing.T) {
+	ctx := context.Background()
+	testCases := map[string]func(*gothreatmatrix.ThreatMatrixClient) (*gothreatmatrix.AggregateResult, error){
+		"type": func(client *gothreatmatrix.ThreatMatrixClient) (*gothreatmatrix.AggregateResult, error) {
+			return client.JobService.AggregateType(ctx, nil)
+		},
+		"observable_classification": func(client *gothreatmatrix.ThreatMatrixClient) (*gothreatmatrix.AggregateResult, error) {
+			return client.JobService.AggregateObservableClassification(ctx, nil)
+		},
+		"file_mimetype": func(client *gothreatmatrix.ThreatMatrixClient) (*gothreatmatrix.AggregateResult, error) {
+			return client.JobService.AggregateFileMimetype(ctx, nil)
+		},
+	}
+	testData := TestData{
+		Data:       `[{"date":"2023-03-01T00:00:00Z","a":2,"b":5}]`,
+		StatusCode: http.StatusOK,
+	}
+	for field, aggregate := range testCases {
+		t.Run(field, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.Handle(fmt.Sprintf(constants.AGGREGATE_JOB_URL, field), serverHandler(t, testData, "GET"))
+			gottenResult, err := aggregate(&client)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, []int{7}, gottenResult.Totals())
+		})
+	}
+}
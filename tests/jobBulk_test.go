@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestJobServiceKillAll(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.BASE_JOB_URL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testWantData(t, "hussain", r.URL.Query().Get("user__username"))
+		_, _ = w.Write([]byte(`{"count":3,"total_pages":1,"results":[{"id":1,"status":"running"},{"id":2,"status":"reported_without_fails"},{"id":3,"status":"pending"}]}`))
+	})
+	apiHandler.Handle(fmt.Sprintf(constants.KILL_JOB_URL, 1), serverHandler(t, TestData{StatusCode: http.StatusNoContent}, "PATCH"))
+	apiHandler.Handle(fmt.Sprintf(constants.KILL_JOB_URL, 2), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Job 2 is done and should not be killed")
+	}))
+	apiHandler.Handle(fmt.Sprintf(constants.KILL_JOB_URL, 3), serverHandler(t, TestData{Data: `{"detail":"Job is not running"}`, StatusCode: http.StatusBadRequest}, "PATCH"))
+	gottenResults, err := client.JobService.KillAll(context.Background(), &gothreatmatrix.JobListParams{User: "hussain"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 2, len(gottenResults))
+	testWantData(t, gothreatmatrix.KillResult{JobID: 1, Killed: true}, gottenResults[0])
+	testWantData(t, 3, gottenResults[1].JobID)
+	testWantData(t, false, gottenResults[1].Killed)
+	if !errors.Is(gottenResults[1].Err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, gottenResults[1].Err)
+	}
+}
+
+func TestJobServiceKillAllListError(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.Handle(constants.BASE_JOB_URL, serverHandler(t, TestData{Data: `{"detail":"Invalid token."}`, StatusCode: http.StatusUnauthorized}, "GET"))
+	gottenResults, err := client.JobService.KillAll(context.Background(), nil)
+	if !errors.Is(err, gothreatmatrix.ErrUnauthorized) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrUnauthorized, err)
+	}
+	testWantData(t, 0, len(gottenResults))
+}
+
+func TestJobServiceRetryFailedAnalyzers(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	jobJson := `{"id":7,"status":"reported_with_fails","analyzer_reports":[{"name":"Classic_DNS","status":"SUCCESS"},{"name":"Shodan","status":"FAILED"},{"name":"GreyNoise","status":"FAILED"}]}`
+	apiHandler.Handle(fmt.Sprintf(constants.SPECIFIC_JOB_URL, 7), serverHandler(t, TestData{Data: jobJson, StatusCode: http.StatusOK}, "GET"))
+	apiHandler.Handle(fmt.Sprintf(constants.RETRY_ANALYZER_JOB_URL, 7, "Shodan"), serverHandler(t, TestData{StatusCode: http.StatusNoContent}, "PATCH"))
+	apiHandler.Handle(fmt.Sprintf(constants.RETRY_ANALYZER_JOB_URL, 7, "GreyNoise"), serverHandler(t, TestData{Data: `{"detail":"Plugin call is not failed"}`, StatusCode: http.StatusBadRequest}, "PATCH"))
+	apiHandler.Handle(fmt.Sprintf(constants.RETRY_ANALYZER_JOB_URL, 7, "Classic_DNS"), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Classic_DNS succeeded and should not be retried")
+	}))
+	gottenResults, err := client.JobService.RetryFailedAnalyzers(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 2, len(gottenResults))
+	testWantData(t, gothreatmatrix.RetryResult{Retried: true}, gottenResults["Shodan"])
+	testWantData(t, false, gottenResults["GreyNoise"].Retried)
+	if !errors.Is(gottenResults["GreyNoise"].Err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, gottenResults["GreyNoise"].Err)
+	}
+}
@@ -1,14 +1,23 @@
 package tests
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/khulnasoft/go-threatmatrix/constants"
 	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+	"github.com/yeka/zip"
 )
 
 func TestJobServiceList(t *testing.T) {
@@ -33,7 +42,7 @@ func TestJobServiceList(t *testing.T) {
 				defer closeServer()
 				ctx := context.Background()
 				apiHandler.Handle(constants.BASE_JOB_URL, serverHandler(t, testCase, "GET"))
-				gottenJobList, err := client.JobService.List(ctx)
+				gottenJobList, err := client.JobService.List(ctx, nil)
 				if err != nil {
 					testError(t, testCase, err)
 				} else {
@@ -44,6 +53,60 @@ func TestJobServiceList(t *testing.T) {
 	}
 }
 
+func TestJobServiceListParams(t *testing.T) {
+	receivedAfter := time.Date(2022, 7, 15, 0, 0, 0, 0, time.UTC)
+	testCases := make(map[string]TestData)
+	testCases["noParams"] = TestData{
+		Input: (*gothreatmatrix.JobListParams)(nil),
+		Want:  url.Values{},
+	}
+	testCases["filters"] = TestData{
+		Input: &gothreatmatrix.JobListParams{
+			Status:                   "reported_with_fails",
+			Tlp:                      "WHITE",
+			ObservableClassification: "ip",
+			Analyzer:                 "Classic_DNS",
+			Tag:                      "phishing",
+			User:                     "hussain",
+			ReceivedRequestTimeAfter: receivedAfter,
+			Ordering:                 "-received_request_time",
+			Page:                     2,
+			PageSize:                 50,
+		},
+		Want: url.Values{
+			"status":                     {"reported_with_fails"},
+			"tlp":                        {"WHITE"},
+			"observable_classification":  {"ip"},
+			"analyzers_to_execute":       {"Classic_DNS"},
+			"tags__label":                {"phishing"},
+			"user__username":             {"hussain"},
+			"received_request_time__gte": {"2022-07-15T00:00:00Z"},
+			"ordering":                   {"-received_request_time"},
+			"page":                       {"2"},
+			"page_size":                  {"50"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			ctx := context.Background()
+			apiHandler.HandleFunc(constants.BASE_JOB_URL, func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				testWantData(t, testCase.Want, r.URL.Query())
+				_, _ = w.Write([]byte(`{"count":0,"total_pages":0,"results":[]}`))
+			})
+			params, ok := testCase.Input.(*gothreatmatrix.JobListParams)
+			if !ok {
+				t.Fatalf("Casting failed!")
+			}
+			if _, err := client.JobService.List(ctx, params); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestJobServiceGet(t *testing.T) {
 	jobJsonString := `{"id":72,"user":{"username":"hussain"},"tags":[],"process_time":87.87,"analyzer_reports":[{"name":"CryptoScamDB_CheckAPI","status":"SUCCESS","report":{"input":"8.8.8.8","result":{"type":"ip","status":"neutral","entries":[]},"success":true},"errors":[],"process_time":1.91,"start_time":"2022-07-15T20:25:45.681509Z","end_time":"2022-07-15T20:25:47.595517Z","runtime_configuration":{},"type":"analyzer"},{"name":"Darksearch_Query","status":"FAILED","report":{},"errors":["DarkSearchRequestException: "],"process_time":1.51,"start_time":"2022-07-15T20:25:45.681505Z","end_time":"2022-07-15T20:25:47.189974Z","runtime_configuration":{},"type":"analyzer"},{"name":"Classic_DNS","status":"SUCCESS","report":{"observable":"8.8.8.8","resolutions":["dns.google"]},"errors":[],"process_time":0.51,"start_time":"2022-07-15T20:25:47.601891Z","end_time":"2022-07-15T20:25:48.116356Z","runtime_configuration":{},"type":"analyzer"},{"name":"FileScan_Search","status":"SUCCESS","report":{"count":5,"items":[{"id":"bc6d3a15-b371-41f6-8b53-57333fc779be","date":"06/30/2022, 01:16:19","file":{"name":"test.bat","sha256":"b636ee9b411b5cc6ea5fae704f0889d05f509b9642574136f086c23220ce951a","mime_type":"application/x-bat","short_type":null},"tags":[],"state":"success_partial","matches":[{"origin":{"sha256":"b636ee9b411b5cc6ea5fae704f0889d05f509b9642574136f086c23220ce951a","filetype":null,"relation":"source","mime_type":"application/x-bat"},"matches":{"ip":[{"value":"8.8.8.8"}]}}],"verdict":"informational","scan_init":{"id":"62bcf6c787c294f96f8b67e7"},"updated_date":"06/30/2022, 01:16:58"},{"id":"7d849b04-3f66-42d3-9059-0c3f0d3d6af5","date":"06/30/2022, 01:12:01","file":{"name":"Anyrun_port80.bat","sha256":"2b0411d9f1bdc2c3906710bd62cb35288e50ee774f845a7665dbc86a0d6be40d","mime_type":"application/x-bat","short_type":"html"},"tags":[{"tag":{"name":"html","verdict":{"verdict":"INFORMATIONAL","confidence":1,"threatLevel":0.1},"synonyms":[],"descriptions":[]},"source":"MEDIA_TYPE","isRootTag":true,"sourceIdentifier":"2b0411d9f1bdc2c3906710bd62cb35288e50ee774f845a7665dbc86a0d6be40d"}],"state":"success_partial","matches":[{"origin":{"sha256":"2b0411d9f1bdc2c3906710bd62cb35288e50ee774f845a7665dbc86a0d6be40d","filetype":null,"relation":"source","mime_type":"application/x-bat"},"matches":{"ip":[{"value":"8.8.8.8"}]}}],"verdict":"informational","scan_init":{"id":"62bcf6cab0578633c8b24d36"},"updated_date":"06/30/2022, 01:21:09"},{"id":"aed83c14-0c79-44b4-96c4-7da063a6fd30","date":"06/30/2022, 01:05:21","file":{"name":"Anyrun_port80.bat","sha256":"2b0411d9f1bdc2c3906710bd62cb35288e50ee774f845a7665dbc86a0d6be40d","mime_type":"application/x-bat","short_type":"html"},"tags":[{"tag":{"name":"html","verdict":{"verdict":"INFORMATIONAL","confidence":1,"threatLevel":0.1},"synonyms":[],"descriptions":[]},"source":"MEDIA_TYPE","isRootTag":true,"sourceIdentifier":"2b0411d9f1bdc2c3906710bd62cb35288e50ee774f845a7665dbc86a0d6be40d"}],"state":"success_partial","matches":[{"origin":{"sha256":"2b0411d9f1bdc2c3906710bd62cb35288e50ee774f845a7665dbc86a0d6be40d","filetype":null,"relation":"source","mime_type":"application/x-bat"},"matches":{"ip":[{"value":"8.8.8.8"}]}}],"verdict":"informational","scan_init":{"id":"62bcf6be95a8514e298d7edd"},"retry_count":1,"updated_date":"07/01/2022, 01:53:30"},{"id":"4fc5a2a8-5554-4b7f-bb2a-8a0056629aa9","date":"01/02/2022, 00:34:33","file":{"name":"6e1d9a9c12395e4b505e1606cc0a6e26446412cd","sha256":"a258701294dffe74d811d173db94ec6cad2227c792a4233cd1dc2124544b9899","mime_type":"application/vnd.ms-excel.sheet.macroenabled.12","short_type":"xlsx"},"tags":[{"tag":{"name":"xlsx","verdict":{"verdict":"INFORMATIONAL","confidence":1,"threatLevel":0.1},"synonyms":[],"descriptions":[]},"source":"MEDIA_TYPE","isRootTag":true,"sourceIdentifier":"a258701294dffe74d811d173db94ec6cad2227c792a4233cd1dc2124544b9899"},{"tag":{"name":"html","verdict":{"verdict":"INFORMATIONAL","confidence":1,"threatLevel":0.1},"synonyms":[],"descriptions":[]},"source":"MEDIA_TYPE","isRootTag":true,"sourceIdentifier":"a258701294dffe74d811d173db94ec6cad2227c792a4233cd1dc2124544b9899"},{"tag":{"name":"fingerprint","verdict":{"verdict":"LIKELY_MALICIOUS","confidence":1,"threatLevel":0.75},"synonyms":[],"descriptions":[]},"source":"SIGNAL","isRootTag":true,"sourceIdentifier":"a258701294dffe74d811d173db94ec6cad2227c792a4233cd1dc2124544b9899"},{"tag":{"name":"stealer","verdict":{"verdict":"LIKELY_MALICIOUS","confidence":1,"threatLevel":0.75},"synonyms":[],"descriptions":[]},"source":"SIGNAL","isRootTag":true,"sourceIdentifier":"a258701294dffe74d811d173db94ec6cad2227c792a4233cd1dc2124544b9899"},{"tag":{"name":"macros","verdict":{"verdict":"INFORMATIONAL","confidence":1,"threatLevel":0.1},"synonyms":[],"descriptions":[]},"source":"SIGNAL","isRootTag":true,"sourceIdentifier":"a258701294dffe74d811d173db94ec6cad2227c792a4233cd1dc2124544b9899"}],"state":"success_partial","matches":[{"origin":{"sha256":"a258701294dffe74d811d173db94ec6cad2227c792a4233cd1dc2124544b9899","filetype":"xlsx","relation":"source","mime_type":"application/vnd.ms-excel.sheet.macroenabled.12"},"matches":{"ip":[{"value":"8.8.8.8"}]}}],"verdict":"suspicious","scan_init":{"id":"61d0f2da4ab5c44cbf5b1f85"},"updated_date":"01/02/2022, 00:38:53"},{"id":"595a3779-d327-4ac9-81b4-eb793479cae6","date":"08/23/2021, 01:28:03","file":{"name":"king.bat","sha256":"3f285b9294040d32eaaff486866372b79f1236bfb300d9821d20024142831f05","mime_type":"application/x-bat","short_type":null},"tags":[],"state":"success_partial","matches":[{"origin":{"sha256":"3f285b9294040d32eaaff486866372b79f1236bfb300d9821d20024142831f05","filetype":null,"relation":"source","mime_type":"application/x-bat"},"matches":{"ip":[{"value":"8.8.8.8"}]}}],"verdict":"informational","scan_init":{"id":"6122aec2d972e521533a7b28"},"updated_date":"08/23/2021, 01:28:03"}],"query":"OC44LjguOA==","method":"and","count_search_params":1},"errors":[],"process_time":8.41,"start_time":"2022-07-15T20:25:47.665641Z","end_time":"2022-07-15T20:25:56.079799Z","runtime_configuration":{},"type":"analyzer"},{"name":"GreyNoiseCommunity","status":"SUCCESS","report":{"ip":"8.8.8.8","link":"https://viz.greynoise.io/riot/8.8.8.8","name":"Google APIs and Services","riot":true,"noise":false,"message":"Success","last_seen":"2022-07-15","classification":"benign"},"errors":[],"process_time":1.4,"start_time":"2022-07-15T20:25:48.982345Z","end_time":"2022-07-15T20:25:50.385093Z","runtime_configuration":{},"type":"analyzer"},{"name":"InQuest_IOCdb","status":"SUCCESS","report":{"data":[],"success":true},"errors":["No API key retrieved"],"process_time":1.68,"start_time":"2022-07-15T20:25:49.007793Z","end_time":"2022-07-15T20:25:50.691163Z","runtime_configuration":{},"type":"analyzer"},{"name":"GoogleWebRisk","status":"FAILED","report":{},"errors":["/opt/deploy/intel_owl/configuration/service_account_keyfile.json should be an existing file. Check the docs on how to add this file to properly execute this analyzer"],"process_time":0.09,"start_time":"2022-07-15T20:27:11.329940Z","end_time":"2022-07-15T20:27:11.420128Z","runtime_configuration":{},"type":"analyzer"}],"connector_reports":[],"permissions":{"kill":true,"delete":true,"plugin_actions":true},"is_sample":false,"md5":"40ff44d9e619b17524bf3763204f9cbb","observable_name":"8.8.8.8","observable_classification":"ip","file_name":"","file_mimetype":"","status":"reported_with_fails","analyzers_requested":["Classic_DNS","CryptoScamDB_CheckAPI","Darksearch_Query","GoogleWebRisk","FileScan_Search","InQuest_IOCdb","GreyNoiseAlpha","GreyNoiseCommunity"],"connectors_requested":[],"analyzers_to_execute":["Classic_DNS","CryptoScamDB_CheckAPI","Darksearch_Query","GoogleWebRisk","FileScan_Search","InQuest_IOCdb","GreyNoiseCommunity"],"connectors_to_execute":["YETI"],"received_request_time":"2022-07-15T20:25:44.041286Z","finished_analysis_time":"2022-07-15T20:27:11.909898Z","tlp":"WHITE","errors":[]}`
 	job := gothreatmatrix.Job{}
@@ -67,6 +130,7 @@ func TestJobServiceGet(t *testing.T) {
 			Want: &gothreatmatrix.ThreatMatrixError{
 				StatusCode: http.StatusNotFound,
 				Message:    `{"detail":"Not found."}`,
+				Detail:     "Not found.",
 			},
 		}
 		for name, testCase := range testCases {
@@ -111,6 +175,7 @@ func TestJobServiceDownloadSample(t *testing.T) {
 		Want: &gothreatmatrix.ThreatMatrixError{
 			StatusCode: http.StatusBadRequest,
 			Message:    doesNotHaveASampleResponseJsonString,
+			Detail:     "Requested job does not have a sample associated with it.",
 		},
 	}
 	for name, testCase := range testCases {
@@ -137,6 +202,106 @@ func TestJobServiceDownloadSample(t *testing.T) {
 	}
 }
 
+func TestJobServiceDownloadSampleTo(t *testing.T) {
+	sampleString := strings.Repeat("This is the sample", 4096)
+	doesNotHaveASampleResponseJsonString := `{"errors":{"detail":"Requested job does not have a sample associated with it."}}`
+	testCases := make(map[string]TestData)
+	testCases["simple"] = TestData{
+		Input:      1,
+		Data:       sampleString,
+		StatusCode: http.StatusOK,
+		Want:       sampleString,
+	}
+	testCases["doesNotHaveASample"] = TestData{
+		Input:      2,
+		Data:       doesNotHaveASampleResponseJsonString,
+		StatusCode: http.StatusBadRequest,
+		Want: &gothreatmatrix.ThreatMatrixError{
+			StatusCode: http.StatusBadRequest,
+			Message:    doesNotHaveASampleResponseJsonString,
+			Detail:     "Requested job does not have a sample associated with it.",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			jobId := uint64(testCase.Input.(int))
+			apiHandler.Handle(fmt.Sprintf(constants.DOWNLOAD_SAMPLE_JOB_URL, jobId), serverHandler(t, testCase, "GET"))
+			sample := bytes.Buffer{}
+			written, err := client.JobService.DownloadSampleTo(context.Background(), jobId, &sample)
+			if err != nil {
+				testError(t, testCase, err)
+				testWantData(t, 0, sample.Len())
+				return
+			}
+			testWantData(t, int64(len(sampleString)), written)
+			testWantData(t, testCase.Want, sample.String())
+		})
+	}
+}
+
+func TestJobServiceDownloadSampleZipped(t *testing.T) {
+	sampleString := "This is the sample"
+	testCases := make(map[string]TestData)
+	testCases["defaultPassword"] = TestData{
+		Input:      "",
+		Data:       sampleString,
+		StatusCode: http.StatusOK,
+		Want:       gothreatmatrix.DefaultSamplePassword,
+	}
+	testCases["customPassword"] = TestData{
+		Input:      "s3cr3t",
+		Data:       sampleString,
+		StatusCode: http.StatusOK,
+		Want:       "s3cr3t",
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.Handle(fmt.Sprintf(constants.DOWNLOAD_SAMPLE_JOB_URL, 1), serverHandler(t, testCase, "GET"))
+			archive := bytes.Buffer{}
+			if err := client.JobService.DownloadSampleZipped(context.Background(), 1, testCase.Input.(string), &archive); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			zipReader, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+			if err != nil {
+				t.Fatalf("Could not read the archive: %v", err)
+			}
+			testWantData(t, 1, len(zipReader.File))
+			entry := zipReader.File[0]
+			testWantData(t, "job_1_sample", entry.Name)
+			testWantData(t, true, entry.IsEncrypted())
+			entry.SetPassword(testCase.Want.(string))
+			entryReader, err := entry.Open()
+			if err != nil {
+				t.Fatalf("Could not open the sample: %v", err)
+			}
+			defer entryReader.Close()
+			gottenSample, err := io.ReadAll(entryReader)
+			if err != nil {
+				t.Fatalf("Could not decrypt the sample: %v", err)
+			}
+			testWantData(t, sampleString, string(gottenSample))
+		})
+	}
+}
+
+func TestJobServiceDownloadSampleZippedError(t *testing.T) {
+	testData := TestData{
+		Data:       `{"errors":{"detail":"Requested job does not have a sample associated with it."}}`,
+		StatusCode: http.StatusBadRequest,
+	}
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.Handle(fmt.Sprintf(constants.DOWNLOAD_SAMPLE_JOB_URL, 2), serverHandler(t, testData, "GET"))
+	err := client.JobService.DownloadSampleZipped(context.Background(), 2, "", io.Discard)
+	if !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+}
+
 func TestJobServiceDelete(t *testing.T) {
 	// *table test case
 	testCases := make(map[string]TestData)
@@ -154,6 +319,7 @@ func TestJobServiceDelete(t *testing.T) {
 		Want: &gothreatmatrix.ThreatMatrixError{
 			StatusCode: http.StatusNotFound,
 			Message:    notFoundJson,
+			Detail:     "Not found.",
 		},
 	}
 	for name, testCase := range testCases {
@@ -194,6 +360,7 @@ func TestJobServiceKill(t *testing.T) {
 		Want: &gothreatmatrix.ThreatMatrixError{
 			StatusCode: http.StatusNotFound,
 			Message:    `{"detail":"Not found."}`,
+			Detail:     "Not found.",
 		},
 	}
 	testCases["jobNotRunning"] = TestData{
@@ -203,6 +370,7 @@ func TestJobServiceKill(t *testing.T) {
 		Want: &gothreatmatrix.ThreatMatrixError{
 			StatusCode: http.StatusBadRequest,
 			Message:    `{"errors":{"detail":"Job is not running"}}`,
+			Detail:     "Job is not running",
 		},
 	}
 	for name, testCase := range testCases {
@@ -252,8 +420,9 @@ func TestJobServiceKillAnalyzer(t *testing.T) {
 		Data:       `{"errors":{"analyzer report":"Not found."}}`,
 		StatusCode: http.StatusNotFound,
 		Want: &gothreatmatrix.ThreatMatrixError{
-			StatusCode: http.StatusNotFound,
-			Message:    `{"errors":{"analyzer report":"Not found."}}`,
+			StatusCode:  http.StatusNotFound,
+			Message:     `{"errors":{"analyzer report":"Not found."}}`,
+			FieldErrors: map[string][]string{"analyzer report": {"Not found."}},
 		},
 	}
 	testCases["analyzerNotRunning"] = TestData{
@@ -266,6 +435,7 @@ func TestJobServiceKillAnalyzer(t *testing.T) {
 		Want: &gothreatmatrix.ThreatMatrixError{
 			StatusCode: http.StatusBadRequest,
 			Message:    `{"errors":{"detail":"Plugin call is not running or pending"}}`,
+			Detail:     "Plugin call is not running or pending",
 		},
 	}
 	for name, testCase := range testCases {
@@ -387,3 +557,213 @@ func TestJobServiceRetryConnector(t *testing.T) {
 		})
 	}
 }
+
+func TestJobServiceIter(t *testing.T) {
+	pages := map[string]string{
+		"1": `{"count":3,"total_pages":2,"results":[{"id":3},{"id":2}]}`,
+		"2": `{"count":3,"total_pages":2,"results":[{"id":1}]}`,
+	}
+	testCases := make(map[string]TestData)
+	testCases["allPages"] = TestData{
+		Input: context.Background(),
+		Want:  []int{3, 2, 1},
+	}
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	testCases["canceled"] = TestData{
+		Input: canceledCtx,
+		Want:  []int{},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.HandleFunc(constants.BASE_JOB_URL, func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				testWantData(t, "2", r.URL.Query().Get("page_size"))
+				_, _ = w.Write([]byte(pages[r.URL.Query().Get("page")]))
+			})
+			ctx := testCase.Input.(context.Context)
+			iterator := client.JobService.Iter(ctx, &gothreatmatrix.JobListParams{PageSize: 2})
+			gottenIds := []int{}
+			for iterator.Next() {
+				gottenIds = append(gottenIds, iterator.Job().ID)
+			}
+			testWantData(t, testCase.Want, gottenIds)
+			testWantData(t, true, errors.Is(iterator.Err(), ctx.Err()))
+		})
+	}
+}
+
+func TestJobServiceRecentScans(t *testing.T) {
+	recentScans := `[{"pk":12,"tlp":"AMBER","user":"hussain","observable_name":"8.8.8.8","file_name":"","finished_analysis_time":"2023-03-01T12:00:00Z"}]`
+	finished := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	wantScans := []gothreatmatrix.RecentScan{{ID: 12, Tlp: "AMBER", User: "hussain", ObservableName: "8.8.8.8", FinishedAnalysisTime: gothreatmatrix.NewTimestamp(finished)}}
+	testCases := make(map[string]TestData)
+	testCases["md5"] = TestData{
+		Input:      "F1D2D2F924E986AC86FDF7B36C94BCDF",
+		Data:       recentScans,
+		StatusCode: http.StatusOK,
+		Want:       wantScans,
+	}
+	testCases["observable"] = TestData{
+		Input:      "8.8.8.8",
+		Data:       recentScans,
+		StatusCode: http.StatusOK,
+		Want:       wantScans,
+	}
+	testCases["empty"] = TestData{
+		Input: " ",
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	wantMd5 := map[string]string{
+		"md5":        "F1D2D2F924E986AC86FDF7B36C94BCDF",
+		"observable": fmt.Sprintf("%x", md5.Sum([]byte("8.8.8.8"))),
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.HandleFunc(constants.RECENT_SCANS_JOB_URL, func(w http.ResponseWriter, r *http.Request) {
+				gottenParams := map[string]string{}
+				if err := json.NewDecoder(r.Body).Decode(&gottenParams); err != nil {
+					t.Errorf("Could not decode the request body: %v", err)
+				}
+				testWantData(t, wantMd5[name], gottenParams["md5"])
+				serverHandler(t, testCase, "POST").ServeHTTP(w, r)
+			})
+			gottenScans, err := client.JobService.RecentScans(context.Background(), testCase.Input.(string))
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenScans)
+		})
+	}
+}
+
+func TestJobServiceSearch(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["observable"] = TestData{
+		Input: &gothreatmatrix.JobSearchQuery{ObservableName: " 8.8.8.8 ", PageSize: 20},
+		Want: url.Values{
+			"observable_name": {"8.8.8.8"},
+			"ordering":        {"-received_request_time"},
+			"page_size":       {"20"},
+		},
+	}
+	testCases["md5AndFileName"] = TestData{
+		Input: &gothreatmatrix.JobSearchQuery{Md5: "F1D2D2F924E986AC86FDF7B36C94BCDF", FileName: "invoice.docm", Page: 2},
+		Want: url.Values{
+			"md5":       {"f1d2d2f924e986ac86fdf7b36c94bcdf"},
+			"file_name": {"invoice.docm"},
+			"ordering":  {"-received_request_time"},
+			"page":      {"2"},
+		},
+	}
+	testCases["empty"] = TestData{
+		Input: &gothreatmatrix.JobSearchQuery{Page: 2},
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	testCases["badMd5"] = TestData{
+		Input: &gothreatmatrix.JobSearchQuery{Md5: "8.8.8.8"},
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.HandleFunc(constants.BASE_JOB_URL, func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				testWantData(t, testCase.Want, r.URL.Query())
+				_, _ = w.Write([]byte(`{"count":1,"total_pages":1,"results":[{"id":12,"observable_name":"8.8.8.8"}]}`))
+			})
+			gottenJobs, err := client.JobService.Search(context.Background(), testCase.Input.(*gothreatmatrix.JobSearchQuery))
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, 12, gottenJobs.Results[0].ID)
+		})
+	}
+}
+
+func TestJobServiceDownloadSampleChecksum(t *testing.T) {
+	sample := "This is the sample"
+	md5Sum := fmt.Sprintf("%x", md5.Sum([]byte(sample)))
+	sha256Sum := fmt.Sprintf("%x", sha256.Sum256([]byte(sample)))
+	testCases := map[string]struct {
+		job     string
+		served  string
+		wantErr error
+	}{
+		"md5":             {job: fmt.Sprintf(`{"id":1,"is_sample":true,"md5":%q}`, md5Sum), served: sample},
+		"md5AndSha256":    {job: fmt.Sprintf(`{"id":1,"is_sample":true,"md5":%q,"sha256":%q}`, md5Sum, sha256Sum), served: sample},
+		"truncated":       {job: fmt.Sprintf(`{"id":1,"is_sample":true,"md5":%q}`, md5Sum), served: sample[:10], wantErr: gothreatmatrix.ErrChecksumMismatch},
+		"sha256Mismatch":  {job: fmt.Sprintf(`{"id":1,"is_sample":true,"md5":%q,"sha256":%q}`, md5Sum, strings.Repeat("0", 64)), served: sample, wantErr: gothreatmatrix.ErrChecksumMismatch},
+		"notASampleJob":   {job: fmt.Sprintf(`{"id":1,"is_sample":false,"md5":%q}`, md5Sum), served: sample, wantErr: gothreatmatrix.ErrValidation},
+		"missingChecksum": {job: `{"id":1,"is_sample":true,"md5":""}`, served: sample, wantErr: gothreatmatrix.ErrValidation},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.HandleFunc(fmt.Sprintf(constants.SPECIFIC_JOB_URL, 1), func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				_, _ = w.Write([]byte(testCase.job))
+			})
+			apiHandler.HandleFunc(fmt.Sprintf(constants.DOWNLOAD_SAMPLE_JOB_URL, 1), func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(testCase.served))
+			})
+			ctx := context.Background()
+			downloaded, err := client.JobService.DownloadSample(ctx, 1, gothreatmatrix.WithChecksumVerification())
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("Expected %v got: %v", testCase.wantErr, err)
+			}
+			if testCase.wantErr == nil {
+				testWantData(t, sample, string(downloaded))
+			}
+			err = client.JobService.DownloadSampleZipped(ctx, 1, "", io.Discard, gothreatmatrix.WithChecksumVerification())
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("Expected %v got: %v", testCase.wantErr, err)
+			}
+			// without the option the sample isn't verified
+			if _, err := client.JobService.DownloadSample(ctx, 1); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestJobPermissions(t *testing.T) {
+	job := gothreatmatrix.Job{}
+	if err := json.Unmarshal([]byte(`{"id":1,"permissions":{"kill":true,"delete":false,"plugin_actions":true}}`), &job); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, &gothreatmatrix.Permissions{CanKill: true, CanRetry: true}, job.Permissions)
+	testWantData(t, true, job.CanKill())
+	testWantData(t, false, job.CanDelete())
+	testWantData(t, true, job.CanRetry())
+	if _, ok := job.Extra["permissions"]; ok {
+		t.Errorf("the permissions should not be left in Extra")
+	}
+
+	// a job without permissions allows nothing
+	job = gothreatmatrix.Job{}
+	if err := json.Unmarshal([]byte(`{"id":1}`), &job); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, false, job.CanKill())
+	testWantData(t, false, job.CanDelete())
+	testWantData(t, false, job.CanRetry())
+}
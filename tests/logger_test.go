@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestClientRequestLogging(t *testing.T) {
+	longBody := `[{"id":1,"label":"` + strings.Repeat("a", 2000) + `","color":"#ffffff"}]`
+	testCases := make(map[string]TestData)
+	testCases["simple"] = TestData{
+		Input:      slog.LevelDebug,
+		Data:       `[]`,
+		StatusCode: http.StatusOK,
+		Want:       `[]`,
+	}
+	testCases["truncated"] = TestData{
+		Input:      slog.LevelDebug,
+		Data:       longBody,
+		StatusCode: http.StatusOK,
+		Want:       longBody[:1024] + "...(truncated)",
+	}
+	testCases["infoLevel"] = TestData{
+		Input:      slog.LevelInfo,
+		Data:       `[]`,
+		StatusCode: http.StatusOK,
+		Want:       nil,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := http.NewServeMux()
+			testServer := httptest.NewServer(apiHandler)
+			defer testServer.Close()
+			apiHandler.Handle(constants.BASE_TAG_URL, serverHandler(t, testCase, "GET"))
+
+			logs := &bytes.Buffer{}
+			logger := slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: testCase.Input.(slog.Level)}))
+			client := gothreatmatrix.NewClient(
+				gothreatmatrix.WithURL(testServer.URL),
+				gothreatmatrix.WithToken("test-token"),
+				gothreatmatrix.WithLogger(logger),
+			)
+			if _, err := client.TagService.List(context.Background()); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if testCase.Want == nil {
+				testWantData(t, "", logs.String())
+				return
+			}
+			record := map[string]interface{}{}
+			if err := json.Unmarshal(logs.Bytes(), &record); err != nil {
+				t.Fatalf("Could not parse log record: %v", err)
+			}
+			testWantData(t, "TagService.List", record["operation"])
+			testWantData(t, "GET", record["method"])
+			testWantData(t, testServer.URL+constants.BASE_TAG_URL, record["url"])
+			testWantData(t, float64(http.StatusOK), record["status"])
+			testWantData(t, testCase.Want, record["body"])
+		})
+	}
+}
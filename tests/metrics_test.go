@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestClientMetrics(t *testing.T) {
+	calls := 0
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	})
+	apiHandler.HandleFunc("/api/tags/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"detail":"Not found."}`))
+	})
+
+	registry := prometheus.NewPedanticRegistry()
+	newClient := func() *gothreatmatrix.ThreatMatrixClient {
+		return gothreatmatrix.NewClient(
+			gothreatmatrix.WithURL(testServer.URL),
+			gothreatmatrix.WithToken("test-token"),
+			gothreatmatrix.WithRetry(2, time.Millisecond),
+			gothreatmatrix.WithMetricsRegisterer(registry),
+		)
+	}
+	// two clients sharing one registry must not clash
+	client, otherClient := newClient(), newClient()
+	ctx := context.Background()
+	if _, err := client.TagService.List(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_, _ = otherClient.TagService.Get(ctx, 1)
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	values := map[string]float64{}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := ""
+			for _, label := range metric.GetLabel() {
+				labels += label.GetName() + "=" + label.GetValue() + ","
+			}
+			switch {
+			case metric.GetCounter() != nil:
+				values[family.GetName()+"{"+labels+"}"] = metric.GetCounter().GetValue()
+			case metric.GetHistogram() != nil:
+				values[family.GetName()+"{"+labels+"}"] = float64(metric.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	want := map[string]float64{
+		"threatmatrix_client_requests_total{code=200,method=GET,operation=TagService.List,}":  1,
+		"threatmatrix_client_requests_total{code=404,method=GET,operation=TagService.Get,}":   1,
+		"threatmatrix_client_request_duration_seconds{method=GET,operation=TagService.List,}": 1,
+		"threatmatrix_client_request_duration_seconds{method=GET,operation=TagService.Get,}":  1,
+		"threatmatrix_client_errors_total{kind=client,operation=TagService.Get,}":             1,
+		"threatmatrix_client_retries_total{operation=TagService.List,}":                       1,
+	}
+	testWantData(t, want, values)
+}
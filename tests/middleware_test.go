@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestClientMiddleware(t *testing.T) {
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+		testWantData(t, "audit", r.Header.Get("X-Injected"))
+		_, _ = w.Write([]byte(`[]`))
+	})
+
+	order := []string{}
+	tracer := func(name string) gothreatmatrix.Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return gothreatmatrix.RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+				order = append(order, name+" request")
+				response, err := next.RoundTrip(request)
+				order = append(order, name+" response")
+				return response, err
+			})
+		}
+	}
+	statusCodes := []int{}
+	userHTTPClient := &http.Client{}
+	client := gothreatmatrix.NewClient(
+		gothreatmatrix.WithURL(testServer.URL),
+		gothreatmatrix.WithToken("test-token"),
+		gothreatmatrix.WithHTTPClient(userHTTPClient),
+		gothreatmatrix.WithMiddleware(
+			tracer("outer"),
+			tracer("inner"),
+			gothreatmatrix.OnRequest(func(request *http.Request) error {
+				request.Header.Set("X-Injected", "audit")
+				return nil
+			}),
+			gothreatmatrix.OnResponse(func(response *http.Response) error {
+				statusCodes = append(statusCodes, response.StatusCode)
+				return nil
+			}),
+		),
+	)
+	if _, err := client.TagService.List(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, []string{"outer request", "inner request", "inner response", "outer response"}, order)
+	testWantData(t, []int{http.StatusOK}, statusCodes)
+	if userHTTPClient.Transport != nil {
+		t.Fatalf("The user's http.Client was modified")
+	}
+}
+
+func TestClientMiddlewareAbortsRequest(t *testing.T) {
+	abortError := errors.New("blocked by policy")
+	client := gothreatmatrix.NewClient(
+		gothreatmatrix.WithURL("http://127.0.0.1:0"),
+		gothreatmatrix.WithMiddleware(gothreatmatrix.OnRequest(func(request *http.Request) error {
+			return abortError
+		})),
+	)
+	_, err := client.TagService.List(context.Background())
+	testWantData(t, true, errors.Is(err, abortError))
+}
@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// tagLabels stands for consumer code depending on the SDK through its service interfaces.
+func tagLabels(ctx context.Context, tagService gothreatmatrix.TagServiceInterface) ([]string, error) {
+	tags, err := tagService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	labels := []string{}
+	for _, tag := range *tags {
+		labels = append(labels, tag.Label)
+	}
+	return labels, nil
+}
+
+func TestMockTagService(t *testing.T) {
+	controller := gomock.NewController(t)
+	tagService := mocks.NewMockTagServiceInterface(controller)
+	tagService.EXPECT().List(gomock.Any()).Return(&[]gothreatmatrix.Tag{{ID: 1, Label: "phishing"}, {ID: 2, Label: "apt"}}, nil)
+	gottenLabels, err := tagLabels(context.Background(), tagService)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, []string{"phishing", "apt"}, gottenLabels)
+}
+
+func TestMockJobServiceOnClient(t *testing.T) {
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	apiHandler.Handle(constants.ANALYZE_OBSERVABLE_URL, serverHandler(t, TestData{
+		Data:       `{"job_id":260,"status":"accepted","warnings":[],"analyzers_running":["Classic_DNS"],"connectors_running":[]}`,
+		StatusCode: http.StatusOK,
+	}, "POST"))
+	controller := gomock.NewController(t)
+	jobService := mocks.NewMockJobServiceInterface(controller)
+	wantJob := &gothreatmatrix.Job{BaseJob: gothreatmatrix.BaseJob{ID: 260, Status: gothreatmatrix.StatusReportedWithoutFails}}
+	jobService.EXPECT().WaitForCompletion(gomock.Any(), uint64(260)).Return(wantJob, nil)
+	client := gothreatmatrix.NewClient(gothreatmatrix.WithURL(testServer.URL), gothreatmatrix.WithToken("test-token"))
+	client.JobService = jobService
+	gottenJob, err := client.AnalyzeService.AnalyzeObservableAndWait(context.Background(), gothreatmatrix.ObservableAnalysisRequest{Value: "8.8.8.8"}, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, wantJob, gottenJob)
+}
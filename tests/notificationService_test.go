@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestNotificationServiceList(t *testing.T) {
+	notification := gothreatmatrix.Notification{
+		ID:        3,
+		Title:     "New analyzer",
+		Body:      "Shodan_Honeyscore is now available",
+		BaseColor: "info",
+		CreatedAt: gothreatmatrix.Timestamp{Time: time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)},
+	}
+	notificationJson := `{"id":3,"title":"New analyzer","body":"Shodan_Honeyscore is now available","base_color":"info","read":false,"created_at":"2023-03-01T12:00:00Z"}`
+	testCases := make(map[string]TestData)
+	testCases["unread"] = TestData{
+		Input:      &gothreatmatrix.NotificationListParams{Unread: true},
+		Data:       `[` + notificationJson + `]`,
+		StatusCode: http.StatusOK,
+		Want:       []gothreatmatrix.Notification{notification},
+	}
+	testCases["paginated"] = TestData{
+		Input:      nil,
+		Data:       `{"count":1,"total_pages":1,"results":[` + notificationJson + `]}`,
+		StatusCode: http.StatusOK,
+		Want:       []gothreatmatrix.Notification{notification},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			params, _ := testCase.Input.(*gothreatmatrix.NotificationListParams)
+			apiHandler.HandleFunc(constants.BASE_NOTIFICATION_URL, func(w http.ResponseWriter, r *http.Request) {
+				if params != nil {
+					testWantData(t, "false", r.URL.Query().Get("read"))
+				} else {
+					testWantData(t, "page=1", r.URL.RawQuery)
+				}
+				serverHandler(t, testCase, "GET").ServeHTTP(w, r)
+			})
+			gottenNotifications, err := client.NotificationService.List(context.Background(), params)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenNotifications)
+		})
+	}
+}
+
+func TestNotificationServiceListPages(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	pages := map[string]string{
+		"1": `{"count":3,"total_pages":2,"results":[{"id":3,"title":"third"},{"id":2,"title":"second"}]}`,
+		"2": `{"count":3,"total_pages":2,"results":[{"id":1,"title":"first"}]}`,
+	}
+	apiHandler.HandleFunc(constants.BASE_NOTIFICATION_URL, func(w http.ResponseWriter, r *http.Request) {
+		testWantData(t, "false", r.URL.Query().Get("read"))
+		_, _ = w.Write([]byte(pages[r.URL.Query().Get("page")]))
+	})
+	notifications, err := client.NotificationService.List(context.Background(), &gothreatmatrix.NotificationListParams{Unread: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ids := []uint64{}
+	for _, notification := range notifications {
+		ids = append(ids, notification.ID)
+	}
+	testWantData(t, []uint64{3, 2, 1}, ids)
+}
+
+func TestNotificationServiceMarkRead(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.Handle(fmt.Sprintf(constants.MARK_READ_NOTIFICATION_URL, 3), serverHandler(t, TestData{StatusCode: http.StatusNoContent}, "POST"))
+	ctx := context.Background()
+	marked, err := client.NotificationService.MarkRead(ctx, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, true, marked)
+	if _, err := client.NotificationService.MarkRead(ctx, 0); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+}
@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestNewClient(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["defaults"] = TestData{
+		Input:      []gothreatmatrix.Option{gothreatmatrix.WithToken("test-token")},
+		Data:       `[]`,
+		StatusCode: http.StatusOK,
+		Want:       gothreatmatrix.DefaultUserAgent,
+	}
+	testCases["customUserAgent"] = TestData{
+		Input: []gothreatmatrix.Option{
+			gothreatmatrix.WithToken("test-token"),
+			gothreatmatrix.WithUserAgent("soc-pipeline/1.0"),
+			gothreatmatrix.WithHTTPClient(&http.Client{}),
+		},
+		Data:       `[]`,
+		StatusCode: http.StatusOK,
+		Want:       "soc-pipeline/1.0",
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := http.NewServeMux()
+			testServer := httptest.NewServer(apiHandler)
+			defer testServer.Close()
+			apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				testWantData(t, "token test-token", r.Header.Get("Authorization"))
+				testWantData(t, testCase.Want, r.Header.Get("User-Agent"))
+				w.WriteHeader(testCase.StatusCode)
+				_, _ = w.Write([]byte(testCase.Data))
+			})
+			opts, ok := testCase.Input.([]gothreatmatrix.Option)
+			if !ok {
+				t.Fatalf("Casting failed!")
+			}
+			client := gothreatmatrix.NewClient(append(opts, gothreatmatrix.WithURL(testServer.URL))...)
+			_, err := client.TagService.List(context.Background())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestOrganizationServiceGet(t *testing.T) {
+	orgRespJsonStr := `{"members_count":2,"owner":{"username":"hussain","full_name":"h k","joined":"2022-07-23T09:11:08.674294Z"},"is_user_owner":true,"created_at":"2022-07-23T09:11:08.580533Z","name":"StrawHats","members":[{"username":"hussain","full_name":"h k","joined":"2022-07-23T09:11:08Z","is_owner":true,"is_admin":true},{"username":"luffy","full_name":"m l","joined":"2022-08-01T10:00:00Z","is_owner":false,"is_admin":false}]}`
+	orgResponse := &gothreatmatrix.Organization{}
+	if unmarshalError := json.Unmarshal([]byte(orgRespJsonStr), &orgResponse); unmarshalError != nil {
+		t.Fatalf("Error: %s", unmarshalError)
+	}
+	testData := TestData{
+		Data:       orgRespJsonStr,
+		StatusCode: http.StatusOK,
+		Want:       orgResponse,
+	}
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.Handle(constants.ORGANIZATION_URL, serverHandler(t, testData, "GET"))
+	ctx := context.Background()
+	gottenOrganization, err := client.OrganizationService.Get(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, testData.Want, gottenOrganization)
+	members, err := client.OrganizationService.ListMembers(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, []gothreatmatrix.Member{
+		{Username: "hussain", FullName: "h k", Joined: time.Date(2022, 7, 23, 9, 11, 8, 0, time.UTC), IsOwner: true, IsAdmin: true},
+		{Username: "luffy", FullName: "m l", Joined: time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC)},
+	}, members)
+}
+
+func TestOrganizationServiceCreate(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["simple"] = TestData{
+		Input:      &gothreatmatrix.OrganizationParams{Name: "StrawHats"},
+		Data:       `{"members_count":1,"owner":{"username":"hussain","full_name":"h k","joined":"2022-07-23T09:11:08Z"},"is_user_owner":true,"name":"StrawHats"}`,
+		StatusCode: http.StatusCreated,
+		Want: &gothreatmatrix.Organization{
+			MembersCount: 1,
+			Owner:        gothreatmatrix.Owner{Username: "hussain", FullName: "h k", Joined: time.Date(2022, 7, 23, 9, 11, 8, 0, time.UTC)},
+			IsUserOwner:  true,
+			Name:         "StrawHats",
+		},
+	}
+	testCases["noName"] = TestData{
+		Input: &gothreatmatrix.OrganizationParams{},
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.Handle(constants.ORGANIZATION_URL, serverHandler(t, testCase, "POST"))
+			gottenOrganization, err := client.OrganizationService.Create(context.Background(), testCase.Input.(*gothreatmatrix.OrganizationParams))
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenOrganization)
+		})
+	}
+}
+
+func TestOrganizationServiceActions(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	gottenRequests := []string{}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gottenRequests = append(gottenRequests, r.Method+" "+r.URL.Path+" "+string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}
+	apiHandler.HandleFunc(constants.ORGANIZATION_URL, handler)
+	apiHandler.HandleFunc(constants.REMOVE_MEMBER_FROM_ORGANIZATION_URL, handler)
+	apiHandler.HandleFunc(constants.LEAVE_ORGANIZATION_URL, handler)
+	apiHandler.HandleFunc(fmt.Sprintf(constants.ACCEPT_INVITATION_URL, 3), handler)
+	apiHandler.HandleFunc(fmt.Sprintf(constants.DECLINE_INVITATION_URL, 4), handler)
+	ctx := context.Background()
+
+	actions := []func() (bool, error){
+		func() (bool, error) { return client.OrganizationService.RemoveMember(ctx, "luffy") },
+		func() (bool, error) { return client.OrganizationService.Leave(ctx) },
+		func() (bool, error) { return client.OrganizationService.Delete(ctx) },
+		func() (bool, error) { return client.InvitationService.Accept(ctx, 3) },
+		func() (bool, error) { return client.InvitationService.Decline(ctx, 4) },
+	}
+	for _, action := range actions {
+		done, err := action()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		testWantData(t, true, done)
+	}
+	testWantData(t, []string{
+		"POST /api/me/organization/remove_member {\"username\":\"luffy\"}",
+		"POST /api/me/organization/leave ",
+		"DELETE /api/me/organization ",
+		"POST /api/me/invitations/3/accept ",
+		"POST /api/me/invitations/4/decline ",
+	}, gottenRequests)
+
+	if _, err := client.OrganizationService.RemoveMember(ctx, ""); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Errorf("Expected a validation error got: %v", err)
+	}
+	if _, err := client.InvitationService.Accept(ctx, 0); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Errorf("Expected a validation error got: %v", err)
+	}
+}
+
+func TestInvitationServiceInviteAndList(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	inviteTestData := TestData{
+		Data:       `{"id":5,"created_at":"2022-07-24T10:00:00Z","status":"pending"}`,
+		StatusCode: http.StatusOK,
+	}
+	listTestData := TestData{
+		Data:       `[{"id":5,"created_at":"2022-07-24T10:00:00Z","status":"pending","organization":{"members_count":1,"owner":{"username":"hussain","full_name":"h k","joined":"2022-07-23T09:11:08Z"},"name":"StrawHats"}}]`,
+		StatusCode: http.StatusOK,
+	}
+	apiHandler.Handle(constants.INVITE_TO_ORGANIZATION_URL, serverHandler(t, inviteTestData, "POST"))
+	apiHandler.Handle(constants.INVITATIONS_URL, serverHandler(t, listTestData, "GET"))
+	ctx := context.Background()
+	invite := gothreatmatrix.Invite{Id: 5, CreatedAt: time.Date(2022, 7, 24, 10, 0, 0, 0, time.UTC), Status: "pending"}
+
+	gottenInvite, err := client.InvitationService.Invite(ctx, "luffy")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, &invite, gottenInvite)
+	gottenInvitations, err := client.InvitationService.List(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, []gothreatmatrix.Invitation{{
+		Invite: invite,
+		Organization: gothreatmatrix.Organization{
+			MembersCount: 1,
+			Owner:        gothreatmatrix.Owner{Username: "hussain", FullName: "h k", Joined: time.Date(2022, 7, 23, 9, 11, 8, 0, time.UTC)},
+			Name:         "StrawHats",
+		},
+	}}, gottenInvitations)
+}
+
+func TestOrganizationServicePlugins(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["disable"] = TestData{Input: "POST", StatusCode: http.StatusCreated, Want: true}
+	testCases["enable"] = TestData{Input: "DELETE", StatusCode: http.StatusNoContent, Want: true}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.Handle(fmt.Sprintf(constants.PLUGIN_ORGANIZATION_URL, "analyzer", "Shodan_Search"), serverHandler(t, testCase, testCase.Input.(string)))
+			var gotten bool
+			var err error
+			if testCase.Input == "POST" {
+				gotten, err = client.OrganizationService.DisablePlugin(context.Background(), gothreatmatrix.PluginTypeAnalyzer, "Shodan_Search")
+			} else {
+				gotten, err = client.OrganizationService.EnablePlugin(context.Background(), gothreatmatrix.PluginTypeAnalyzer, "Shodan_Search")
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gotten)
+		})
+	}
+	client, _, closeServer := setup()
+	defer closeServer()
+	if _, err := client.OrganizationService.DisablePlugin(context.Background(), "plugin", "Shodan_Search"); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+}
@@ -0,0 +1,243 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestJobServicePager(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.BASE_JOB_URL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testWantData(t, "2", r.URL.Query().Get("page_size"))
+		switch r.URL.Query().Get("page") {
+		case "1":
+			_, _ = w.Write([]byte(`{"count":3,"total_pages":2,"results":[{"id":1},{"id":2}]}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"count":3,"total_pages":2,"results":[{"id":3}]}`))
+		default:
+			t.Errorf("Unexpected page %s", r.URL.Query().Get("page"))
+		}
+	})
+	pager := client.JobService.Pager(context.Background(), &gothreatmatrix.JobListParams{PageSize: 2})
+	gottenPages := []int{}
+	gottenIds := []int{}
+	err := pager.EachPage(func(page *gothreatmatrix.Page[gothreatmatrix.JobList]) error {
+		gottenPages = append(gottenPages, page.Number)
+		for _, job := range page.Results {
+			gottenIds = append(gottenIds, job.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, []int{1, 2}, gottenPages)
+	testWantData(t, []int{1, 2, 3}, gottenIds)
+	testWantData(t, false, pager.HasNextPage())
+	if _, err := pager.NextPage(); !errors.Is(err, gothreatmatrix.ErrNoMorePages) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrNoMorePages, err)
+	}
+}
+
+func TestPagerEachPageStops(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.BASE_JOB_URL, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "1" {
+			t.Errorf("Only the first page should be fetched")
+		}
+		_, _ = w.Write([]byte(`{"count":4,"total_pages":2,"results":[{"id":1},{"id":2}]}`))
+	})
+	stop := errors.New("stop")
+	err := client.JobService.Pager(context.Background(), nil).EachPage(func(page *gothreatmatrix.Page[gothreatmatrix.JobList]) error {
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("Expected %v got: %v", stop, err)
+	}
+}
+
+func TestTagServicePager(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["plain list"] = TestData{
+		Input:      0,
+		Data:       `[{"id":1,"label":"apt","color":"#ff0000"},{"id":2,"label":"spam","color":"#00ff00"}]`,
+		StatusCode: http.StatusOK,
+		Want: []gothreatmatrix.Tag{
+			{ID: 1, Label: "apt", Color: "#ff0000"},
+			{ID: 2, Label: "spam", Color: "#00ff00"},
+		},
+	}
+	testCases["paginated without total pages"] = TestData{
+		Input:      2,
+		Data:       `{"count":2,"results":[{"id":1,"label":"apt","color":"#ff0000"},{"id":2,"label":"spam","color":"#00ff00"}]}`,
+		StatusCode: http.StatusOK,
+		Want: []gothreatmatrix.Tag{
+			{ID: 1, Label: "apt", Color: "#ff0000"},
+			{ID: 2, Label: "spam", Color: "#00ff00"},
+		},
+	}
+	testCases["unauthorized"] = TestData{
+		Input:      0,
+		Data:       `{"detail":"Invalid token."}`,
+		StatusCode: http.StatusUnauthorized,
+		Want:       gothreatmatrix.ErrUnauthorized,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			calls := 0
+			apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				serverHandler(t, testCase, "GET").ServeHTTP(w, r)
+			})
+			gottenTags, err := client.TagService.Pager(context.Background(), testCase.Input.(int)).AllPages()
+			if testCase.StatusCode < http.StatusOK || testCase.StatusCode >= http.StatusBadRequest {
+				if !errors.Is(err, testCase.Want.(error)) {
+					t.Fatalf("Expected %v got: %v", testCase.Want, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenTags)
+			testWantData(t, 1, calls)
+		})
+	}
+}
+
+func TestAnalyzerServicePager(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.BASE_ANALYZER_URL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			_, _ = w.Write([]byte(`{"count":2,"total_pages":2,"results":[{"name":"Classic_DNS"}]}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"count":2,"total_pages":2,"results":[{"name":"AbuseIPDB"}]}`))
+		}
+	})
+	analyzers, err := client.AnalyzerService.Pager(context.Background(), 1).AllPages()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 2, len(analyzers))
+	testWantData(t, "Classic_DNS", analyzers[0].Name)
+	testWantData(t, "AbuseIPDB", analyzers[1].Name)
+}
+
+func TestJobServiceListAll(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["concurrent"] = TestData{
+		Input:      `{"count":9,"total_pages":5,"results":[{"id":%d},{"id":%d}]}`,
+		StatusCode: http.StatusOK,
+		Want:       []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+	}
+	testCases["withoutTotalPages"] = TestData{
+		Input:      `{"count":9,"results":[{"id":%d},{"id":%d}]}`,
+		StatusCode: http.StatusOK,
+		Want:       []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+	}
+	testCases["failedPage"] = TestData{
+		Input:      `{"count":9,"total_pages":5,"results":[{"id":%d},{"id":%d}]}`,
+		StatusCode: http.StatusInternalServerError,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			mutex := sync.Mutex{}
+			inFlight, maxInFlight := 0, 0
+			apiHandler.HandleFunc(constants.BASE_JOB_URL, func(w http.ResponseWriter, r *http.Request) {
+				mutex.Lock()
+				inFlight++
+				maxInFlight = max(maxInFlight, inFlight)
+				mutex.Unlock()
+				time.Sleep(20 * time.Millisecond)
+				mutex.Lock()
+				inFlight--
+				mutex.Unlock()
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				if page == 3 && testCase.StatusCode != http.StatusOK {
+					w.WriteHeader(testCase.StatusCode)
+					_, _ = w.Write([]byte(`{"detail":"server error"}`))
+					return
+				}
+				_, _ = fmt.Fprintf(w, testCase.Input.(string), 2*page-1, 2*page)
+			})
+			jobs, err := client.JobService.ListAll(context.Background(), &gothreatmatrix.JobListParams{PageSize: 2}, gothreatmatrix.WithPageConcurrency(2))
+			if testCase.Want == nil {
+				if err == nil {
+					t.Fatalf("Expected an error, got %d jobs", len(jobs))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			gottenIds := []int{}
+			for _, job := range jobs {
+				gottenIds = append(gottenIds, job.ID)
+			}
+			testWantData(t, testCase.Want, gottenIds)
+			if maxInFlight > 2 {
+				t.Errorf("Expected at most 2 pages fetched at the same time, got %d", maxInFlight)
+			}
+		})
+	}
+}
+
+func TestJobServiceStream(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.BASE_JOB_URL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testWantData(t, "reported_without_fails", r.URL.Query().Get("status"))
+		switch r.URL.Query().Get("page") {
+		case "1":
+			_, _ = w.Write([]byte(`{"count":3,"total_pages":2,"next":"/api/jobs?page=2","results":[{"id":1,"observable_name":"8.8.8.8"},{"id":2}]}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"results":[{"id":3}],"count":3,"total_pages":2}`))
+		default:
+			t.Errorf("Unexpected page %s", r.URL.Query().Get("page"))
+		}
+	})
+	params := &gothreatmatrix.JobListParams{Status: gothreatmatrix.StatusReportedWithoutFails}
+	gottenIds := []int{}
+	err := client.JobService.Stream(context.Background(), params, func(job *gothreatmatrix.JobList) error {
+		gottenIds = append(gottenIds, job.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, []int{1, 2, 3}, gottenIds)
+
+	stop := errors.New("stop")
+	gottenIds = []int{}
+	err = client.JobService.Stream(context.Background(), params, func(job *gothreatmatrix.JobList) error {
+		gottenIds = append(gottenIds, job.ID)
+		if job.ID == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("Expected %v got: %v", stop, err)
+	}
+	testWantData(t, []int{1, 2}, gottenIds)
+}
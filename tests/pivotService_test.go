@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestPivotServiceList(t *testing.T) {
+	pivotJsonString := `{"name":"AbuseIpToSubmission","python_module":"compare.Compare","disabled":false,"description":"Pivot from an abused IP to its submissions","related_analyzer_configs":["AbuseIPDB"],"related_connector_configs":[],"playbooks_choice":["FREE_TO_USE_ANALYZERS"]}`
+	pivotConfig := gothreatmatrix.PivotConfig{}
+	if unmarshalError := json.Unmarshal([]byte(pivotJsonString), &pivotConfig); unmarshalError != nil {
+		t.Fatalf("Error: %s", unmarshalError)
+	}
+	testCases := make(map[string]TestData)
+	testCases["list"] = TestData{
+		Data:       "[" + pivotJsonString + "]",
+		StatusCode: http.StatusOK,
+		Want:       []gothreatmatrix.PivotConfig{pivotConfig},
+	}
+	testCases["paginated"] = TestData{
+		Data:       `{"count":1,"total_pages":1,"results":[` + pivotJsonString + "]}",
+		StatusCode: http.StatusOK,
+		Want:       []gothreatmatrix.PivotConfig{pivotConfig},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.Handle(constants.BASE_PIVOT_URL, serverHandler(t, testCase, "GET"))
+			gottenPivotConfigs, err := client.PivotService.List(context.Background())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenPivotConfigs)
+			testWantData(t, []string{"AbuseIPDB"}, gottenPivotConfigs[0].RelatedAnalyzerConfigs)
+		})
+	}
+}
+
+func TestPivotServiceGet(t *testing.T) {
+	pivotJsonString := `{"name":"AbuseIpToSubmission","disabled":true,"playbooks_choice":["FREE_TO_USE_ANALYZERS"]}`
+	pivotConfig := gothreatmatrix.PivotConfig{}
+	if unmarshalError := json.Unmarshal([]byte(pivotJsonString), &pivotConfig); unmarshalError != nil {
+		t.Fatalf("Error: %s", unmarshalError)
+	}
+	testCases := make(map[string]TestData)
+	testCases["simple"] = TestData{
+		Input:      "AbuseIpToSubmission",
+		Data:       pivotJsonString,
+		StatusCode: http.StatusOK,
+		Want:       &pivotConfig,
+	}
+	testCases["notFound"] = TestData{
+		Input:      "Missing",
+		Data:       `{"detail":"Not found."}`,
+		StatusCode: http.StatusNotFound,
+		Want:       gothreatmatrix.ErrNotFound,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			pivotName := testCase.Input.(string)
+			apiHandler.Handle(fmt.Sprintf(constants.SPECIFIC_PIVOT_URL, pivotName), serverHandler(t, testCase, "GET"))
+			gottenPivotConfig, err := client.PivotService.Get(context.Background(), pivotName)
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenPivotConfig)
+		})
+	}
+}
+
+// flattenPivotTree lists the job IDs of the tree depth first.
+func flattenPivotTree(node *gothreatmatrix.PivotNode) []int {
+	jobIds := []int{node.Job.ID}
+	for _, child := range node.Children {
+		jobIds = append(jobIds, flattenPivotTree(child)...)
+	}
+	return jobIds
+}
+
+func TestPivotServiceTree(t *testing.T) {
+	// job 1 pivots to 2 and 4, job 2 pivots to 3 and job 3 pivots back to 1
+	jobs := map[int]string{
+		1: `{"id":1,"pivot_reports":[{"name":"A","status":"SUCCESS","report":{"job_id":[2,4]}}]}`,
+		2: `{"id":2,"pivot_reports":[{"name":"B","status":"SUCCESS","report":{"job_id":3}}]}`,
+		3: `{"id":3,"pivot_reports":[{"name":"C","status":"SUCCESS","report":{"job_id":1}}]}`,
+		4: `{"id":4,"pivot_reports":[]}`,
+	}
+	testCases := make(map[string]TestData)
+	testCases["full"] = TestData{Input: 0, Want: []int{1, 2, 3, 4}}
+	testCases["oneLevel"] = TestData{Input: 1, Want: []int{1, 2, 4}}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			for jobId, jobJsonString := range jobs {
+				jobJsonString := jobJsonString
+				apiHandler.HandleFunc(fmt.Sprintf(constants.SPECIFIC_JOB_URL, jobId), func(w http.ResponseWriter, r *http.Request) {
+					testMethod(t, r, "GET")
+					_, _ = w.Write([]byte(jobJsonString))
+				})
+			}
+			tree, err := client.PivotService.Tree(context.Background(), 1, testCase.Input.(int))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, flattenPivotTree(tree))
+
+			children, err := client.PivotService.Children(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, 2, len(children))
+			testWantData(t, 4, children[1].ID)
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestPlaybookServiceAnalyzeObservable(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["simple"] = TestData{
+		Input: gothreatmatrix.PlaybookObservableAnalysisRequest{
+			Playbook:       "FREE_TO_USE_ANALYZERS",
+			Value:          "8.8.8.8",
+			Classification: "ip",
+			Tags:           []string{"dns"},
+		},
+		Data:       `{"count":1,"results":[{"job_id":500,"status":"accepted","warnings":[],"analyzers_running":["Classic_DNS"],"connectors_running":[]}]}`,
+		StatusCode: http.StatusOK,
+		Want: map[string]interface{}{
+			"playbook_requested":    "FREE_TO_USE_ANALYZERS",
+			"tlp":                   "WHITE",
+			"runtime_configuration": map[string]interface{}{},
+			"tags_labels":           []interface{}{"dns"},
+			"observables":           []interface{}{[]interface{}{"ip", "8.8.8.8"}},
+		},
+	}
+	testCases["noPlaybook"] = TestData{
+		Input: gothreatmatrix.PlaybookObservableAnalysisRequest{Value: "8.8.8.8"},
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	testCases["notFound"] = TestData{
+		Input:      gothreatmatrix.PlaybookObservableAnalysisRequest{Playbook: "MISSING", Value: "8.8.8.8"},
+		Data:       `{"detail":"Playbook MISSING does not exist."}`,
+		StatusCode: http.StatusBadRequest,
+		Want:       gothreatmatrix.ErrValidation,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			var gottenBody map[string]interface{}
+			apiHandler.HandleFunc(constants.PLAYBOOK_ANALYZE_MULTIPLE_OBSERVABLES_URL, func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&gottenBody); err != nil {
+					t.Errorf("Could not decode the request body: %v", err)
+				}
+				serverHandler(t, testCase, "POST").ServeHTTP(w, r)
+			})
+			analysisResponse, err := client.PlaybookService.AnalyzeObservable(context.Background(), testCase.Input.(gothreatmatrix.PlaybookObservableAnalysisRequest))
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, 500, analysisResponse.JobID)
+			testWantData(t, testCase.Want, gottenBody)
+		})
+	}
+}
+
+func TestPlaybookServiceAnalyzeFile(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.PLAYBOOK_ANALYZE_MULTIPLE_FILES_URL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("Could not parse the multipart form: %v", err)
+			return
+		}
+		testWantData(t, map[string][]string{
+			"playbook_requested":    {"Sample_Static_Analysis"},
+			"tlp":                   {"AMBER"},
+			"runtime_configuration": {"{}"},
+		}, r.MultipartForm.Value)
+		file, header, err := r.FormFile("files")
+		if err != nil {
+			t.Errorf("Could not get the file: %v", err)
+			return
+		}
+		defer file.Close()
+		content, _ := io.ReadAll(file)
+		testWantData(t, "sample.exe:MZ", header.Filename+":"+string(content))
+		_, _ = w.Write([]byte(`{"count":1,"results":[{"job_id":501,"status":"accepted","warnings":[],"analyzers_running":["File_Info"],"connectors_running":[]}]}`))
+	})
+	analysisResponse, err := client.PlaybookService.AnalyzeFile(context.Background(), gothreatmatrix.PlaybookFileAnalysisRequest{
+		Playbook: "Sample_Static_Analysis",
+		File:     strings.NewReader("MZ"),
+		FileName: "sample.exe",
+		TLP:      gothreatmatrix.AMBER,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 501, analysisResponse.JobID)
+}
+
+func TestPlaybookServiceList(t *testing.T) {
+	playbookJsonString := `{"name":"FREE_TO_USE_ANALYZERS","description":"A playbook containing all free to use analyzers.","type":["ip","url","domain","generic","hash","file"],"analyzers":["Classic_DNS","TorProject"],"connectors":[],"runtime_configuration":{"analyzers":{},"connectors":{}},"disabled":false,"tlp":"AMBER","owner":"admin","is_editable":false}`
+	playbookConfig := gothreatmatrix.PlaybookConfig{}
+	if unmarshalError := json.Unmarshal([]byte(playbookJsonString), &playbookConfig); unmarshalError != nil {
+		t.Fatalf("Error: %s", unmarshalError)
+	}
+	testData := TestData{
+		Data:       `{"count":1,"total_pages":1,"results":[` + playbookJsonString + "]}",
+		StatusCode: http.StatusOK,
+		Want:       []gothreatmatrix.PlaybookConfig{playbookConfig},
+	}
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.Handle(constants.BASE_PLAYBOOK_URL, serverHandler(t, testData, "GET"))
+	gottenPlaybooks, err := client.PlaybookService.List(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, testData.Want, gottenPlaybooks)
+	testWantData(t, []string{"Classic_DNS", "TorProject"}, gottenPlaybooks[0].Analyzers)
+}
+
+func TestPlaybookServiceGet(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["simple"] = TestData{
+		Input:      "DNS",
+		Data:       `{"name":"DNS","type":["domain"],"analyzers":["Classic_DNS"],"connectors":[]}`,
+		StatusCode: http.StatusOK,
+		Want: &gothreatmatrix.PlaybookConfig{
+			Name:       "DNS",
+			Type:       []string{"domain"},
+			Analyzers:  []string{"Classic_DNS"},
+			Connectors: []string{},
+		},
+	}
+	testCases["notFound"] = TestData{
+		Input:      "MISSING",
+		Data:       `{"detail":"Not found."}`,
+		StatusCode: http.StatusNotFound,
+		Want: &gothreatmatrix.ThreatMatrixError{
+			StatusCode: http.StatusNotFound,
+			Message:    `{"detail":"Not found."}`,
+			Detail:     "Not found.",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			playbookName := testCase.Input.(string)
+			apiHandler.Handle(fmt.Sprintf(constants.SPECIFIC_PLAYBOOK_URL, playbookName), serverHandler(t, testCase, "GET"))
+			gottenPlaybook, err := client.PlaybookService.Get(context.Background(), playbookName)
+			if err != nil {
+				testError(t, testCase, err)
+			} else {
+				testWantData(t, testCase.Want, gottenPlaybook)
+			}
+		})
+	}
+}
+
+func TestPlaybookServiceCreateAndUpdate(t *testing.T) {
+	playbookParams := gothreatmatrix.PlaybookParams{
+		Name:       "DNS",
+		Type:       []string{"domain"},
+		Analyzers:  []string{"Classic_DNS"},
+		Connectors: []string{},
+	}
+	playbookJsonString := `{"name":"DNS","description":"","type":["domain"],"analyzers":["Classic_DNS"],"connectors":[],"disabled":false}`
+	want := &gothreatmatrix.PlaybookConfig{
+		Name:       "DNS",
+		Type:       []string{"domain"},
+		Analyzers:  []string{"Classic_DNS"},
+		Connectors: []string{},
+	}
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	gottenBodies := map[string]string{}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gottenBodies[r.Method] = string(body)
+		_, _ = w.Write([]byte(playbookJsonString))
+	}
+	apiHandler.HandleFunc(constants.BASE_PLAYBOOK_URL, handler)
+	apiHandler.HandleFunc(fmt.Sprintf(constants.SPECIFIC_PLAYBOOK_URL, "DNS"), handler)
+	ctx := context.Background()
+
+	createdPlaybook, err := client.PlaybookService.Create(ctx, &playbookParams)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, want, createdPlaybook)
+	updatedPlaybook, err := client.PlaybookService.Update(ctx, "DNS", &playbookParams)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, want, updatedPlaybook)
+	testWantData(t, map[string]string{"POST": playbookJsonString, "PATCH": playbookJsonString}, gottenBodies)
+
+	invalidParams := []gothreatmatrix.PlaybookParams{
+		{Type: []string{"domain"}, Analyzers: []string{"Classic_DNS"}},
+		{Name: "DNS", Analyzers: []string{"Classic_DNS"}},
+		{Name: "DNS", Type: []string{"domain"}},
+	}
+	for _, params := range invalidParams {
+		params := params
+		if _, err := client.PlaybookService.Create(ctx, &params); !errors.Is(err, gothreatmatrix.ErrValidation) {
+			t.Errorf("Expected a validation error for %+v got: %v", params, err)
+		}
+	}
+}
+
+func TestPlaybookServiceDelete(t *testing.T) {
+	testData := TestData{
+		StatusCode: http.StatusNoContent,
+		Want:       true,
+	}
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.Handle(fmt.Sprintf(constants.SPECIFIC_PLAYBOOK_URL, "DNS"), serverHandler(t, testData, "DELETE"))
+	deleted, err := client.PlaybookService.Delete(context.Background(), "DNS")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, testData.Want, deleted)
+}
@@ -0,0 +1,194 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestPluginConfigServiceList(t *testing.T) {
+	pluginConfigJsonString := `{"id":7,"attribute":"api_key_name","value":"abc123","type":"str","is_secret":true,"for_organization":true,"owner":"hussain","organization":"khulnasoft","parameter":3,"analyzer_config":"VirusTotal_v3_Get_Observable"}`
+	testCases := make(map[string]TestData)
+	testCases["list"] = TestData{
+		Data:       "[" + pluginConfigJsonString + "]",
+		StatusCode: http.StatusOK,
+	}
+	testCases["paginated"] = TestData{
+		Data:       `{"count":1,"total_pages":1,"results":[` + pluginConfigJsonString + "]}",
+		StatusCode: http.StatusOK,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.Handle(constants.BASE_PLUGIN_CONFIG_URL, serverHandler(t, testCase, "GET"))
+			gottenPluginConfigs, err := client.PluginConfigService.List(context.Background())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, 1, len(gottenPluginConfigs))
+			pluginType, pluginName := gottenPluginConfigs[0].Plugin()
+			testWantData(t, gothreatmatrix.PluginTypeAnalyzer, pluginType)
+			testWantData(t, "VirusTotal_v3_Get_Observable", pluginName)
+			testWantData(t, "abc123", gottenPluginConfigs[0].Value)
+		})
+	}
+}
+
+func TestPluginConfigServiceListForPlugin(t *testing.T) {
+	testData := TestData{
+		Data:       `[{"id":8,"attribute":"url_key_name","value":"https://misp.local","type":"str","connector_config":"MISP"}]`,
+		StatusCode: http.StatusOK,
+	}
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.Handle(fmt.Sprintf(constants.PLUGIN_CONFIG_OF_PLUGIN_URL, "connector", "MISP"), serverHandler(t, testData, "GET"))
+	gottenPluginConfigs, err := client.PluginConfigService.ListForPlugin(context.Background(), gothreatmatrix.PluginTypeConnector, "MISP")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, "https://misp.local", gottenPluginConfigs[0].Value)
+	if _, err := client.PluginConfigService.ListForPlugin(context.Background(), "playground", "MISP"); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+}
+
+func TestPluginConfigServiceCreate(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["simple"] = TestData{
+		Input: []gothreatmatrix.PluginConfigParams{{
+			PluginType:      gothreatmatrix.PluginTypeAnalyzer,
+			PluginName:      "Shodan_Search",
+			Attribute:       "api_key_name",
+			Value:           "abc123",
+			ForOrganization: true,
+		}},
+		Data:       `[{"id":9,"attribute":"api_key_name","value":"abc123","is_secret":true,"for_organization":true,"analyzer_config":"Shodan_Search"}]`,
+		StatusCode: http.StatusCreated,
+		Want: []gothreatmatrix.PluginConfig{{
+			ID:              9,
+			Attribute:       "api_key_name",
+			Value:           "abc123",
+			IsSecret:        true,
+			ForOrganization: true,
+			Analyzer:        "Shodan_Search",
+		}},
+	}
+	testCases["empty"] = TestData{
+		Input: []gothreatmatrix.PluginConfigParams{},
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	testCases["noAttribute"] = TestData{
+		Input: []gothreatmatrix.PluginConfigParams{{PluginType: gothreatmatrix.PluginTypeAnalyzer, PluginName: "Shodan_Search"}},
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.HandleFunc(constants.BASE_PLUGIN_CONFIG_URL, func(w http.ResponseWriter, r *http.Request) {
+				gottenParams := []map[string]interface{}{}
+				if err := json.NewDecoder(r.Body).Decode(&gottenParams); err != nil {
+					t.Errorf("Could not decode the request body: %v", err)
+				}
+				testWantData(t, []map[string]interface{}{{
+					"analyzer_config":  "Shodan_Search",
+					"attribute":        "api_key_name",
+					"value":            "abc123",
+					"for_organization": true,
+				}}, gottenParams)
+				serverHandler(t, testCase, "POST").ServeHTTP(w, r)
+			})
+			gottenPluginConfigs, err := client.PluginConfigService.Create(context.Background(), testCase.Input.([]gothreatmatrix.PluginConfigParams))
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenPluginConfigs)
+		})
+	}
+}
+
+func TestPluginConfigServiceUpdateDelete(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(fmt.Sprintf(constants.SPECIFIC_PLUGIN_CONFIG_URL, 9), func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PATCH":
+			gottenParams := map[string]interface{}{}
+			if err := json.NewDecoder(r.Body).Decode(&gottenParams); err != nil {
+				t.Errorf("Could not decode the request body: %v", err)
+			}
+			testWantData(t, map[string]interface{}{"value": "def456"}, gottenParams)
+			_, _ = w.Write([]byte(`{"id":9,"attribute":"api_key_name","value":"def456","is_secret":true}`))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method: %s", r.Method)
+		}
+	})
+	updated, err := client.PluginConfigService.Update(context.Background(), 9, "def456")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, "def456", updated.Value)
+	deleted, err := client.PluginConfigService.Delete(context.Background(), 9)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, true, deleted)
+	if _, err := client.PluginConfigService.Delete(context.Background(), 0); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+}
+
+func TestPluginConfigSecretsNeverLogged(t *testing.T) {
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	apiHandler.HandleFunc(constants.BASE_PLUGIN_CONFIG_URL, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`[{"id":9,"attribute":"api_key_name","value":"super-secret-key","is_secret":true,"analyzer_config":"Shodan_Search"}]`))
+	})
+	logs := &bytes.Buffer{}
+	dump := &bytes.Buffer{}
+	client := gothreatmatrix.NewClient(
+		gothreatmatrix.WithURL(testServer.URL),
+		gothreatmatrix.WithToken("test-token"),
+		gothreatmatrix.WithLogger(slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		gothreatmatrix.WithDebug(dump),
+	)
+	pluginConfigs, err := client.PluginConfigService.Create(context.Background(), []gothreatmatrix.PluginConfigParams{{
+		PluginType: gothreatmatrix.PluginTypeAnalyzer,
+		PluginName: "Shodan_Search",
+		Attribute:  "api_key_name",
+		Value:      "super-secret-key",
+	}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	slog.New(slog.NewJSONHandler(logs, nil)).Info("created", "plugin_config", pluginConfigs[0])
+	for name, output := range map[string]string{"logs": logs.String(), "dump": dump.String()} {
+		if strings.Contains(output, "super-secret-key") {
+			t.Errorf("The secret leaked into the %s: %s", name, output)
+		}
+		if !strings.Contains(output, "(redacted)") {
+			t.Errorf("Expected the bodies to be redacted in the %s: %s", name, output)
+		}
+	}
+}
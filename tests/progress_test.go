@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// progressRecorder records the calls made to a WithProgress callback.
+type progressRecorder struct {
+	mutex sync.Mutex
+	calls [][2]int64
+}
+
+func (recorder *progressRecorder) record(sent int64, total int64) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.calls = append(recorder.calls, [2]int64{sent, total})
+}
+
+func (recorder *progressRecorder) last(t *testing.T) [2]int64 {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	if len(recorder.calls) == 0 {
+		t.Fatalf("the progress was never reported")
+	}
+	for index := 1; index < len(recorder.calls); index++ {
+		if recorder.calls[index][0] < recorder.calls[index-1][0] {
+			t.Errorf("the progress went backwards: %v", recorder.calls)
+		}
+	}
+	return recorder.calls[len(recorder.calls)-1]
+}
+
+func TestWithProgressUpload(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.ANALYZE_FILE_URL, func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			return
+		}
+		_, _ = io.Copy(io.Discard, file)
+		_, _ = w.Write([]byte(`{"job_id":1,"status":"accepted"}`))
+	})
+	ctx := context.Background()
+	sample := strings.Repeat("A", 256*1024)
+
+	recorder := &progressRecorder{}
+	request := gothreatmatrix.FileAnalysisRequest{File: strings.NewReader(sample), FileName: "memory.dmp"}
+	if _, err := client.AnalyzeService.AnalyzeFile(ctx, request, gothreatmatrix.WithProgress(recorder.record)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, [2]int64{int64(len(sample)), int64(len(sample))}, recorder.last(t))
+
+	// the size of a plain reader isn't known
+	recorder = &progressRecorder{}
+	request = gothreatmatrix.FileAnalysisRequest{File: io.MultiReader(strings.NewReader(sample)), FileName: "memory.dmp"}
+	if _, err := client.AnalyzeService.AnalyzeFile(ctx, request, gothreatmatrix.WithProgress(recorder.record)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, [2]int64{int64(len(sample)), -1}, recorder.last(t))
+}
+
+func TestWithProgressDownload(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	sample := bytes.Repeat([]byte("MZ"), 64*1024)
+	apiHandler.HandleFunc(fmt.Sprintf(constants.DOWNLOAD_SAMPLE_JOB_URL, 1), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", fmt.Sprint(len(sample)))
+		_, _ = w.Write(sample)
+	})
+	ctx := context.Background()
+	want := [2]int64{int64(len(sample)), int64(len(sample))}
+
+	recorder := &progressRecorder{}
+	downloaded, err := client.JobService.DownloadSample(ctx, 1, gothreatmatrix.WithProgress(recorder.record))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, sample, downloaded)
+	testWantData(t, want, recorder.last(t))
+
+	recorder = &progressRecorder{}
+	if _, err := client.JobService.DownloadSampleTo(ctx, 1, io.Discard, gothreatmatrix.WithProgress(recorder.record)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, want, recorder.last(t))
+
+	// the other methods don't report anything
+	recorder = &progressRecorder{}
+	apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+	if _, err := client.TagService.List(ctx, gothreatmatrix.WithProgress(recorder.record)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 0, len(recorder.calls))
+}
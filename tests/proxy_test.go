@@ -0,0 +1,146 @@
+package tests
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// socks5Server runs a minimal SOCKS5 proxy without authentication, reporting the addresses it connected to.
+func socks5Server(t *testing.T) (string, func() []string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	mutex := sync.Mutex{}
+	targets := []string{}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				// greeting: version, number of methods, methods
+				header := make([]byte, 2)
+				if _, err := io.ReadFull(conn, header); err != nil {
+					return
+				}
+				if _, err := io.ReadFull(conn, make([]byte, header[1])); err != nil {
+					return
+				}
+				_, _ = conn.Write([]byte{5, 0})
+				// request: version, command, reserved, address type, address, port
+				request := make([]byte, 4)
+				if _, err := io.ReadFull(conn, request); err != nil {
+					return
+				}
+				var host string
+				switch request[3] {
+				case 1:
+					address := make([]byte, 4)
+					_, _ = io.ReadFull(conn, address)
+					host = net.IP(address).String()
+				case 3:
+					length := make([]byte, 1)
+					_, _ = io.ReadFull(conn, length)
+					address := make([]byte, length[0])
+					_, _ = io.ReadFull(conn, address)
+					host = string(address)
+				default:
+					return
+				}
+				port := make([]byte, 2)
+				_, _ = io.ReadFull(conn, port)
+				target := net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port))))
+				mutex.Lock()
+				targets = append(targets, target)
+				mutex.Unlock()
+				upstream, err := net.Dial("tcp", target)
+				if err != nil {
+					_, _ = conn.Write([]byte{5, 1, 0, 1, 0, 0, 0, 0, 0, 0})
+					return
+				}
+				defer upstream.Close()
+				_, _ = conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+				go func() { _, _ = io.Copy(upstream, conn) }()
+				_, _ = io.Copy(conn, upstream)
+			}()
+		}
+	}()
+	return "socks5://" + listener.Addr().String(), func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]string{}, targets...)
+	}
+}
+
+func TestWithProxyHTTP(t *testing.T) {
+	proxiedHosts := []string{}
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// a forward proxy gets the absolute URL of the request
+		proxiedHosts = append(proxiedHosts, r.URL.Host)
+		testWantData(t, constants.BASE_TAG_URL, r.URL.Path)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer proxyServer.Close()
+	client := gothreatmatrix.NewClient(
+		gothreatmatrix.WithURL("http://threatmatrix.internal"),
+		gothreatmatrix.WithToken("test-token"),
+		gothreatmatrix.WithProxy(proxyServer.URL),
+	)
+	if _, err := client.TagService.List(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, []string{"threatmatrix.internal"}, proxiedHosts)
+}
+
+func TestWithProxySOCKS5(t *testing.T) {
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	apiHandler.Handle(constants.BASE_TAG_URL, serverHandler(t, TestData{Data: `[]`, StatusCode: http.StatusOK}, "GET"))
+	proxyUrl, targets := socks5Server(t)
+	client := gothreatmatrix.NewClient(
+		gothreatmatrix.WithURL(testServer.URL),
+		gothreatmatrix.WithToken("test-token"),
+		gothreatmatrix.WithProxy(proxyUrl),
+	)
+	if _, err := client.TagService.List(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, []string{testServer.Listener.Addr().String()}, targets())
+}
+
+func TestWithProxyInvalid(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["scheme"] = TestData{Input: "ftp://proxy.corp:21"}
+	testCases["noHost"] = TestData{Input: "http://"}
+	testCases["unparsable"] = TestData{Input: "http://proxy corp:%zz"}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := gothreatmatrix.NewClient(gothreatmatrix.WithURL("http://threatmatrix.internal"), gothreatmatrix.WithProxy(testCase.Input.(string)))
+			if _, err := client.TagService.List(context.Background()); !errors.Is(err, gothreatmatrix.ErrValidation) {
+				t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+			}
+			t.Setenv(gothreatmatrix.EnvURL, "http://threatmatrix.internal")
+			t.Setenv(gothreatmatrix.EnvAPIKey, "test-token")
+			t.Setenv(gothreatmatrix.EnvProxy, testCase.Input.(string))
+			if _, err := gothreatmatrix.NewClientFromEnv(); !errors.Is(err, gothreatmatrix.ErrValidation) {
+				t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestJobQueryReports(t *testing.T) {
+	jobJsonString := `{"id":1,"status":"reported_without_fails","analyzer_reports":[
+		{"name":"VirusTotal_v3_Get_Observable","status":"SUCCESS","report":{"data":{"attributes":{"last_analysis_stats":{"harmless":80,"malicious":2},"tags":["dns","google"]}}}},
+		{"name":"Classic_DNS","status":"SUCCESS","report":{"observable":"dns.google","resolutions":[{"data":"8.8.8.8","TTL":300},{"data":"8.8.4.4","TTL":"300"}],"www.example":true}}
+	],"connector_reports":[{"name":"MISP","status":"SUCCESS","report":{"event_id":"1337"}}]}`
+	job := gothreatmatrix.Job{}
+	if err := json.Unmarshal([]byte(jobJsonString), &job); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	testCases := make(map[string]TestData)
+	testCases["nestedNumber"] = TestData{
+		Input: "VirusTotal_v3_Get_Observable.data.attributes.last_analysis_stats.malicious",
+		Want:  []interface{}{true, "2", 2},
+	}
+	testCases["arrayIndex"] = TestData{
+		Input: "Classic_DNS.resolutions.1.data",
+		Want:  []interface{}{true, "8.8.4.4", 0},
+	}
+	testCases["arrayLength"] = TestData{
+		Input: "Classic_DNS.resolutions.#",
+		Want:  []interface{}{true, "2", 2},
+	}
+	testCases["arrayMap"] = TestData{
+		Input: "Classic_DNS.resolutions.#.TTL",
+		Want:  []interface{}{true, `[300,"300"]`, 0},
+	}
+	testCases["escapedDot"] = TestData{
+		Input: `Classic_DNS.www\.example`,
+		Want:  []interface{}{true, "true", 0},
+	}
+	testCases["connector"] = TestData{
+		Input: "MISP.event_id",
+		Want:  []interface{}{true, "1337", 1337},
+	}
+	testCases["missingKey"] = TestData{
+		Input: "VirusTotal_v3_Get_Observable.data.attributes.reputation",
+		Want:  []interface{}{false, "", 0},
+	}
+	testCases["outOfRange"] = TestData{
+		Input: "Classic_DNS.resolutions.2.data",
+		Want:  []interface{}{false, "", 0},
+	}
+	testCases["missingPlugin"] = TestData{
+		Input: "Shodan.ports",
+		Want:  []interface{}{false, "", 0},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result := job.QueryReports(testCase.Input.(string))
+			want := testCase.Want.([]interface{})
+			testWantData(t, want[0], result.Exists)
+			testWantData(t, want[1], result.String())
+			testWantData(t, want[2], result.Int())
+		})
+	}
+
+	tags := job.QueryReports("VirusTotal_v3_Get_Observable.data.attributes.tags").Array()
+	testWantData(t, 2, len(tags))
+	testWantData(t, "google", tags[1].String())
+	testWantData(t, true, job.QueryReports(`Classic_DNS.www\.example`).Bool())
+}
@@ -0,0 +1,215 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestQueue(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	mutex := sync.Mutex{}
+	reachable := false
+	idempotencyKeys := map[string][]string{}
+	apiHandler.HandleFunc(constants.ANALYZE_OBSERVABLE_URL, func(w http.ResponseWriter, r *http.Request) {
+		params := gothreatmatrix.ObservableAnalysisParams{}
+		_ = json.NewDecoder(r.Body).Decode(&params)
+		mutex.Lock()
+		defer mutex.Unlock()
+		idempotencyKeys[params.ObservableName] = append(idempotencyKeys[params.ObservableName], r.Header.Get(gothreatmatrix.IdempotencyKeyHeader))
+		switch {
+		case !reachable:
+			w.WriteHeader(http.StatusBadGateway)
+		case params.ObservableName == "invalid.com":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"detail":"invalid observable"}`))
+		default:
+			_, _ = w.Write([]byte(`{"job_id":1,"status":"accepted"}`))
+		}
+	})
+	apiHandler.HandleFunc(constants.ANALYZE_FILE_URL, func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			return
+		}
+		content, _ := io.ReadAll(file)
+		testWantData(t, "sample.txt", header.Filename)
+		testWantData(t, "malicious content", string(content))
+		_, _ = w.Write([]byte(`{"job_id":2,"status":"accepted"}`))
+	})
+	directory := filepath.Join(t.TempDir(), "queue")
+	submitter := gothreatmatrix.NewSubmitter(&client, &gothreatmatrix.SubmitterOptions{Concurrency: 1, MaxAttempts: 2, RetryDelay: time.Millisecond})
+	queue, err := gothreatmatrix.OpenQueue(directory, submitter)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, submission := range []gothreatmatrix.Submission{
+		{Observable: &gothreatmatrix.ObservableAnalysisRequest{Value: "8.8.8.8"}},
+		{Observable: &gothreatmatrix.ObservableAnalysisRequest{Value: "invalid.com"}},
+		{File: &gothreatmatrix.FileAnalysisRequest{File: strings.NewReader("malicious content"), FileName: "sample.txt"}},
+	} {
+		if err := queue.Enqueue(submission); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if err := queue.Enqueue(gothreatmatrix.Submission{}); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+	ctx := context.Background()
+
+	// ThreatMatrix can't be reached: the flush stops at the first submission and keeps them all
+	results, err := queue.Flush(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) == 0 || len(results) == 3 {
+		t.Fatalf("Expected the flush to stop early got %d results", len(results))
+	}
+	if !errors.Is(results[0].Err, gothreatmatrix.ErrServer) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrServer, results[0].Err)
+	}
+	pending, _ := queue.Len()
+	testWantData(t, 3, pending)
+
+	// the journal survives a restart of the process
+	queue, err = gothreatmatrix.OpenQueue(directory, submitter)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	mutex.Lock()
+	reachable = true
+	mutex.Unlock()
+	results, err = queue.Flush(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 3, len(results))
+	pending, _ = queue.Len()
+	testWantData(t, 0, pending)
+	// every attempt at sending 8.8.8.8 carried the same key
+	mutex.Lock()
+	keys := idempotencyKeys["8.8.8.8"]
+	testWantData(t, keys[0], keys[len(keys)-1])
+	mutex.Unlock()
+
+	rejected, _ := filepath.Glob(filepath.Join(directory, "rejected", "*.error"))
+	testWantData(t, 1, len(rejected))
+	rejection, _ := os.ReadFile(rejected[0])
+	if !strings.Contains(string(rejection), "invalid observable") {
+		t.Errorf("Expected the rejection to be journaled got: %s", rejection)
+	}
+}
+
+func TestQueueSharedIdempotencyKey(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	mutex := sync.Mutex{}
+	contents := []string{}
+	apiHandler.HandleFunc(constants.ANALYZE_FILE_URL, func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			return
+		}
+		content, _ := io.ReadAll(file)
+		mutex.Lock()
+		contents = append(contents, string(content))
+		mutex.Unlock()
+		_, _ = w.Write([]byte(`{"job_id":2,"status":"accepted"}`))
+	})
+	directory := t.TempDir()
+	submitter := gothreatmatrix.NewSubmitter(&client, &gothreatmatrix.SubmitterOptions{Concurrency: 2, MaxAttempts: 1})
+	queue, err := gothreatmatrix.OpenQueue(directory, submitter)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// the same submission enqueued twice, such as after a timeout, keeps its key
+	for i := 0; i < 2; i++ {
+		submission := gothreatmatrix.Submission{
+			File:           &gothreatmatrix.FileAnalysisRequest{File: strings.NewReader("malicious content"), FileName: "sample.txt"},
+			IdempotencyKey: "incident-42",
+		}
+		if err := queue.Enqueue(submission); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	results, err := queue.Flush(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 2, len(results))
+	for _, result := range results {
+		if result.Err != nil {
+			t.Fatalf("Unexpected error: %v", result.Err)
+		}
+	}
+	testWantData(t, []string{"malicious content", "malicious content"}, contents)
+	// both entries are removed from the journal rather than one of them being sent again on every flush
+	pending, _ := queue.Len()
+	testWantData(t, 0, pending)
+}
+
+func TestOpenQueueCleansUp(t *testing.T) {
+	client, _, closeServer := setup()
+	defer closeServer()
+	directory := t.TempDir()
+	for _, name := range []string{"1-a.json.tmp", "2-b.sample"} {
+		if err := os.WriteFile(filepath.Join(directory, name), []byte("partial"), 0o600); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if _, err := gothreatmatrix.OpenQueue(directory, gothreatmatrix.NewSubmitter(&client, nil)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	leftovers, _ := filepath.Glob(filepath.Join(directory, "*.*"))
+	testWantData(t, 0, len(leftovers))
+}
+
+func TestQueueKeepsEntriesOnAuthFailure(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.ANALYZE_OBSERVABLE_URL, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"detail":"Invalid token."}`))
+	})
+	directory := t.TempDir()
+	submitter := gothreatmatrix.NewSubmitter(&client, &gothreatmatrix.SubmitterOptions{Concurrency: 1, MaxAttempts: 1})
+	queue, err := gothreatmatrix.OpenQueue(directory, submitter)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// the key is up to the caller, it can't escape the journal
+	submission := gothreatmatrix.Submission{Observable: &gothreatmatrix.ObservableAnalysisRequest{Value: "8.8.8.8"}, IdempotencyKey: "../../escape"}
+	if err := queue.Enqueue(submission); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	entries, _ := filepath.Glob(filepath.Join(directory, "*.json"))
+	testWantData(t, 1, len(entries))
+
+	results, err := queue.Flush(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 1, len(results))
+	if !errors.Is(results[0].Err, gothreatmatrix.ErrUnauthorized) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrUnauthorized, results[0].Err)
+	}
+	// a refused token isn't the fault of the submission, it's sent again on the next flush
+	pending, _ := queue.Len()
+	testWantData(t, 1, pending)
+	rejected, _ := filepath.Glob(filepath.Join(directory, "rejected", "*"))
+	testWantData(t, 0, len(rejected))
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestClientRetryAfter(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["honorsRetryAfter"] = TestData{
+		Input:      "0",
+		StatusCode: http.StatusOK,
+		Want:       2,
+	}
+	testCases["retryAfterPastDeadline"] = TestData{
+		Input:      "120",
+		StatusCode: http.StatusTooManyRequests,
+		Want:       1,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			apiHandler := http.NewServeMux()
+			testServer := httptest.NewServer(apiHandler)
+			defer testServer.Close()
+			apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "POST")
+				calls++
+				if calls == 1 {
+					w.Header().Set("Retry-After", testCase.Input.(string))
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				_, _ = w.Write([]byte(`{"id":1,"label":"throttled","color":"#ffffff"}`))
+			})
+			client := gothreatmatrix.NewClient(
+				gothreatmatrix.WithURL(testServer.URL),
+				gothreatmatrix.WithToken("test-token"),
+			)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err := client.TagService.Create(ctx, &gothreatmatrix.TagParams{Label: "throttled", Color: "#ffffff"})
+			if testCase.StatusCode == http.StatusOK && err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if testCase.StatusCode != http.StatusOK {
+				threatMatrixError, ok := err.(*gothreatmatrix.ThreatMatrixError)
+				if !ok {
+					t.Fatalf("Expected a ThreatMatrixError got: %v", err)
+				}
+				testWantData(t, testCase.StatusCode, threatMatrixError.StatusCode)
+			}
+			testWantData(t, testCase.Want, calls)
+		})
+	}
+}
+
+func TestClientRateLimit(t *testing.T) {
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+	client := gothreatmatrix.NewClient(
+		gothreatmatrix.WithURL(testServer.URL),
+		gothreatmatrix.WithToken("test-token"),
+		gothreatmatrix.WithRateLimit(20, 1),
+	)
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.TagService.List(ctx); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	// the first request uses the burst token, the other two wait 50ms each
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("Requests were not throttled, took %v", elapsed)
+	}
+}
@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+type classicDNSReport struct {
+	Observable  string `json:"observable"`
+	Resolutions []struct {
+		Data string `json:"data"`
+		TTL  int    `json:"TTL"`
+	} `json:"resolutions"`
+}
+
+func TestReportDecode(t *testing.T) {
+	jobJsonString := `{"id":1,"status":"reported_without_fails","analyzer_reports":[
+		{"name":"AbuseIPDB","status":"SUCCESS","report":{"data":{"ipAddress":"8.8.8.8","abuseConfidenceScore":12,"countryCode":"US","totalReports":3}}},
+		{"name":"VirusTotal_v3_Get_Observable","status":"SUCCESS","report":{"data":{"id":"8.8.8.8","type":"ip_address","attributes":{"last_analysis_stats":{"harmless":80,"malicious":2},"reputation":5}},"link":"https://www.virustotal.com/gui/ip-address/8.8.8.8"}},
+		{"name":"Classic_DNS","status":"SUCCESS","report":{"observable":"dns.google","resolutions":[{"data":"8.8.8.8","TTL":300}]}},
+		{"name":"Unknown_Analyzer","status":"SUCCESS","report":{"value":1}}
+	]}`
+	job := gothreatmatrix.Job{}
+	if err := json.Unmarshal([]byte(jobJsonString), &job); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	gothreatmatrix.RegisterReportType("Classic_DNS", &classicDNSReport{})
+
+	abuseIPDBReport, ok := job.AnalyzerReport("AbuseIPDB")
+	if !ok {
+		t.Fatalf("Expected the AbuseIPDB report")
+	}
+	decoded, err := abuseIPDBReport.Decode()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	abuseIPDB := decoded.(*gothreatmatrix.AbuseIPDBReport)
+	testWantData(t, "8.8.8.8", abuseIPDB.Data.IPAddress)
+	testWantData(t, 12, abuseIPDB.Data.AbuseConfidenceScore)
+	testWantData(t, 3, abuseIPDB.Data.TotalReports)
+
+	virusTotalReport, _ := job.AnalyzerReport("VirusTotal_v3_Get_Observable")
+	decoded, err = virusTotalReport.Decode()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	virusTotal := decoded.(*gothreatmatrix.VirusTotalReport)
+	testWantData(t, gothreatmatrix.VirusTotalAnalysisStats{Harmless: 80, Malicious: 2}, virusTotal.Data.Attributes.LastAnalysisStats)
+
+	dnsReport, _ := job.AnalyzerReport("Classic_DNS")
+	decoded, err = dnsReport.Decode()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, "8.8.8.8", decoded.(*classicDNSReport).Resolutions[0].Data)
+
+	unknownReport, _ := job.AnalyzerReport("Unknown_Analyzer")
+	if _, err := unknownReport.Decode(); !errors.Is(err, gothreatmatrix.ErrReportNotRegistered) {
+		t.Fatalf("Expected ErrReportNotRegistered got: %v", err)
+	}
+	unknown := struct {
+		Value int `json:"value"`
+	}{}
+	if err := unknownReport.DecodeInto(&unknown); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 1, unknown.Value)
+
+	if _, ok := job.AnalyzerReport("Missing"); ok {
+		t.Fatalf("Expected no report for a missing analyzer")
+	}
+}
+
+func TestJobReportHelpers(t *testing.T) {
+	jobJsonString := `{"id":1,"status":"reported_with_fails","analyzer_reports":[
+		{"name":"Classic_DNS","status":"SUCCESS","report":{"observable":"dns.google"},"errors":[]},
+		{"name":"AbuseIPDB","status":"FAILED","report":{},"errors":["Invalid API key","quota exceeded"]},
+		{"name":"Shodan","status":"failed","report":{},"errors":["timeout"]},
+		{"name":"Tor","status":"KILLED","report":{},"errors":[]}
+	],"connector_reports":[
+		{"name":"MISP","status":"FAILED","report":{},"errors":["unreachable"]},
+		{"name":"OpenCTI","status":"SUCCESS","report":{"id":"1"},"errors":[]}
+	]}`
+	job := gothreatmatrix.Job{}
+	if err := json.Unmarshal([]byte(jobJsonString), &job); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	testWantData(t, []string{"AbuseIPDB", "Shodan"}, job.FailedAnalyzers())
+	testWantData(t, []string{"MISP"}, job.FailedConnectors())
+	successfulReports := job.SuccessfulReports()
+	testWantData(t, 1, len(successfulReports))
+	testWantData(t, "Classic_DNS", successfulReports[0].Name)
+	testWantData(t, "AbuseIPDB: Invalid API key; quota exceeded\nShodan: timeout\nMISP: unreachable", job.ErrorsSummary())
+
+	testCases := make(map[string]TestData)
+	testCases["analyzer"] = TestData{Input: "Tor", Want: gothreatmatrix.ReportStatusKilled}
+	testCases["connector"] = TestData{Input: "OpenCTI", Want: gothreatmatrix.ReportStatusSuccess}
+	testCases["missing"] = TestData{Input: "Missing", Want: ""}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			report, ok := job.ReportByName(testCase.Input.(string))
+			testWantData(t, testCase.Want != "", ok)
+			if ok {
+				testWantData(t, testCase.Want, report.Status)
+			}
+		})
+	}
+
+	testWantData(t, "", (&gothreatmatrix.Job{}).ErrorsSummary())
+	testWantData(t, []string{}, (&gothreatmatrix.Job{}).FailedAnalyzers())
+}
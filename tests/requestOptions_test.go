@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestRequestOptionsHeaderAndQuery(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.BASE_JOB_URL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testWantData(t, "running", r.URL.Query().Get("status"))
+		testWantData(t, []string{"a", "b"}, r.URL.Query()["custom"])
+		testWantData(t, "incident-42", r.Header.Get("X-Correlation-Id"))
+		testWantData(t, "soc-pipeline/1.0", r.Header.Get("User-Agent"))
+		_, _ = w.Write([]byte(`{"count":0,"total_pages":1,"results":[]}`))
+	})
+	_, err := client.JobService.List(context.Background(), &gothreatmatrix.JobListParams{Status: gothreatmatrix.StatusRunning},
+		gothreatmatrix.WithHeader("X-Correlation-Id", "incident-42"),
+		gothreatmatrix.WithHeader("User-Agent", "soc-pipeline/1.0"),
+		gothreatmatrix.WithQueryParam("custom", "a"),
+		gothreatmatrix.WithQueryParam("custom", "b"),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// the options don't leak to the next calls
+	apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+		testWantData(t, "", r.Header.Get("X-Correlation-Id"))
+		testWantData(t, "", r.URL.RawQuery)
+		_, _ = w.Write([]byte(`[]`))
+	})
+	if _, err := client.TagService.List(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestRequestOptionsInherited(t *testing.T) {
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	checkHeader := func(w http.ResponseWriter, r *http.Request) {
+		testWantData(t, "incident-42", r.Header.Get("X-Correlation-Id"))
+	}
+	apiHandler.HandleFunc(constants.ANALYZE_OBSERVABLE_URL, func(w http.ResponseWriter, r *http.Request) {
+		checkHeader(w, r)
+		_, _ = w.Write([]byte(`{"job_id":1,"status":"accepted"}`))
+	})
+	apiHandler.HandleFunc(fmt.Sprintf(constants.SPECIFIC_JOB_URL, 1), func(w http.ResponseWriter, r *http.Request) {
+		checkHeader(w, r)
+		_, _ = w.Write([]byte(`{"id":1,"status":"reported_without_fails"}`))
+	})
+	client := gothreatmatrix.NewClient(gothreatmatrix.WithURL(testServer.URL), gothreatmatrix.WithPollInterval(time.Millisecond))
+	// the job polling done along the way honors the options as well
+	job, err := client.AnalyzeService.AnalyzeObservableAndWait(context.Background(), gothreatmatrix.ObservableAnalysisRequest{Value: "8.8.8.8"}, time.Second,
+		gothreatmatrix.WithHeader("X-Correlation-Id", "incident-42"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, gothreatmatrix.StatusReportedWithoutFails, job.Status)
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+		_, _ = w.Write([]byte(`[]`))
+	})
+	testCases := make(map[string]TestData)
+	testCases["clientTimeout"] = TestData{
+		Input: []interface{}{50 * time.Millisecond, []gothreatmatrix.RequestOption{}},
+		Want:  false,
+	}
+	testCases["longerRequestTimeout"] = TestData{
+		Input: []interface{}{50 * time.Millisecond, []gothreatmatrix.RequestOption{gothreatmatrix.WithRequestTimeout(5 * time.Second)}},
+		Want:  true,
+	}
+	testCases["shorterRequestTimeout"] = TestData{
+		Input: []interface{}{5 * time.Second, []gothreatmatrix.RequestOption{gothreatmatrix.WithRequestTimeout(50 * time.Millisecond)}},
+		Want:  false,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			input := testCase.Input.([]interface{})
+			client := gothreatmatrix.NewClient(gothreatmatrix.WithURL(testServer.URL), gothreatmatrix.WithTimeout(input[0].(time.Duration)))
+			_, err := client.TagService.List(context.Background(), input[1].([]gothreatmatrix.RequestOption)...)
+			testWantData(t, testCase.Want, err == nil)
+			if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("Expected %v got: %v", context.DeadlineExceeded, err)
+			}
+		})
+	}
+}
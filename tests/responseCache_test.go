@@ -0,0 +1,138 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// etagServer serves the tags list with an ETag, answering 304 when the client already has the current version.
+type etagServer struct {
+	mutex        sync.Mutex
+	body         string
+	etag         string
+	fullCount    int
+	notModified  int
+	lastModified string
+}
+
+func (server *etagServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if server.etag != "" {
+		w.Header().Set("ETag", server.etag)
+		if r.Header.Get("If-None-Match") == server.etag {
+			server.notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	if server.lastModified != "" {
+		w.Header().Set("Last-Modified", server.lastModified)
+		if r.Header.Get("If-Modified-Since") == server.lastModified {
+			server.notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	server.fullCount++
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(server.body))
+}
+
+func TestWithResponseCache(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["etag"] = TestData{Input: &etagServer{etag: `"v1"`, body: `[{"id":1,"label":"apt","color":"#ff0000"}]`}}
+	testCases["lastModified"] = TestData{Input: &etagServer{lastModified: "Mon, 02 Jan 2006 15:04:05 GMT", body: `[{"id":1,"label":"apt","color":"#ff0000"}]`}}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := testCase.Input.(*etagServer)
+			apiHandler := http.NewServeMux()
+			apiHandler.Handle(constants.BASE_TAG_URL, server)
+			testServer := httptest.NewServer(apiHandler)
+			defer testServer.Close()
+			client := gothreatmatrix.NewClient(gothreatmatrix.WithURL(testServer.URL), gothreatmatrix.WithResponseCache(0))
+			ctx := context.Background()
+			for i := 0; i < 3; i++ {
+				tags, err := client.TagService.List(ctx)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				testWantData(t, []gothreatmatrix.Tag{{ID: 1, Label: "apt", Color: "#ff0000"}}, *tags)
+			}
+			testWantData(t, 1, server.fullCount)
+			testWantData(t, 2, server.notModified)
+
+			// a changed resource is fetched again
+			server.mutex.Lock()
+			server.body = `[]`
+			if server.etag != "" {
+				server.etag = `"v2"`
+			} else {
+				server.lastModified = "Tue, 03 Jan 2006 15:04:05 GMT"
+			}
+			server.mutex.Unlock()
+			tags, err := client.TagService.List(ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, []gothreatmatrix.Tag{}, *tags)
+			testWantData(t, 2, server.fullCount)
+		})
+	}
+}
+
+func TestWithResponseCacheEviction(t *testing.T) {
+	server := &etagServer{etag: `"v1"`, body: `[]`}
+	apiHandler := http.NewServeMux()
+	apiHandler.Handle(constants.BASE_TAG_URL, server)
+	apiHandler.Handle(constants.BASE_ANALYZER_URL, server)
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	client := gothreatmatrix.NewClient(gothreatmatrix.WithURL(testServer.URL), gothreatmatrix.WithResponseCache(1))
+	ctx := context.Background()
+	if _, err := client.TagService.List(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// caching the analyzers evicts the tags
+	if _, err := client.AnalyzerService.List(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.TagService.List(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 3, server.fullCount)
+	testWantData(t, 0, server.notModified)
+}
+
+func TestWithResponseCacheSkipsDownloads(t *testing.T) {
+	sample := bytes.Repeat([]byte("sample"), 1024)
+	apiHandler := http.NewServeMux()
+	apiHandler.HandleFunc(fmt.Sprintf(constants.DOWNLOAD_SAMPLE_JOB_URL, 1), func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			t.Errorf("a sample download should not be revalidated")
+		}
+		w.Header().Set("ETag", `"sample"`)
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(sample)
+	})
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	client := gothreatmatrix.NewClient(gothreatmatrix.WithURL(testServer.URL), gothreatmatrix.WithResponseCache(0))
+	for i := 0; i < 2; i++ {
+		downloaded, err := client.JobService.DownloadSample(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !bytes.Equal(sample, downloaded) {
+			t.Fatalf("Expected the %d bytes of the sample got %d bytes", len(sample), len(downloaded))
+		}
+	}
+}
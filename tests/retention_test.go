@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+const retentionJobsJson = `{"count":6,"total_pages":1,"results":[
+	{"id":1,"status":"reported_without_fails","tlp":"CLEAR","is_sample":false,"received_request_time":"2020-01-01T00:00:00Z"},
+	{"id":2,"status":"running","tlp":"CLEAR","is_sample":false,"received_request_time":"2020-01-01T00:00:00Z"},
+	{"id":3,"status":"failed","tlp":"CLEAR","is_sample":true,"received_request_time":"2020-01-01T00:00:00Z"},
+	{"id":4,"status":"killed","tlp":"RED","is_sample":false,"received_request_time":"2020-01-01T00:00:00Z"},
+	{"id":5,"status":"reported_with_fails","tlp":"AMBER","is_sample":false,"received_request_time":"2020-01-01T00:00:00Z"},
+	{"id":6,"status":"reported_without_fails","tlp":"CLEAR","is_sample":false,"received_request_time":null}
+]}`
+
+func TestJobServiceApplyRetention(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.BASE_JOB_URL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("received_request_time__lte") == "" {
+			t.Errorf("the jobs should be listed up to the cutoff")
+		}
+		_, _ = w.Write([]byte(retentionJobsJson))
+	})
+	deletedMutex := sync.Mutex{}
+	deleted := map[int]bool{}
+	for _, jobId := range []int{1, 2, 3, 4, 5, 6} {
+		jobId := jobId
+		apiHandler.HandleFunc(fmt.Sprintf(constants.SPECIFIC_JOB_URL, jobId), func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "DELETE")
+			deletedMutex.Lock()
+			deleted[jobId] = true
+			deletedMutex.Unlock()
+			if jobId == 5 {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"detail":"You do not have permission to perform this action."}`))
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+	progress := []gothreatmatrix.RetentionProgress{}
+	policy := gothreatmatrix.RetentionPolicy{
+		OlderThan:   30 * 24 * time.Hour,
+		KeepSamples: true,
+		TLPs:        []gothreatmatrix.TLP{gothreatmatrix.TLPClear, gothreatmatrix.TLPAmber},
+		Progress: func(update gothreatmatrix.RetentionProgress) {
+			progress = append(progress, update)
+		},
+	}
+
+	report, err := client.JobService.ApplyRetention(context.Background(), policy, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 0, len(deleted))
+	testWantData(t, 6, report.Scanned)
+	testWantData(t, []gothreatmatrix.RetentionJob{{JobID: 1}, {JobID: 5}}, report.Jobs)
+	testWantData(t, []int{6}, report.Skipped)
+	testWantData(t, 2, len(progress))
+
+	progress = nil
+	report, err = client.JobService.ApplyRetention(context.Background(), policy, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, map[int]bool{1: true, 5: true}, deleted)
+	testWantData(t, 1, report.Deleted())
+	testWantData(t, gothreatmatrix.RetentionJob{JobID: 1, Deleted: true}, report.Jobs[0])
+	if !errors.Is(report.Jobs[1].Err, gothreatmatrix.ErrForbidden) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrForbidden, report.Jobs[1].Err)
+	}
+	testWantData(t, 2, len(progress))
+	testWantData(t, 2, progress[1].Done)
+	testWantData(t, 2, progress[1].Total)
+}
+
+func TestJobServiceApplyRetentionInvalidPolicy(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.BASE_JOB_URL, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("an invalid policy should not list the jobs")
+	})
+	policy := gothreatmatrix.RetentionPolicy{Statuses: []gothreatmatrix.JobStatus{"archived"}}
+	_, err := client.JobService.ApplyRetention(context.Background(), policy, false)
+	if !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+	var validationErrors gothreatmatrix.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		t.Fatalf("Expected ValidationErrors got: %T", err)
+	}
+	testWantData(t, []string{"OlderThan", "Statuses"}, []string{validationErrors[0].Field, validationErrors[1].Field})
+}
@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+type retryInput struct {
+	Method      string
+	Failures    int
+	MaxAttempts int
+}
+
+func TestClientRetry(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["recoversAfterServerErrors"] = TestData{
+		Input:      retryInput{Method: "GET", Failures: 2, MaxAttempts: 3},
+		StatusCode: http.StatusOK,
+		Want:       3,
+	}
+	testCases["givesUpAfterMaxAttempts"] = TestData{
+		Input:      retryInput{Method: "GET", Failures: 5, MaxAttempts: 2},
+		StatusCode: http.StatusServiceUnavailable,
+		Want:       2,
+	}
+	testCases["doesNotRetryPost"] = TestData{
+		Input:      retryInput{Method: "POST", Failures: 1, MaxAttempts: 3},
+		StatusCode: http.StatusServiceUnavailable,
+		Want:       1,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			inputData, ok := testCase.Input.(retryInput)
+			if !ok {
+				t.Fatalf("Casting failed!")
+			}
+			calls := 0
+			apiHandler := http.NewServeMux()
+			testServer := httptest.NewServer(apiHandler)
+			defer testServer.Close()
+			apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, inputData.Method)
+				calls++
+				if calls <= inputData.Failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				if r.Method == "POST" {
+					_, _ = w.Write([]byte(`{"id":1,"label":"retry","color":"#ffffff"}`))
+				} else {
+					_, _ = w.Write([]byte(`[]`))
+				}
+			})
+			client := gothreatmatrix.NewClient(
+				gothreatmatrix.WithURL(testServer.URL),
+				gothreatmatrix.WithToken("test-token"),
+				gothreatmatrix.WithRetry(inputData.MaxAttempts, time.Millisecond),
+			)
+			ctx := context.Background()
+			var err error
+			if inputData.Method == "POST" {
+				_, err = client.TagService.Create(ctx, &gothreatmatrix.TagParams{Label: "retry", Color: "#ffffff"})
+			} else {
+				_, err = client.TagService.List(ctx)
+			}
+			if testCase.StatusCode == http.StatusOK && err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if testCase.StatusCode != http.StatusOK && err == nil {
+				t.Fatalf("Expected an error")
+			}
+			testWantData(t, testCase.Want, calls)
+		})
+	}
+}
+
+func TestClientRetryRespectsContext(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["overallDeadline"] = TestData{
+		Input: []gothreatmatrix.Option{gothreatmatrix.WithOverallDeadline(100 * time.Millisecond)},
+		Want:  context.DeadlineExceeded,
+	}
+	testCases["canceledMidBackoff"] = TestData{
+		Input: []gothreatmatrix.Option{},
+		Want:  context.Canceled,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := http.NewServeMux()
+			testServer := httptest.NewServer(apiHandler)
+			defer testServer.Close()
+			apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			})
+			opts := append([]gothreatmatrix.Option{
+				gothreatmatrix.WithURL(testServer.URL),
+				gothreatmatrix.WithRetry(5, time.Minute),
+			}, testCase.Input.([]gothreatmatrix.Option)...)
+			client := gothreatmatrix.NewClient(opts...)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			time.AfterFunc(50*time.Millisecond, cancel)
+			start := time.Now()
+			_, err := client.TagService.List(ctx)
+			if !errors.Is(err, testCase.Want.(error)) {
+				t.Fatalf("Expected %v got: %v", testCase.Want, err)
+			}
+			// the minute long backoff is never waited for
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Fatalf("Expected to give up right away, took %s", elapsed)
+			}
+		})
+	}
+}
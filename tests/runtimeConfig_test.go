@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestRuntimeConfigMap(t *testing.T) {
+	runtimeConfig := gothreatmatrix.NewRuntimeConfig().
+		ForAnalyzer("VirusTotal_v3_Get_Observable", map[string]interface{}{"max_tries": 5}).
+		ForAnalyzer("VirusTotal_v3_Get_Observable", map[string]interface{}{"force_active_scan": true}).
+		ForConnector("MISP", map[string]interface{}{"ssl_check": false})
+	runtimeConfiguration := runtimeConfig.Map()
+	testWantData(t, map[string]interface{}{
+		"analyzers": map[string]interface{}{
+			"VirusTotal_v3_Get_Observable": map[string]interface{}{"max_tries": 5, "force_active_scan": true},
+		},
+		"connectors": map[string]interface{}{
+			"MISP": map[string]interface{}{"ssl_check": false},
+		},
+	}, runtimeConfiguration)
+
+	// the map is a copy
+	runtimeConfiguration["analyzers"].(map[string]interface{})["VirusTotal_v3_Get_Observable"].(map[string]interface{})["max_tries"] = 1
+	runtimeConfigJson, err := json.Marshal(runtimeConfig)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, `{"analyzers":{"VirusTotal_v3_Get_Observable":{"force_active_scan":true,"max_tries":5}},"connectors":{"MISP":{"ssl_check":false}}}`, string(runtimeConfigJson))
+}
+
+func TestRuntimeConfigValidate(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["valid"] = TestData{
+		Input: gothreatmatrix.NewRuntimeConfig().
+			ForAnalyzer("VirusTotal_v3_Get_Observable", map[string]interface{}{"max_tries": 5}).
+			ForAnalyzer("Classic_DNS", map[string]interface{}{"anything": 1}),
+		Want: []string{},
+	}
+	testCases["unknownAnalyzer"] = TestData{
+		Input: gothreatmatrix.NewRuntimeConfig().ForAnalyzer("VirusTotal", map[string]interface{}{"max_tries": 5}),
+		Want:  []string{`unknown analyzer "VirusTotal"`},
+	}
+	testCases["unknownParameters"] = TestData{
+		Input: gothreatmatrix.NewRuntimeConfig().
+			ForAnalyzer("VirusTotal_v3_Get_Observable", map[string]interface{}{"retries": 5}).
+			ForConnector("MISP", map[string]interface{}{"ssl_check": false}),
+		Want: []string{`analyzer "VirusTotal_v3_Get_Observable" has no parameter "retries"`, `unknown connector "MISP"`},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.HandleFunc(constants.BASE_ANALYZER_URL, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`[{"name":"VirusTotal_v3_Get_Observable","params":{"max_tries":{"value":10,"type":"int"}}},{"name":"Classic_DNS","params":{}}]`))
+			})
+			apiHandler.HandleFunc(constants.BASE_CONNECTOR_URL, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`[{"name":"OpenCTI"}]`))
+			})
+			err := testCase.Input.(*gothreatmatrix.RuntimeConfig).Validate(context.Background(), &client)
+			wantErrors := testCase.Want.([]string)
+			if len(wantErrors) == 0 {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, gothreatmatrix.ErrValidation) {
+				t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+			}
+			joined, ok := err.(interface{ Unwrap() []error })
+			if !ok {
+				t.Fatalf("Expected joined errors got: %v", err)
+			}
+			gottenErrors := []string{}
+			for _, err := range joined.Unwrap() {
+				gottenErrors = append(gottenErrors, err.Error())
+			}
+			for index := range wantErrors {
+				wantErrors[index] = gothreatmatrix.ErrValidation.Error() + ": " + wantErrors[index]
+			}
+			testWantData(t, wantErrors, gottenErrors)
+		})
+	}
+}
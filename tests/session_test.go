@@ -0,0 +1,174 @@
+package tests
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// testJWT returns an unsigned JWT named name expiring at expiresAt, ThreatMatrix being the one checking signatures.
+func testJWT(name string, expiresAt time.Time) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d,"name":%q}`, expiresAt.Unix(), name)))
+	return header + "." + payload + "." + name
+}
+
+// sessionServer serves the session endpoints and records the Authorization header of the tag requests.
+type sessionServer struct {
+	mutex          sync.Mutex
+	logins         int
+	refreshes      int
+	refreshStatus  int
+	accessLifetime time.Duration
+	authorizations []string
+}
+
+func (server *sessionServer) register(t *testing.T, apiHandler *http.ServeMux) {
+	apiHandler.HandleFunc(constants.LOGIN_URL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		credentials := gothreatmatrix.LoginParams{}
+		_ = json.NewDecoder(r.Body).Decode(&credentials)
+		if credentials != (gothreatmatrix.LoginParams{Username: "hussain", Password: "hunter2"}) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"detail":"No active account found with the given credentials"}`))
+			return
+		}
+		server.mutex.Lock()
+		server.logins++
+		name := fmt.Sprintf("login%d", server.logins)
+		server.mutex.Unlock()
+		_, _ = fmt.Fprintf(w, `{"access":%q,"refresh":"refresh-token"}`, testJWT(name, time.Now().Add(server.accessLifetime)))
+	})
+	apiHandler.HandleFunc(constants.REFRESH_TOKEN_URL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		params := map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&params)
+		testWantData(t, "refresh-token", params["refresh"])
+		if server.refreshStatus != 0 {
+			w.WriteHeader(server.refreshStatus)
+			_, _ = w.Write([]byte(`{"detail":"Token is invalid or expired"}`))
+			return
+		}
+		server.mutex.Lock()
+		server.refreshes++
+		name := fmt.Sprintf("refresh%d", server.refreshes)
+		server.mutex.Unlock()
+		_, _ = fmt.Fprintf(w, `{"access":%q}`, testJWT(name, time.Now().Add(time.Hour)))
+	})
+	apiHandler.HandleFunc(constants.LOGOUT_URL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(http.StatusResetContent)
+	})
+	apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+		server.mutex.Lock()
+		server.authorizations = append(server.authorizations, r.Header.Get("Authorization"))
+		server.mutex.Unlock()
+		_, _ = w.Write([]byte(`[]`))
+	})
+}
+
+func TestUserServiceLoginLogout(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	server := &sessionServer{accessLifetime: time.Hour}
+	server.register(t, apiHandler)
+	ctx := context.Background()
+
+	session, err := client.UserService.Login(ctx, "hussain", "hunter2")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, "refresh-token", session.RefreshToken)
+	if time.Until(session.ExpiresAt) < 59*time.Minute {
+		t.Errorf("the session should expire in an hour, not at %v", session.ExpiresAt)
+	}
+	if _, err := client.TagService.List(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	loggedOut, err := client.UserService.Logout(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, true, loggedOut)
+	if _, err := client.TagService.List(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, []string{"Bearer " + session.AccessToken, "token test-token"}, server.authorizations)
+}
+
+func TestUserServiceLoginErrors(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	server := &sessionServer{accessLifetime: time.Hour}
+	server.register(t, apiHandler)
+	ctx := context.Background()
+	if _, err := client.UserService.Login(ctx, "hussain", ""); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+	if _, err := client.UserService.Login(ctx, "hussain", "wrong"); !errors.Is(err, gothreatmatrix.ErrUnauthorized) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrUnauthorized, err)
+	}
+	if _, err := client.UserService.RefreshToken(ctx); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+	if _, err := client.UserService.Logout(ctx); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+}
+
+func TestSessionRefreshBeforeExpiry(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	// the access token expires within the refresh margin
+	server := &sessionServer{accessLifetime: 10 * time.Second}
+	server.register(t, apiHandler)
+	ctx := context.Background()
+	if _, err := client.UserService.Login(ctx, "hussain", "hunter2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for attempt := 0; attempt < 2; attempt++ {
+		if _, err := client.TagService.List(ctx); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	testWantData(t, 1, server.refreshes)
+	testWantData(t, 2, len(server.authorizations))
+	for _, authorization := range server.authorizations {
+		if !strings.HasPrefix(authorization, "Bearer ") || !strings.HasSuffix(authorization, ".refresh1") {
+			t.Errorf("Expected the refreshed token got: %s", authorization)
+		}
+	}
+}
+
+func TestWithCredentials(t *testing.T) {
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	server := &sessionServer{accessLifetime: 10 * time.Second, refreshStatus: http.StatusUnauthorized}
+	server.register(t, apiHandler)
+	client := gothreatmatrix.NewClient(
+		gothreatmatrix.WithURL(testServer.URL),
+		gothreatmatrix.WithCredentials("hussain", "hunter2"),
+	)
+	ctx := context.Background()
+	if _, err := client.TagService.List(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 1, server.logins)
+	// the refresh token is rejected so the client logs in again
+	if _, err := client.TagService.List(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 2, server.logins)
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestStatsServiceSubmissions(t *testing.T) {
+	testData := TestData{
+		Input:      6,
+		Data:       `[{"month":"2023-02-01","observables":40,"files":2,"total":42},{"month":"2023-03-01","observables":10,"files":0,"total":10}]`,
+		StatusCode: http.StatusOK,
+		Want: []gothreatmatrix.MonthlySubmissions{
+			{Month: gothreatmatrix.Timestamp{Time: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)}, Observables: 40, Files: 2, Total: 42},
+			{Month: gothreatmatrix.Timestamp{Time: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)}, Observables: 10, Total: 10},
+		},
+	}
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.SUBMISSIONS_STATS_URL, func(w http.ResponseWriter, r *http.Request) {
+		testWantData(t, "6", r.URL.Query().Get("months"))
+		serverHandler(t, testData, "GET").ServeHTTP(w, r)
+	})
+	gottenSubmissions, err := client.StatsService.Submissions(context.Background(), testData.Input.(int))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, testData.Want, gottenSubmissions)
+}
+
+func TestStatsServiceTopAnalyzers(t *testing.T) {
+	testData := TestData{
+		Input:      &gothreatmatrix.StatsParams{Range: gothreatmatrix.AggregateRangeWeek, Limit: 2},
+		Data:       `[{"name":"Classic_DNS","runs":120,"failures":1},{"name":"VirusTotal_v3_Get_File","runs":80,"failures":12}]`,
+		StatusCode: http.StatusOK,
+		Want: []gothreatmatrix.AnalyzerUsage{
+			{Name: "Classic_DNS", Runs: 120, Failures: 1},
+			{Name: "VirusTotal_v3_Get_File", Runs: 80, Failures: 12},
+		},
+	}
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.TOP_ANALYZERS_STATS_URL, func(w http.ResponseWriter, r *http.Request) {
+		testWantData(t, "7d", r.URL.Query().Get("range"))
+		testWantData(t, "2", r.URL.Query().Get("limit"))
+		serverHandler(t, testData, "GET").ServeHTTP(w, r)
+	})
+	gottenAnalyzers, err := client.StatsService.TopAnalyzers(context.Background(), testData.Input.(*gothreatmatrix.StatsParams))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, testData.Want, gottenAnalyzers)
+}
+
+func TestStatsServiceUsers(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.Handle(constants.USERS_STATS_URL, serverHandler(t, TestData{
+		Data:       `[{"username":"hussain","submissions":300,"observable_submissions":290,"file_submissions":10,"month_submissions":95,"month_quota":100},{"username":"analyst","submissions":12,"month_submissions":3,"month_quota":null}]`,
+		StatusCode: http.StatusOK,
+	}, "GET"))
+	gottenUsers, err := client.StatsService.Users(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, 2, len(gottenUsers))
+	left, limited := gottenUsers[0].QuotaLeft()
+	testWantData(t, 5, left)
+	testWantData(t, true, limited)
+	_, limited = gottenUsers[1].QuotaLeft()
+	testWantData(t, false, limited)
+}
+
+func TestStatsServicePluginFailureRates(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.Handle(constants.PLUGIN_FAILURES_STATS_URL, serverHandler(t, TestData{
+		Data:       `[{"name":"Shodan","type":"analyzer","runs":40,"failures":10},{"name":"MISP","type":"connector","runs":0,"failures":0}]`,
+		StatusCode: http.StatusOK,
+	}, "GET"))
+	gottenFailureRates, err := client.StatsService.PluginFailureRates(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, gothreatmatrix.PluginTypeAnalyzer, gottenFailureRates[0].Type)
+	testWantData(t, 0.25, gottenFailureRates[0].Rate())
+	testWantData(t, 0.0, gottenFailureRates[1].Rate())
+}
+
+func TestStatsServiceForbidden(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.Handle(constants.USERS_STATS_URL, serverHandler(t, TestData{
+		Data:       `{"detail":"You do not have permission to perform this action."}`,
+		StatusCode: http.StatusForbidden,
+	}, "GET"))
+	_, err := client.StatsService.Users(context.Background(), nil)
+	if !errors.Is(err, gothreatmatrix.ErrForbidden) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrForbidden, err)
+	}
+}
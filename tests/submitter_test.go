@@ -0,0 +1,202 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestSubmitterSubmitObservables(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	mutex := sync.Mutex{}
+	attempts := map[string]int{}
+	apiHandler.HandleFunc(constants.ANALYZE_OBSERVABLE_URL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		params := gothreatmatrix.ObservableAnalysisParams{}
+		_ = json.NewDecoder(r.Body).Decode(&params)
+		mutex.Lock()
+		attempts[params.ObservableName]++
+		attempt := attempts[params.ObservableName]
+		mutex.Unlock()
+		switch {
+		case params.ObservableName == "flaky.com" && attempt == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case params.ObservableName == "limited.com" && attempt == 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case params.ObservableName == "broken.com":
+			w.WriteHeader(http.StatusInternalServerError)
+		case params.ObservableName == "invalid.com":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"detail":"invalid observable"}`))
+		default:
+			_, _ = w.Write([]byte(`{"job_id":1,"status":"accepted"}`))
+		}
+	})
+	submitter := gothreatmatrix.NewSubmitter(&client, &gothreatmatrix.SubmitterOptions{Concurrency: 3, MaxAttempts: 2, RetryDelay: time.Millisecond})
+	requests := []gothreatmatrix.ObservableAnalysisRequest{{Value: "8.8.8.8"}, {Value: "flaky.com"}, {Value: "limited.com"}, {Value: "broken.com"}, {Value: "invalid.com"}}
+	results := map[string]gothreatmatrix.SubmissionResult{}
+	for result := range submitter.SubmitObservables(context.Background(), requests) {
+		results[result.Submission.Observable.Value] = result
+	}
+	testWantData(t, len(requests), len(results))
+	for _, value := range []string{"8.8.8.8", "flaky.com", "limited.com"} {
+		if results[value].Err != nil {
+			t.Fatalf("Unexpected error for %s: %v", value, results[value].Err)
+		}
+		testWantData(t, 1, results[value].Response.JobID)
+	}
+	testWantData(t, 1, results["8.8.8.8"].Attempts)
+	testWantData(t, 2, results["flaky.com"].Attempts)
+	// the client itself waits out the 429 response, the submitter never sees it
+	testWantData(t, 1, results["limited.com"].Attempts)
+	testWantData(t, 2, results["broken.com"].Attempts)
+	if !errors.Is(results["broken.com"].Err, gothreatmatrix.ErrServer) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrServer, results["broken.com"].Err)
+	}
+	testWantData(t, 1, results["invalid.com"].Attempts)
+	if !errors.Is(results["invalid.com"].Err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, results["invalid.com"].Err)
+	}
+}
+
+func TestSubmitterSubmitFiles(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	mutex := sync.Mutex{}
+	attempt := 0
+	apiHandler.HandleFunc(constants.ANALYZE_FILE_URL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			return
+		}
+		content, _ := io.ReadAll(file)
+		testWantData(t, "MZ sample", string(content))
+		mutex.Lock()
+		attempt++
+		current := attempt
+		mutex.Unlock()
+		if current%2 == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"job_id":%d,"status":"accepted"}`, current)))
+	})
+	submitter := gothreatmatrix.NewSubmitter(&client, &gothreatmatrix.SubmitterOptions{Concurrency: 1, RetryDelay: time.Millisecond})
+	results := []gothreatmatrix.SubmissionResult{}
+	// a plain reader cannot be rewound so the second file is never retried
+	requests := []gothreatmatrix.FileAnalysisRequest{
+		{File: strings.NewReader("MZ sample"), FileName: "seekable.exe"},
+		{File: io.MultiReader(strings.NewReader("MZ sample")), FileName: "stream.exe"},
+	}
+	for result := range submitter.SubmitFiles(context.Background(), requests) {
+		results = append(results, result)
+	}
+	testWantData(t, 2, len(results))
+	testWantData(t, 2, results[0].Attempts)
+	testWantData(t, 2, results[0].Response.JobID)
+	testWantData(t, 1, results[1].Attempts)
+	if !errors.Is(results[1].Err, gothreatmatrix.ErrServer) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrServer, results[1].Err)
+	}
+}
+
+func TestSubmitterInvalidSubmission(t *testing.T) {
+	client, _, closeServer := setup()
+	defer closeServer()
+	submissions := make(chan gothreatmatrix.Submission, 1)
+	submissions <- gothreatmatrix.Submission{}
+	close(submissions)
+	for result := range gothreatmatrix.NewSubmitter(&client, nil).Submit(context.Background(), submissions) {
+		testWantData(t, 0, result.Attempts)
+		if !errors.Is(result.Err, gothreatmatrix.ErrValidation) {
+			t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, result.Err)
+		}
+	}
+}
+
+func TestSubmitterDedupeWindow(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	mutex := sync.Mutex{}
+	submitted := map[string]int{}
+	apiHandler.HandleFunc(constants.ANALYZE_OBSERVABLE_URL, func(w http.ResponseWriter, r *http.Request) {
+		params := gothreatmatrix.ObservableAnalysisParams{}
+		_ = json.NewDecoder(r.Body).Decode(&params)
+		mutex.Lock()
+		submitted[params.ObservableName]++
+		count := submitted[params.ObservableName]
+		mutex.Unlock()
+		if params.ObservableName == "flaky.com" && count == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"job_id":%d,"status":"accepted"}`, count)))
+	})
+	apiHandler.HandleFunc(constants.ANALYZE_FILE_URL, func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		submitted["file"]++
+		mutex.Unlock()
+		_, _ = w.Write([]byte(`{"job_id":10,"status":"accepted"}`))
+	})
+	submitter := gothreatmatrix.NewSubmitter(&client, &gothreatmatrix.SubmitterOptions{Concurrency: 4, MaxAttempts: 1, DedupeWindow: time.Hour})
+	requests := []gothreatmatrix.ObservableAnalysisRequest{{Value: "8.8.8.8"}, {Value: "8.8.8.8", Classification: "ip"}, {Value: "8.8.8.8"}, {Value: "flaky.com"}}
+	deduplicated := 0
+	for result := range submitter.SubmitObservables(context.Background(), requests) {
+		if result.Submission.Observable.Value == "flaky.com" {
+			continue
+		}
+		if result.Err != nil {
+			t.Fatalf("Unexpected error: %v", result.Err)
+		}
+		// every submission of 8.8.8.8 answers with the job the first one created
+		testWantData(t, 1, result.Response.JobID)
+		if result.Deduplicated {
+			deduplicated++
+			testWantData(t, 0, result.Attempts)
+		}
+	}
+	testWantData(t, 2, deduplicated)
+	testWantData(t, 1, submitted["8.8.8.8"])
+
+	// a failed submission isn't remembered
+	for result := range submitter.SubmitObservables(context.Background(), []gothreatmatrix.ObservableAnalysisRequest{{Value: "flaky.com"}}) {
+		if result.Err != nil {
+			t.Fatalf("Unexpected error: %v", result.Err)
+		}
+		testWantData(t, false, result.Deduplicated)
+	}
+
+	// files are deduplicated by their content and the reader is rewound after being hashed
+	files := []gothreatmatrix.FileAnalysisRequest{
+		{File: strings.NewReader("MZ sample"), FileName: "first.exe"},
+		{File: strings.NewReader("MZ sample"), FileName: "second.exe"},
+	}
+	for result := range submitter.SubmitFiles(context.Background(), files) {
+		if result.Err != nil {
+			t.Fatalf("Unexpected error: %v", result.Err)
+		}
+		testWantData(t, 10, result.Response.JobID)
+	}
+	testWantData(t, 1, submitted["file"])
+
+	// the window is per Submitter
+	fresh := gothreatmatrix.NewSubmitter(&client, &gothreatmatrix.SubmitterOptions{DedupeWindow: time.Nanosecond})
+	for result := range fresh.SubmitObservables(context.Background(), []gothreatmatrix.ObservableAnalysisRequest{{Value: "8.8.8.8"}}) {
+		testWantData(t, false, result.Deduplicated)
+	}
+	testWantData(t, 2, submitted["8.8.8.8"])
+}
@@ -2,8 +2,10 @@ package tests
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/khulnasoft/go-threatmatrix/constants"
@@ -76,6 +78,7 @@ func TestTagServiceGet(t *testing.T) {
 		Want: &gothreatmatrix.ThreatMatrixError{
 			StatusCode: http.StatusNotFound,
 			Message:    `{"detail": "Not found."}`,
+			Detail:     "Not found.",
 		},
 	}
 
@@ -110,26 +113,27 @@ func TestTagServiceCreate(t *testing.T) {
 	testCases["simple"] = TestData{
 		Input: gothreatmatrix.TagParams{
 			Label: "TEST TAG",
-			Color: "#fffff",
+			Color: "#ffffff",
 		},
-		Data:       `{"id": 1,"label": "TEST TAG","color": "#fffff"}`,
+		Data:       `{"id": 1,"label": "TEST TAG","color": "#ffffff"}`,
 		StatusCode: http.StatusOK,
 		Want: &gothreatmatrix.Tag{
 			ID:    1,
 			Label: "TEST TAG",
-			Color: "#fffff",
+			Color: "#ffffff",
 		},
 	}
 	testCases["duplicate"] = TestData{
 		Input: gothreatmatrix.TagParams{
 			Label: "TEST TAG",
-			Color: "#fffff",
+			Color: "#ffffff",
 		},
 		Data:       `{"label":["tag with this label already exists."]}`,
 		StatusCode: http.StatusBadRequest,
 		Want: &gothreatmatrix.ThreatMatrixError{
-			StatusCode: http.StatusBadRequest,
-			Message:    `{"label":["tag with this label already exists."]}`,
+			StatusCode:  http.StatusBadRequest,
+			Message:     `{"label":["tag with this label already exists."]}`,
+			FieldErrors: map[string][]string{"label": {"tag with this label already exists."}},
 		},
 	}
 	for name, testCase := range testCases {
@@ -161,14 +165,14 @@ func TestTagServiceUpdate(t *testing.T) {
 		Input: gothreatmatrix.Tag{
 			ID:    1,
 			Label: "UPDATED TEST TAG",
-			Color: "#f4",
+			Color: "#f4f4f4",
 		},
-		Data:       `{"id": 1,"label": "UPDATED TEST TAG","color": "#f4"}`,
+		Data:       `{"id": 1,"label": "UPDATED TEST TAG","color": "#f4f4f4"}`,
 		StatusCode: http.StatusOK,
 		Want: &gothreatmatrix.Tag{
 			ID:    1,
 			Label: "UPDATED TEST TAG",
-			Color: "#f4",
+			Color: "#f4f4f4",
 		},
 	}
 	for name, testCase := range testCases {
@@ -225,3 +229,47 @@ func TestTagServiceDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestTagServiceValidation(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["emptyLabel"] = TestData{
+		Input: gothreatmatrix.TagParams{Label: " ", Color: "#ffffff"},
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	testCases["longLabel"] = TestData{
+		Input: gothreatmatrix.TagParams{Label: strings.Repeat("a", 51), Color: "#ffffff"},
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	testCases["shortColor"] = TestData{
+		Input: gothreatmatrix.TagParams{Label: "TEST TAG", Color: "#fff"},
+	}
+	testCases["fiveDigitColor"] = TestData{
+		Input: gothreatmatrix.TagParams{Label: "TEST TAG", Color: "#fffff"},
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	testCases["namedColor"] = TestData{
+		Input: gothreatmatrix.TagParams{Label: "TEST TAG", Color: "red"},
+		Want:  gothreatmatrix.ErrValidation,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			ctx := context.Background()
+			apiHandler.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				if testCase.Want != nil {
+					t.Errorf("No request should be sent for invalid tags")
+				}
+				_, _ = w.Write([]byte(`{"id":1,"label":"TEST TAG","color":"#fff"}`))
+			})
+			tagParams := testCase.Input.(gothreatmatrix.TagParams)
+			wantError, _ := testCase.Want.(error)
+			if _, err := client.TagService.Create(ctx, &tagParams); !errors.Is(err, wantError) {
+				t.Fatalf("Expected %v on create got: %v", wantError, err)
+			}
+			if _, err := client.TagService.Update(ctx, 1, &tagParams); !errors.Is(err, wantError) {
+				t.Fatalf("Expected %v on update got: %v", wantError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestTimestampUnmarshalJSON(t *testing.T) {
+	want := time.Date(2023, 3, 1, 12, 0, 0, 500000000, time.UTC)
+	testCases := make(map[string]TestData)
+	testCases["rfc3339"] = TestData{Input: `"2023-03-01T13:00:00.5+01:00"`, Want: want}
+	testCases["pythonOffset"] = TestData{Input: `"2023-03-01T12:00:00.500000+0000"`, Want: want}
+	testCases["withoutTimezone"] = TestData{Input: `"2023-03-01T12:00:00.5"`, Want: want}
+	testCases["space"] = TestData{Input: `"2023-03-01 12:00:00.5"`, Want: want}
+	testCases["date"] = TestData{Input: `"2023-03-01"`, Want: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)}
+	testCases["epochFloat"] = TestData{Input: `1677672000.5`, Want: want}
+	testCases["epochString"] = TestData{Input: `"1677672000.5"`, Want: want}
+	testCases["epochMilliseconds"] = TestData{Input: `1677672000500`, Want: want}
+	testCases["null"] = TestData{Input: `null`, Want: time.Time{}}
+	testCases["empty"] = TestData{Input: `""`, Want: time.Time{}}
+	testCases["unknownFormat"] = TestData{Input: `"the first of March"`, Want: time.Time{}}
+	testCases["unexpectedType"] = TestData{Input: `{"seconds":1677672000}`, Want: time.Time{}}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			timestamp := gothreatmatrix.Timestamp{}
+			if err := json.Unmarshal([]byte(testCase.Input.(string)), &timestamp); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !timestamp.Equal(testCase.Want.(time.Time)) {
+				t.Fatalf("Expected %v got %v", testCase.Want, timestamp.Time)
+			}
+		})
+	}
+}
+
+func TestTimestampInJob(t *testing.T) {
+	data := `{"id":1,"received_request_time":"2023-03-01 12:00:00","finished_analysis_time":null,
+		"analyzer_reports":[{"name":"Classic_DNS","start_time":1677672000,"end_time":"not a time"}]}`
+	job := gothreatmatrix.Job{}
+	if err := json.Unmarshal([]byte(data), &job); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	received := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	testWantData(t, received, job.ReceivedRequestTime.Time)
+	if job.FinishedAnalysisTime != nil {
+		t.Fatalf("Expected no finished time got %v", job.FinishedAnalysisTime)
+	}
+	testWantData(t, received, job.AnalyzerReports[0].StartTime.Time)
+	testWantData(t, true, job.AnalyzerReports[0].EndTime.IsZero())
+	encoded, err := json.Marshal(job.ReceivedRequestTime)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, `"2023-03-01T12:00:00Z"`, string(encoded))
+}
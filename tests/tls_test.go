@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// testCertificate is a certificate along with its PEM encoding.
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	certPEM     []byte
+	keyPEM      []byte
+}
+
+// newTestCertificate makes a certificate signed by parent, or a self-signed CA when parent is nil.
+func newTestCertificate(t *testing.T, parent *testCertificate, template *x509.Certificate) *testCertificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.certificate, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	certificate, _ := x509.ParseCertificate(der)
+	keyDer, _ := x509.MarshalECPrivateKey(key)
+	return &testCertificate{
+		certificate: certificate,
+		key:         key,
+		certPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+	}
+}
+
+// mTLSServer runs a test server whose certificate is signed by a private CA and that requires a client certificate signed by it.
+// It returns the CA and a valid client certificate.
+func mTLSServer(t *testing.T) (*httptest.Server, *testCertificate, *testCertificate) {
+	ca := newTestCertificate(t, nil, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "ThreatMatrix test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	})
+	serverCertificate := newTestCertificate(t, ca, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "threatmatrix"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	clientCertificate := newTestCertificate(t, ca, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "analyst"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	apiHandler := http.NewServeMux()
+	apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+		testWantData(t, "analyst", r.TLS.PeerCertificates[0].Subject.CommonName)
+		_, _ = w.Write([]byte(`[]`))
+	})
+	serverKeyPair, err := tls.X509KeyPair(serverCertificate.certPEM, serverCertificate.keyPEM)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.certificate)
+	testServer := httptest.NewUnstartedServer(apiHandler)
+	testServer.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverKeyPair},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	testServer.StartTLS()
+	return testServer, ca, clientCertificate
+}
+
+func TestClientCertificate(t *testing.T) {
+	testServer, ca, clientCertificate := mTLSServer(t)
+	defer testServer.Close()
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.certificate)
+	clientKeyPair, err := tls.X509KeyPair(clientCertificate.certPEM, clientCertificate.keyPEM)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testCases := make(map[string]TestData)
+	testCases["mTLS"] = TestData{
+		Input: []gothreatmatrix.Option{gothreatmatrix.WithRootCAs(rootCAs), gothreatmatrix.WithClientCertificate(clientKeyPair)},
+		Want:  true,
+	}
+	testCases["tlsConfig"] = TestData{
+		Input: []gothreatmatrix.Option{
+			gothreatmatrix.WithTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12, RootCAs: rootCAs}),
+			gothreatmatrix.WithClientCertificate(clientKeyPair),
+		},
+		Want: true,
+	}
+	testCases["noClientCertificate"] = TestData{
+		Input: []gothreatmatrix.Option{gothreatmatrix.WithRootCAs(rootCAs)},
+		Want:  false,
+	}
+	testCases["untrustedServer"] = TestData{
+		Input: []gothreatmatrix.Option{gothreatmatrix.WithClientCertificate(clientKeyPair)},
+		Want:  false,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			opts := append(testCase.Input.([]gothreatmatrix.Option), gothreatmatrix.WithURL(testServer.URL), gothreatmatrix.WithToken("test-token"))
+			client := gothreatmatrix.NewClient(opts...)
+			_, err := client.TagService.List(context.Background())
+			testWantData(t, testCase.Want, err == nil)
+		})
+	}
+}
+
+func TestClientCertificateFromEnv(t *testing.T) {
+	testServer, ca, clientCertificate := mTLSServer(t)
+	defer testServer.Close()
+	directory := t.TempDir()
+	files := map[string][]byte{"ca.pem": ca.certPEM, "client.pem": clientCertificate.certPEM, "client.key": clientCertificate.keyPEM}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(directory, name), content, 0o600); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	t.Setenv(gothreatmatrix.EnvURL, testServer.URL)
+	t.Setenv(gothreatmatrix.EnvAPIKey, "test-token")
+	t.Setenv(gothreatmatrix.EnvCACert, filepath.Join(directory, "ca.pem"))
+	t.Setenv(gothreatmatrix.EnvClientCert, filepath.Join(directory, "client.pem"))
+	t.Setenv(gothreatmatrix.EnvClientKey, filepath.Join(directory, "client.key"))
+	client, err := gothreatmatrix.NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.TagService.List(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	t.Setenv(gothreatmatrix.EnvClientKey, "")
+	if _, err := gothreatmatrix.NewClientFromEnv(); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+}
+
+func TestLoadCertPool(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(path, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := gothreatmatrix.LoadCertPool(path); !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+}
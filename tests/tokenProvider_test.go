@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestWithTokenProvider(t *testing.T) {
+	authorizations := []string{}
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	apiHandler.HandleFunc(constants.BASE_TAG_URL, func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`[]`))
+	})
+	currentToken := "first-token"
+	errVault := errors.New("vault is sealed")
+	var providerError error
+	client := gothreatmatrix.NewClient(
+		gothreatmatrix.WithURL(testServer.URL),
+		gothreatmatrix.WithToken("static-token"),
+		gothreatmatrix.WithTokenProvider(func(ctx context.Context) (string, error) {
+			return currentToken, providerError
+		}),
+	)
+	ctx := context.Background()
+	if _, err := client.TagService.List(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// the token is rotated without recreating the client
+	currentToken = "rotated-token"
+	if _, err := client.TagService.List(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testWantData(t, []string{"token first-token", "token rotated-token"}, authorizations)
+
+	providerError = errVault
+	if _, err := client.TagService.List(ctx); !errors.Is(err, errVault) {
+		t.Fatalf("Expected %v got: %v", errVault, err)
+	}
+	testWantData(t, 2, len(authorizations))
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestClientTracing(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["simple"] = TestData{
+		Input:      1,
+		Data:       "",
+		StatusCode: http.StatusNoContent,
+		Want:       codes.Unset,
+	}
+	testCases["notFound"] = TestData{
+		Input:      2,
+		Data:       `{"detail":"Not found."}`,
+		StatusCode: http.StatusNotFound,
+		Want:       codes.Error,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := http.NewServeMux()
+			testServer := httptest.NewServer(apiHandler)
+			defer testServer.Close()
+			jobId := uint64(testCase.Input.(int))
+			testUrl := fmt.Sprintf(constants.SPECIFIC_JOB_URL, jobId)
+			apiHandler.Handle(testUrl, serverHandler(t, testCase, "DELETE"))
+
+			recorder := tracetest.NewSpanRecorder()
+			client := gothreatmatrix.NewClient(
+				gothreatmatrix.WithURL(testServer.URL),
+				gothreatmatrix.WithToken("test-token"),
+				gothreatmatrix.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+			)
+			_, _ = client.JobService.Delete(context.Background(), jobId)
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("Expected 1 span got %d", len(spans))
+			}
+			span := spans[0]
+			testWantData(t, "JobService.Delete", span.Name())
+			testWantData(t, testCase.Want, span.Status().Code)
+			attributes := map[attribute.Key]attribute.Value{}
+			for _, keyValue := range span.Attributes() {
+				attributes[keyValue.Key] = keyValue.Value
+			}
+			testWantData(t, int64(jobId), attributes["threatmatrix.job_id"].AsInt64())
+			testWantData(t, testUrl, attributes["threatmatrix.endpoint"].AsString())
+			testWantData(t, int64(testCase.StatusCode), attributes["http.response.status_code"].AsInt64())
+			testWantData(t, int64(0), attributes["threatmatrix.retry_count"].AsInt64())
+		})
+	}
+}
@@ -1,10 +1,15 @@
 package tests
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"log/slog"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/khulnasoft/go-threatmatrix/constants"
 	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
@@ -169,3 +174,96 @@ func TestUserServiceInviteToOrganization(t *testing.T) {
 		})
 	}
 }
+
+func TestUserServiceAPIToken(t *testing.T) {
+	tokenJsonString := `{"key":"12345678abcdef","created":"2023-01-01T10:00:00Z"}`
+	wantToken := &gothreatmatrix.APIToken{
+		Key:     "12345678abcdef",
+		Created: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+	testCases := make(map[string]TestData)
+	testCases["get"] = TestData{
+		Input:      "GET",
+		Data:       tokenJsonString,
+		StatusCode: http.StatusOK,
+		Want:       wantToken,
+	}
+	testCases["create"] = TestData{
+		Input:      "POST",
+		Data:       tokenJsonString,
+		StatusCode: http.StatusCreated,
+		Want:       wantToken,
+	}
+	testCases["createExisting"] = TestData{
+		Input:      "POST",
+		Data:       `{"errors":{"detail":"An API token was already issued to you."}}`,
+		StatusCode: http.StatusBadRequest,
+		Want: &gothreatmatrix.ThreatMatrixError{
+			StatusCode: http.StatusBadRequest,
+			Message:    `{"errors":{"detail":"An API token was already issued to you."}}`,
+			Detail:     "An API token was already issued to you.",
+		},
+	}
+	testCases["delete"] = TestData{
+		Input:      "DELETE",
+		StatusCode: http.StatusNoContent,
+		Want:       true,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			method := testCase.Input.(string)
+			apiHandler.Handle(constants.API_TOKEN_URL, serverHandler(t, testCase, method))
+			ctx := context.Background()
+			var gotten interface{}
+			var err error
+			switch method {
+			case "GET":
+				gotten, err = client.UserService.APITokenGet(ctx)
+			case "POST":
+				gotten, err = client.UserService.APITokenCreate(ctx)
+			case "DELETE":
+				gotten, err = client.UserService.APITokenDelete(ctx)
+			}
+			if err != nil {
+				testError(t, testCase, err)
+			} else {
+				testWantData(t, testCase.Want, gotten)
+			}
+		})
+	}
+}
+
+func TestUserServiceAPITokenNeverLogged(t *testing.T) {
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	apiHandler.HandleFunc(constants.API_TOKEN_URL, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"key":"super-secret-token","created":"2024-01-01T00:00:00Z"}`))
+	})
+	logs := &bytes.Buffer{}
+	dump := &bytes.Buffer{}
+	client := gothreatmatrix.NewClient(
+		gothreatmatrix.WithURL(testServer.URL),
+		gothreatmatrix.WithToken("test-token"),
+		gothreatmatrix.WithLogger(slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		gothreatmatrix.WithDebug(dump),
+	)
+	ctx := context.Background()
+	for _, call := range []func(context.Context, ...gothreatmatrix.RequestOption) (*gothreatmatrix.APIToken, error){client.UserService.APITokenGet, client.UserService.APITokenCreate} {
+		token, err := call(ctx)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		testWantData(t, "super-secret-token", token.Key)
+	}
+	for name, output := range map[string]string{"logs": logs.String(), "dump": dump.String()} {
+		if strings.Contains(output, "super-secret-token") {
+			t.Errorf("The token leaked into the %s: %s", name, output)
+		}
+		if !strings.Contains(output, "(redacted)") {
+			t.Errorf("Expected the bodies to be redacted in the %s: %s", name, output)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+// validationCatalog lists the analyzers and connectors the requests are validated against.
+const (
+	validationAnalyzers = `[
+		{"name":"Classic_DNS","type":"observable","observable_supported":["domain","url"]},
+		{"name":"Shodan","type":"observable","observable_supported":["ip"],"disabled":true},
+		{"name":"File_Info","type":"file"}
+	]`
+	validationConnectors = `[{"name":"MISP"},{"name":"OpenCTI","disabled":true}]`
+)
+
+func TestObservableAnalysisRequestValidate(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["valid"] = TestData{
+		Input: gothreatmatrix.ObservableAnalysisRequest{Value: "evil.com", Analyzers: []string{"Classic_DNS"}, Connectors: []string{"MISP"}, TLP: gothreatmatrix.TLPAmber},
+		Want:  map[string][]string(nil),
+	}
+	testCases["generic"] = TestData{
+		Input: gothreatmatrix.ObservableAnalysisRequest{Value: "evil.com", Classification: gothreatmatrix.ClassificationGeneric},
+		Want:  map[string][]string(nil),
+	}
+	testCases["empty"] = TestData{
+		Input: gothreatmatrix.ObservableAnalysisRequest{Value: " ", TLP: "PURPLE"},
+		Want: map[string][]string{
+			"Value": {"observable value cannot be empty"},
+			"TLP":   {`unknown TLP "PURPLE"`},
+		},
+	}
+	testCases["inconsistent"] = TestData{
+		Input: gothreatmatrix.ObservableAnalysisRequest{Value: "evil.com", Classification: gothreatmatrix.ClassificationIP, Analyzers: []string{"Classic_DNS", "Shodan", "File_Info", "VirusTotal"}, Connectors: []string{"OpenCTI", "Slack"}},
+		Want: map[string][]string{
+			"Classification": {`"evil.com" is not a valid ip`},
+			"Analyzers":      {`analyzer "Classic_DNS" doesn't support ip observables`, `analyzer "Shodan" is disabled`, `analyzer "File_Info" doesn't support ip observables`, `unknown analyzer "VirusTotal"`},
+			"Connectors":     {`connector "OpenCTI" is disabled`, `unknown connector "Slack"`},
+		},
+	}
+	testCases["unknownClassification"] = TestData{
+		Input: gothreatmatrix.ObservableAnalysisRequest{Value: "evil.com", Classification: "email"},
+		Want:  map[string][]string{"Classification": {`unknown classification "email", expected one of ip, url, domain, hash, generic`}},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.HandleFunc(constants.BASE_ANALYZER_URL, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(validationAnalyzers))
+			})
+			apiHandler.HandleFunc(constants.BASE_CONNECTOR_URL, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(validationConnectors))
+			})
+			request := testCase.Input.(gothreatmatrix.ObservableAnalysisRequest)
+			testValidationErrors(t, request.Validate(context.Background(), &client), testCase.Want.(map[string][]string))
+		})
+	}
+}
+
+func TestFileAnalysisRequestValidate(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["valid"] = TestData{
+		Input: gothreatmatrix.FileAnalysisRequest{File: strings.NewReader("MZ"), FileName: "sample.exe", Analyzers: []string{"File_Info"}},
+		Want:  map[string][]string(nil),
+	}
+	testCases["invalid"] = TestData{
+		Input: gothreatmatrix.FileAnalysisRequest{Analyzers: []string{"Classic_DNS"}},
+		Want: map[string][]string{
+			"File":      {"file cannot be nil"},
+			"FileName":  {"file name cannot be empty"},
+			"Analyzers": {`analyzer "Classic_DNS" doesn't support files`},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.HandleFunc(constants.BASE_ANALYZER_URL, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(validationAnalyzers))
+			})
+			request := testCase.Input.(gothreatmatrix.FileAnalysisRequest)
+			testValidationErrors(t, request.Validate(context.Background(), &client), testCase.Want.(map[string][]string))
+		})
+	}
+}
+
+func TestValidateCachesCatalog(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	analyzers := validationAnalyzers
+	fetches := 0
+	apiHandler.HandleFunc(constants.BASE_ANALYZER_URL, func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		_, _ = w.Write([]byte(analyzers))
+	})
+	ctx := context.Background()
+	request := gothreatmatrix.ObservableAnalysisRequest{Value: "evil.com", Analyzers: []string{"Classic_DNS"}}
+	for i := 0; i < 3; i++ {
+		testValidationErrors(t, request.Validate(ctx, &client), nil)
+	}
+	testWantData(t, 1, fetches)
+
+	// an analyzer missing from the cached catalog may have been added since, the catalog is fetched again
+	analyzers = `[{"name":"Classic_DNS","type":"observable"},{"name":"VirusTotal","type":"observable"}]`
+	request.Analyzers = []string{"VirusTotal"}
+	testValidationErrors(t, request.Validate(ctx, &client), nil)
+	testWantData(t, 2, fetches)
+	request.Analyzers = []string{"Unknown"}
+	testValidationErrors(t, request.Validate(ctx, &client), map[string][]string{"Analyzers": {`unknown analyzer "Unknown"`}})
+	testWantData(t, 3, fetches)
+}
+
+func TestValidateWithoutClient(t *testing.T) {
+	// without a client the analyzers aren't checked, so no request is sent
+	request := gothreatmatrix.ObservableAnalysisRequest{Value: "8.8.8.8", Analyzers: []string{"Unknown"}}
+	testValidationErrors(t, request.Validate(context.Background(), nil), nil)
+}
+
+// testValidationErrors checks err holds the wanted field errors, none being wanted when wantFieldErrors is nil.
+func testValidationErrors(t *testing.T, err error, wantFieldErrors map[string][]string) {
+	t.Helper()
+	if wantFieldErrors == nil {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return
+	}
+	if !errors.Is(err, gothreatmatrix.ErrValidation) {
+		t.Fatalf("Expected %v got: %v", gothreatmatrix.ErrValidation, err)
+	}
+	var validationErrors gothreatmatrix.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		t.Fatalf("Expected ValidationErrors got: %T", err)
+	}
+	testWantData(t, wantFieldErrors, validationErrors.FieldErrors())
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestJobVerdict(t *testing.T) {
+	testCases := make(map[string]TestData)
+	testCases["noEvidence"] = TestData{
+		Input: []gothreatmatrix.Report{
+			{Name: "Classic_DNS", Status: "SUCCESS", Report: map[string]interface{}{"resolutions": []interface{}{}}},
+			{Name: "AbuseIPDB", Status: "FAILED"},
+		},
+		Want: gothreatmatrix.Verdict{Label: gothreatmatrix.VerdictUnknown, Evidence: []gothreatmatrix.Evidence{}},
+	}
+	testCases["mostSevere"] = TestData{
+		Input: []gothreatmatrix.Report{
+			{Name: "AbuseIPDB", Status: "SUCCESS", Report: map[string]interface{}{"data": map[string]interface{}{"abuseConfidenceScore": 40, "totalReports": 12}}},
+			{Name: "VirusTotal_v3_Get_Observable", Status: "SUCCESS", Report: map[string]interface{}{"data": map[string]interface{}{"attributes": map[string]interface{}{
+				"last_analysis_stats": map[string]interface{}{"malicious": 5, "harmless": 60, "undetected": 5},
+			}}}},
+		},
+		Want: gothreatmatrix.Verdict{
+			Score: 77,
+			Label: gothreatmatrix.VerdictMalicious,
+			Evidence: []gothreatmatrix.Evidence{
+				{Plugin: "AbuseIPDB", Label: gothreatmatrix.VerdictSuspicious, Score: 40, Reason: "abuse confidence score of 40% over 12 reports"},
+				{Plugin: "VirusTotal_v3_Get_Observable", Label: gothreatmatrix.VerdictMalicious, Score: 77, Reason: "5/70 engines detected it as malicious, 0 as suspicious"},
+			},
+		},
+	}
+	testCases["benign"] = TestData{
+		Input: []gothreatmatrix.Report{
+			{Name: "VirusTotal_v3_Get_File", Status: "SUCCESS", Report: map[string]interface{}{"data": map[string]interface{}{"attributes": map[string]interface{}{
+				"last_analysis_stats": map[string]interface{}{"harmless": 10, "undetected": 60},
+			}}}},
+		},
+		Want: gothreatmatrix.Verdict{
+			Label:    gothreatmatrix.VerdictBenign,
+			Evidence: []gothreatmatrix.Evidence{{Plugin: "VirusTotal_v3_Get_File", Label: gothreatmatrix.VerdictBenign, Reason: "0/70 engines detected it as malicious, 0 as suspicious"}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			job := gothreatmatrix.Job{AnalyzerReports: testCase.Input.([]gothreatmatrix.Report)}
+			testWantData(t, testCase.Want, job.Verdict())
+		})
+	}
+}
+
+func TestRegisterVerdictEvaluator(t *testing.T) {
+	gothreatmatrix.RegisterVerdictEvaluator("Test_Evaluator", gothreatmatrix.VerdictEvaluatorFunc(func(report *gothreatmatrix.Report) (gothreatmatrix.Evidence, bool) {
+		if found, _ := report.Report["found"].(bool); found {
+			return gothreatmatrix.Evidence{Label: gothreatmatrix.VerdictMalicious, Score: 100, Reason: "listed"}, true
+		}
+		return gothreatmatrix.Evidence{}, false
+	}))
+	job := gothreatmatrix.Job{AnalyzerReports: []gothreatmatrix.Report{
+		{Name: "Test_Evaluator", Status: "SUCCESS", Report: map[string]interface{}{"found": true}},
+	}}
+	verdict := job.Verdict()
+	testWantData(t, gothreatmatrix.VerdictMalicious, verdict.Label)
+	testWantData(t, 100, verdict.Score)
+	testWantData(t, "Test_Evaluator", verdict.Evidence[0].Plugin)
+
+	job.AnalyzerReports[0].Report["found"] = false
+	testWantData(t, gothreatmatrix.VerdictUnknown, job.Verdict().Label)
+}
@@ -0,0 +1,135 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestVisualizerServiceList(t *testing.T) {
+	visualizerJsonString := `{"name":"DNS","python_module":"dns.DNS","disabled":false,"description":"Visualize information about DNS resolvers and DNS malicious detectors","playbooks":["Dns"]}`
+	visualizerConfig := gothreatmatrix.VisualizerConfig{}
+	if unmarshalError := json.Unmarshal([]byte(visualizerJsonString), &visualizerConfig); unmarshalError != nil {
+		t.Fatalf("Error: %s", unmarshalError)
+	}
+	testCases := make(map[string]TestData)
+	testCases["list"] = TestData{
+		Data:       "[" + visualizerJsonString + "]",
+		StatusCode: http.StatusOK,
+		Want:       []gothreatmatrix.VisualizerConfig{visualizerConfig},
+	}
+	testCases["paginated"] = TestData{
+		Data:       `{"count":1,"total_pages":1,"results":[` + visualizerJsonString + "]}",
+		StatusCode: http.StatusOK,
+		Want:       []gothreatmatrix.VisualizerConfig{visualizerConfig},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.Handle(constants.BASE_VISUALIZER_URL, serverHandler(t, testCase, "GET"))
+			gottenVisualizerConfigs, err := client.VisualizerService.List(context.Background())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenVisualizerConfigs)
+			testWantData(t, []string{"Dns"}, gottenVisualizerConfigs[0].Playbooks)
+		})
+	}
+}
+
+func TestVisualizerServiceGet(t *testing.T) {
+	visualizerJsonString := `{"name":"DNS","disabled":true,"playbooks":["Dns"]}`
+	visualizerConfig := gothreatmatrix.VisualizerConfig{}
+	if unmarshalError := json.Unmarshal([]byte(visualizerJsonString), &visualizerConfig); unmarshalError != nil {
+		t.Fatalf("Error: %s", unmarshalError)
+	}
+	testCases := make(map[string]TestData)
+	testCases["simple"] = TestData{
+		Input:      "DNS",
+		Data:       visualizerJsonString,
+		StatusCode: http.StatusOK,
+		Want:       &visualizerConfig,
+	}
+	testCases["notFound"] = TestData{
+		Input:      "Missing",
+		Data:       `{"detail":"Not found."}`,
+		StatusCode: http.StatusNotFound,
+		Want:       gothreatmatrix.ErrNotFound,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			visualizerName := testCase.Input.(string)
+			apiHandler.Handle(fmt.Sprintf(constants.SPECIFIC_VISUALIZER_URL, visualizerName), serverHandler(t, testCase, "GET"))
+			gottenVisualizerConfig, err := client.VisualizerService.Get(context.Background(), visualizerName)
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, testCase.Want, gottenVisualizerConfig)
+		})
+	}
+}
+
+func TestVisualizerServiceJobReport(t *testing.T) {
+	jobJsonString := `{"id":1,"status":"reported_without_fails","visualizer_reports":[
+		{"name":"DNS","status":"SUCCESS","errors":[],"report":[{"level_position":1,"level_size":"3","elements":{"type":"horizontal_list","values":[{"type":"title","title":{"value":"Classic_DNS"}}]}}]},
+		{"name":"Yara","status":"FAILED","errors":["no rules"],"report":[]}
+	]}`
+	testCases := make(map[string]TestData)
+	testCases["ran"] = TestData{
+		Input: "DNS",
+		Data:  jobJsonString,
+		Want:  "horizontal_list",
+	}
+	testCases["didNotRun"] = TestData{
+		Input: "Passive_DNS",
+		Data:  jobJsonString,
+		Want:  gothreatmatrix.ErrNotFound,
+	}
+	testCases["jobNotFound"] = TestData{
+		Input:      "DNS",
+		Data:       `{"detail":"Not found."}`,
+		StatusCode: http.StatusNotFound,
+		Want:       gothreatmatrix.ErrNotFound,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, apiHandler, closeServer := setup()
+			defer closeServer()
+			apiHandler.Handle(fmt.Sprintf(constants.SPECIFIC_JOB_URL, 1), serverHandler(t, testCase, "GET"))
+			visualizerReport, err := client.VisualizerService.JobReport(context.Background(), 1, testCase.Input.(string))
+			if wantError, ok := testCase.Want.(error); ok {
+				if !errors.Is(err, wantError) {
+					t.Fatalf("Expected %v got: %v", wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, 1, len(visualizerReport.Report))
+			testWantData(t, 1, visualizerReport.Report[0].LevelPosition)
+			testWantData(t, testCase.Want, visualizerReport.Report[0].Elements["type"])
+
+			visualizerReports, err := client.VisualizerService.JobReports(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			testWantData(t, []string{"no rules"}, visualizerReports[1].Errors)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+)
+
+func TestJobServiceWatch(t *testing.T) {
+	jobStates := []string{
+		`{"id":1,"status":"pending"}`,
+		`{"id":1,"status":"running"}`,
+		`{"id":1,"status":"running"}`,
+		`{"id":1,"status":"reported_without_fails"}`,
+	}
+	testCases := make(map[string]TestData)
+	testCases["websocket"] = TestData{
+		Input: true,
+		Want:  []string{"pending", "running", "running", "reported_without_fails"},
+	}
+	testCases["pollingFallback"] = TestData{
+		Input: false,
+		// polling only reports status changes
+		Want: []string{"pending", "running", "reported_without_fails"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := http.NewServeMux()
+			testServer := httptest.NewServer(apiHandler)
+			defer testServer.Close()
+			if testCase.Input.(bool) {
+				apiHandler.HandleFunc(fmt.Sprintf(constants.JOB_WEBSOCKET_URL, 1), func(w http.ResponseWriter, r *http.Request) {
+					testWantData(t, "token test-token", r.Header.Get("Authorization"))
+					connection, err := websocket.Accept(w, r, nil)
+					if err != nil {
+						t.Errorf("Could not accept websocket: %v", err)
+						return
+					}
+					defer connection.CloseNow()
+					for _, jobState := range jobStates {
+						if err := connection.Write(r.Context(), websocket.MessageText, []byte(jobState)); err != nil {
+							return
+						}
+					}
+					_, _, _ = connection.Read(r.Context())
+				})
+			}
+			polls := 0
+			apiHandler.HandleFunc(fmt.Sprintf(constants.SPECIFIC_JOB_URL, 1), func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				_, _ = w.Write([]byte(jobStates[polls]))
+				polls++
+			})
+			client := gothreatmatrix.NewClient(
+				gothreatmatrix.WithURL(testServer.URL),
+				gothreatmatrix.WithToken("test-token"),
+				gothreatmatrix.WithPollInterval(time.Millisecond),
+			)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			statuses := []string{}
+			for update := range client.JobService.Watch(ctx, 1) {
+				if update.Err != nil {
+					t.Fatalf("Unexpected error: %v", update.Err)
+				}
+				statuses = append(statuses, string(update.Job.Status))
+			}
+			testWantData(t, testCase.Want, statuses)
+		})
+	}
+}
+
+func TestJobServiceWatchMinPollInterval(t *testing.T) {
+	apiHandler := http.NewServeMux()
+	testServer := httptest.NewServer(apiHandler)
+	defer testServer.Close()
+	polls := 0
+	apiHandler.HandleFunc(fmt.Sprintf(constants.SPECIFIC_JOB_URL, 1), func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		_, _ = w.Write([]byte(`{"id":1,"status":"running"}`))
+	})
+	client := gothreatmatrix.NewClient(gothreatmatrix.WithURL(testServer.URL), gothreatmatrix.WithPollInterval(0))
+	ctx, cancel := context.WithTimeout(context.Background(), gothreatmatrix.MinPollInterval/2)
+	defer cancel()
+	for range client.JobService.Watch(ctx, 1) {
+	}
+	// a zero interval is raised to the minimum instead of polling in a loop
+	testWantData(t, 1, polls)
+}
+
+func TestJobServiceWatchError(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	testData := TestData{
+		Data:       `{"detail":"Not found."}`,
+		StatusCode: http.StatusNotFound,
+	}
+	apiHandler.Handle(fmt.Sprintf(constants.SPECIFIC_JOB_URL, 9000), serverHandler(t, testData, "GET"))
+	updates := []gothreatmatrix.JobUpdate{}
+	for update := range client.JobService.Watch(context.Background(), 9000) {
+		updates = append(updates, update)
+	}
+	if len(updates) != 1 || updates[0].Err == nil {
+		t.Fatalf("Expected a single error update got: %v", updates)
+	}
+}
@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-threatmatrix/constants"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix"
+	"github.com/khulnasoft/go-threatmatrix/gothreatmatrix/webhook"
+)
+
+// signedCallback returns a callback request carrying body, signed with secret at timestamp.
+func signedCallback(method string, body string, secret []byte, timestamp time.Time) *http.Request {
+	request := httptest.NewRequest(method, "/callback", strings.NewReader(body))
+	if secret != nil {
+		signature, timestampHeader := webhook.Sign(secret, timestamp, []byte(body))
+		request.Header.Set(webhook.SignatureHeader, signature)
+		request.Header.Set(webhook.TimestampHeader, timestampHeader)
+	}
+	return request
+}
+
+func TestWebhookReceiver(t *testing.T) {
+	secret := []byte("shared-secret")
+	jobBody := `{"id":42,"status":"reported_without_fails","observable_name":"8.8.8.8"}`
+	now := time.Now()
+	testCases := map[string]struct {
+		request    *http.Request
+		statusCode int
+		wantJobId  int
+	}{
+		"valid":        {request: signedCallback("POST", jobBody, secret, now), statusCode: http.StatusNoContent, wantJobId: 42},
+		"badSignature": {request: signedCallback("POST", jobBody, []byte("other-secret"), now), statusCode: http.StatusUnauthorized},
+		"unsigned":     {request: signedCallback("POST", jobBody, nil, now), statusCode: http.StatusUnauthorized},
+		"tooOld":       {request: signedCallback("POST", jobBody, secret, now.Add(-time.Hour)), statusCode: http.StatusUnauthorized},
+		"notJson":      {request: signedCallback("POST", "not json", secret, now), statusCode: http.StatusBadRequest},
+		"wrongMethod":  {request: signedCallback("GET", "", nil, now), statusCode: http.StatusMethodNotAllowed},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			receiver, err := webhook.NewReceiver(string(secret))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			handledJobId := 0
+			receiver.Handle(func(ctx context.Context, job *gothreatmatrix.Job) error {
+				handledJobId = job.ID
+				testWantData(t, gothreatmatrix.StatusReportedWithoutFails, job.Status)
+				return nil
+			})
+			recorder := httptest.NewRecorder()
+			receiver.ServeHTTP(recorder, testCase.request)
+			testWantData(t, testCase.statusCode, recorder.Code)
+			testWantData(t, testCase.wantJobId, handledJobId)
+		})
+	}
+}
+
+func TestWebhookReceiverReplay(t *testing.T) {
+	secret := []byte("shared-secret")
+	receiver, err := webhook.NewReceiver(string(secret))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	handled := 0
+	receiver.Handle(func(ctx context.Context, job *gothreatmatrix.Job) error {
+		handled++
+		return nil
+	})
+	body := `{"id":1}`
+	now := time.Now()
+	for _, wantCode := range []int{http.StatusNoContent, http.StatusConflict} {
+		recorder := httptest.NewRecorder()
+		receiver.ServeHTTP(recorder, signedCallback("POST", body, secret, now))
+		testWantData(t, wantCode, recorder.Code)
+	}
+	testWantData(t, 1, handled)
+}
+
+func TestWebhookEmptySecret(t *testing.T) {
+	if _, err := webhook.NewReceiver(""); !errors.Is(err, webhook.ErrEmptySecret) {
+		t.Fatalf("Expected %v got: %v", webhook.ErrEmptySecret, err)
+	}
+	body := []byte(`{"id":1}`)
+	signature, timestamp := webhook.Sign(nil, time.Now(), body)
+	if err := webhook.Verify(nil, body, signature, timestamp, time.Minute); !errors.Is(err, webhook.ErrEmptySecret) {
+		t.Fatalf("Expected %v got: %v", webhook.ErrEmptySecret, err)
+	}
+}
+
+func TestWebhookReceiverHandlerError(t *testing.T) {
+	secret := []byte("shared-secret")
+	receiver, err := webhook.NewReceiver(string(secret), webhook.WithMaxBodySize(64))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	failing := true
+	receiver.Handle(func(ctx context.Context, job *gothreatmatrix.Job) error {
+		if failing {
+			return errors.New("downstream is down")
+		}
+		return nil
+	})
+	body := `{"id":1}`
+	now := time.Now()
+	recorder := httptest.NewRecorder()
+	receiver.ServeHTTP(recorder, signedCallback("POST", body, secret, now))
+	testWantData(t, http.StatusInternalServerError, recorder.Code)
+	// the same callback sent again isn't taken for a replay as it couldn't be handled
+	failing = false
+	recorder = httptest.NewRecorder()
+	receiver.ServeHTTP(recorder, signedCallback("POST", body, secret, now))
+	testWantData(t, http.StatusNoContent, recorder.Code)
+
+	largeBody := `{"id":1,"observable_name":"` + strings.Repeat("a", 64) + `"}`
+	recorder = httptest.NewRecorder()
+	receiver.ServeHTTP(recorder, signedCallback("POST", largeBody, secret, now))
+	testWantData(t, http.StatusRequestEntityTooLarge, recorder.Code)
+}
+
+func TestWebhookCallbackURL(t *testing.T) {
+	client, apiHandler, closeServer := setup()
+	defer closeServer()
+	apiHandler.HandleFunc(constants.ANALYZE_OBSERVABLE_URL, func(w http.ResponseWriter, r *http.Request) {
+		testWantData(t, "https://soar.local/callback", r.Header.Get(webhook.CallbackURLHeader))
+		_, _ = w.Write([]byte(`{"job_id":1,"status":"accepted","warnings":[],"analyzers_running":[],"connectors_running":[]}`))
+	})
+	_, err := client.AnalyzeService.AnalyzeObservable(context.Background(), gothreatmatrix.ObservableAnalysisRequest{Value: "8.8.8.8"}, webhook.CallbackURL("https://soar.local/callback"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}